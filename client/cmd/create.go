@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/stream"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
 	"github.com/rs/zerolog"
@@ -14,44 +16,81 @@ import (
 func NewCreateCmd(dcfg *config.Config) *cobra.Command {
 	log := logger.StdoutConsole(zerolog.DebugLevel)
 
-	var (
-		secretName  string
-		secretType  string
-		secretValue string
-	)
+	input := &app.SecretInput{}
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Creates a user secret in GophKeeper",
 		RunE: func(_ *cobra.Command, _ []string) error {
-			if secretName == "" {
+			if input.Name == "" {
 				return fmt.Errorf("[%w] --secret flag is required", e.ErrInvalidInput)
 			}
-			switch secretType {
-			case "binary", "card", "credentials":
+			switch input.Type {
+			case "binary", "card", "credentials", "text":
 			default:
-				return fmt.Errorf("[%w] --type must be one of: binary, card, credentials", e.ErrInvalidInput)
+				return fmt.Errorf("[%w] --type must be one of: binary, card, credentials, text", e.ErrInvalidInput)
 			}
-			if secretValue == "" {
+			if _, err := stream.ParseCodec(input.Compression); err != nil {
+				return err
+			}
+			if input.Value == "" && !hasStructuredInput(input) {
 				return fmt.Errorf("[%w] --value must be provided for secret", e.ErrInvalidInput)
 			}
 
+			if input.Type == "text" && input.Value == "" && isPiped(os.Stdin) {
+				input.Stdin = os.Stdin
+			}
+
 			cfg := config.LoadConfig(dcfg)
-			return app.CreateSecret(cfg, secretType, secretName, secretValue, log)
+			return app.CreateSecret(cfg, input, log)
 		},
 		SilenceUsage: true,
 	}
 
 	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username")
 	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password")
-	cmd.Flags().StringVarP(&secretName, "secret", "s", "", "Secret name (required)")
-	cmd.Flags().StringVar(&secretType, "type", "", "Type of secret: binary, card, credentials (required)")
-	cmd.Flags().StringVar(&secretValue, "value", "", "Secret value (file path, card string, or credentials)")
+	cmd.Flags().StringVarP(&input.Name, "secret", "s", "", "Secret name (required)")
+	cmd.Flags().StringVar(&input.Type, "type", "", "Type of secret: binary, card, credentials, text (required)")
+	cmd.Flags().StringVar(&input.Value, "value", "", "Secret value (file path, card json, credentials json or text)")
+	cmd.Flags().StringVar(&input.Compression, "compress", "none", "Compress content before encryption: none, gzip, zstd")
+	cmd.Flags().StringVar(&input.Card.Number, "card-number", "", "Card number (card secrets)")
+	cmd.Flags().StringVar(&input.Card.Holder, "card-holder", "", "Cardholder name (card secrets)")
+	cmd.Flags().StringVar(&input.Card.Expiry, "card-expiry", "", "Card expiry date MM/YY (card secrets)")
+	cmd.Flags().StringVar(&input.Card.CVV, "card-cvv", "", "Card CVV (card secrets)")
+	cmd.Flags().StringVar(&input.Credentials.Username, "login", "", "Login (credentials secrets)")
+	cmd.Flags().StringVar(&input.Credentials.Password, "login-password", "", "Login password (credentials secrets)")
+	cmd.Flags().StringSliceVar(&input.Credentials.URLs, "url", nil, "Site URL, repeatable (credentials secrets)")
+	cmd.Flags().StringVar(&input.Credentials.Notes, "notes", "", "Notes (credentials secrets)")
+	cmd.Flags().StringVar(&input.Credentials.TOTP, "totp", "", "Base32 TOTP seed (credentials secrets)")
 
 	// Mark required flags
 	_ = cmd.MarkFlagRequired("secret")
 	_ = cmd.MarkFlagRequired("type")
-	_ = cmd.MarkFlagRequired("value")
 
 	return cmd
 }
+
+// hasStructuredInput reports whether structured secret content was provided with dedicated flags.
+func hasStructuredInput(input *app.SecretInput) bool {
+	switch input.Type {
+	case "card":
+		return !input.Card.IsEmpty()
+	case "credentials":
+		return !input.Credentials.IsEmpty()
+	case "text":
+		// text note is read from stdin or editor when value is omitted.
+		return true
+	}
+
+	return false
+}
+
+// isPiped reports whether file is not a terminal, e.g. stdin redirected from a pipe or file.
+func isPiped(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice == 0
+}
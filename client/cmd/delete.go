@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewDeleteCmd(dcfg *config.Config) *cobra.Command {
+	log := logger.StdoutConsole(zerolog.DebugLevel)
+
+	var secretName string
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Deletes user's secret on gophkeeper server and locally",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.DeleteSecret(cfg, secretName, log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+	cmd.Flags().StringVarP(&secretName, "secret", "s", "", "Secret name (required)")
+	_ = cmd.MarkFlagRequired("secret")
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewDevicesCmd(dcfg *config.Config) *cobra.Command {
+	log := logger.StderrConsole(zerolog.DebugLevel)
+
+	cmd := &cobra.Command{
+		Use:   "devices",
+		Short: "Lists devices user's tokens have been issued to",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.ListDevices(cfg, log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.PersistentFlags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.PersistentFlags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "revoke <device-id>",
+		Short: "Revokes user's device, tokens issued to it stop working",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.RevokeDevice(cfg, args[0], log)
+		},
+		SilenceUsage: true,
+	})
+
+	return cmd
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewGetCmd(dcfg *config.Config) *cobra.Command {
+	// logs go to stderr so that secret printed to stdout can be piped.
+	log := logger.StderrConsole(zerolog.DebugLevel)
+
+	var (
+		secretName string
+		versionID  string
+		outPath    string
+		reveal     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Downloads and decrypts user's secret from gophkeeper server",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.GetSecret(cfg, secretName, versionID, outPath, reveal, log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+	cmd.Flags().StringVarP(&secretName, "secret", "s", "", "Secret name (required)")
+	cmd.Flags().StringVar(&versionID, "version", "", "Secret version id (current version if omitted)")
+	cmd.Flags().StringVarP(&outPath, "output", "o", "", "Output file path (stdout if omitted)")
+	cmd.Flags().BoolVar(&reveal, "reveal", false, "Show sensitive fields of structured secrets unmasked")
+	_ = cmd.MarkFlagRequired("secret")
+
+	return cmd
+}
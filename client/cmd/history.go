@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewHistoryCmd(dcfg *config.Config) *cobra.Command {
+	log := logger.StderrConsole(zerolog.DebugLevel)
+
+	cmd := &cobra.Command{
+		Use:   "history <name>",
+		Short: "Lists all server versions of user's secret",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.SecretHistory(cfg, args[0], log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+
+	return cmd
+}
@@ -22,6 +22,7 @@ func NewInstallCmd(cfg *config.Config) *cobra.Command {
 	cmd.Flags().StringVarP(&cfg.ServerHost, "server-host", "a", cfg.ServerHost, "Server host")
 	cmd.Flags().StringVarP(&cfg.ServerTLSCertPath, "server-ca-cert", "c", cfg.ServerTLSCertPath, "CA certificate path")
 	cmd.Flags().StringVarP(&cfg.InstallDir, "dir", "d", cfg.InstallDir, "installation path")
+	cmd.Flags().StringVar(&cfg.AvroSchemaDir, "avro-schema-dir", cfg.AvroSchemaDir, "Avro schemas directory")
 	_ = cmd.MarkFlagRequired("path")
 
 	return cmd
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewListCmd(dcfg *config.Config) *cobra.Command {
+	log := logger.StderrConsole(zerolog.DebugLevel)
+
+	var namePrefix string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists user's secrets with their sync state",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.ListSecrets(cfg, namePrefix, log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+	cmd.Flags().StringVar(&namePrefix, "prefix", "", "List only secrets which names start with prefix")
+
+	return cmd
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewLoginCmd(dcfg *config.Config) *cobra.Command {
+	log := logger.StdoutConsole(zerolog.DebugLevel)
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to gophkeeper from this device",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.Login(cfg, log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+	cmd.Flags().StringVar(&dcfg.TOTPCode, "otp", dcfg.TOTPCode, "TOTP code, required once two-factor authentication is enabled")
+	cmd.Flags().StringVar(&dcfg.OldPassword, "old-password", dcfg.OldPassword,
+		"Password before it was changed on another device, keeps secret versions not uploaded before the change")
+	cmd.Flags().StringVar(&dcfg.RecoveryCode, "recovery-code", dcfg.RecoveryCode, "Single-use recovery code instead of TOTP code")
+
+	return cmd
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewLogoutCmd(dcfg *config.Config) *cobra.Command {
+	var allDevices bool
+
+	log := logger.StderrConsole(zerolog.DebugLevel)
+
+	cmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Revokes user's refresh tokens and forgets local token",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.Logout(cfg, allDevices, log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+	cmd.Flags().BoolVar(&allDevices, "all", false, "Log out of all user's devices")
+
+	return cmd
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewPasswdCmd(dcfg *config.Config) *cobra.Command {
+	var newPassword string
+
+	log := logger.StdoutConsole(zerolog.DebugLevel)
+	cmd := &cobra.Command{
+		Use:   "passwd",
+		Short: "Changes user password re-encrypting secret keys, interrupted change is resumed by running it again",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.ChangePassword(cfg, newPassword, log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Current password (required)")
+	cmd.Flags().StringVar(&newPassword, "new-password", "", "New password (required)")
+	cmd.Flags().StringVar(&dcfg.TOTPCode, "otp", dcfg.TOTPCode, "TOTP code, required once two-factor authentication is enabled")
+
+	_ = cmd.MarkFlagRequired("new-password")
+
+	return cmd
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewResolveCmd(dcfg *config.Config) *cobra.Command {
+	log := logger.StdoutConsole(zerolog.DebugLevel)
+
+	var keep string
+
+	cmd := &cobra.Command{
+		Use:   "resolve <name>",
+		Short: "Resolves secret conflict keeping local, remote or both versions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			side, err := app.ParseKeepSide(keep)
+			if err != nil {
+				return err
+			}
+
+			cfg := config.LoadConfig(dcfg)
+			return app.ResolveConflict(cfg, args[0], side, log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+	cmd.Flags().StringVar(&keep, "keep", "", "Version to keep: local, remote or both (required)")
+	_ = cmd.MarkFlagRequired("keep")
+
+	return cmd
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewRestoreCmd(dcfg *config.Config) *cobra.Command {
+	log := logger.StdoutConsole(zerolog.DebugLevel)
+
+	var versionID string
+
+	cmd := &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Restores older version of user's secret as a new current version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.RestoreSecret(cfg, args[0], versionID, log)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+	cmd.Flags().StringVar(&versionID, "version", "", "Secret version id to restore (required)")
+	_ = cmd.MarkFlagRequired("version")
+
+	return cmd
+}
@@ -18,8 +18,21 @@ func NewRootCmd() *cobra.Command {
 
 	cmd.AddCommand(NewInstallCmd(dcfg))
 	cmd.AddCommand(NewRegisterCmd(dcfg))
+	cmd.AddCommand(NewLoginCmd(dcfg))
 	cmd.AddCommand(NewCreateCmd(dcfg))
 	cmd.AddCommand(NewSyncCmd(dcfg))
+	cmd.AddCommand(NewGetCmd(dcfg))
+	cmd.AddCommand(NewListCmd(dcfg))
+	cmd.AddCommand(NewHistoryCmd(dcfg))
+	cmd.AddCommand(NewRestoreCmd(dcfg))
+	cmd.AddCommand(NewDeleteCmd(dcfg))
+	cmd.AddCommand(NewConflictsCmd(dcfg))
+	cmd.AddCommand(NewResolveCmd(dcfg))
+	cmd.AddCommand(NewWatchCmd(dcfg))
+	cmd.AddCommand(NewDevicesCmd(dcfg))
+	cmd.AddCommand(NewLogoutCmd(dcfg))
+	cmd.AddCommand(NewTOTPCmd(dcfg))
+	cmd.AddCommand(NewPasswdCmd(dcfg))
 
 	return cmd
 }
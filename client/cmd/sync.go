@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
@@ -11,22 +14,32 @@ import (
 func NewSyncCmd(dcfg *config.Config) *cobra.Command {
 	log := logger.StdoutConsole(zerolog.DebugLevel)
 
-	var secretName string
+	var (
+		secretName string
+		all        bool
+		resume     bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "sync",
 		Short: "syncronizes user's secret witth gophkeeper server",
 		RunE: func(_ *cobra.Command, _ []string) error {
+			if secretName == "" && !all && !resume {
+				return fmt.Errorf("[%w] either --secret, --all or --resume flag is required", e.ErrInvalidInput)
+			}
+
 			cfg := config.LoadConfig(dcfg)
-			return app.SyncSecrets(cfg, secretName, log)
+			return app.SyncSecrets(cfg, secretName, all, resume, log)
 		},
 		SilenceUsage: true,
 	}
 
 	cmd.Flags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
 	cmd.Flags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
-	cmd.Flags().StringVarP(&secretName, "secret", "s", "", "Secret name (required)")
-	_ = cmd.MarkFlagRequired("secret")
+	cmd.Flags().StringVarP(&secretName, "secret", "s", "", "Secret name")
+	cmd.Flags().BoolVar(&all, "all", false, "Pull remote changes and push local ones for all secrets")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Continue interrupted uploads and downloads")
+	cmd.MarkFlagsMutuallyExclusive("secret", "all", "resume")
 
 	return cmd
 }
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+func NewTOTPCmd(dcfg *config.Config) *cobra.Command {
+	log := logger.StderrConsole(zerolog.DebugLevel)
+
+	cmd := &cobra.Command{
+		Use:   "totp",
+		Short: "Manages TOTP two-factor authentication of the user",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.PersistentFlags().StringVarP(&dcfg.Username, "username", "u", dcfg.Username, "Username (required)")
+	cmd.PersistentFlags().StringVarP(&dcfg.Password, "password", "p", dcfg.Password, "Password (required)")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "enroll",
+		Short: "Generates TOTP key to add to authenticator app",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.EnrollTOTP(cfg, log)
+		},
+		SilenceUsage: true,
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "confirm <code>",
+		Short: "Enables two-factor authentication with a code from authenticator app",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cfg := config.LoadConfig(dcfg)
+			return app.ConfirmTOTP(cfg, args[0], log)
+		},
+		SilenceUsage: true,
+	})
+
+	return cmd
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	uavro "github.com/patraden/ya-practicum-gophkeeper/pkg/avro"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/stream"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/card"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const cardSchemaFileName = "card.avsc"
+
+// CardInput is a bank card provided by user either with flags or as JSON value.
+type CardInput struct {
+	Number string `json:"number"`
+	Holder string `json:"holder"`
+	Expiry string `json:"expiry"`
+	CVV    string `json:"cvv"`
+}
+
+// IsEmpty reports whether none of card fields were provided.
+func (in CardInput) IsEmpty() bool {
+	return in == CardInput{}
+}
+
+// ParseCard builds bank card of the user from JSON value or, if value is empty, from card input fields.
+// Card is validated with Luhn check and must not be expired.
+func ParseCard(value string, input CardInput, userID string) (*card.BankCard, error) {
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), &input); err != nil {
+			return nil, fmt.Errorf("[%w] card json", e.ErrInvalidInput)
+		}
+	}
+
+	month, year, err := card.ParseExpiry(input.Expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	number := strings.NewReplacer(" ", "", "-", "").Replace(input.Number)
+
+	// cvv is stored as number, so its digits count is defined by the card.
+	cvvDigits := strings.TrimSpace(input.CVV)
+	if len(cvvDigits) != card.CVVLength(number) {
+		return nil, fmt.Errorf("[%w] card cvv must have %d digits", e.ErrValidation, card.CVVLength(number))
+	}
+
+	cvv, err := strconv.Atoi(cvvDigits)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] card cvv", e.ErrValidation)
+	}
+
+	bankCard := &card.BankCard{
+		UserID:         userID,
+		CardholderName: strings.TrimSpace(input.Holder),
+		CardNumber:     number,
+		ExpiryMonth:    month,
+		ExpiryYear:     year,
+		Cvv:            cvv,
+	}
+
+	if err := bankCard.Validate(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return bankCard, nil
+}
+
+func cardSchemaFile(cfg *config.Config) *uavro.SchemaFile {
+	return uavro.NewSchemaFile(filepath.Join(cfg.AvroSchemaDir, cardSchemaFileName))
+}
+
+func createCardSecret(
+	cfg *config.Config,
+	kek []byte,
+	input *SecretInput,
+	codec stream.Codec,
+	usr *user.User,
+	log zerolog.Logger,
+) (*secret.Secret, error) {
+	log.Info().Msg("Creating card secret...")
+
+	bankCard, err := ParseCard(input.Value, input.Card, usr.ID.String())
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid card")
+		return nil, err
+	}
+
+	data, err := bankCard.Marshal(cardSchemaFile(cfg))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to serialize card")
+		return nil, err
+	}
+
+	return encryptSecret(cfg, kek, bytes.NewReader(data), input, codec, usr, log)
+}
+
+// decryptCardSecret decrypts card secret and writes it as text with masked number unless reveal is set.
+func decryptCardSecret(
+	cfg *config.Config,
+	encPath, outPath string,
+	dek []byte,
+	reveal bool,
+	log zerolog.Logger,
+) error {
+	var buf bytes.Buffer
+
+	if err := decryptSecretTo(encPath, &buf, dek, log); err != nil {
+		return err
+	}
+
+	bankCard, err := card.UnmarshalBankCard(cardSchemaFile(cfg), buf.Bytes())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to deserialize card")
+		return err
+	}
+
+	return writeSecretOutput(outPath, func(dest io.Writer) error {
+		_, err := io.WriteString(dest, bankCard.Format(reveal))
+		return err
+	}, log)
+}
@@ -0,0 +1,72 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     string
+		input     app.CardInput
+		expectErr error
+	}{
+		{
+			name:  "from flags",
+			input: app.CardInput{Number: "4111 1111 1111 1111", Holder: "Denis", Expiry: "12/99", CVV: "012"},
+		},
+		{
+			name:  "from json",
+			value: `{"number":"4111-1111-1111-1111","holder":"Denis","expiry":"12/2099","cvv":"123"}`,
+		},
+		{
+			name:      "invalid json",
+			value:     `{"number":`,
+			expectErr: e.ErrInvalidInput,
+		},
+		{
+			name:      "luhn check failed",
+			input:     app.CardInput{Number: "4111111111111112", Holder: "Denis", Expiry: "12/99", CVV: "123"},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "expired",
+			input:     app.CardInput{Number: "4111111111111111", Holder: "Denis", Expiry: "01/2001", CVV: "123"},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "3 digit cvv of american express",
+			input:     app.CardInput{Number: "3782 822463 10005", Holder: "Denis", Expiry: "12/99", CVV: "123"},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "invalid cvv",
+			input:     app.CardInput{Number: "4111111111111111", Holder: "Denis", Expiry: "12/99", CVV: "abc"},
+			expectErr: e.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bankCard, err := app.ParseCard(tt.value, tt.input, "user")
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "4111111111111111", bankCard.CardNumber)
+			require.Equal(t, "Denis", bankCard.CardholderName)
+			require.Equal(t, 12, bankCard.ExpiryMonth)
+			require.Equal(t, "user", bankCard.UserID)
+		})
+	}
+}
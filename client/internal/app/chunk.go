@@ -0,0 +1,323 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/chunker"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/checksum"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/stream"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// manifestExt is appended to the path of the local secret file to keep its manifest while uploading.
+	manifestExt = ".manifest"
+	// assemblyExt is appended to the path of the secret file being assembled from chunks.
+	assemblyExt = ".assembly"
+)
+
+// secretDEK unwraps data encryption key of the secret with the user key encryption key.
+func secretDEK(cfg *config.Config, usr *user.User, wrappedDEK []byte) ([]byte, error) {
+	kek, err := keys.KEK(usr, cfg.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys.UnwrapDEK(kek, wrappedDEK)
+}
+
+// scanSecretChunks decrypts local secret file and passes its content-defined chunks to fn
+// together with their ids. Chunk is only valid until fn returns.
+func scanSecretChunks(
+	filePath string,
+	dek []byte,
+	log zerolog.Logger,
+	fn func(chunkID string, chunk []byte) error,
+) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrOpen)
+	}
+	defer file.Close()
+
+	decryptReader, err := stream.DecryptSecretStream(file, dek, log)
+	if err != nil {
+		return err
+	}
+
+	idKey := keys.ChunkIDKey(dek)
+	chunks := chunker.New(decryptReader)
+
+	for {
+		chunk, err := chunks.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("[%w] secret file", e.ErrDecrypt)
+		}
+
+		if err := fn(keys.ChunkID(idKey, chunk), chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// secretCodec returns codec compressing secret content before encryption.
+func secretCodec(metaData secret.MetaData) (stream.Codec, error) {
+	return stream.ParseCodec(metaData[secret.MetaKeyCompression])
+}
+
+// BuildManifest splits plaintext of the local secret file into content-defined chunks
+// and references them in the manifest. The same content always results in the same manifest.
+// Sizes of chunks are the sizes of chunks compressed with codec and encrypted.
+func BuildManifest(filePath string, dek []byte, codec stream.Codec, log zerolog.Logger) (*secret.Manifest, error) {
+	manifest := secret.NewManifest()
+
+	err := scanSecretChunks(filePath, dek, log, func(chunkID string, chunk []byte) error {
+		size, err := stream.EncryptedChunkSize(chunk, codec)
+		if err != nil {
+			return err
+		}
+
+		manifest.Chunks = append(manifest.Chunks, secret.ChunkRef{ID: chunkID, Size: size})
+		manifest.Size += int64(len(chunk))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// prepareChunkedUpload writes manifest of the local secret version next to its file and returns
+// the version describing manifest object, which is uploaded and committed instead of the file.
+func prepareChunkedUpload(scrt *dto.Secret, dek []byte, log zerolog.Logger) (*dto.Secret, error) {
+	codec, err := secretCodec(scrt.MetaData)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := BuildManifest(scrt.FilePath, dek, codec, log)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := manifest.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	upload := *scrt
+	upload.FilePath = scrt.FilePath + manifestExt
+	upload.SecretSize = int64(len(data))
+
+	if err := os.WriteFile(upload.FilePath, data, secretFilePerm); err != nil {
+		return nil, fmt.Errorf("[%w] secret manifest", e.ErrWrite)
+	}
+
+	upload.SecretHash = checksum.Sum(data)
+
+	log.Info().
+		Int("chunks", len(manifest.Chunks)).
+		Int64("size", manifest.Size).
+		Msg("Secret split into chunks")
+
+	return &upload, nil
+}
+
+// uploadChunks uploads encrypted chunks of the local secret file which are not stored yet.
+// Chunks stored by previous versions or by interrupted upload are skipped,
+// so that only changed content of the new version is sent.
+func uploadChunks(
+	ctx context.Context,
+	cfg *config.Config,
+	chunkOperator s3.ChunkOperator,
+	bucketName string,
+	scrt *dto.Secret,
+	dek []byte,
+	log zerolog.Logger,
+) error {
+	codec, err := secretCodec(scrt.MetaData)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	uploaded, skipped := 0, 0
+
+	err = scanSecretChunks(scrt.FilePath, dek, log, func(chunkID string, chunk []byte) error {
+		if _, ok := seen[chunkID]; ok {
+			return nil
+		}
+
+		seen[chunkID] = struct{}{}
+		objectKey := secret.ChunkObjectKey(chunkID)
+
+		chunkCtx, cancel := context.WithTimeout(ctx, cfg.RequestsTimeout)
+		defer cancel()
+
+		exists, err := chunkOperator.ObjectExists(chunkCtx, bucketName, objectKey)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			skipped++
+			return nil
+		}
+
+		encrypted, err := stream.EncryptChunk(chunk, dek, codec)
+		if err != nil {
+			return err
+		}
+
+		if err := chunkOperator.PutObjectBytes(chunkCtx, bucketName, objectKey, encrypted); err != nil {
+			return err
+		}
+
+		uploaded++
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Int("uploaded", uploaded).
+		Int("skipped", skipped).
+		Msg("Uploaded secret chunks")
+
+	return nil
+}
+
+// assembleChunkedSecret downloads chunks referenced by the manifest and re-encrypts their content
+// into the local secret file at encPath verifying each chunk against its id.
+// Size and hash of the response are set to ones of the assembled file.
+//
+//nolint:funlen //reason: logging.
+func assembleChunkedSecret(
+	ctx context.Context,
+	cfg *config.Config,
+	objectReader s3.MultipartOperator,
+	usr *user.User,
+	manifestPath, encPath string,
+	resp *dto.SecretDownloadInitResponse,
+	log zerolog.Logger,
+) error {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("[%w] secret manifest", e.ErrRead)
+	}
+
+	manifest, err := secret.ParseManifest(data)
+	if err != nil {
+		return fmt.Errorf("[%w] secret manifest", e.ErrCorrupt)
+	}
+
+	dek, err := secretDEK(cfg, usr, resp.SecretDEK)
+	if err != nil {
+		return err
+	}
+
+	metaData, err := parseMetaData(resp.MetaData)
+	if err != nil {
+		return err
+	}
+
+	// local file is compressed the same way as chunks are.
+	codec, err := secretCodec(metaData)
+	if err != nil {
+		return err
+	}
+
+	assemblyPath := encPath + assemblyExt
+
+	file, err := os.OpenFile(assemblyPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, secretFilePerm)
+	if err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrOpen)
+	}
+	defer os.Remove(assemblyPath)
+	defer file.Close()
+
+	// checksum is computed in the same pass the assembled secret is written.
+	hasher := checksum.New()
+
+	encryptWriter, err := stream.EncryptSecretWriter(io.MultiWriter(file, hasher), dek, codec, log)
+	if err != nil {
+		return err
+	}
+
+	idKey := keys.ChunkIDKey(dek)
+	buf := &bytes.Buffer{}
+
+	log.Info().
+		Int("chunks", len(manifest.Chunks)).
+		Msg("Assembling secret from chunks...")
+
+	for _, ref := range manifest.Chunks {
+		buf.Reset()
+
+		chunkCtx, cancel := context.WithTimeout(ctx, cfg.RequestsTimeout)
+		_, err := objectReader.GetObjectRange(
+			chunkCtx, usr.BucketName, secret.ChunkObjectKey(ref.ID), 0, ref.Size, buf,
+		)
+
+		cancel()
+
+		if err != nil {
+			return err
+		}
+
+		chunk, err := stream.DecryptChunk(buf.Bytes(), dek)
+		if err != nil {
+			return fmt.Errorf("[%w] secret chunk %s", e.ErrCorrupt, ref.ID)
+		}
+
+		if keys.ChunkID(idKey, chunk) != ref.ID {
+			return fmt.Errorf("[%w] secret chunk %s", e.ErrCorrupt, ref.ID)
+		}
+
+		if _, err := encryptWriter.Write(chunk); err != nil {
+			return fmt.Errorf("[%w] secret file", e.ErrWrite)
+		}
+	}
+
+	if err := encryptWriter.Close(); err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrWrite)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrWrite)
+	}
+
+	info, err := os.Stat(assemblyPath)
+	if err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrRead)
+	}
+
+	if err := os.Rename(assemblyPath, encPath); err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrWrite)
+	}
+
+	resp.SecretSize = info.Size()
+	resp.SecretHash = hasher.Sum(nil)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package app_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/stream"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEncryptedFile(t *testing.T, path string, data, dek []byte) {
+	t.Helper()
+
+	log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+
+	reader, err := stream.EncryptSecretStream(bytes.NewReader(data), dek, log)
+	require.NoError(t, err)
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+	require.NoError(t, err)
+}
+
+func TestBuildManifest(t *testing.T) {
+	t.Parallel()
+
+	log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+	dir := t.TempDir()
+
+	dek, err := keys.DEK()
+	require.NoError(t, err)
+
+	data := make([]byte, 8*1024*1024)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	original := filepath.Join(dir, "original.secret")
+	writeEncryptedFile(t, original, data, dek)
+
+	manifest, err := app.BuildManifest(original, dek, stream.CodecNone, log)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), manifest.Size)
+	require.Greater(t, len(manifest.Chunks), 2)
+
+	// encryption of the same content is randomized, manifest is not.
+	reencrypted := filepath.Join(dir, "reencrypted.secret")
+	writeEncryptedFile(t, reencrypted, data, dek)
+
+	again, err := app.BuildManifest(reencrypted, dek, stream.CodecNone, log)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, again)
+
+	edited := append([]byte{}, data...)
+	edited[len(edited)/2] ^= 0xff
+
+	editedPath := filepath.Join(dir, "edited.secret")
+	writeEncryptedFile(t, editedPath, edited, dek)
+
+	changed, err := app.BuildManifest(editedPath, dek, stream.CodecNone, log)
+	require.NoError(t, err)
+
+	known := make(map[string]struct{}, len(manifest.Chunks))
+	for _, ref := range manifest.Chunks {
+		known[ref.ID] = struct{}{}
+	}
+
+	fresh := 0
+
+	for _, ref := range changed.Chunks {
+		if _, ok := known[ref.ID]; !ok {
+			fresh++
+		}
+	}
+
+	assert.LessOrEqual(t, fresh, 2)
+	assert.Positive(t, fresh)
+}
@@ -0,0 +1,445 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// KeepSide tells which side of the conflict is kept by resolution.
+type KeepSide string
+
+const (
+	KeepLocal  KeepSide = "local"
+	KeepRemote KeepSide = "remote"
+	KeepBoth   KeepSide = "both"
+)
+
+const (
+	maxSecretNameLen   = 64
+	forkSuffixTimeFmt  = "20060102150405"
+	forkSuffixTemplate = ".conflict-%s"
+)
+
+// ParseKeepSide validates conflict resolution side.
+func ParseKeepSide(keep string) (KeepSide, error) {
+	switch side := KeepSide(keep); side {
+	case KeepLocal, KeepRemote, KeepBoth:
+		return side, nil
+	default:
+		return "", fmt.Errorf("[%w] keep must be one of local, remote, both", e.ErrInvalidInput)
+	}
+}
+
+// ForkSecretName builds name of the secret which the losing side of the conflict is forked into.
+// Original name is trimmed so that fork name fits secret name limit.
+func ForkSecretName(secretName string, at time.Time) string {
+	suffix := fmt.Sprintf(forkSuffixTemplate, at.UTC().Format(forkSuffixTimeFmt))
+
+	name := []rune(secretName)
+	if maxLen := maxSecretNameLen - len(suffix); len(name) > maxLen {
+		name = name[:maxLen]
+	}
+
+	return string(name) + suffix
+}
+
+// openDB connects to local database upgrading its schema,
+// so that installations made by previous client versions keep working.
+func openDB(cfg *config.Config, log logger.Logger) (*sqlite.DB, error) {
+	zlog := log.GetZeroLog()
+
+	if err := sqlite.RunClientMigrations(cfg, log); err != nil {
+		zlog.Error().Err(err).Msg("Failed to migrate db")
+		return nil, err
+	}
+
+	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to connect to db")
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// secretFilePath is a path of the local encrypted secret file.
+func secretFilePath(cfg *config.Config, usr *user.User, secretName, secretID string) string {
+	return filepath.Join(cfg.InstallDir, usr.BucketName, fmt.Sprintf("%s_%s.secret", secretName, secretID))
+}
+
+// recordConflict downloads current server version of the diverged local secret next to it
+// and records the conflict, so that user can resolve it later.
+func recordConflict(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	local *dto.Secret,
+	log zerolog.Logger,
+) error {
+	log.Info().Msg("Downloading diverged server version...")
+
+	resp, err := requestSecretDownload(ctx, client, usr.ID.String(), local.SecretName, "")
+	if err != nil {
+		return err
+	}
+
+	remotePath := filepath.Join(
+		cfg.InstallDir,
+		usr.BucketName,
+		fmt.Sprintf("%s_%s.remote", local.SecretName, resp.VersionID),
+	)
+
+	if err := downloadSecret(ctx, cfg, client, secretRepo, usr, remotePath, resp, log); err != nil {
+		os.Remove(remotePath)
+		return err
+	}
+
+	metaData, err := parseMetaData(resp.MetaData)
+	if err != nil {
+		return err
+	}
+
+	conflict := &dto.SecretConflict{
+		UserID:                usr.ID.String(),
+		SecretID:              local.ID,
+		SecretName:            local.SecretName,
+		LocalVersionID:        local.VersionID,
+		RemoteSecretID:        resp.SecretID,
+		RemoteVersionID:       resp.VersionID,
+		RemoteParentVersionID: resp.ParentVersionID,
+		RemoteFilePath:        remotePath,
+		RemoteSecretSize:      resp.SecretSize,
+		RemoteSecretHash:      resp.SecretHash,
+		RemoteSecretDek:       resp.SecretDEK,
+		RemoteMetaData:        metaData,
+		DetectedAt:            time.Now().UTC(),
+	}
+
+	if err := secretRepo.CreateSecretConflict(ctx, conflict); err != nil {
+		log.Error().Err(err).Msg("Failed to record secret conflict")
+		return err
+	}
+
+	log.Warn().
+		Str("local_version_id", conflict.LocalVersionID).
+		Str("remote_version_id", conflict.RemoteVersionID).
+		Msg("Secret conflict recorded, resolve it with 'gkcli resolve'")
+
+	return nil
+}
+
+// ListConflicts prints conflicts of user secrets waiting for resolution.
+func ListConflicts(cfg *config.Config, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	defer cancel()
+
+	db, err := openDB(cfg, log)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+	secretRepo := repository.NewSecretRepo(db, zlog)
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	conflicts, err := secretRepo.ListSecretConflicts(ctx, usr.ID.String())
+	if err != nil {
+		return err
+	}
+
+	return printConflicts(os.Stdout, conflicts)
+}
+
+// ResolveConflict resolves recorded conflict of the secret keeping local, remote or both versions.
+// With both, local version is forked into a new secret and remote version takes the original name.
+//
+//nolint:funlen //reason: logging.
+func ResolveConflict(cfg *config.Config, secretName string, keep KeepSide, log logger.Logger) error {
+	zlog := log.GetZeroLog().With().
+		Str("secret_name", secretName).
+		Str("keep", string(keep)).Logger()
+
+	// transfer is limited per part by requests timeout.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := openDB(cfg, log)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+	secretRepo := repository.NewSecretRepo(db, zlog)
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	conflict, err := secretRepo.GetSecretConflict(ctx, usr.ID.String(), secretName)
+	if err != nil {
+		return err
+	}
+
+	local, err := secretRepo.GetSecret(ctx, usr.Username, secretName)
+	if err != nil {
+		return err
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	switch keep {
+	case KeepLocal:
+		scrt, err := keepLocalSecret(ctx, cfg, secretRepo, usr, local, conflict)
+		if err != nil {
+			return err
+		}
+
+		if err := secretRepo.DeleteSecretConflict(ctx, conflict); err != nil {
+			return err
+		}
+
+		return uploadSecret(ctx, cfg, client, secretRepo, usr, scrt, zlog)
+	case KeepRemote:
+		if err := keepRemoteSecret(ctx, cfg, secretRepo, usr, local, conflict); err != nil {
+			return err
+		}
+
+		return secretRepo.DeleteSecretConflict(ctx, conflict)
+	case KeepBoth:
+		fork, err := forkSecret(ctx, cfg, secretRepo, usr, local, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		if err := keepRemoteSecret(ctx, cfg, secretRepo, usr, local, conflict); err != nil {
+			return err
+		}
+
+		if err := secretRepo.DeleteSecretConflict(ctx, conflict); err != nil {
+			return err
+		}
+
+		zlog.Info().
+			Str("fork_name", fork.SecretName).
+			Msg("Local version forked into a new secret")
+
+		// fork stays unsynchronized locally if upload fails and is pushed by the next sync.
+		if err := uploadSecret(ctx, cfg, client, secretRepo, usr, fork, zlog); err != nil {
+			zlog.Warn().Err(err).Msg("Failed to upload forked secret")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("[%w] conflict resolution %s", e.ErrInvalidInput, keep)
+	}
+}
+
+// keepLocalSecret rebases local changes onto current server version.
+func keepLocalSecret(
+	ctx context.Context,
+	cfg *config.Config,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	local *dto.Secret,
+	conflict *dto.SecretConflict,
+) (*dto.Secret, error) {
+	scrt := *local
+	scrt.ID = conflict.RemoteSecretID
+	scrt.ParentVersionID = conflict.RemoteVersionID
+	scrt.UpdatedAt = time.Now().UTC()
+	scrt.InSync = false
+
+	if scrt.ID != local.ID {
+		// the same name was created independently on another device, local changes take over its identity.
+		scrt.FilePath = secretFilePath(cfg, usr, scrt.SecretName, scrt.ID)
+		if err := copyFile(local.FilePath, scrt.FilePath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := replaceLocalSecret(ctx, secretRepo, local, &scrt); err != nil {
+		return nil, err
+	}
+
+	if err := secretRepo.CreateOutboxOperation(ctx, dto.NewOutboxOperation(&scrt, dto.OutboxUpdate)); err != nil {
+		return nil, err
+	}
+
+	return &scrt, nil
+}
+
+// keepRemoteSecret replaces local secret with downloaded server version.
+func keepRemoteSecret(
+	ctx context.Context,
+	cfg *config.Config,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	local *dto.Secret,
+	conflict *dto.SecretConflict,
+) error {
+	parentVersionID := conflict.RemoteParentVersionID
+	if parentVersionID == "" {
+		parentVersionID = uuid.Nil.String()
+	}
+
+	now := time.Now().UTC()
+	scrt := &dto.Secret{
+		ID:              conflict.RemoteSecretID,
+		UserID:          usr.ID.String(),
+		SecretName:      conflict.SecretName,
+		VersionID:       conflict.RemoteVersionID,
+		ParentVersionID: parentVersionID,
+		FilePath:        secretFilePath(cfg, usr, conflict.SecretName, conflict.RemoteSecretID),
+		SecretSize:      conflict.RemoteSecretSize,
+		SecretHash:      conflict.RemoteSecretHash,
+		SecretDek:       conflict.RemoteSecretDek,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		InSync:          true,
+		MetaData:        conflict.RemoteMetaData,
+	}
+
+	if err := copyFile(conflict.RemoteFilePath, scrt.FilePath); err != nil {
+		return err
+	}
+
+	return replaceLocalSecret(ctx, secretRepo, local, scrt)
+}
+
+// forkSecret copies local secret into a new unsynchronized secret with fork name.
+func forkSecret(
+	ctx context.Context,
+	cfg *config.Config,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	local *dto.Secret,
+	now time.Time,
+) (*dto.Secret, error) {
+	fork := *local
+	fork.ID = uuid.New().String()
+	fork.SecretName = ForkSecretName(local.SecretName, now)
+	fork.VersionID = uuid.New().String()
+	fork.ParentVersionID = uuid.Nil.String()
+	fork.FilePath = secretFilePath(cfg, usr, fork.SecretName, fork.ID)
+	fork.CreatedAt = now
+	fork.UpdatedAt = now
+	fork.InSync = false
+
+	if err := copyFile(local.FilePath, fork.FilePath); err != nil {
+		return nil, err
+	}
+
+	if err := secretRepo.CreateSecret(ctx, &fork); err != nil {
+		os.Remove(fork.FilePath)
+		return nil, err
+	}
+
+	if err := secretRepo.CreateOutboxOperation(ctx, dto.NewOutboxOperation(&fork, dto.OutboxCreate)); err != nil {
+		return nil, err
+	}
+
+	return &fork, nil
+}
+
+// replaceLocalSecret stores scrt in place of the local secret which may have different identity.
+func replaceLocalSecret(
+	ctx context.Context,
+	secretRepo repository.SecretRepository,
+	local, scrt *dto.Secret,
+) error {
+	if local.ID == scrt.ID {
+		return secretRepo.UpdateSecret(ctx, scrt)
+	}
+
+	if err := secretRepo.DeleteSecret(ctx, local); err != nil {
+		return err
+	}
+
+	return secretRepo.CreateSecret(ctx, scrt)
+}
+
+// copyFile copies encrypted secret file to dstPath replacing it if exists.
+func copyFile(srcPath, dstPath string) error {
+	if srcPath == dstPath {
+		return nil
+	}
+
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrOpen)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, secretFilePerm)
+	if err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrOpen)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrWrite)
+	}
+
+	return nil
+}
+
+func printConflicts(out io.Writer, conflicts []*dto.SecretConflict) error {
+	const (
+		minWidth = 0
+		tabWidth = 8
+		padding  = 2
+	)
+
+	writer := tabwriter.NewWriter(out, minWidth, tabWidth, padding, ' ', 0)
+
+	fmt.Fprintln(writer, "NAME\tLOCAL VERSION\tREMOTE VERSION\tREMOTE TYPE\tDETECTED")
+
+	for _, conflict := range conflicts {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			conflict.SecretName,
+			conflict.LocalVersionID,
+			conflict.RemoteVersionID,
+			conflict.RemoteMetaData.Type(),
+			conflict.DetectedAt.Local().Format(time.DateTime),
+		)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("[%w] conflicts list", e.ErrWrite)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package app_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkSecretName(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, time.March, 7, 10, 11, 12, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		secretName string
+		want       string
+	}{
+		{
+			name:       "short name",
+			secretName: "github",
+			want:       "github.conflict-20250307101112",
+		},
+		{
+			name:       "long name is trimmed",
+			secretName: strings.Repeat("a", 64),
+			want:       strings.Repeat("a", 40) + ".conflict-20250307101112",
+		},
+		{
+			name:       "multibyte name is trimmed by runes",
+			secretName: strings.Repeat("ж", 50),
+			want:       strings.Repeat("ж", 40) + ".conflict-20250307101112",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := app.ForkSecretName(tt.secretName, at)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, utf8.RuneCountInString(got), 64)
+		})
+	}
+}
+
+func TestParseKeepSide(t *testing.T) {
+	t.Parallel()
+
+	for _, keep := range []string{"local", "remote", "both"} {
+		side, err := app.ParseKeepSide(keep)
+		require.NoError(t, err)
+		assert.Equal(t, app.KeepSide(keep), side)
+	}
+
+	_, err := app.ParseKeepSide("mine")
+	require.ErrorIs(t, err, e.ErrInvalidInput)
+}
@@ -10,10 +10,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
-	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/checksum"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
-	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/md5"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/stream"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
@@ -23,13 +22,30 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// SecretInput holds user provided content of the secret to create.
+type SecretInput struct {
+	Type        string
+	Name        string
+	Value       string
+	Card        CardInput
+	Credentials CredentialsInput
+	// Stdin is a source of text note content when it is piped to the command.
+	Stdin io.Reader
+	// Compression is the codec compressing content before encryption, none if empty.
+	Compression string
+}
+
 //nolint:cyclop,funlen //reason: to refactor
-func CreateSecret(cfg *config.Config, secretType, secretName, secretValue string, log logger.Logger) error {
+func CreateSecret(cfg *config.Config, input *SecretInput, log logger.Logger) error {
 	zlog := log.GetZeroLog()
 
-	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	codec, err := stream.ParseCodec(input.Compression)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDB(cfg, log)
 	if err != nil {
-		zlog.Error().Err(err).Msg("Failed to connect to db")
 		return err
 	}
 
@@ -58,11 +74,11 @@ func CreateSecret(cfg *config.Config, secretType, secretName, secretValue string
 		return err
 	}
 
-	_, err = secretRepo.GetSecret(ctx, cfg.Username, secretName)
+	_, err = secretRepo.GetSecret(ctx, cfg.Username, input.Name)
 	if err == nil {
 		zlog.Error().
 			Str("username", cfg.Username).
-			Str("secret_name", secretName).
+			Str("secret_name", input.Name).
 			Msg("Secret already exists")
 
 		return fmt.Errorf("[%w] db secret", e.ErrExists)
@@ -77,13 +93,15 @@ func CreateSecret(cfg *config.Config, secretType, secretName, secretValue string
 		secretErr error
 	)
 
-	switch secretType {
-	case "creds":
-		secretErr = e.ErrNotImplemented
-	case "binary":
-		scrt, secretErr = createBinarySecret(cfg, kek, secretValue, secretName, usr, zlog)
-	case "card":
-		secretErr = e.ErrNotImplemented
+	switch secret.Type(input.Type) {
+	case secret.TypeBinary:
+		scrt, secretErr = createBinarySecret(cfg, kek, input, codec, usr, zlog)
+	case secret.TypeCard:
+		scrt, secretErr = createCardSecret(cfg, kek, input, codec, usr, zlog)
+	case secret.TypeCredentials:
+		scrt, secretErr = createCredentialsSecret(cfg, kek, input, codec, usr, zlog)
+	case secret.TypeText:
+		scrt, secretErr = createTextSecret(cfg, kek, input, codec, usr, zlog)
 	default:
 		secretErr = e.ErrUnsupported
 	}
@@ -94,7 +112,7 @@ func CreateSecret(cfg *config.Config, secretType, secretName, secretValue string
 
 	zlog.Info().Msg("Storing secret in db...")
 
-	err = secretRepo.CreateSecret(ctx, &dto.Secret{
+	local := &dto.Secret{
 		ID:              scrt.ID.String(),
 		UserID:          scrt.UserID.String(),
 		SecretName:      scrt.Name,
@@ -107,33 +125,68 @@ func CreateSecret(cfg *config.Config, secretType, secretName, secretValue string
 		CreatedAt:       scrt.CreatedAt,
 		UpdatedAt:       scrt.UpdatedAt,
 		InSync:          false,
-	})
-	if err != nil {
+		MetaData:        secret.MetaData{secret.MetaKeyType: input.Type},
+	}
+
+	// binary secrets are stored in chunks, so that new versions only upload changed content.
+	if secret.Type(input.Type) == secret.TypeBinary {
+		local.MetaData[secret.MetaKeyStorage] = secret.StorageChunked
+	}
+
+	// codec is kept with the secret, so that its chunks are compressed the same way.
+	if codec != stream.CodecNone {
+		local.MetaData[secret.MetaKeyCompression] = codec.String()
+	}
+
+	if err := secretRepo.CreateSecret(ctx, local); err != nil {
 		zlog.Error().Err(err).Msg("Failed to create secret in db")
 		return err
 	}
 
 	zlog.Info().Msg("successfully stored secret in db!")
 
+	if err := queueSecretChange(ctx, secretRepo, dto.NewOutboxOperation(local, dto.OutboxCreate), zlog); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-//nolint:funlen //reason: to refactor
 func createBinarySecret(
 	cfg *config.Config,
 	kek []byte,
-	filePath, secretName string,
+	input *SecretInput,
+	codec stream.Codec,
 	usr *user.User,
 	log zerolog.Logger,
 ) (*secret.Secret, error) {
 	log.Info().Msg("Creating secret...")
 
-	srcFile, err := os.Open(filePath)
+	srcFile, err := os.Open(input.Value)
 	if err != nil {
 		return nil, fmt.Errorf("[%w] secret file", e.ErrRead)
 	}
 	defer srcFile.Close()
 
+	return encryptSecret(cfg, kek, srcFile, input, codec, usr, log)
+}
+
+// encryptSecret compresses secret content with the requested codec and encrypts it with a fresh
+// data encryption key into the user bucket directory and sets it as the current version of the new secret.
+// Secret size and hash are the ones of the written file, which is the one uploaded.
+//
+//nolint:funlen //reason: to refactor
+func encryptSecret(
+	cfg *config.Config,
+	kek []byte,
+	src io.Reader,
+	input *SecretInput,
+	codec stream.Codec,
+	usr *user.User,
+	log zerolog.Logger,
+) (*secret.Secret, error) {
+	secretName := input.Name
+
 	dek, err := keys.DEK()
 	if err != nil {
 		log.Error().Err(err).
@@ -161,12 +214,14 @@ func createBinarySecret(
 
 	log.Info().Msg("Encrypting secret...")
 
-	encryptReader, err := stream.EncryptSecretStream(srcFile, dek, log)
+	encryptReader, err := stream.EncryptCompressedStream(src, dek, codec, log)
 	if err != nil {
 		return nil, err
 	}
+	// checksum is computed in the same pass the encrypted secret is written.
+	hasher := checksum.New()
 
-	secretSize, err := io.Copy(destFile, encryptReader)
+	secretSize, err := io.Copy(io.MultiWriter(destFile, hasher), encryptReader)
 	if err != nil {
 		log.Error().Err(err).
 			Str("path", destPath).
@@ -176,16 +231,6 @@ func createBinarySecret(
 	}
 
 	log.Info().Msg("Encrypted secret successfully!")
-	log.Info().Msg("Getting secret md5 hash...")
-
-	secretMD5Hash, err := md5.GetFileMD5(destPath)
-	if err != nil {
-		log.Error().Err(err).
-			Str("path", destPath).
-			Msg("failed to get md5 hash for secret")
-
-		return nil, err
-	}
 
 	encryptedDEK, err := keys.WrapDEK(kek, dek)
 	if err != nil {
@@ -199,7 +244,7 @@ func createBinarySecret(
 		uuid.Nil,
 		destPath,
 		secretSize,
-		secretMD5Hash,
+		hasher.Sum(nil),
 		encryptedDEK,
 	)
 
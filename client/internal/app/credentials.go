@@ -0,0 +1,123 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	uavro "github.com/patraden/ya-practicum-gophkeeper/pkg/avro"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/stream"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/creds"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const credentialsSchemaFileName = "creds.avsc"
+
+// CredentialsInput is a login provided by user either with flags or as JSON value.
+type CredentialsInput struct {
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	URLs     []string `json:"urls"`
+	Notes    string   `json:"notes"`
+	TOTP     string   `json:"totp"`
+}
+
+// IsEmpty reports whether none of credentials fields were provided.
+func (in CredentialsInput) IsEmpty() bool {
+	return in.Username == "" && in.Password == "" && len(in.URLs) == 0 && in.Notes == "" && in.TOTP == ""
+}
+
+// ParseCredentials builds user credentials from JSON value or, if value is empty, from credentials input fields.
+func ParseCredentials(value string, input CredentialsInput, userID string) (*creds.UserCredentials, error) {
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), &input); err != nil {
+			return nil, fmt.Errorf("[%w] credentials json", e.ErrInvalidInput)
+		}
+	}
+
+	userCreds := &creds.UserCredentials{
+		UserID:   userID,
+		Username: strings.TrimSpace(input.Username),
+		Password: input.Password,
+		Urls:     input.URLs,
+		Notes:    input.Notes,
+	}
+
+	if userCreds.Urls == nil {
+		userCreds.Urls = []string{}
+	}
+
+	if input.TOTP != "" {
+		seed := creds.NormalizeTOTPSecret(input.TOTP)
+		userCreds.TotpSecret = &seed
+	}
+
+	if err := userCreds.Validate(); err != nil {
+		return nil, err
+	}
+
+	return userCreds, nil
+}
+
+func credentialsSchemaFile(cfg *config.Config) *uavro.SchemaFile {
+	return uavro.NewSchemaFile(filepath.Join(cfg.AvroSchemaDir, credentialsSchemaFileName))
+}
+
+func createCredentialsSecret(
+	cfg *config.Config,
+	kek []byte,
+	input *SecretInput,
+	codec stream.Codec,
+	usr *user.User,
+	log zerolog.Logger,
+) (*secret.Secret, error) {
+	log.Info().Msg("Creating credentials secret...")
+
+	userCreds, err := ParseCredentials(input.Value, input.Credentials, usr.ID.String())
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid credentials")
+		return nil, err
+	}
+
+	data, err := userCreds.Marshal(credentialsSchemaFile(cfg))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to serialize credentials")
+		return nil, err
+	}
+
+	return encryptSecret(cfg, kek, bytes.NewReader(data), input, codec, usr, log)
+}
+
+// decryptCredentialsSecret decrypts credentials secret and writes it as text
+// with masked password and TOTP secret unless reveal is set.
+func decryptCredentialsSecret(
+	cfg *config.Config,
+	encPath, outPath string,
+	dek []byte,
+	reveal bool,
+	log zerolog.Logger,
+) error {
+	var buf bytes.Buffer
+
+	if err := decryptSecretTo(encPath, &buf, dek, log); err != nil {
+		return err
+	}
+
+	userCreds, err := creds.UnmarshalUserCreds(credentialsSchemaFile(cfg), buf.Bytes())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to deserialize credentials")
+		return err
+	}
+
+	return writeSecretOutput(outPath, func(dest io.Writer) error {
+		_, err := io.WriteString(dest, userCreds.Format(reveal))
+		return err
+	}, log)
+}
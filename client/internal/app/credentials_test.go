@@ -0,0 +1,82 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCredentials(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		value      string
+		input      app.CredentialsInput
+		expectTOTP string
+		expectErr  error
+	}{
+		{
+			name: "from flags",
+			input: app.CredentialsInput{
+				Username: "denis",
+				Password: "secret",
+				URLs:     []string{"https://example.com"},
+				TOTP:     "jbsw y3dp ehpk 3pxp",
+			},
+			expectTOTP: "JBSWY3DPEHPK3PXP",
+		},
+		{
+			name:  "from json",
+			value: `{"username":"denis","password":"secret","urls":["https://example.com"],"notes":"work"}`,
+		},
+		{
+			name:      "invalid json",
+			value:     `{"username":`,
+			expectErr: e.ErrInvalidInput,
+		},
+		{
+			name:      "missing password",
+			input:     app.CredentialsInput{Username: "denis"},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "relative url",
+			input:     app.CredentialsInput{Username: "denis", Password: "secret", URLs: []string{"example.com"}},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "invalid totp seed",
+			input:     app.CredentialsInput{Username: "denis", Password: "secret", TOTP: "not-base32!"},
+			expectErr: e.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			userCreds, err := app.ParseCredentials(tt.value, tt.input, "user")
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "denis", userCreds.Username)
+			require.Equal(t, "secret", userCreds.Password)
+			require.Equal(t, []string{"https://example.com"}, userCreds.Urls)
+			require.Equal(t, "user", userCreds.UserID)
+
+			if tt.expectTOTP == "" {
+				require.Nil(t, userCreds.TotpSecret)
+				return
+			}
+
+			require.NotNil(t, userCreds.TotpSecret)
+			require.Equal(t, tt.expectTOTP, *userCreds.TotpSecret)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeleteSecret deletes the secret on the server provided it was not changed there
+// since last sync and then removes local secret record and its encrypted file.
+// If the server is unreachable, deletion of the local secret is queued and replayed by the next sync.
+//
+//nolint:funlen //reason: to refactor
+func DeleteSecret(cfg *config.Config, secretName string, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	// server calls are limited by requests timeout, so that deletion is queued when server is unreachable.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := openDB(cfg, log)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+	secretRepo := repository.NewSecretRepo(db, zlog)
+
+	zlog.Info().Msg("Validating user...")
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	zlog.Info().Msg("User is valid!...")
+
+	local, err := secretRepo.GetSecret(ctx, usr.Username, secretName)
+	if err != nil && !errors.Is(err, e.ErrNotFound) {
+		return err
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	versionID, err := expectedRemoteVersion(ctx, client, usr.ID.String(), secretName, local)
+	if err != nil {
+		return err
+	}
+
+	if versionID != "" {
+		zlog.Info().Msg("Sending delete request to server...")
+
+		_, err = client.SecretDelete(ctx, usr.ID.String(), secretName, versionID)
+
+		switch {
+		case isOffline(err) && local != nil:
+			op := dto.NewOutboxOperation(local, dto.OutboxDelete)
+			op.VersionID = versionID
+
+			if err := queueSecretChange(ctx, secretRepo, op, zlog); err != nil {
+				return err
+			}
+
+			zlog.Warn().Err(err).Msg("Server is unreachable, deletion is queued until next sync")
+		case err != nil && status.Code(err) != codes.NotFound:
+			return err
+		default:
+			zlog.Info().Msg("Secret deleted on server!!")
+		}
+	}
+
+	if local == nil {
+		return nil
+	}
+
+	if err := secretRepo.DeleteSecret(ctx, local); err != nil {
+		zlog.Error().Err(err).Msg("Failed to delete local secret")
+		return err
+	}
+
+	zlog.Info().Msg("Local secret deleted successfully!")
+
+	return nil
+}
+
+// expectedRemoteVersion resolves server version of the secret deletion is based on.
+// It is the last synced version of the local secret or current server version if secret is not known locally.
+// Empty version means that secret has never been uploaded to the server.
+func expectedRemoteVersion(
+	ctx context.Context,
+	client *grpcclient.Client,
+	userID, secretName string,
+	local *dto.Secret,
+) (string, error) {
+	if local != nil {
+		if local.InSync {
+			return local.VersionID, nil
+		}
+
+		if local.ParentVersionID == uuid.Nil.String() {
+			return "", nil
+		}
+
+		return local.ParentVersionID, nil
+	}
+
+	resp, err := client.ListSecretVersions(ctx, userID, secretName)
+	if status.Code(err) == codes.NotFound {
+		return "", fmt.Errorf("[%w] secret %s", e.ErrNotFound, secretName)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetCurrentVersionId(), nil
+}
+
+// applyTombstone removes local copy of the secret deleted on the server from another device.
+// Local secret with unsynchronized changes is kept and ErrConflict is returned.
+func applyTombstone(
+	ctx context.Context,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	scrt *dto.Secret,
+	log zerolog.Logger,
+) (bool, error) {
+	resp, err := client.ListSecretTombstones(ctx, scrt.UserID, time.Time{})
+	if err != nil {
+		return false, err
+	}
+
+	for _, pbTombstone := range resp.GetTombstones() {
+		tombstone := dto.SecretTombstoneFromProto(pbTombstone)
+		if tombstone.SecretID != scrt.ID {
+			continue
+		}
+
+		if !scrt.InSync {
+			log.Error().
+				Str("secret_name", scrt.SecretName).
+				Time("deleted_at", tombstone.DeletedAt).
+				Msg("Secret with local changes was deleted on server")
+
+			return false, fmt.Errorf("[%w] secret was deleted on server", e.ErrConflict)
+		}
+
+		if err := secretRepo.DeleteSecret(ctx, scrt); err != nil {
+			return false, err
+		}
+
+		log.Info().
+			Str("secret_name", scrt.SecretName).
+			Time("deleted_at", tombstone.DeletedAt).
+			Msg("Secret was deleted on server, local copy removed")
+
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+)
+
+// ListDevices prints devices user tokens have been issued to.
+func ListDevices(cfg *config.Config, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	defer cancel()
+
+	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to connect to db")
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	resp, err := client.ListDevices(ctx)
+	if err != nil {
+		return err
+	}
+
+	devices := make([]dto.DeviceInfo, 0, len(resp.GetDevices()))
+	for _, device := range resp.GetDevices() {
+		devices = append(devices, dto.DeviceInfoFromProto(device))
+	}
+
+	return printDevices(os.Stdout, devices)
+}
+
+// RevokeDevice revokes user device, tokens issued to it are rejected by the server afterwards.
+func RevokeDevice(cfg *config.Config, deviceID string, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	defer cancel()
+
+	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to connect to db")
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	resp, err := client.RevokeDevice(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+
+	return printDevices(os.Stdout, []dto.DeviceInfo{dto.DeviceInfoFromProto(resp.GetDevice())})
+}
+
+func printDevices(out io.Writer, devices []dto.DeviceInfo) error {
+	const (
+		minWidth = 0
+		tabWidth = 8
+		padding  = 2
+	)
+
+	writer := tabwriter.NewWriter(out, minWidth, tabWidth, padding, ' ', 0)
+
+	fmt.Fprintln(writer, "DEVICE ID\tCLIENT INFO\tFIRST SEEN\tLAST SEEN\tSTATUS\tCURRENT")
+
+	for _, device := range devices {
+		status, current := "active", ""
+
+		if device.IsRevoked() {
+			status = "revoked"
+		}
+
+		if device.Current {
+			current = "*"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			device.DeviceID,
+			device.ClientInfo,
+			device.FirstSeenAt.Local().Format(time.DateTime),
+			device.LastSeenAt.Local().Format(time.DateTime),
+			status,
+			current,
+		)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("[%w] devices", e.ErrWrite)
+	}
+
+	return nil
+}
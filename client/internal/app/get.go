@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/stream"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+const secretFilePerm = 0o600
+
+// GetSecret downloads current or requested version of the secret from the server,
+// decrypts it and writes plaintext to outPath or to stdout if outPath is empty.
+//
+//nolint:funlen //reason: to refactor
+func GetSecret(cfg *config.Config, secretName, versionID, outPath string, reveal bool, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	// transfer is limited per part by requests timeout.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := openDB(cfg, log)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+	secretRepo := repository.NewSecretRepo(db, zlog)
+
+	zlog.Info().Msg("Validating user...")
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	kek, err := keys.KEK(usr, cfg.Password)
+	if err != nil {
+		zlog.Error().Err(err).
+			Msg("Failed generate keys encryption key")
+
+		return err
+	}
+
+	zlog.Info().Msg("User is valid!...")
+	zlog.Info().Msg("Sending get request to server...")
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	resp, err := requestSecretDownload(ctx, client, usr.ID.String(), secretName, versionID)
+	if err != nil {
+		return err
+	}
+
+	zlog.Info().Msg("Get request confirmed by server!!")
+
+	encPath := filepath.Join(
+		cfg.InstallDir,
+		usr.BucketName,
+		fmt.Sprintf("%s_%s.download", secretName, resp.VersionID),
+	)
+	defer os.Remove(encPath)
+
+	if err := downloadSecret(ctx, cfg, client, secretRepo, usr, encPath, resp, zlog); err != nil {
+		return err
+	}
+
+	dek, err := keys.UnwrapDEK(kek, resp.SecretDEK)
+	if err != nil {
+		zlog.Error().Err(err).
+			Msg("Failed to decrypt data encryption key")
+
+		return err
+	}
+
+	metaData, err := parseMetaData(resp.MetaData)
+	if err != nil {
+		return err
+	}
+
+	switch metaData.Type() {
+	case secret.TypeCard:
+		return decryptCardSecret(cfg, encPath, outPath, dek, reveal, zlog)
+	case secret.TypeCredentials:
+		return decryptCredentialsSecret(cfg, encPath, outPath, dek, reveal, zlog)
+	case secret.TypeText:
+		return decryptTextSecret(encPath, outPath, dek, zlog)
+	default:
+		return decryptSecret(encPath, outPath, dek, zlog)
+	}
+}
+
+// parseMetaData parses secret metadata received from the server.
+func parseMetaData(raw string) (secret.MetaData, error) {
+	metaData := secret.MetaData{}
+	if raw == "" {
+		return metaData, nil
+	}
+
+	if err := metaData.UnmarshalJSON([]byte(raw)); err != nil {
+		return nil, fmt.Errorf("[%w] secret metadata", e.ErrUnmarshal)
+	}
+
+	return metaData, nil
+}
+
+// requestSecretDownload asks server for a download of the specific secret version
+// or of the current one if versionID is empty.
+func requestSecretDownload(
+	ctx context.Context,
+	client *grpcclient.Client,
+	userID, secretName, versionID string,
+) (*dto.SecretDownloadInitResponse, error) {
+	if versionID == "" {
+		resp, err := client.SecretGetInitRequest(ctx, userID, secretName)
+		if err != nil {
+			return nil, err
+		}
+
+		return dto.SecretDownloadInitResponseFromProto(resp), nil
+	}
+
+	resp, err := client.GetSecretVersion(ctx, userID, secretName, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.GetSecretVersionResponseFromProto(resp), nil
+}
+
+// decryptSecret streams encrypted file through decryption into outPath or stdout.
+func decryptSecret(encPath, outPath string, dek []byte, log zerolog.Logger) error {
+	return writeSecretOutput(outPath, func(dest io.Writer) error {
+		return decryptSecretTo(encPath, dest, dek, log)
+	}, log)
+}
+
+// decryptSecretTo streams encrypted file through decryption into dest.
+func decryptSecretTo(encPath string, dest io.Writer, dek []byte, log zerolog.Logger) error {
+	srcFile, err := os.Open(encPath)
+	if err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrRead)
+	}
+	defer srcFile.Close()
+
+	decryptReader, err := stream.DecryptSecretStream(srcFile, dek, log)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(dest, decryptReader); err != nil {
+		log.Error().Err(err).
+			Msg("failed to write decrypted secret")
+
+		return fmt.Errorf("[%w] write decrypted stream", e.ErrWrite)
+	}
+
+	log.Info().Msg("Secret decrypted successfully!")
+
+	return nil
+}
+
+// writeSecretOutput opens outPath (stdout if empty) and passes it to write.
+func writeSecretOutput(outPath string, write func(dest io.Writer) error, log zerolog.Logger) error {
+	if outPath == "" {
+		return write(os.Stdout)
+	}
+
+	destFile, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, secretFilePerm)
+	if err != nil {
+		log.Error().Err(err).
+			Str("path", outPath).
+			Msg("failed to create output file")
+
+		return fmt.Errorf("[%w] output file", e.ErrOpen)
+	}
+	defer destFile.Close()
+
+	if err := write(destFile); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+)
+
+// SecretHistory prints all server versions of the secret, newest first.
+func SecretHistory(cfg *config.Config, secretName string, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	defer cancel()
+
+	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to connect to db")
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	resp, err := client.ListSecretVersions(ctx, usr.ID.String(), secretName)
+	if err != nil {
+		return err
+	}
+
+	return printSecretHistory(os.Stdout, dto.ListSecretVersionsResponseFromProto(resp))
+}
+
+func printSecretHistory(out io.Writer, history *dto.ListSecretVersionsResponse) error {
+	const (
+		minWidth = 0
+		tabWidth = 8
+		padding  = 2
+		noValue  = "-"
+	)
+
+	writer := tabwriter.NewWriter(out, minWidth, tabWidth, padding, ' ', 0)
+
+	fmt.Fprintln(writer, "VERSION\tPARENT\tSIZE\tCREATED\tCURRENT")
+
+	for _, ver := range history.Versions {
+		parent, current := noValue, ""
+
+		if ver.ParentVersionID != "" {
+			parent = ver.ParentVersionID
+		}
+
+		if ver.VersionID == history.CurrentVersionID {
+			current = "*"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\n",
+			ver.VersionID,
+			parent,
+			ver.SecretSize,
+			ver.CreatedAt.Local().Format(time.DateTime),
+			current,
+		)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("[%w] secret history", e.ErrWrite)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/google/uuid"
 	"github.com/mailru/easyjson"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
@@ -16,6 +17,7 @@ import (
 const (
 	appDirPermissions = 0o700
 	caCertFilename    = "ca.cert"
+	avroSchemaDirName = "avro"
 )
 
 //nolint:funlen //reason: logging.
@@ -47,6 +49,10 @@ func SetupLocal(cfg *config.Config, log logger.Logger) error {
 		return err
 	}
 
+	if err := CopyAvroSchemasToInstallDir(cfg, zlog); err != nil {
+		return err
+	}
+
 	dbPath := filepath.Join(basePath, cfg.DatabaseFileName)
 
 	db, err := sql.Open("sqlite3", dbPath)
@@ -70,6 +76,9 @@ func SetupLocal(cfg *config.Config, log logger.Logger) error {
 		return e.InternalErr(err)
 	}
 
+	// device ID identifies this installation on the server for its whole lifetime.
+	cfg.DeviceID = uuid.NewString()
+
 	if err := SaveToFile(cfg, zlog); err != nil {
 		zlog.Error().Err(err).
 			Str("path", configFilePath).
@@ -113,6 +122,21 @@ func SaveToFile(cfg *config.Config, log zerolog.Logger) error {
 	return nil
 }
 
+// ensureDeviceID generates device ID for installation made before devices were registered on the server.
+func ensureDeviceID(cfg *config.Config, log zerolog.Logger) error {
+	if cfg.DeviceID != "" {
+		return nil
+	}
+
+	cfg.DeviceID = uuid.NewString()
+
+	log.Info().
+		Str("device_id", cfg.DeviceID).
+		Msg("Generated device ID of the installation")
+
+	return SaveToFile(cfg, log)
+}
+
 func CopyCACertToInstallDir(cfg *config.Config, log zerolog.Logger) error {
 	srcPath := cfg.ServerTLSCertPath
 	dstPath := filepath.Join(cfg.InstallDir, caCertFilename)
@@ -158,3 +182,56 @@ func CopyCACertToInstallDir(cfg *config.Config, log zerolog.Logger) error {
 
 	return nil
 }
+
+// CopyAvroSchemasToInstallDir copies avro schemas of structured secrets to install directory,
+// so that client does not depend on the working directory.
+func CopyAvroSchemasToInstallDir(cfg *config.Config, log zerolog.Logger) error {
+	srcDir := cfg.AvroSchemaDir
+	dstDir := filepath.Join(cfg.InstallDir, avroSchemaDirName)
+
+	log.Info().
+		Str("src", srcDir).
+		Str("dst", dstDir).
+		Msg("Copying avro schemas to install directory")
+
+	schemas, err := filepath.Glob(filepath.Join(srcDir, "*.avsc"))
+	if err != nil {
+		return e.InternalErr(err)
+	}
+
+	if err := os.MkdirAll(dstDir, appDirPermissions); err != nil {
+		log.Error().Err(err).
+			Str("dst", dstDir).
+			Msg("Failed to create avro schemas directory")
+
+		return e.InternalErr(err)
+	}
+
+	for _, srcPath := range schemas {
+		data, err := os.ReadFile(srcPath)
+		if err != nil {
+			log.Error().Err(err).
+				Str("src", srcPath).
+				Msg("Failed to read avro schema")
+
+			return e.InternalErr(err)
+		}
+
+		dstPath := filepath.Join(dstDir, filepath.Base(srcPath))
+		if err := os.WriteFile(dstPath, data, secretFilePerm); err != nil {
+			log.Error().Err(err).
+				Str("dst", dstPath).
+				Msg("Failed to write avro schema")
+
+			return e.InternalErr(err)
+		}
+	}
+
+	cfg.AvroSchemaDir = dstDir
+
+	log.Info().
+		Int("schemas", len(schemas)).
+		Msg("Avro schemas copied successfully")
+
+	return nil
+}
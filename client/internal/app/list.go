@@ -0,0 +1,206 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+)
+
+// SyncState describes how local secret relates to its server copy.
+type SyncState string
+
+const (
+	SyncStateLocalOnly  SyncState = "local-only"
+	SyncStateRemoteOnly SyncState = "remote-only"
+	SyncStateInSync     SyncState = "in-sync"
+	SyncStateDiverged   SyncState = "diverged"
+)
+
+// SecretListing is a merged view of local and remote secret.
+type SecretListing struct {
+	Name   string
+	State  SyncState
+	Local  *dto.Secret
+	Remote *dto.SecretInfo
+}
+
+// ListSecrets prints user secrets known locally or on the server together with their sync state.
+func ListSecrets(cfg *config.Config, namePrefix string, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	defer cancel()
+
+	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to connect to db")
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+	secretRepo := repository.NewSecretRepo(db, zlog)
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	local, err := secretRepo.ListSecrets(ctx, usr.Username)
+	if err != nil {
+		return err
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	remote, err := listRemoteSecrets(ctx, client, usr.ID.String(), namePrefix)
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]*dto.Secret, 0, len(local))
+
+	for _, scrt := range local {
+		if strings.HasPrefix(scrt.SecretName, namePrefix) {
+			filtered = append(filtered, scrt)
+		}
+	}
+
+	return printSecretListings(os.Stdout, MergeSecrets(filtered, remote))
+}
+
+// listRemoteSecrets fetches all pages of user secrets from the server.
+func listRemoteSecrets(
+	ctx context.Context,
+	client *grpcclient.Client,
+	userID, namePrefix string,
+) ([]dto.SecretInfo, error) {
+	var (
+		secrets   []dto.SecretInfo
+		pageToken string
+	)
+
+	for {
+		resp, err := client.ListSecrets(ctx, userID, namePrefix, pageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range resp.GetSecrets() {
+			secrets = append(secrets, dto.SecretInfoFromProto(info))
+		}
+
+		pageToken = resp.GetNextPageToken()
+		if pageToken == "" {
+			return secrets, nil
+		}
+	}
+}
+
+// MergeSecrets joins local and remote secrets by secret id and resolves their sync state.
+func MergeSecrets(local []*dto.Secret, remote []dto.SecretInfo) []SecretListing {
+	listings := make([]SecretListing, 0, len(local)+len(remote))
+	remoteByID := make(map[string]*dto.SecretInfo, len(remote))
+
+	for i := range remote {
+		remoteByID[remote[i].SecretID] = &remote[i]
+	}
+
+	for _, scrt := range local {
+		listing := SecretListing{Name: scrt.SecretName, Local: scrt, State: SyncStateLocalOnly}
+
+		if info, ok := remoteByID[scrt.ID]; ok {
+			listing.Remote = info
+			listing.State = SyncStateDiverged
+
+			if scrt.InSync && scrt.VersionID == info.VersionID {
+				listing.State = SyncStateInSync
+			}
+
+			delete(remoteByID, scrt.ID)
+		}
+
+		listings = append(listings, listing)
+	}
+
+	for i := range remote {
+		if _, ok := remoteByID[remote[i].SecretID]; ok {
+			listings = append(listings, SecretListing{
+				Name:   remote[i].SecretName,
+				State:  SyncStateRemoteOnly,
+				Remote: &remote[i],
+			})
+		}
+	}
+
+	sort.Slice(listings, func(i, j int) bool { return listings[i].Name < listings[j].Name })
+
+	return listings
+}
+
+func printSecretListings(out io.Writer, listings []SecretListing) error {
+	const (
+		minWidth = 0
+		tabWidth = 8
+		padding  = 2
+		noValue  = "-"
+	)
+
+	writer := tabwriter.NewWriter(out, minWidth, tabWidth, padding, ' ', 0)
+
+	fmt.Fprintln(writer, "NAME\tSTATE\tLOCAL VERSION\tREMOTE VERSION\tSIZE\tUPDATED")
+
+	for _, listing := range listings {
+		localVersion, remoteVersion := noValue, noValue
+
+		var (
+			size      int64
+			updatedAt time.Time
+		)
+
+		if listing.Remote != nil {
+			remoteVersion = listing.Remote.VersionID
+			size = listing.Remote.SecretSize
+			updatedAt = listing.Remote.UpdatedAt
+		}
+
+		if listing.Local != nil {
+			localVersion = listing.Local.VersionID
+			size = listing.Local.SecretSize
+			updatedAt = listing.Local.UpdatedAt
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			listing.Name,
+			listing.State,
+			localVersion,
+			remoteVersion,
+			size,
+			updatedAt.Local().Format(time.DateTime),
+		)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("[%w] secrets list", e.ErrWrite)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSecrets(t *testing.T) {
+	t.Parallel()
+
+	local := []*dto.Secret{
+		{ID: "1", SecretName: "synced", VersionID: "v1", InSync: true},
+		{ID: "2", SecretName: "changed", VersionID: "v3", InSync: false},
+		{ID: "3", SecretName: "new", VersionID: "v1", InSync: false},
+	}
+
+	remote := []dto.SecretInfo{
+		{SecretID: "1", SecretName: "synced", VersionID: "v1"},
+		{SecretID: "2", SecretName: "changed", VersionID: "v2"},
+		{SecretID: "4", SecretName: "elsewhere", VersionID: "v1"},
+	}
+
+	listings := app.MergeSecrets(local, remote)
+	require.Len(t, listings, 4)
+
+	states := make(map[string]app.SyncState, len(listings))
+	for _, listing := range listings {
+		states[listing.Name] = listing.State
+	}
+
+	require.Equal(t, map[string]app.SyncState{
+		"synced":    app.SyncStateInSync,
+		"changed":   app.SyncStateDiverged,
+		"new":       app.SyncStateLocalOnly,
+		"elsewhere": app.SyncStateRemoteOnly,
+	}, states)
+
+	require.Equal(t, "changed", listings[0].Name)
+	require.Equal(t, "synced", listings[3].Name)
+}
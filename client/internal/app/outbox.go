@@ -0,0 +1,197 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/retry"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OutboxAction is what replay does with a queued offline change.
+type OutboxAction string
+
+const (
+	OutboxActionPush        OutboxAction = "push"
+	OutboxActionDelete      OutboxAction = "delete"
+	OutboxActionAcknowledge OutboxAction = "acknowledge"
+	OutboxActionDrop        OutboxAction = "drop"
+)
+
+// PlanOutboxOperation decides how to replay the queued change given current local and server state,
+// so that change applied by an interrupted replay is not applied again:
+//   - version already current on server is only acknowledged locally;
+//   - version superseded or deleted locally, already synced or deleted on server is dropped;
+//   - otherwise version is pushed or deleted on server.
+func PlanOutboxOperation(op *dto.OutboxOperation, local *dto.Secret, remote []dto.SecretInfo) OutboxAction {
+	var current *dto.SecretInfo
+
+	// remote secrets are listed by name prefix.
+	for i := range remote {
+		if remote[i].SecretName == op.SecretName {
+			current = &remote[i]
+		}
+	}
+
+	if op.Operation == dto.OutboxDelete {
+		if current == nil || current.SecretID != op.SecretID {
+			return OutboxActionDrop
+		}
+
+		return OutboxActionDelete
+	}
+
+	if local == nil || local.ID != op.SecretID || local.VersionID != op.VersionID {
+		return OutboxActionDrop
+	}
+
+	if current != nil && current.VersionID == op.VersionID {
+		if local.InSync {
+			return OutboxActionDrop
+		}
+
+		return OutboxActionAcknowledge
+	}
+
+	if local.InSync {
+		return OutboxActionDrop
+	}
+
+	return OutboxActionPush
+}
+
+// queueSecretChange records local secret change to be replayed to the server.
+func queueSecretChange(
+	ctx context.Context,
+	secretRepo repository.SecretRepository,
+	op *dto.OutboxOperation,
+	log zerolog.Logger,
+) error {
+	if err := secretRepo.CreateOutboxOperation(ctx, op); err != nil {
+		log.Error().Err(err).Msg("Failed to queue secret change")
+		return err
+	}
+
+	return nil
+}
+
+// replayOutbox pushes secret changes made offline to the server in the order they were made.
+// Each change is retried with backoff while the server is unreachable. Replay stops at the first
+// change that fails otherwise, keeping it and later changes queued for the next replay.
+func replayOutbox(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	log zerolog.Logger,
+) error {
+	ops, err := secretRepo.ListOutboxOperations(ctx, usr.ID.String())
+	if err != nil || len(ops) == 0 {
+		return err
+	}
+
+	log.Info().
+		Int("operations", len(ops)).
+		Msg("Replaying offline changes...")
+
+	for _, op := range ops {
+		opLog := log.With().
+			Int64("seq", op.Seq).
+			Str("operation", string(op.Operation)).
+			Str("secret_name", op.SecretName).
+			Str("version_id", op.VersionID).Logger()
+
+		err := retry.WithRetry(ctx, backoff.NewExponentialBackOff(), opLog, isOffline, func() error {
+			return replayOutboxOperation(ctx, cfg, client, secretRepo, usr, op, opLog)
+		})
+		if err != nil {
+			if failErr := secretRepo.SetOutboxOperationFailed(ctx, op, err); failErr != nil {
+				opLog.Error().Err(failErr).Msg("Failed to record replay attempt")
+			}
+
+			opLog.Error().Err(err).Msg("Failed to replay offline change, later changes stay queued")
+
+			return err
+		}
+
+		if err := secretRepo.DeleteOutboxOperation(ctx, op); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Msg("Offline changes replayed!!")
+
+	return nil
+}
+
+// replayOutboxOperation applies single queued change against current server state.
+func replayOutboxOperation(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	op *dto.OutboxOperation,
+	log zerolog.Logger,
+) error {
+	local, err := secretRepo.GetSecret(ctx, usr.Username, op.SecretName)
+	if err != nil && !errors.Is(err, e.ErrNotFound) {
+		return err
+	}
+
+	remote, err := listRemoteSecrets(ctx, client, usr.ID.String(), op.SecretName)
+	if err != nil {
+		return err
+	}
+
+	switch PlanOutboxOperation(op, local, remote) {
+	case OutboxActionPush:
+		err := uploadSecret(ctx, cfg, client, secretRepo, usr, local, log)
+		if errors.Is(err, e.ErrConflict) {
+			// conflict is recorded locally and resolved with 'gkcli resolve'.
+			return nil
+		}
+
+		return err
+	case OutboxActionDelete:
+		_, err := client.SecretDelete(ctx, usr.ID.String(), op.SecretName, op.VersionID)
+		switch status.Code(err) {
+		case codes.OK, codes.NotFound:
+			log.Info().Msg("Secret deleted on server!!")
+			return nil
+		case codes.Aborted:
+			log.Warn().Msg("Secret was changed on server after it was deleted offline, server version is kept")
+			return nil
+		default:
+			return err
+		}
+	case OutboxActionAcknowledge:
+		log.Info().Msg("Secret version was already committed by server")
+		return secretRepo.SetSecretInSync(ctx, local, true)
+	case OutboxActionDrop:
+		log.Info().Msg("Offline change is already applied or superseded")
+	}
+
+	return nil
+}
+
+// isOffline reports whether request failed because the server is unreachable,
+// so that the change is queued or its replay is retried until connectivity comes back.
+func isOffline(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
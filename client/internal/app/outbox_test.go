@@ -0,0 +1,125 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+//nolint:funlen // reason: table driven testing functions are ok to be long
+func TestPlanOutboxOperation(t *testing.T) {
+	t.Parallel()
+
+	const (
+		secretID  = "11111111-1111-1111-1111-111111111111"
+		otherID   = "22222222-2222-2222-2222-222222222222"
+		versionID = "33333333-3333-3333-3333-333333333333"
+		parentID  = "44444444-4444-4444-4444-444444444444"
+		newerID   = "55555555-5555-5555-5555-555555555555"
+	)
+
+	push := &dto.OutboxOperation{
+		SecretID:   secretID,
+		SecretName: "github",
+		VersionID:  versionID,
+		Operation:  dto.OutboxUpdate,
+	}
+	remove := &dto.OutboxOperation{
+		SecretID:   secretID,
+		SecretName: "github",
+		VersionID:  versionID,
+		Operation:  dto.OutboxDelete,
+	}
+
+	local := func(versionID string, inSync bool) *dto.Secret {
+		return &dto.Secret{ID: secretID, SecretName: "github", VersionID: versionID, InSync: inSync}
+	}
+	remote := func(secretID, versionID string) []dto.SecretInfo {
+		return []dto.SecretInfo{
+			{SecretID: otherID, SecretName: "github-work", VersionID: newerID},
+			{SecretID: secretID, SecretName: "github", VersionID: versionID},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		op     *dto.OutboxOperation
+		local  *dto.Secret
+		remote []dto.SecretInfo
+		want   app.OutboxAction
+	}{
+		{
+			name:   "local version is pushed",
+			op:     push,
+			local:  local(versionID, false),
+			remote: remote(secretID, parentID),
+			want:   app.OutboxActionPush,
+		},
+		{
+			name:   "new secret is pushed",
+			op:     push,
+			local:  local(versionID, false),
+			remote: nil,
+			want:   app.OutboxActionPush,
+		},
+		{
+			name:   "version committed by interrupted replay is acknowledged",
+			op:     push,
+			local:  local(versionID, false),
+			remote: remote(secretID, versionID),
+			want:   app.OutboxActionAcknowledge,
+		},
+		{
+			name:   "synced version is dropped",
+			op:     push,
+			local:  local(versionID, true),
+			remote: remote(secretID, versionID),
+			want:   app.OutboxActionDrop,
+		},
+		{
+			name:   "version superseded locally is dropped",
+			op:     push,
+			local:  local(newerID, false),
+			remote: remote(secretID, parentID),
+			want:   app.OutboxActionDrop,
+		},
+		{
+			name:   "secret deleted locally is dropped",
+			op:     push,
+			local:  nil,
+			remote: remote(secretID, parentID),
+			want:   app.OutboxActionDrop,
+		},
+		{
+			name:   "deletion is sent to server",
+			op:     remove,
+			local:  nil,
+			remote: remote(secretID, versionID),
+			want:   app.OutboxActionDelete,
+		},
+		{
+			name:   "secret already deleted on server is dropped",
+			op:     remove,
+			local:  nil,
+			remote: nil,
+			want:   app.OutboxActionDrop,
+		},
+		{
+			name:   "secret recreated on server under the same name is kept",
+			op:     remove,
+			local:  nil,
+			remote: remote(otherID, newerID),
+			want:   app.OutboxActionDrop,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, app.PlanOutboxOperation(tt.op, tt.local, tt.remote))
+		})
+	}
+}
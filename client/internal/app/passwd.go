@@ -0,0 +1,235 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// passwordChangeAttempts limits attempts to change password on server
+// while user secrets are updated concurrently from other devices.
+const passwordChangeAttempts = 3
+
+// ChangePassword changes password of the user to newPassword.
+// DEKs of all user secret versions are unwrapped with KEK of the current password and wrapped
+// with KEK of the new one, first on server and then locally, server never sees either KEK.
+// Salt of the new password is stored before server is called, so that interrupted change
+// is completed by running it again with the same passwords.
+func ChangePassword(cfg *config.Config, newPassword string, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	if newPassword == "" {
+		return fmt.Errorf("[%w] empty new password", e.ErrInvalidInput)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	defer cancel()
+
+	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to connect to db")
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	kek, err := keys.KEK(usr, cfg.Password)
+	if err != nil {
+		return err
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	params, err := client.GetLoginParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	change, newKEK, err := startPasswordChange(ctx, userRepo, usr, newPassword, params.GetSalt())
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(params.GetSalt(), change.Salt) {
+		zlog.Info().Msg("Server has already accepted new password, completing password change...")
+	} else if err := changeServerPassword(ctx, client, usr, kek, newKEK, change, zlog); err != nil {
+		return err
+	}
+
+	// server revokes all user sessions, so user logs in again with the new password.
+	cfg.Password = newPassword
+
+	if err := userRepo.DeleteUserToken(ctx, usr); err != nil {
+		return err
+	}
+
+	localKeys, err := userRepo.ListSecretKeys(ctx, usr)
+	if err != nil {
+		return err
+	}
+
+	rewrapped, err := rewrapKeys(localKeys, kek, newKEK)
+	if err != nil {
+		return err
+	}
+
+	change.Apply(usr)
+
+	if err := userRepo.ChangeUserKeys(ctx, usr, rewrapped); err != nil {
+		return err
+	}
+
+	zlog.Info().Int("keys", len(rewrapped)).Msg("Password successfully changed!")
+
+	return nil
+}
+
+// startPasswordChange returns KEK of the new password along with password change of the user,
+// change in progress is resumed if it is for the same new password, otherwise a new one is started.
+// Change which server has already accepted can only be completed with its password.
+func startPasswordChange(
+	ctx context.Context,
+	repo repository.UserRepository,
+	usr *user.User,
+	newPassword string,
+	serverSalt []byte,
+) (*user.PasswordChange, []byte, error) {
+	change, err := repo.GetPasswordChange(ctx, usr)
+	if err != nil && !errors.Is(err, e.ErrNotFound) {
+		return nil, nil, err
+	}
+
+	if err == nil {
+		newKEK, err := keys.DeriveKEK(newPassword, change.Salt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if change.CheckAuthKey(keys.AuthKey(newKEK)) {
+			return change, newKEK, nil
+		}
+
+		if bytes.Equal(serverSalt, change.Salt) {
+			return nil, nil, fmt.Errorf("[%w] new password does not match the one accepted by server", e.ErrInvalidInput)
+		}
+	}
+
+	salt, err := user.NewSalt()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newKEK, err := keys.DeriveKEK(newPassword, salt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	change = user.NewPasswordChange(usr.ID, keys.AuthKey(newKEK), salt)
+	if err := repo.SavePasswordChange(ctx, change); err != nil {
+		return nil, nil, err
+	}
+
+	return change, newKEK, nil
+}
+
+// changeServerPassword re-wraps DEKs of all user secret versions stored on server and changes
+// user auth key. Keys are listed again if server rejects them as outdated.
+func changeServerPassword(
+	ctx context.Context,
+	client *grpcclient.Client,
+	usr *user.User,
+	kek, newKEK []byte,
+	change *user.PasswordChange,
+	log zerolog.Logger,
+) error {
+	for attempt := 1; attempt <= passwordChangeAttempts; attempt++ {
+		serverKeys, err := listServerKeys(ctx, client, usr)
+		if err != nil {
+			return err
+		}
+
+		rewrapped, err := rewrapKeys(serverKeys, kek, newKEK)
+		if err != nil {
+			return err
+		}
+
+		log.Info().Int("keys", len(rewrapped)).Msg("Sending password change request to server...")
+
+		_, err = client.ChangePassword(ctx, keys.AuthKey(kek), keys.AuthKey(newKEK), change.Salt, rewrapped)
+		if status.Code(err) != codes.Aborted {
+			return err
+		}
+
+		log.Warn().Err(err).
+			Int("attempt", attempt).
+			Msg("Secrets are being updated, retrying password change...")
+	}
+
+	return fmt.Errorf("[%w] secrets are being updated, try again later", e.ErrConflict)
+}
+
+// listServerKeys lists wrapped DEKs of all user secret versions stored on server.
+// Staff users have no secrets.
+func listServerKeys(ctx context.Context, client *grpcclient.Client, usr *user.User) ([]*secret.WrappedKey, error) {
+	if user.IsStaff(usr.Role) {
+		return []*secret.WrappedKey{}, nil
+	}
+
+	resp, err := client.ListSecretKeys(ctx, usr.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.SecretKeysFromProto(resp.GetKeys())
+}
+
+// rewrapKeys unwraps DEKs with kek and wraps them with newKEK.
+func rewrapKeys(wrapped []*secret.WrappedKey, kek, newKEK []byte) ([]*secret.WrappedKey, error) {
+	rewrapped := make([]*secret.WrappedKey, 0, len(wrapped))
+
+	for _, key := range wrapped {
+		dek, err := keys.UnwrapDEK(kek, key.DEK)
+		if err != nil {
+			return nil, fmt.Errorf("[%w] key of secret version %s", e.ErrInvalidInput, key.VersionID)
+		}
+
+		encryptedDEK, err := keys.WrapDEK(newKEK, dek)
+		if err != nil {
+			return nil, err
+		}
+
+		rewrapped = append(rewrapped, &secret.WrappedKey{
+			SecretID:  key.SecretID,
+			VersionID: key.VersionID,
+			DEK:       encryptedDEK,
+		})
+	}
+
+	return rewrapped, nil
+}
@@ -0,0 +1,352 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// SyncAction is what full sync does with a secret.
+type SyncAction string
+
+const (
+	SyncActionPush        SyncAction = "push"
+	SyncActionPull        SyncAction = "pull"
+	SyncActionDeleteLocal SyncAction = "delete-local"
+	SyncActionUpToDate    SyncAction = "up-to-date"
+	SyncActionConflict    SyncAction = "conflict"
+)
+
+// SyncItem is a planned sync action for a single secret.
+type SyncItem struct {
+	Name      string
+	Action    SyncAction
+	Local     *dto.Secret
+	Remote    *dto.SecretInfo
+	Tombstone *dto.SecretTombstone
+	Err       error
+}
+
+// PlanSync reconciles local and remote secrets and decides what to do with each of them:
+//   - remote version newer than synced local one is pulled;
+//   - local changes based on current remote version are pushed;
+//   - synced local secret deleted on server is removed locally;
+//   - local changes based on outdated or deleted remote version are reported as conflicts.
+func PlanSync(local []*dto.Secret, remote []dto.SecretInfo, tombstones []dto.SecretTombstone) []SyncItem {
+	items := make([]SyncItem, 0, len(local)+len(remote))
+	remoteByID := make(map[string]*dto.SecretInfo, len(remote))
+	remoteByName := make(map[string]*dto.SecretInfo, len(remote))
+	tombstoneByID := make(map[string]*dto.SecretTombstone, len(tombstones))
+
+	for i := range remote {
+		remoteByID[remote[i].SecretID] = &remote[i]
+		remoteByName[remote[i].SecretName] = &remote[i]
+	}
+
+	for i := range tombstones {
+		tombstoneByID[tombstones[i].SecretID] = &tombstones[i]
+	}
+
+	for _, scrt := range local {
+		item := SyncItem{Name: scrt.SecretName, Local: scrt}
+
+		info, onServer := remoteByID[scrt.ID]
+		tombstone, deleted := tombstoneByID[scrt.ID]
+
+		switch {
+		case onServer:
+			item.Remote = info
+			item.Action = planSyncedSecret(scrt, info)
+
+			delete(remoteByName, info.SecretName)
+		case deleted:
+			item.Tombstone = tombstone
+			item.Action = SyncActionDeleteLocal
+
+			if !scrt.InSync {
+				item.Action = SyncActionConflict
+			}
+		case remoteByName[scrt.SecretName] != nil:
+			// the same name was created independently on another device.
+			item.Remote = remoteByName[scrt.SecretName]
+			item.Action = SyncActionConflict
+
+			delete(remoteByName, scrt.SecretName)
+		case !scrt.InSync && scrt.ParentVersionID == uuid.Nil.String():
+			item.Action = SyncActionPush
+		default:
+			// secret was synced, but server has neither the secret nor its tombstone.
+			item.Action = SyncActionConflict
+		}
+
+		items = append(items, item)
+	}
+
+	for _, info := range remoteByName {
+		items = append(items, SyncItem{Name: info.SecretName, Action: SyncActionPull, Remote: info})
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+
+	return items
+}
+
+func planSyncedSecret(scrt *dto.Secret, info *dto.SecretInfo) SyncAction {
+	if scrt.InSync {
+		if scrt.VersionID == info.VersionID {
+			return SyncActionUpToDate
+		}
+
+		return SyncActionPull
+	}
+
+	if scrt.ParentVersionID == info.VersionID {
+		return SyncActionPush
+	}
+
+	return SyncActionConflict
+}
+
+// syncAll performs full bidirectional sync of user secrets and prints per-secret summary.
+func syncAll(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	log zerolog.Logger,
+) error {
+	// offline changes are replayed first, so that deleted secrets are not pulled back.
+	if err := replayOutbox(ctx, cfg, client, secretRepo, usr, log); err != nil {
+		return err
+	}
+
+	local, err := secretRepo.ListSecrets(ctx, usr.Username)
+	if err != nil {
+		return err
+	}
+
+	remote, err := listRemoteSecrets(ctx, client, usr.ID.String(), "")
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ListSecretTombstones(ctx, usr.ID.String(), time.Time{})
+	if err != nil {
+		return err
+	}
+
+	tombstones := make([]dto.SecretTombstone, 0, len(resp.GetTombstones()))
+	for _, pbTombstone := range resp.GetTombstones() {
+		tombstones = append(tombstones, dto.SecretTombstoneFromProto(pbTombstone))
+	}
+
+	items := PlanSync(local, remote, tombstones)
+	unsynced := 0
+
+	for i := range items {
+		item := &items[i]
+		itemLog := log.With().
+			Str("secret_name", item.Name).
+			Str("action", string(item.Action)).Logger()
+
+		applySyncItem(ctx, cfg, client, secretRepo, usr, item, itemLog)
+
+		if item.Err != nil {
+			itemLog.Error().Err(item.Err).Msg("Failed to sync secret")
+		}
+
+		if item.Err != nil || item.Action == SyncActionConflict {
+			unsynced++
+		}
+	}
+
+	if err := printSyncSummary(os.Stdout, items); err != nil {
+		return err
+	}
+
+	if unsynced > 0 {
+		return fmt.Errorf("[%w] %d secrets are not synchronized", e.ErrConflict, unsynced)
+	}
+
+	return nil
+}
+
+// applySyncItem performs planned sync action of the item recording its error.
+func applySyncItem(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	item *SyncItem,
+	log zerolog.Logger,
+) {
+	switch item.Action {
+	case SyncActionPush:
+		item.Err = uploadSecret(ctx, cfg, client, secretRepo, usr, item.Local, log)
+	case SyncActionPull:
+		item.Err = pullSecret(ctx, cfg, client, secretRepo, usr, item.Local, item.Name, log)
+	case SyncActionDeleteLocal:
+		item.Err = secretRepo.DeleteSecret(ctx, item.Local)
+	case SyncActionConflict:
+		if item.Remote != nil {
+			item.Err = recordConflict(ctx, cfg, client, secretRepo, usr, item.Local, log)
+		}
+	case SyncActionUpToDate:
+	}
+}
+
+// pullSecret downloads current server version of the secret and stores it locally as synchronized,
+// so that local version and parent version chain follow the server.
+func pullSecret(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	local *dto.Secret,
+	secretName string,
+	log zerolog.Logger,
+) error {
+	log.Info().Msg("Pulling secret from server...")
+
+	resp, err := requestSecretDownload(ctx, client, usr.ID.String(), secretName, "")
+	if err != nil {
+		return err
+	}
+
+	secretPath := filepath.Join(
+		cfg.InstallDir,
+		usr.BucketName,
+		fmt.Sprintf("%s_%s.secret", secretName, resp.SecretID),
+	)
+	encPath := filepath.Join(
+		cfg.InstallDir,
+		usr.BucketName,
+		fmt.Sprintf("%s_%s.download", secretName, resp.VersionID),
+	)
+	defer os.Remove(encPath)
+
+	if err := downloadSecret(ctx, cfg, client, secretRepo, usr, encPath, resp, log); err != nil {
+		return err
+	}
+
+	if err := os.Rename(encPath, secretPath); err != nil {
+		log.Error().Err(err).
+			Str("path", secretPath).
+			Msg("failed to replace local secret file")
+
+		return fmt.Errorf("[%w] secret file", e.ErrWrite)
+	}
+
+	scrt, err := SavePulledSecret(ctx, secretRepo, usr, local, secretName, secretPath, resp)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to store pulled secret in db")
+		return err
+	}
+
+	log.Info().
+		Str("version_id", scrt.VersionID).
+		Msg("Secret pulled successfully!")
+
+	return nil
+}
+
+// SavePulledSecret stores downloaded secret version in sync with server,
+// creating local secret or replacing current version of the existing one.
+func SavePulledSecret(
+	ctx context.Context,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	local *dto.Secret,
+	secretName, secretPath string,
+	resp *dto.SecretDownloadInitResponse,
+) (*dto.Secret, error) {
+	metaData, err := parseMetaData(resp.MetaData)
+	if err != nil {
+		return nil, err
+	}
+
+	parentVersionID := resp.ParentVersionID
+	if parentVersionID == "" {
+		parentVersionID = uuid.Nil.String()
+	}
+
+	now := time.Now().UTC()
+	scrt := &dto.Secret{
+		ID:              resp.SecretID,
+		UserID:          usr.ID.String(),
+		SecretName:      secretName,
+		VersionID:       resp.VersionID,
+		ParentVersionID: parentVersionID,
+		FilePath:        secretPath,
+		SecretSize:      resp.SecretSize,
+		SecretHash:      resp.SecretHash,
+		SecretDek:       resp.SecretDEK,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		InSync:          true,
+		MetaData:        metaData,
+	}
+
+	if local == nil {
+		err = secretRepo.CreateSecret(ctx, scrt)
+	} else {
+		err = secretRepo.UpdateSecret(ctx, scrt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scrt, nil
+}
+
+func printSyncSummary(out io.Writer, items []SyncItem) error {
+	const (
+		minWidth = 0
+		tabWidth = 8
+		padding  = 2
+	)
+
+	writer := tabwriter.NewWriter(out, minWidth, tabWidth, padding, ' ', 0)
+
+	fmt.Fprintln(writer, "NAME\tACTION\tRESULT")
+
+	for _, item := range items {
+		result := "ok"
+
+		switch {
+		case item.Err != nil:
+			result = item.Err.Error()
+		case item.Action == SyncActionConflict:
+			result = "local and remote changes diverged"
+			if item.Remote != nil {
+				result += ", run resolve"
+			}
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", item.Name, item.Action, result)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("[%w] sync summary", e.ErrWrite)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package app_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanSync(t *testing.T) {
+	t.Parallel()
+
+	noParent := uuid.Nil.String()
+
+	local := []*dto.Secret{
+		{ID: "1", SecretName: "synced", VersionID: "v1", ParentVersionID: noParent, InSync: true},
+		{ID: "2", SecretName: "outdated", VersionID: "v1", ParentVersionID: noParent, InSync: true},
+		{ID: "3", SecretName: "changed", VersionID: "v3", ParentVersionID: "v2", InSync: false},
+		{ID: "4", SecretName: "diverged", VersionID: "v3", ParentVersionID: "v1", InSync: false},
+		{ID: "5", SecretName: "new", VersionID: "v1", ParentVersionID: noParent, InSync: false},
+		{ID: "6", SecretName: "deleted", VersionID: "v1", ParentVersionID: noParent, InSync: true},
+		{ID: "7", SecretName: "deleted-changed", VersionID: "v2", ParentVersionID: "v1", InSync: false},
+		{ID: "8", SecretName: "same-name", VersionID: "v1", ParentVersionID: noParent, InSync: false},
+	}
+
+	remote := []dto.SecretInfo{
+		{SecretID: "1", SecretName: "synced", VersionID: "v1"},
+		{SecretID: "2", SecretName: "outdated", VersionID: "v2"},
+		{SecretID: "3", SecretName: "changed", VersionID: "v2"},
+		{SecretID: "4", SecretName: "diverged", VersionID: "v2"},
+		{SecretID: "9", SecretName: "same-name", VersionID: "v1"},
+		{SecretID: "10", SecretName: "elsewhere", VersionID: "v1"},
+	}
+
+	tombstones := []dto.SecretTombstone{
+		{SecretID: "6", SecretName: "deleted", VersionID: "v1"},
+		{SecretID: "7", SecretName: "deleted-changed", VersionID: "v1"},
+	}
+
+	items := app.PlanSync(local, remote, tombstones)
+
+	actions := make(map[string]app.SyncAction, len(items))
+	for _, item := range items {
+		actions[item.Name] = item.Action
+	}
+
+	require.Equal(t, map[string]app.SyncAction{
+		"synced":          app.SyncActionUpToDate,
+		"outdated":        app.SyncActionPull,
+		"changed":         app.SyncActionPush,
+		"diverged":        app.SyncActionConflict,
+		"new":             app.SyncActionPush,
+		"deleted":         app.SyncActionDeleteLocal,
+		"deleted-changed": app.SyncActionConflict,
+		"same-name":       app.SyncActionConflict,
+		"elsewhere":       app.SyncActionPull,
+	}, actions)
+}
+
+func TestSavePulledSecretIsUpToDate(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.InstallDir = t.TempDir()
+
+	log := logger.Stdout(zerolog.Disabled)
+	require.NoError(t, sqlite.RunClientMigrations(cfg, log))
+
+	db, err := sqlite.NewDB(filepath.Join(cfg.InstallDir, cfg.DatabaseFileName))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	usr, err := user.NewWithID(uuid.NewString(), "patraden", user.RoleUser)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	err = db.Queries.CreateUser(t.Context(), sqlite.CreateUserParams{
+		ID:         usr.ID.String(),
+		Username:   usr.Username,
+		Verifier:   []byte("verifier"),
+		Role:       usr.Role,
+		Salt:       []byte("salt"),
+		Bucketname: usr.BucketName,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	require.NoError(t, err)
+
+	remote := dto.SecretInfo{SecretID: uuid.NewString(), SecretName: "remote-only", VersionID: uuid.NewString()}
+	resp := &dto.SecretDownloadInitResponse{
+		UserID:          usr.ID.String(),
+		SecretID:        remote.SecretID,
+		SecretName:      remote.SecretName,
+		VersionID:       remote.VersionID,
+		ParentVersionID: uuid.NewString(),
+		SecretSize:      4,
+		SecretHash:      []byte("hash"),
+		SecretDEK:       []byte("dek"),
+	}
+
+	secretRepo := repository.NewSecretRepo(db, log.GetZeroLog())
+
+	items := app.PlanSync(nil, []dto.SecretInfo{remote}, nil)
+	require.Len(t, items, 1)
+	require.Equal(t, app.SyncActionPull, items[0].Action)
+
+	_, err = app.SavePulledSecret(t.Context(), secretRepo, usr, nil, remote.SecretName, "remote-only.secret", resp)
+	require.NoError(t, err)
+
+	local, err := secretRepo.GetSecret(t.Context(), usr.Username, remote.SecretName)
+	require.NoError(t, err)
+	require.True(t, local.InSync)
+
+	items = app.PlanSync([]*dto.Secret{local}, []dto.SecretInfo{remote}, nil)
+	require.Len(t, items, 1)
+	require.Equal(t, app.SyncActionUpToDate, items[0].Action)
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
-	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/auth"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
@@ -18,6 +18,10 @@ import (
 func RegisterUser(cfg *config.Config, log logger.Logger) error {
 	zlog := log.GetZeroLog()
 
+	if err := ensureDeviceID(cfg, zlog); err != nil {
+		return err
+	}
+
 	client, err := grpcclient.New(cfg, zlog)
 	if err != nil {
 		return e.InternalErr(err)
@@ -47,13 +51,15 @@ func RegisterUser(cfg *config.Config, log logger.Logger) error {
 	usr.BucketName = resp.GetBucketName()
 	usr.Verifier = resp.GetVerifier()
 
-	token := &dto.ServerToken{
-		UserID: resp.GetUserId(),
-		Token:  resp.GetToken(),
-		TTL:    resp.GetTokenTtlSeconds(),
-	}
+	token := dto.NewServerToken(
+		resp.GetUserId(),
+		resp.GetToken(),
+		resp.GetTokenTtlSeconds(),
+		resp.GetRefreshToken(),
+		resp.GetRefreshTokenTtlSeconds(),
+	)
 
-	if ok := auth.VerifyVerifier(cfg.Password, usr.Salt, usr.Verifier); !ok {
+	if _, err := keys.KEK(usr, cfg.Password); err != nil {
 		return fmt.Errorf("[%w] wrong user verifier", e.ErrInternal)
 	}
 
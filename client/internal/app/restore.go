@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+)
+
+// RestoreSecret makes an older version of the secret current again.
+// Restored content is uploaded as a new version which parent is the current server version,
+// so that the regular optimistic concurrency checks apply.
+//
+//nolint:funlen //reason: to refactor
+func RestoreSecret(cfg *config.Config, secretName, versionID string, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	// transfer is limited per part by requests timeout.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := openDB(cfg, log)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+	secretRepo := repository.NewSecretRepo(db, zlog)
+
+	zlog.Info().Msg("Validating user...")
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	zlog.Info().Msg("User is valid!...")
+
+	local, err := secretRepo.GetSecret(ctx, usr.Username, secretName)
+	if err != nil && !errors.Is(err, e.ErrNotFound) {
+		return err
+	}
+
+	if local != nil && !local.InSync {
+		zlog.Error().
+			Str("secret_name", secretName).
+			Msg("Local secret has unsynchronized changes")
+
+		return fmt.Errorf("[%w] local secret is not in sync", e.ErrConflict)
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	resp, err := requestSecretDownload(ctx, client, usr.ID.String(), secretName, versionID)
+	if err != nil {
+		return err
+	}
+
+	if resp.VersionID == resp.CurrentVersionID {
+		zlog.Info().
+			Str("version_id", versionID).
+			Msg("Requested version is already current")
+
+		return nil
+	}
+
+	secretPath := filepath.Join(
+		cfg.InstallDir,
+		usr.BucketName,
+		fmt.Sprintf("%s_%s.secret", secretName, resp.SecretID),
+	)
+	encPath := filepath.Join(
+		cfg.InstallDir,
+		usr.BucketName,
+		fmt.Sprintf("%s_%s.download", secretName, resp.VersionID),
+	)
+	defer os.Remove(encPath)
+
+	zlog.Info().Msg("Downloading restored version...")
+
+	if err := downloadSecret(ctx, cfg, client, secretRepo, usr, encPath, resp, zlog); err != nil {
+		return err
+	}
+
+	if err := os.Rename(encPath, secretPath); err != nil {
+		zlog.Error().Err(err).
+			Str("path", secretPath).
+			Msg("failed to replace local secret file")
+
+		return fmt.Errorf("[%w] secret file", e.ErrWrite)
+	}
+
+	metaData, err := parseMetaData(resp.MetaData)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	scrt := &dto.Secret{
+		ID:              resp.SecretID,
+		UserID:          usr.ID.String(),
+		SecretName:      secretName,
+		VersionID:       uuid.New().String(),
+		ParentVersionID: resp.CurrentVersionID,
+		FilePath:        secretPath,
+		SecretSize:      resp.SecretSize,
+		SecretHash:      resp.SecretHash,
+		SecretDek:       resp.SecretDEK,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		InSync:          false,
+		MetaData:        metaData,
+	}
+
+	if local == nil {
+		err = secretRepo.CreateSecret(ctx, scrt)
+	} else {
+		err = secretRepo.UpdateSecret(ctx, scrt)
+	}
+
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to store restored secret in db")
+		return err
+	}
+
+	if err := uploadSecret(ctx, cfg, client, secretRepo, usr, scrt, zlog); err != nil {
+		return err
+	}
+
+	zlog.Info().
+		Str("version_id", scrt.VersionID).
+		Msg("Secret restored successfully!")
+
+	return nil
+}
@@ -0,0 +1,473 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TokenRefresher renews access token with refresh token issued to the device.
+type TokenRefresher interface {
+	RefreshToken(ctx context.Context, refreshToken string) (*pb.RefreshTokenResponse, error)
+}
+
+// RefreshUserToken renews user access token which is about to expire and stores rotated token pair,
+// token which is still valid is returned as is. Rejected refresh token is deleted, as server never
+// accepts it again, and ErrUnauthenticated is returned so that user logs in again.
+func RefreshUserToken(
+	ctx context.Context,
+	refresher TokenRefresher,
+	repo repository.UserRepository,
+	usr *user.User,
+	token *dto.ServerToken,
+) (*dto.ServerToken, error) {
+	if !token.NeedsRefresh() {
+		return token, nil
+	}
+
+	return renewUserToken(ctx, refresher, repo, usr, token)
+}
+
+// renewUserToken exchanges refresh token for a new token pair regardless of access token expiration.
+func renewUserToken(
+	ctx context.Context,
+	refresher TokenRefresher,
+	repo repository.UserRepository,
+	usr *user.User,
+	token *dto.ServerToken,
+) (*dto.ServerToken, error) {
+	if !token.IsRefreshable() {
+		return nil, fmt.Errorf("[%w] session is expired, login again", e.ErrUnauthenticated)
+	}
+
+	resp, err := refresher.RefreshToken(ctx, token.RefreshToken)
+	if status.Code(err) == codes.Unauthenticated {
+		if err := repo.DeleteUserToken(ctx, usr); err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("[%w] session is revoked, login again", e.ErrUnauthenticated)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed := dto.NewServerToken(
+		usr.ID.String(),
+		resp.GetToken(),
+		resp.GetTokenTtlSeconds(),
+		resp.GetRefreshToken(),
+		resp.GetRefreshTokenTtlSeconds(),
+	)
+
+	if err := repo.SaveUserToken(ctx, refreshed); err != nil {
+		return nil, err
+	}
+
+	return refreshed, nil
+}
+
+// userTokens is the grpcclient.TokenSource of the local user. Access token is renewed with refresh token,
+// user logs in again with configured credentials when there is no token or refresh token is rejected.
+// Calls are serialized, so that concurrent server calls never present the same refresh token twice.
+type userTokens struct {
+	mu     sync.Mutex
+	client *grpcclient.Client
+	repo   repository.UserRepository
+	usr    *user.User
+	log    zerolog.Logger
+}
+
+// Token returns access token of the user, renewing it if needed.
+func (t *userTokens) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	token, err := t.repo.GetUserToken(ctx, t.usr)
+	if errors.Is(err, e.ErrNotFound) {
+		return t.login(ctx)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	token, err = RefreshUserToken(ctx, t.client, t.repo, t.usr, token)
+	if errors.Is(err, e.ErrUnauthenticated) {
+		return t.login(ctx)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return token.Token, nil
+}
+
+// Renew obtains new access token after server has rejected the current one.
+func (t *userTokens) Renew(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	token, err := t.repo.GetUserToken(ctx, t.usr)
+	if err != nil && !errors.Is(err, e.ErrNotFound) {
+		return "", err
+	}
+
+	if err == nil && token.IsRefreshable() {
+		token, err = renewUserToken(ctx, t.client, t.repo, t.usr, token)
+		if err == nil {
+			return token.Token, nil
+		}
+
+		if !errors.Is(err, e.ErrUnauthenticated) {
+			return "", err
+		}
+	}
+
+	return t.login(ctx)
+}
+
+// login logs user in from this device and stores issued token.
+func (t *userTokens) login(ctx context.Context) (string, error) {
+	t.log.Info().Msg("Logging in...")
+
+	resp, err := t.client.Login(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token := serverTokenFromLogin(resp)
+	if err := t.repo.SaveUserToken(ctx, token); err != nil {
+		return "", err
+	}
+
+	return token.Token, nil
+}
+
+// newUserClient creates server client making calls on behalf of the local user.
+func newUserClient(
+	cfg *config.Config,
+	repo repository.UserRepository,
+	usr *user.User,
+	log zerolog.Logger,
+) (*grpcclient.Client, error) {
+	if err := ensureDeviceID(cfg, log); err != nil {
+		return nil, err
+	}
+
+	client, err := grpcclient.New(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	client.UseTokenSource(&userTokens{
+		client: client,
+		repo:   repo,
+		usr:    usr,
+		log:    log,
+	})
+
+	return client, nil
+}
+
+func serverTokenFromLogin(resp *pb.LoginResponse) *dto.ServerToken {
+	return dto.NewServerToken(
+		resp.GetUserId(),
+		resp.GetToken(),
+		resp.GetTokenTtlSeconds(),
+		resp.GetRefreshToken(),
+		resp.GetRefreshTokenTtlSeconds(),
+	)
+}
+
+// Login logs user in from this device and stores issued token.
+// User registered from another device is set up locally on the first login.
+func Login(cfg *config.Config, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	if err := ensureDeviceID(cfg, zlog); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	defer cancel()
+
+	client, err := grpcclient.New(cfg, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	zlog.Info().Msg("Sending user login request to server...")
+
+	resp, err := client.Login(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to connect to db")
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+	token := serverTokenFromLogin(resp)
+
+	usr, err := userRepo.GetUser(ctx, cfg.Username)
+	if errors.Is(err, e.ErrNotFound) {
+		return createLoggedInUser(ctx, cfg, userRepo, resp, token, zlog)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if usr.ID.String() != resp.GetUserId() {
+		return fmt.Errorf("[%w] local user %s belongs to another server user", e.ErrConflict, cfg.Username)
+	}
+
+	// verifier of legacy user is replaced by server when user is migrated to auth key,
+	// salt is replaced along with KEK when password is changed on another device.
+	localUsr := &user.User{ID: usr.ID, Username: usr.Username, Role: usr.Role, Salt: usr.Salt, Verifier: usr.Verifier}
+	saltChanged := !bytes.Equal(usr.Salt, resp.GetSalt())
+	if saltChanged || !bytes.Equal(usr.Verifier, resp.GetVerifier()) {
+		usr.Salt = resp.GetSalt()
+		usr.Verifier = resp.GetVerifier()
+
+		if _, err := keys.KEK(usr, cfg.Password); err != nil {
+			return fmt.Errorf("[%w] wrong user verifier", e.ErrInternal)
+		}
+	}
+
+	if !saltChanged && !bytes.Equal(usr.Verifier, resp.GetVerifier()) {
+		if err := userRepo.UpdateUserVerifier(ctx, usr); err != nil {
+			return err
+		}
+	}
+
+	if err := userRepo.SaveUserToken(ctx, token); err != nil {
+		return err
+	}
+
+	if saltChanged {
+		client.UseTokenSource(&userTokens{client: client, repo: userRepo, usr: usr, log: zlog})
+
+		if err := refreshUserKeys(ctx, cfg, client, userRepo, localUsr, usr, zlog); err != nil {
+			return err
+		}
+	}
+
+	zlog.Info().Msg("Successfully logged in!")
+	warnTOTPEnrollment(resp, zlog)
+
+	return nil
+}
+
+// refreshUserKeys replaces local DEKs of the user with ones re-wrapped with the new KEK on
+// another device the password was changed from, new salt and verifier of the user are stored along.
+// Versions never uploaded to server are re-wrapped locally from KEK of the old password of localUsr.
+func refreshUserKeys(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	repo repository.UserRepository,
+	localUsr *user.User,
+	usr *user.User,
+	log zerolog.Logger,
+) error {
+	log.Info().Msg("Password was changed on another device, updating local secret keys...")
+
+	serverKeys, err := listServerKeys(ctx, client, usr)
+	if err != nil {
+		return err
+	}
+
+	localKeys, err := repo.ListSecretKeys(ctx, usr)
+	if err != nil {
+		return err
+	}
+
+	unsynced := UnsyncedKeys(localKeys, serverKeys)
+	for _, key := range unsynced {
+		log.Warn().
+			Str("secret_id", key.SecretID.String()).
+			Str("version_id", key.VersionID.String()).
+			Msg("Secret version was not uploaded before password change, it is re-wrapped with old password")
+	}
+
+	rewrapped, err := RewrapUnsyncedKeys(unsynced, localUsr, usr, cfg.OldPassword, cfg.Password)
+	if err != nil {
+		return err
+	}
+
+	return repo.ChangeUserKeys(ctx, usr, append(serverKeys, rewrapped...))
+}
+
+// UnsyncedKeys returns local keys of secret versions missing among keys stored on server.
+func UnsyncedKeys(localKeys, serverKeys []*secret.WrappedKey) []*secret.WrappedKey {
+	versions := make(map[uuid.UUID]struct{}, len(serverKeys))
+	for _, key := range serverKeys {
+		versions[key.VersionID] = struct{}{}
+	}
+
+	unsynced := make([]*secret.WrappedKey, 0)
+
+	for _, key := range localKeys {
+		if _, ok := versions[key.VersionID]; !ok {
+			unsynced = append(unsynced, key)
+		}
+	}
+
+	return unsynced
+}
+
+// RewrapUnsyncedKeys re-wraps DEKs of secret versions never uploaded to server from KEK of
+// localUsr old password to KEK of usr password. Server never had these keys to re-wrap them,
+// so the old password is required, otherwise versions could never be decrypted again.
+func RewrapUnsyncedKeys(
+	unsynced []*secret.WrappedKey,
+	localUsr *user.User,
+	usr *user.User,
+	oldPassword string,
+	password string,
+) ([]*secret.WrappedKey, error) {
+	if len(unsynced) == 0 {
+		return []*secret.WrappedKey{}, nil
+	}
+
+	if oldPassword == "" {
+		return nil, fmt.Errorf(
+			"[%w] %d secret versions were not uploaded before password was changed on another device, "+
+				"log in with --old-password to keep them",
+			e.ErrConflict, len(unsynced),
+		)
+	}
+
+	oldKEK, err := keys.KEK(localUsr, oldPassword)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] wrong old password", e.ErrInvalidInput)
+	}
+
+	kek, err := keys.KEK(usr, password)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] wrong password", e.ErrInvalidInput)
+	}
+
+	return rewrapKeys(unsynced, oldKEK, kek)
+}
+
+// warnTOTPEnrollment tells user that issued token only allows TOTP enrollment.
+func warnTOTPEnrollment(resp *pb.LoginResponse, log zerolog.Logger) {
+	if resp.GetTotpEnrollmentRequired() {
+		log.Warn().Msg("Two-factor authentication is required for your account, " +
+			"set it up with 'totp enroll' and 'totp confirm', then log in again with --otp")
+	}
+}
+
+// createLoggedInUser sets up local user logged in from this device for the first time.
+func createLoggedInUser(
+	ctx context.Context,
+	cfg *config.Config,
+	repo repository.UserRepository,
+	resp *pb.LoginResponse,
+	token *dto.ServerToken,
+	log zerolog.Logger,
+) error {
+	usr, err := user.NewWithID(resp.GetUserId(), cfg.Username, resp.GetRole())
+	if err != nil {
+		return e.InternalErr(err)
+	}
+
+	usr.Salt = resp.GetSalt()
+	usr.BucketName = resp.GetBucketName()
+	usr.Verifier = resp.GetVerifier()
+
+	if _, err := keys.KEK(usr, cfg.Password); err != nil {
+		return fmt.Errorf("[%w] wrong user verifier", e.ErrInternal)
+	}
+
+	log.Info().Msg("Creating logged in user locally...")
+
+	if err := repo.CreateUser(ctx, usr, token); err != nil {
+		return err
+	}
+
+	log.Info().Msg("Successfully logged in!")
+	warnTOTPEnrollment(resp, log)
+
+	return nil
+}
+
+// Logout revokes refresh tokens of this device or of all user devices and forgets local user token.
+func Logout(cfg *config.Config, allDevices bool, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	defer cancel()
+
+	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to connect to db")
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	if _, err := userRepo.GetUserToken(ctx, usr); err != nil {
+		if errors.Is(err, e.ErrNotFound) {
+			return fmt.Errorf("[%w] user is not logged in", e.ErrUnauthenticated)
+		}
+
+		return err
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	resp, err := client.Logout(ctx, allDevices)
+	if err != nil {
+		return err
+	}
+
+	if err := userRepo.DeleteUserToken(ctx, usr); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "logged out, %d refresh tokens revoked\n", resp.GetRevokedTokens())
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubRefresher struct {
+	resp      *pb.RefreshTokenResponse
+	err       error
+	presented string
+}
+
+func (r *stubRefresher) RefreshToken(_ context.Context, refreshToken string) (*pb.RefreshTokenResponse, error) {
+	r.presented = refreshToken
+	return r.resp, r.err
+}
+
+type stubTokenRepo struct {
+	repository.UserRepository
+	saved   *dto.ServerToken
+	deleted bool
+}
+
+func (r *stubTokenRepo) SaveUserToken(_ context.Context, token *dto.ServerToken) error {
+	r.saved = token
+	return nil
+}
+
+func (r *stubTokenRepo) DeleteUserToken(_ context.Context, _ *user.User) error {
+	r.deleted = true
+	return nil
+}
+
+func TestRefreshUserToken(t *testing.T) {
+	t.Parallel()
+
+	usr := user.New("patraden", user.RoleUser)
+	expired := dto.NewServerToken(usr.ID.String(), "access", 0, "refresh", 3600)
+
+	tests := []struct {
+		name          string
+		token         *dto.ServerToken
+		refresher     *stubRefresher
+		expectErr     error
+		expectToken   string
+		expectSaved   bool
+		expectDeleted bool
+	}{
+		{
+			name:        "valid token is not refreshed",
+			token:       dto.NewServerToken(usr.ID.String(), "access", 900, "refresh", 3600),
+			refresher:   &stubRefresher{},
+			expectToken: "access",
+		},
+		{
+			name:        "token issued before refresh tokens is used as is",
+			token:       &dto.ServerToken{UserID: usr.ID.String(), Token: "legacy"},
+			refresher:   &stubRefresher{},
+			expectToken: "legacy",
+		},
+		{
+			name:  "expired token is refreshed",
+			token: expired,
+			refresher: &stubRefresher{resp: &pb.RefreshTokenResponse{
+				Token:                  "access2",
+				TokenTtlSeconds:        900,
+				RefreshToken:           "refresh2",
+				RefreshTokenTtlSeconds: 3600,
+			}},
+			expectToken: "access2",
+			expectSaved: true,
+		},
+		{
+			name:          "rejected refresh token is forgotten",
+			token:         expired,
+			refresher:     &stubRefresher{err: status.Error(codes.Unauthenticated, "Unauthorized")},
+			expectErr:     e.ErrUnauthenticated,
+			expectDeleted: true,
+		},
+		{
+			name: "expired refresh token",
+			token: &dto.ServerToken{
+				UserID:           usr.ID.String(),
+				Token:            "access",
+				ExpiresAt:        time.Now().Add(-time.Hour),
+				RefreshToken:     "refresh",
+				RefreshExpiresAt: time.Now().Add(-time.Minute),
+			},
+			refresher: &stubRefresher{},
+			expectErr: e.ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &stubTokenRepo{}
+
+			token, err := app.RefreshUserToken(context.Background(), tt.refresher, repo, usr, tt.token)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				assert.Nil(t, token)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectToken, token.Token)
+			}
+
+			assert.Equal(t, tt.expectSaved, repo.saved != nil)
+			assert.Equal(t, tt.expectDeleted, repo.deleted)
+
+			if tt.expectSaved {
+				assert.Equal(t, "refresh", tt.refresher.presented)
+				assert.Equal(t, "refresh2", repo.saved.RefreshToken)
+				assert.Equal(t, usr.ID.String(), repo.saved.UserID)
+			}
+		})
+	}
+}
+
+// userWithPassword returns user which verifier is derived from password with fresh salt, along with its KEK.
+func userWithPassword(t *testing.T, id uuid.UUID, password string) (*user.User, []byte) {
+	t.Helper()
+
+	salt, err := user.NewSalt()
+	require.NoError(t, err)
+
+	kek, err := keys.DeriveKEK(password, salt)
+	require.NoError(t, err)
+
+	usr, err := user.NewWithID(id.String(), "patraden", user.RoleUser)
+	require.NoError(t, err)
+
+	user.NewPasswordChange(usr.ID, keys.AuthKey(kek), salt).Apply(usr)
+
+	return usr, kek
+}
+
+func TestRewrapUnsyncedKeys(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	localUsr, oldKEK := userWithPassword(t, userID, "old-password")
+	usr, kek := userWithPassword(t, userID, "new-password")
+
+	dek, err := keys.DEK()
+	require.NoError(t, err)
+
+	wrapped, err := keys.WrapDEK(oldKEK, dek)
+	require.NoError(t, err)
+
+	synced := &secret.WrappedKey{SecretID: uuid.New(), VersionID: uuid.New(), DEK: wrapped}
+	unsynced := &secret.WrappedKey{SecretID: uuid.New(), VersionID: uuid.New(), DEK: wrapped}
+	serverKeys := []*secret.WrappedKey{{SecretID: synced.SecretID, VersionID: synced.VersionID}}
+
+	keysToRewrap := app.UnsyncedKeys([]*secret.WrappedKey{synced, unsynced}, serverKeys)
+	require.Equal(t, []*secret.WrappedKey{unsynced}, keysToRewrap)
+
+	t.Run("old password is required", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := app.RewrapUnsyncedKeys(keysToRewrap, localUsr, usr, "", "new-password")
+		require.ErrorIs(t, err, e.ErrConflict)
+	})
+
+	t.Run("wrong old password", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := app.RewrapUnsyncedKeys(keysToRewrap, localUsr, usr, "new-password", "new-password")
+		require.ErrorIs(t, err, e.ErrInvalidInput)
+	})
+
+	t.Run("rewrapped with new password", func(t *testing.T) {
+		t.Parallel()
+
+		rewrapped, err := app.RewrapUnsyncedKeys(keysToRewrap, localUsr, usr, "old-password", "new-password")
+		require.NoError(t, err)
+		require.Len(t, rewrapped, 1)
+		assert.Equal(t, unsynced.VersionID, rewrapped[0].VersionID)
+
+		unwrapped, err := keys.UnwrapDEK(kek, rewrapped[0].DEK)
+		require.NoError(t, err)
+		assert.Equal(t, dek, unwrapped)
+	})
+
+	t.Run("nothing to rewrap", func(t *testing.T) {
+		t.Parallel()
+
+		rewrapped, err := app.RewrapUnsyncedKeys(nil, localUsr, usr, "", "new-password")
+		require.NoError(t, err)
+		assert.Empty(t, rewrapped)
+	})
+}
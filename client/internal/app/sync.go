@@ -2,29 +2,34 @@ package app
 
 import (
 	"context"
-	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
 
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
-	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/minio"
-	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/checksum"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
-	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
 )
 
-//nolint:funlen //reason: to refactor
-func SyncSecrets(cfg *config.Config, secretName string, log logger.Logger) error {
+// SyncSecrets pushes local changes of the named secret to the server
+// or, if all is set, reconciles all local and remote secrets in both directions.
+// With resume set it continues interrupted uploads and downloads instead.
+//
+//nolint:funlen //reason: logging.
+func SyncSecrets(cfg *config.Config, secretName string, all, resume bool, log logger.Logger) error {
 	zlog := log.GetZeroLog()
 
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	// transfer is limited per part by requests timeout.
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	db, err := openDB(cfg, log)
 	if err != nil {
-		zlog.Error().Err(err).Msg("Failed to connect to db")
 		return err
 	}
 
@@ -41,6 +46,21 @@ func SyncSecrets(cfg *config.Config, secretName string, log logger.Logger) error
 	}
 
 	zlog.Info().Msg("User is valid!...")
+
+	if all || resume {
+		client, err := newUserClient(cfg, userRepo, usr, zlog)
+		if err != nil {
+			return e.InternalErr(err)
+		}
+		defer client.Close()
+
+		if resume {
+			return resumeTransfers(ctx, cfg, client, secretRepo, usr, zlog)
+		}
+
+		return syncAll(ctx, cfg, client, secretRepo, usr, zlog)
+	}
+
 	zlog.Info().Msg("Validating user sercret...")
 
 	scrt, err := secretRepo.GetSecret(ctx, usr.Username, secretName)
@@ -49,42 +69,107 @@ func SyncSecrets(cfg *config.Config, secretName string, log logger.Logger) error
 	}
 
 	zlog.Info().Msg("User sercret is valid!...")
-	zlog.Info().Msg("Sending sync request to server...")
 
-	client, err := grpcclient.New(cfg, zlog)
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
 	if err != nil {
 		return e.InternalErr(err)
 	}
 	defer client.Close()
 
-	resp, err := client.SecretUpdateInitRequest(ctx, scrt)
+	deleted, err := applyTombstone(ctx, client, secretRepo, scrt, zlog)
+	if err != nil || deleted {
+		return err
+	}
+
+	if scrt.InSync {
+		zlog.Info().Msg("Secret is already in sync!")
+		return nil
+	}
+
+	return uploadSecret(ctx, cfg, client, secretRepo, usr, scrt, zlog)
+}
+
+// uploadSecret performs two-phase upload of the local secret version:
+// init request, multipart object upload to S3 and commit; then marks local secret as synchronized.
+// Chunked secret version is uploaded as its manifest after chunks which are not stored yet.
+// Interrupted upload is recorded locally and continues with the next sync of the same version.
+//
+//nolint:funlen //reason: logging.
+func uploadSecret(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	scrt *dto.Secret,
+	log zerolog.Logger,
+) error {
+	var (
+		upload = scrt
+		dek    []byte
+		err    error
+	)
+
+	if scrt.MetaData.Chunked() {
+		dek, err = secretDEK(cfg, usr, scrt.SecretDek)
+		if err != nil {
+			return err
+		}
+
+		// manifest is the same for the same version, so it is rebuilt when upload is resumed.
+		upload, err = prepareChunkedUpload(scrt, dek, log)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(upload.FilePath)
+	} else if len(scrt.SecretHash) != checksum.Size {
+		// secret created before SHA-256 was introduced carries MD5 hash the server does not accept.
+		sum, err := checksum.File(scrt.FilePath)
+		if err != nil {
+			return err
+		}
+
+		legacy := *scrt
+		legacy.SecretHash = sum
+		upload = &legacy
+	}
+
+	transfer, creds, err := startUpload(ctx, cfg, client, secretRepo, usr, upload, log)
 	if err != nil {
 		return err
 	}
 
-	zlog.Info().Msg("Sync request confirmed by server!!")
+	if dek != nil {
+		minioClient, err := newUploadClient(ctx, cfg, client, usr, transfer, creds, log)
+		if err != nil {
+			return err
+		}
 
-	s3Config := &s3.ClientConfig{
-		S3Endpoint:    cfg.S3Endpoint,
-		S3TLSCertPath: cfg.ServerTLSCertPath,
-		S3AccessKey:   resp.GetCredentials().GetAccessKeyId(),
-		S3SecretKey:   resp.GetCredentials().GetSecretAccessKey(),
-		S3Token:       resp.GetCredentials().GetSessionToken(),
-		S3AccountID:   cfg.S3AccountID,
-		S3Region:      cfg.S3Region,
+		if err := uploadChunks(ctx, cfg, minioClient, usr.BucketName, scrt, dek, log); err != nil {
+			log.Warn().Err(err).Msg("Upload interrupted, continue with 'gkcli sync --resume'")
+			return err
+		}
 	}
 
-	minioClient, err := minio.NewClient(s3Config, zlog)
+	partHashes, err := uploadObject(ctx, cfg, client, secretRepo, usr, transfer, creds, log)
 	if err != nil {
+		log.Warn().Err(err).Msg("Upload interrupted, continue with 'gkcli sync --resume'")
 		return err
 	}
 
-	_, err = minioClient.PutObject(ctx, usr.BucketName, resp.GetS3Url(), scrt.FilePath, s3.PutObjectOptions{})
+	log.Info().Msg("Committing sync request...")
+
+	_, err = client.SecretUpdateCommitRequest(ctx, upload, transfer.Token, partHashes)
 	if err != nil {
 		return err
 	}
 
-	// here we need to commit upload transaction by sending relevant server request...
+	if err := secretRepo.SetSecretInSync(ctx, scrt, true); err != nil {
+		log.Error().Err(err).Msg("Failed to mark secret in sync")
+		return err
+	}
+
+	log.Info().Msg("Sync request committed by server!!")
 
-	return nil
+	return secretRepo.DeleteTransfer(ctx, transfer)
 }
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/stream"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const defaultEditor = "vi"
+
+// ReadTextNote returns text note content taken from value, or from stdin if it is not nil,
+// or from the editor otherwise.
+func ReadTextNote(value string, stdin io.Reader, editor string) ([]byte, error) {
+	var (
+		text []byte
+		err  error
+	)
+
+	switch {
+	case value != "":
+		text = []byte(value)
+	case stdin != nil:
+		text, err = io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("[%w] text note stdin", e.ErrRead)
+		}
+	default:
+		dir, dirErr := ramTempDir()
+		if dirErr != nil {
+			return nil, dirErr
+		}
+
+		text, err = EditTextNote(editor, dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(bytes.TrimSpace(text)) == 0 {
+		return nil, fmt.Errorf("[%w] text note", e.ErrEmptyInput)
+	}
+
+	return text, nil
+}
+
+// EditTextNote opens editor on a temp file in dir and returns what was saved.
+// The temp file is overwritten with zeros and removed afterwards.
+func EditTextNote(editor, dir string) ([]byte, error) {
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{defaultEditor}
+	}
+
+	tmpFile, err := os.CreateTemp(dir, "gophkeeper-note-*.txt")
+	if err != nil {
+		return nil, fmt.Errorf("[%w] text note temp file", e.ErrOpen)
+	}
+
+	tmpPath := tmpFile.Name()
+	tmpFile.Close()
+
+	defer wipeFile(tmpPath)
+
+	//nolint:gosec // reason: editor is chosen by the user running the command.
+	cmd := exec.Command(args[0], append(args[1:], tmpPath)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("[%w] editor %s: %w", e.ErrInvalidInput, args[0], err)
+	}
+
+	text, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] text note temp file", e.ErrRead)
+	}
+
+	return text, nil
+}
+
+// ramTempDir returns the first writable RAM-backed directory, so that plaintext never hits the disk.
+func ramTempDir() (string, error) {
+	for _, dir := range []string{os.Getenv("XDG_RUNTIME_DIR"), "/dev/shm"} {
+		if dir == "" {
+			continue
+		}
+
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, nil
+		}
+	}
+
+	return "", fmt.Errorf("[%w] no RAM-backed temp dir for editor, use --value or stdin", e.ErrUnsupported)
+}
+
+// wipeFile overwrites file content with zeros before removing it.
+func wipeFile(path string) {
+	defer os.Remove(path)
+
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return
+	}
+
+	_, _ = file.Write(make([]byte, info.Size()))
+	_ = file.Sync()
+}
+
+// editorCommand returns user preferred editor from $VISUAL or $EDITOR.
+func editorCommand() string {
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+
+	return os.Getenv("EDITOR")
+}
+
+func createTextSecret(
+	cfg *config.Config,
+	kek []byte,
+	input *SecretInput,
+	codec stream.Codec,
+	usr *user.User,
+	log zerolog.Logger,
+) (*secret.Secret, error) {
+	log.Info().Msg("Creating text secret...")
+
+	text, err := ReadTextNote(input.Value, input.Stdin, editorCommand())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to read text note")
+		return nil, err
+	}
+
+	defer clear(text)
+
+	return encryptSecret(cfg, kek, bytes.NewReader(text), input, codec, usr, log)
+}
+
+// decryptTextSecret decrypts text secret and writes it inline, terminated with a newline.
+func decryptTextSecret(encPath, outPath string, dek []byte, log zerolog.Logger) error {
+	var buf bytes.Buffer
+
+	if err := decryptSecretTo(encPath, &buf, dek, log); err != nil {
+		return err
+	}
+
+	if outPath == "" && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+
+	return writeSecretOutput(outPath, func(dest io.Writer) error {
+		_, err := buf.WriteTo(dest)
+		return err
+	}, log)
+}
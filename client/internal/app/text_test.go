@@ -0,0 +1,60 @@
+package app_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTextNote(t *testing.T) {
+	t.Parallel()
+
+	t.Run("from value", func(t *testing.T) {
+		t.Parallel()
+
+		text, err := app.ReadTextNote("recovery codes", strings.NewReader("ignored"), "")
+		require.NoError(t, err)
+		require.Equal(t, "recovery codes", string(text))
+	})
+
+	t.Run("from stdin", func(t *testing.T) {
+		t.Parallel()
+
+		text, err := app.ReadTextNote("", strings.NewReader("runbook\nstep 1\n"), "")
+		require.NoError(t, err)
+		require.Equal(t, "runbook\nstep 1\n", string(text))
+	})
+
+	t.Run("empty stdin", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := app.ReadTextNote("", strings.NewReader(" \n"), "")
+		require.ErrorIs(t, err, e.ErrEmptyInput)
+	})
+}
+
+func TestEditTextNote(t *testing.T) {
+	t.Parallel()
+
+	scriptDir := t.TempDir()
+	editor := filepath.Join(scriptDir, "editor.sh")
+	require.NoError(t, os.WriteFile(editor, []byte("#!/bin/sh\nprintf 'my note' > \"$1\"\n"), 0o700))
+
+	noteDir := t.TempDir()
+
+	text, err := app.EditTextNote(editor, noteDir)
+	require.NoError(t, err)
+	require.Equal(t, "my note", string(text))
+
+	entries, err := os.ReadDir(noteDir)
+	require.NoError(t, err)
+	require.Empty(t, entries, "temp note file must be removed")
+
+	_, err = app.EditTextNote(filepath.Join(scriptDir, "missing"), noteDir)
+	require.ErrorIs(t, err, e.ErrInvalidInput)
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+)
+
+// EnrollTOTP requests new TOTP seed and prints provisioning URI to add it to authenticator app.
+// Enrollment takes effect once confirmed with ConfirmTOTP.
+func EnrollTOTP(cfg *config.Config, log logger.Logger) error {
+	return withUserClient(cfg, log, func(ctx context.Context, client *grpcclient.Client) error {
+		resp, err := client.EnrollTOTP(ctx)
+		if err != nil {
+			return err
+		}
+
+		return printTOTPEnrollment(os.Stdout, resp.GetProvisioningUri(), resp.GetSecret())
+	})
+}
+
+// ConfirmTOTP enables enrolled TOTP with a code from authenticator app and prints recovery codes.
+// Recovery codes are shown only once.
+func ConfirmTOTP(cfg *config.Config, code string, log logger.Logger) error {
+	return withUserClient(cfg, log, func(ctx context.Context, client *grpcclient.Client) error {
+		resp, err := client.ConfirmTOTP(ctx, code)
+		if err != nil {
+			return err
+		}
+
+		return printRecoveryCodes(os.Stdout, resp.GetRecoveryCodes())
+	})
+}
+
+// withUserClient calls fn with server client making calls on behalf of the local user.
+func withUserClient(
+	cfg *config.Config,
+	log logger.Logger,
+	fn func(ctx context.Context, client *grpcclient.Client) error,
+) error {
+	zlog := log.GetZeroLog()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestsTimeout)
+	defer cancel()
+
+	db, err := sqlite.NewDB(fmt.Sprintf("%s/%s", cfg.InstallDir, cfg.DatabaseFileName))
+	if err != nil {
+		zlog.Error().Err(err).Msg("Failed to connect to db")
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	return fn(ctx, client)
+}
+
+func printTOTPEnrollment(out io.Writer, uri, secret string) error {
+	_, err := fmt.Fprintf(out,
+		"Add the key to your authenticator app, then confirm it with a generated code:\n\n"+
+			"URI:    %s\nSecret: %s\n", uri, secret)
+	if err != nil {
+		return fmt.Errorf("[%w] totp enrollment", e.ErrWrite)
+	}
+
+	return nil
+}
+
+func printRecoveryCodes(out io.Writer, codes []string) error {
+	_, err := fmt.Fprintln(out, "Two-factor authentication is enabled. "+
+		"Store recovery codes safely, each of them replaces TOTP code once:")
+	if err != nil {
+		return fmt.Errorf("[%w] recovery codes", e.ErrWrite)
+	}
+
+	for _, code := range codes {
+		if _, err := fmt.Fprintln(out, "  "+code); err != nil {
+			return fmt.Errorf("[%w] recovery codes", e.ErrWrite)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,550 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/minio"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/checksum"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transferPartSize is the size of multipart upload parts and of download ranges.
+// Each part is limited by requests timeout rather than the whole transfer.
+const transferPartSize = 16 * 1024 * 1024
+
+// partialDownloadExt is appended to the path of partially downloaded object.
+const partialDownloadExt = ".part"
+
+// TransferParts splits object of the given size into parts and returns offset and size of each of them.
+func TransferParts(size, partSize int64) [][2]int64 {
+	if size <= 0 || partSize <= 0 {
+		return nil
+	}
+
+	parts := make([][2]int64, 0, (size+partSize-1)/partSize)
+
+	for offset := int64(0); offset < size; offset += partSize {
+		parts = append(parts, [2]int64{offset, min(partSize, size-offset)})
+	}
+
+	return parts
+}
+
+func s3ClientConfig(cfg *config.Config, creds s3.TemporaryCredentials) *s3.ClientConfig {
+	return &s3.ClientConfig{
+		S3Endpoint:    cfg.S3Endpoint,
+		S3TLSCertPath: cfg.ServerTLSCertPath,
+		S3AccessKey:   creds.AccessKeyID,
+		S3SecretKey:   creds.SecretAccessKey,
+		S3Token:       creds.SessionToken,
+		S3AccountID:   cfg.S3AccountID,
+		S3Region:      cfg.S3Region,
+	}
+}
+
+// startUpload resumes upload of the same secret version recorded locally
+// or initiates a new upload request on the server.
+func startUpload(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	scrt *dto.Secret,
+	log zerolog.Logger,
+) (*dto.Transfer, s3.TemporaryCredentials, error) {
+	transfer, err := secretRepo.GetTransfer(ctx, usr.ID.String(), scrt.ID, dto.TransferUpload)
+	if err != nil && !errors.Is(err, e.ErrNotFound) {
+		return nil, s3.TemporaryCredentials{}, err
+	}
+
+	if transfer != nil && transfer.VersionID == scrt.VersionID && transfer.FileSize == scrt.SecretSize {
+		resp, err := client.RenewUploadCredentials(ctx, usr.ID.String(), scrt.ID, transfer.Token)
+		if err == nil {
+			log.Info().Msg("Resuming interrupted upload...")
+			return transfer, s3.TemporaryCredentialsFromProto(resp.GetCredentials()), nil
+		}
+
+		if status.Code(err) != codes.NotFound {
+			return nil, s3.TemporaryCredentials{}, err
+		}
+
+		log.Warn().Msg("Interrupted upload expired on server, starting over")
+	}
+
+	// parts of abandoned multipart uploads are cleaned up by storage itself.
+	log.Info().Msg("Sending sync request to server...")
+
+	resp, err := client.SecretUpdateInitRequest(ctx, scrt)
+	if conflict, ok := grpcclient.VersionConflictFromError(err); ok {
+		log.Warn().
+			Str("parent_version_id", conflict.ParentVersionID).
+			Str("current_version_id", conflict.CurrentVersionID).
+			Msg("Secret was changed on server since last sync")
+
+		if err := recordConflict(ctx, cfg, client, secretRepo, usr, scrt, log); err != nil {
+			return nil, s3.TemporaryCredentials{}, err
+		}
+
+		return nil, s3.TemporaryCredentials{}, fmt.Errorf("[%w] secret %s diverged from server version %s",
+			e.ErrConflict, scrt.SecretName, conflict.CurrentVersionID)
+	}
+
+	if err != nil {
+		return nil, s3.TemporaryCredentials{}, err
+	}
+
+	log.Info().Msg("Sync request confirmed by server!!")
+
+	now := time.Now().UTC()
+	transfer = &dto.Transfer{
+		UserID:     usr.ID.String(),
+		SecretID:   scrt.ID,
+		Direction:  dto.TransferUpload,
+		SecretName: scrt.SecretName,
+		VersionID:  scrt.VersionID,
+		ObjectKey:  resp.GetS3Url(),
+		Token:      resp.GetToken(),
+		FilePath:   scrt.FilePath,
+		FileSize:   scrt.SecretSize,
+		PartSize:   transferPartSize,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := secretRepo.CreateTransfer(ctx, transfer); err != nil {
+		log.Error().Err(err).Msg("Failed to record upload state")
+		return nil, s3.TemporaryCredentials{}, err
+	}
+
+	return transfer, s3.TemporaryCredentialsFromProto(resp.GetCredentials()), nil
+}
+
+// newUploadClient creates S3 client which renews expired credentials of the upload in progress.
+func newUploadClient(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	usr *user.User,
+	transfer *dto.Transfer,
+	creds s3.TemporaryCredentials,
+	log zerolog.Logger,
+) (*minio.Client, error) {
+	renew := func() (*s3.TemporaryCredentials, error) {
+		resp, err := client.RenewUploadCredentials(ctx, usr.ID.String(), transfer.SecretID, transfer.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		renewed := s3.TemporaryCredentialsFromProto(resp.GetCredentials())
+
+		return &renewed, nil
+	}
+
+	return minio.NewRenewableClient(s3ClientConfig(cfg, creds), creds.Expiration, renew, log)
+}
+
+// uploadObject uploads encrypted secret file by parts recording each uploaded part,
+// so that interrupted upload continues from the first missing part.
+// Returns checksums of all parts in part order, server rejects corrupted parts with them before reading the object.
+//
+//nolint:funlen //reason: logging.
+func uploadObject(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	transfer *dto.Transfer,
+	creds s3.TemporaryCredentials,
+	log zerolog.Logger,
+) ([][]byte, error) {
+	minioClient, err := newUploadClient(ctx, cfg, client, usr, transfer, creds, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.UploadID == "" {
+		partCtx, cancel := context.WithTimeout(ctx, cfg.RequestsTimeout)
+		uploadID, err := minioClient.NewMultipartUpload(partCtx, usr.BucketName, transfer.ObjectKey, s3.PutObjectOptions{})
+
+		cancel()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := secretRepo.SetTransferUploadID(ctx, transfer, uploadID); err != nil {
+			return nil, err
+		}
+	}
+
+	uploaded, err := secretRepo.ListTransferParts(ctx, transfer.UploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[int]dto.TransferPart, len(uploaded))
+	for _, part := range uploaded {
+		done[part.PartNumber] = part
+	}
+
+	file, err := os.Open(transfer.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] secret file", e.ErrOpen)
+	}
+	defer file.Close()
+
+	parts := TransferParts(transfer.FileSize, transfer.PartSize)
+	completeParts := make([]s3.CompletePart, 0, len(parts))
+	partHashes := make([][]byte, 0, len(parts))
+
+	for i, bounds := range parts {
+		partNumber := i + 1
+
+		part, ok := done[partNumber]
+		if !ok {
+			// part is read ahead, its checksum is sent along and verified by storage.
+			data := make([]byte, bounds[1])
+			if _, err := file.ReadAt(data, bounds[0]); err != nil {
+				return nil, fmt.Errorf("[%w] secret file part", e.ErrRead)
+			}
+
+			partSum := checksum.Sum(data)
+
+			partCtx, cancel := context.WithTimeout(ctx, cfg.RequestsTimeout)
+			objectPart, err := minioClient.PutObjectPart(
+				partCtx,
+				usr.BucketName,
+				transfer.ObjectKey,
+				transfer.UploadID,
+				partNumber,
+				bytes.NewReader(data),
+				bounds[1],
+				partSum,
+			)
+
+			cancel()
+
+			if err != nil {
+				return nil, err
+			}
+
+			part = dto.TransferPart{
+				UploadID:   transfer.UploadID,
+				PartNumber: partNumber,
+				ETag:       objectPart.ETag,
+				Size:       bounds[1],
+				Checksum:   partSum,
+				CreatedAt:  time.Now().UTC(),
+			}
+
+			if err := secretRepo.CreateTransferPart(ctx, &part); err != nil {
+				return nil, err
+			}
+
+			log.Info().
+				Int("part", partNumber).
+				Int("parts", len(parts)).
+				Msg("Uploaded secret part")
+		}
+
+		completeParts = append(completeParts, s3.CompletePart{
+			PartNumber:     part.PartNumber,
+			ETag:           part.ETag,
+			ChecksumSHA256: checksum.S3(part.Checksum),
+		})
+		partHashes = append(partHashes, part.Checksum)
+	}
+
+	partCtx, cancel := context.WithTimeout(ctx, cfg.RequestsTimeout)
+	defer cancel()
+
+	_, err = minioClient.CompleteMultipartUpload(partCtx, usr.BucketName, transfer.ObjectKey, transfer.UploadID, completeParts)
+	if errors.Is(err, e.ErrNotFound) {
+		// multipart upload was cleaned up by storage, the next attempt starts a new one.
+		if err := secretRepo.SetTransferUploadID(ctx, transfer, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return partHashes, nil
+}
+
+// downloadSecret fetches encrypted secret object by ranges into encPath and verifies its integrity.
+// Partially downloaded object is kept next to encPath, so that interrupted download continues where it stopped.
+// Chunked secret is assembled from chunks referenced by the downloaded manifest, size and hash
+// of the response are then set to ones of the assembled local file.
+//
+//nolint:funlen //reason: logging.
+func downloadSecret(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	encPath string,
+	resp *dto.SecretDownloadInitResponse,
+	log zerolog.Logger,
+) error {
+	transfer, err := startDownload(ctx, secretRepo, usr, encPath+partialDownloadExt, resp)
+	if err != nil {
+		return err
+	}
+
+	renew := func() (*s3.TemporaryCredentials, error) {
+		renewed, err := requestSecretDownload(ctx, client, usr.ID.String(), transfer.SecretName, transfer.VersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &renewed.S3Creds, nil
+	}
+
+	minioClient, err := minio.NewRenewableClient(s3ClientConfig(cfg, resp.S3Creds), resp.S3Creds.Expiration, renew, log)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(transfer.FilePath, os.O_CREATE|os.O_WRONLY, secretFilePerm)
+	if err != nil {
+		return fmt.Errorf("[%w] partial secret file", e.ErrOpen)
+	}
+
+	offset, err := partialDownloadOffset(file, transfer.FileSize)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if offset > 0 {
+		log.Info().
+			Int64("offset", offset).
+			Int64("size", transfer.FileSize).
+			Msg("Resuming interrupted download...")
+	}
+
+	for offset < transfer.FileSize {
+		length := min(transfer.PartSize, transfer.FileSize-offset)
+
+		partCtx, cancel := context.WithTimeout(ctx, cfg.RequestsTimeout)
+		written, err := minioClient.GetObjectRange(partCtx, usr.BucketName, transfer.ObjectKey, offset, length, file)
+
+		cancel()
+
+		offset += written
+
+		if err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("[%w] partial secret file", e.ErrWrite)
+	}
+
+	log.Info().Msg("Verifying secret checksum...")
+
+	matched, err := checksum.FileMatches(transfer.FilePath, resp.SecretHash)
+	if err != nil {
+		return err
+	}
+
+	if !matched {
+		log.Error().
+			Str("path", transfer.FilePath).
+			Msg("downloaded secret hash mismatch")
+
+		// corrupted download can not be resumed.
+		os.Remove(transfer.FilePath)
+
+		if err := secretRepo.DeleteTransfer(ctx, transfer); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("[%w] secret hash mismatch", e.ErrCorrupt)
+	}
+
+	metaData, err := parseMetaData(resp.MetaData)
+	if err != nil {
+		return err
+	}
+
+	if metaData.Chunked() {
+		// downloaded object is the manifest, secret file is assembled from its chunks.
+		if err := assembleChunkedSecret(ctx, cfg, minioClient, usr, transfer.FilePath, encPath, resp, log); err != nil {
+			return err
+		}
+
+		os.Remove(transfer.FilePath)
+
+		return secretRepo.DeleteTransfer(ctx, transfer)
+	}
+
+	if err := os.Rename(transfer.FilePath, encPath); err != nil {
+		return fmt.Errorf("[%w] secret file", e.ErrWrite)
+	}
+
+	return secretRepo.DeleteTransfer(ctx, transfer)
+}
+
+// startDownload returns recorded download of the same object version
+// or records a new one dropping partial download of another version.
+func startDownload(
+	ctx context.Context,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	partPath string,
+	resp *dto.SecretDownloadInitResponse,
+) (*dto.Transfer, error) {
+	transfer, err := secretRepo.GetTransfer(ctx, usr.ID.String(), resp.SecretID, dto.TransferDownload)
+	if err != nil && !errors.Is(err, e.ErrNotFound) {
+		return nil, err
+	}
+
+	if transfer != nil && transfer.VersionID == resp.VersionID && transfer.FilePath == partPath {
+		return transfer, nil
+	}
+
+	if transfer != nil {
+		os.Remove(transfer.FilePath)
+	}
+
+	// partial file which download is not recorded can not be trusted.
+	os.Remove(partPath)
+
+	now := time.Now().UTC()
+	transfer = &dto.Transfer{
+		UserID:     usr.ID.String(),
+		SecretID:   resp.SecretID,
+		Direction:  dto.TransferDownload,
+		SecretName: resp.SecretName,
+		VersionID:  resp.VersionID,
+		ObjectKey:  resp.S3URL,
+		FilePath:   partPath,
+		FileSize:   resp.SecretSize,
+		PartSize:   transferPartSize,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := secretRepo.CreateTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// partialDownloadOffset positions partially downloaded file at its end and returns its size.
+// File larger than the object is truncated and downloaded from scratch.
+func partialDownloadOffset(file *os.File, size int64) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("[%w] partial secret file", e.ErrRead)
+	}
+
+	offset := info.Size()
+	if offset > size {
+		if err := file.Truncate(0); err != nil {
+			return 0, fmt.Errorf("[%w] partial secret file", e.ErrWrite)
+		}
+
+		offset = 0
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("[%w] partial secret file", e.ErrRead)
+	}
+
+	return offset, nil
+}
+
+// resumeTransfers continues interrupted uploads and downloads of user secrets and prints per-secret summary.
+func resumeTransfers(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	log zerolog.Logger,
+) error {
+	transfers, err := secretRepo.ListTransfers(ctx, usr.ID.String())
+	if err != nil {
+		return err
+	}
+
+	if len(transfers) == 0 {
+		log.Info().Msg("No interrupted transfers to resume")
+		return nil
+	}
+
+	items := make([]SyncItem, 0, len(transfers))
+	failed := 0
+
+	for _, transfer := range transfers {
+		item := SyncItem{Name: transfer.SecretName, Action: SyncActionPush}
+		if transfer.Direction == dto.TransferDownload {
+			item.Action = SyncActionPull
+		}
+
+		itemLog := log.With().
+			Str("secret_name", item.Name).
+			Str("action", string(item.Action)).Logger()
+
+		item.Local, item.Err = secretRepo.GetSecret(ctx, usr.Username, transfer.SecretName)
+		if errors.Is(item.Err, e.ErrNotFound) && item.Action == SyncActionPull {
+			item.Err = nil
+		}
+
+		switch {
+		case item.Err != nil:
+		case item.Action == SyncActionPush && item.Local.InSync:
+			// upload was committed, but its state was not cleaned up.
+			item.Err = secretRepo.DeleteTransfer(ctx, transfer)
+		case item.Action == SyncActionPush:
+			item.Err = uploadSecret(ctx, cfg, client, secretRepo, usr, item.Local, itemLog)
+		case item.Local != nil && !item.Local.InSync:
+			item.Err = fmt.Errorf("[%w] local secret has unsynchronized changes", e.ErrConflict)
+		default:
+			item.Err = pullSecret(ctx, cfg, client, secretRepo, usr, item.Local, item.Name, itemLog)
+		}
+
+		if item.Err != nil {
+			itemLog.Error().Err(item.Err).Msg("Failed to resume transfer")
+
+			failed++
+		}
+
+		items = append(items, item)
+	}
+
+	if err := printSyncSummary(os.Stdout, items); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("[%w] %d transfers are not completed", e.ErrConflict, failed)
+	}
+
+	return nil
+}
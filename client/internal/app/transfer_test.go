@@ -0,0 +1,58 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferParts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		size     int64
+		partSize int64
+		want     [][2]int64
+	}{
+		{
+			name:     "empty object",
+			size:     0,
+			partSize: 10,
+			want:     nil,
+		},
+		{
+			name:     "single short part",
+			size:     7,
+			partSize: 10,
+			want:     [][2]int64{{0, 7}},
+		},
+		{
+			name:     "exact parts",
+			size:     20,
+			partSize: 10,
+			want:     [][2]int64{{0, 10}, {10, 10}},
+		},
+		{
+			name:     "last part is shorter",
+			size:     25,
+			partSize: 10,
+			want:     [][2]int64{{0, 10}, {10, 10}, {20, 5}},
+		},
+		{
+			name:     "invalid part size",
+			size:     25,
+			partSize: 0,
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, app.TransferParts(tt.size, tt.partSize))
+		})
+	}
+}
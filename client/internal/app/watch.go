@@ -0,0 +1,214 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/retry"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchSecrets follows changes of user secrets made on other devices and applies them
+// through the regular sync path until interrupted. Cursor of the last applied event is stored
+// locally, so that watching continues where it stopped and lost connection is re-established.
+func WatchSecrets(cfg *config.Config, log logger.Logger) error {
+	zlog := log.GetZeroLog()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	db, err := openDB(cfg, log)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	userRepo := repository.NewUserRepo(db, cfg, zlog)
+	secretRepo := repository.NewSecretRepo(db, zlog)
+
+	zlog.Info().Msg("Validating user...")
+
+	usr, err := userRepo.ValidateUser(ctx, &dto.UserCredentials{Username: cfg.Username, Password: cfg.Password})
+	if err != nil {
+		return err
+	}
+
+	zlog.Info().Msg("User is valid!...")
+
+	client, err := newUserClient(cfg, userRepo, usr, zlog)
+	if err != nil {
+		return e.InternalErr(err)
+	}
+	defer client.Close()
+
+	reconnect := backoff.NewExponentialBackOff()
+	reconnect.MaxElapsedTime = 0
+
+	err = retry.WithRetry(ctx, reconnect, zlog, retriableWatchError, func() error {
+		return watchOnce(ctx, cfg, client, secretRepo, usr, reconnect, zlog)
+	})
+	if ctx.Err() != nil {
+		zlog.Info().Msg("Stopped watching secrets")
+		return nil
+	}
+
+	return err
+}
+
+// watchOnce streams events from the stored cursor applying them one by one until stream breaks.
+func watchOnce(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	reconnect backoff.BackOff,
+	log zerolog.Logger,
+) error {
+	cursor, err := secretRepo.GetWatchCursor(ctx, usr.ID.String())
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.WatchSecrets(ctx, usr.ID.String(), cursor)
+	if err != nil {
+		return err
+	}
+
+	// server is reachable again, changes made offline are pushed before following remote ones.
+	if err := replayOutbox(ctx, cfg, client, secretRepo, usr, log); err != nil {
+		return err
+	}
+
+	log.Info().
+		Int64("cursor", cursor).
+		Msg("Watching secrets changes...")
+
+	for {
+		pbEvent, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		// stream is healthy again, next disconnect starts reconnecting from the shortest delay.
+		reconnect.Reset()
+
+		event := dto.SecretEventFromProto(pbEvent)
+		if err := applySecretEvent(ctx, cfg, client, secretRepo, usr, event, log); err != nil {
+			return err
+		}
+
+		if err := secretRepo.SetWatchCursor(ctx, usr.ID.String(), event.Cursor); err != nil {
+			return err
+		}
+	}
+}
+
+// retriableWatchError reports whether watching should be re-established after the error.
+func retriableWatchError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.Internal:
+		return true
+	default:
+		return false
+	}
+}
+
+// applySecretEvent reconciles the changed secret with its current server state.
+// Event only tells which secret has changed, so replayed or outdated events are harmless.
+func applySecretEvent(
+	ctx context.Context,
+	cfg *config.Config,
+	client *grpcclient.Client,
+	secretRepo repository.SecretRepository,
+	usr *user.User,
+	event dto.SecretEvent,
+	log zerolog.Logger,
+) error {
+	local, err := secretRepo.GetSecret(ctx, usr.Username, event.SecretName)
+	if err != nil && !errors.Is(err, e.ErrNotFound) {
+		return err
+	}
+
+	remote, err := listRemoteSecrets(ctx, client, usr.ID.String(), event.SecretName)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range PlanSecretEvent(local, remote, event) {
+		itemLog := log.With().
+			Int64("cursor", event.Cursor).
+			Str("event", string(event.Type)).
+			Str("client_info", event.ClientInfo).
+			Str("secret_name", item.Name).
+			Str("action", string(item.Action)).Logger()
+
+		applySyncItem(ctx, cfg, client, secretRepo, usr, &item, itemLog)
+
+		switch {
+		case item.Err != nil:
+			// failed item stays unsynchronized and is reported by the next sync, watching goes on.
+			itemLog.Error().Err(item.Err).Msg("Failed to apply secret event")
+		case item.Action == SyncActionConflict:
+			itemLog.Warn().Msg("Local and remote changes diverged")
+		default:
+			itemLog.Info().Msg("Secret event applied")
+		}
+	}
+
+	return nil
+}
+
+// PlanSecretEvent plans sync of the secret changed by the event against its current server state.
+// Local secret deletion is planned first, so that secret recreated under the same name
+// on another device is pulled after the deleted one is removed.
+func PlanSecretEvent(local *dto.Secret, remote []dto.SecretInfo, event dto.SecretEvent) []SyncItem {
+	var (
+		localSecrets []*dto.Secret
+		tombstones   []dto.SecretTombstone
+		current      []dto.SecretInfo
+	)
+
+	if local != nil {
+		localSecrets = append(localSecrets, local)
+	}
+
+	// remote secrets are listed by name prefix.
+	for _, info := range remote {
+		if info.SecretName == event.SecretName {
+			current = append(current, info)
+		}
+	}
+
+	if event.Type == secret.EventTypeDelete {
+		tombstones = append(tombstones, dto.SecretTombstone{
+			SecretID:   event.SecretID,
+			SecretName: event.SecretName,
+			VersionID:  event.VersionID,
+			DeletedAt:  event.CreatedAt,
+		})
+	}
+
+	items := PlanSync(localSecrets, current, tombstones)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Action == SyncActionDeleteLocal && items[j].Action != SyncActionDeleteLocal
+	})
+
+	return items
+}
@@ -0,0 +1,87 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/app"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen // reason: table driven testing functions are ok to be long
+func TestPlanSecretEvent(t *testing.T) {
+	t.Parallel()
+
+	noParent := uuid.Nil.String()
+
+	tests := []struct {
+		name    string
+		local   *dto.Secret
+		remote  []dto.SecretInfo
+		event   dto.SecretEvent
+		actions []app.SyncAction
+	}{
+		{
+			name:    "created on another device",
+			local:   nil,
+			remote:  []dto.SecretInfo{{SecretID: "1", SecretName: "github", VersionID: "v1"}},
+			event:   dto.SecretEvent{Type: secret.EventTypeCreate, SecretID: "1", SecretName: "github", VersionID: "v1"},
+			actions: []app.SyncAction{app.SyncActionPull},
+		},
+		{
+			name:    "own change is already applied",
+			local:   &dto.Secret{ID: "1", SecretName: "github", VersionID: "v2", ParentVersionID: "v1", InSync: true},
+			remote:  []dto.SecretInfo{{SecretID: "1", SecretName: "github", VersionID: "v2"}},
+			event:   dto.SecretEvent{Type: secret.EventTypeUpdate, SecretID: "1", SecretName: "github", VersionID: "v2"},
+			actions: []app.SyncAction{app.SyncActionUpToDate},
+		},
+		{
+			name:    "outdated event of the current version",
+			local:   &dto.Secret{ID: "1", SecretName: "github", VersionID: "v3", ParentVersionID: "v2", InSync: true},
+			remote:  []dto.SecretInfo{{SecretID: "1", SecretName: "github", VersionID: "v3"}},
+			event:   dto.SecretEvent{Type: secret.EventTypeUpdate, SecretID: "1", SecretName: "github", VersionID: "v2"},
+			actions: []app.SyncAction{app.SyncActionUpToDate},
+		},
+		{
+			name:  "updated while changed locally",
+			local: &dto.Secret{ID: "1", SecretName: "github", VersionID: "v3", ParentVersionID: "v1", InSync: false},
+			remote: []dto.SecretInfo{
+				{SecretID: "1", SecretName: "github", VersionID: "v2"},
+				{SecretID: "2", SecretName: "github-work", VersionID: "v1"},
+			},
+			event:   dto.SecretEvent{Type: secret.EventTypeUpdate, SecretID: "1", SecretName: "github", VersionID: "v2"},
+			actions: []app.SyncAction{app.SyncActionConflict},
+		},
+		{
+			name:    "deleted on another device",
+			local:   &dto.Secret{ID: "1", SecretName: "github", VersionID: "v1", ParentVersionID: noParent, InSync: true},
+			remote:  nil,
+			event:   dto.SecretEvent{Type: secret.EventTypeDelete, SecretID: "1", SecretName: "github", VersionID: "v1"},
+			actions: []app.SyncAction{app.SyncActionDeleteLocal},
+		},
+		{
+			name:    "deleted and recreated under the same name",
+			local:   &dto.Secret{ID: "1", SecretName: "github", VersionID: "v1", ParentVersionID: noParent, InSync: true},
+			remote:  []dto.SecretInfo{{SecretID: "2", SecretName: "github", VersionID: "v1"}},
+			event:   dto.SecretEvent{Type: secret.EventTypeDelete, SecretID: "1", SecretName: "github", VersionID: "v1"},
+			actions: []app.SyncAction{app.SyncActionDeleteLocal, app.SyncActionPull},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			items := app.PlanSecretEvent(tt.local, tt.remote, tt.event)
+
+			actions := make([]app.SyncAction, 0, len(items))
+			for _, item := range items {
+				actions = append(actions, item.Action)
+			}
+
+			require.Equal(t, tt.actions, actions)
+		})
+	}
+}
@@ -20,8 +20,13 @@ type Config struct {
 	S3Endpoint        string `env:"S3_ENDPOINT"             json:"s3_endpoint"`
 	S3AccountID       string `env:"S3_ACCOUNT_ID"           json:"s3_account_id"`
 	S3Region          string `env:"S3_REGION"               json:"s3_region"`
+	AvroSchemaDir     string `env:"AVRO_SCHEMA_DIR"         json:"avro_schema_dir"`
+	DeviceID          string `json:"device_id"`
 	Username          string `env:"GOPHKEEPER_USERNAME"     json:"-"`
 	Password          string `env:"GOPHKEEPER_USERPASSWORD" json:"-"`
+	OldPassword       string `json:"-"`
+	TOTPCode          string `json:"-"`
+	RecoveryCode      string `json:"-"`
 	DebugMode         bool   `env:"DEBUG"                   json:"debug"`
 	InstallMode       bool   `json:"-"`
 	RequestsTimeout   time.Duration
@@ -37,6 +42,7 @@ func DefaultConfig() *Config {
 		S3Endpoint:        `localhost:9000`,
 		S3AccountID:       `gophkeeper`,
 		S3Region:          `eu-central-1`,
+		AvroSchemaDir:     `./avro`,
 		Username:          ``,
 		Password:          ``,
 		RequestsTimeout:   DefaultReqTimeout,
@@ -4,11 +4,10 @@ package config
 
 import (
 	json "encoding/json"
-	time "time"
-
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -48,6 +47,16 @@ func easyjson6615c02eDecodeGithubComPatradenYaPracticumGophkeeperClientInternalC
 			out.ServerTLSCertPath = string(in.String())
 		case "database_dsn":
 			out.DatabaseFileName = string(in.String())
+		case "s3_endpoint":
+			out.S3Endpoint = string(in.String())
+		case "s3_account_id":
+			out.S3AccountID = string(in.String())
+		case "s3_region":
+			out.S3Region = string(in.String())
+		case "avro_schema_dir":
+			out.AvroSchemaDir = string(in.String())
+		case "device_id":
+			out.DeviceID = string(in.String())
 		case "debug":
 			out.DebugMode = bool(in.Bool())
 		case "RequestsTimeout":
@@ -91,6 +100,31 @@ func easyjson6615c02eEncodeGithubComPatradenYaPracticumGophkeeperClientInternalC
 		out.RawString(prefix)
 		out.String(string(in.DatabaseFileName))
 	}
+	{
+		const prefix string = ",\"s3_endpoint\":"
+		out.RawString(prefix)
+		out.String(string(in.S3Endpoint))
+	}
+	{
+		const prefix string = ",\"s3_account_id\":"
+		out.RawString(prefix)
+		out.String(string(in.S3AccountID))
+	}
+	{
+		const prefix string = ",\"s3_region\":"
+		out.RawString(prefix)
+		out.String(string(in.S3Region))
+	}
+	{
+		const prefix string = ",\"avro_schema_dir\":"
+		out.RawString(prefix)
+		out.String(string(in.AvroSchemaDir))
+	}
+	{
+		const prefix string = ",\"device_id\":"
+		out.RawString(prefix)
+		out.String(string(in.DeviceID))
+	}
 	{
 		const prefix string = ",\"debug\":"
 		out.RawString(prefix)
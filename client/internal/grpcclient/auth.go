@@ -0,0 +1,180 @@
+package grpcclient
+
+import (
+	"context"
+
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenSource provides access token of the user server calls are made on behalf of.
+type TokenSource interface {
+	// Token returns access token, the one about to expire is renewed on the way.
+	Token(ctx context.Context) (string, error)
+	// Renew obtains new access token after server has rejected the current one.
+	Renew(ctx context.Context) (string, error)
+}
+
+// UseTokenSource makes client attach access tokens of the source to server calls.
+func (c *Client) UseTokenSource(tokens TokenSource) {
+	c.tokens = tokens
+}
+
+func (c *Client) tokenSource() TokenSource {
+	return c.tokens
+}
+
+// isPublicMethod reports whether server method is called without access token.
+func isPublicMethod(method string) bool {
+	switch method {
+	case
+		pb.UserService_GetLoginParams_FullMethodName,
+		pb.UserService_Login_FullMethodName,
+		pb.UserService_Register_FullMethodName,
+		pb.UserService_RefreshToken_FullMethodName:
+		return true
+	}
+
+	return false
+}
+
+// AuthUnaryInterceptor attaches access token of the source to calls of non-public methods.
+// Call rejected as unauthenticated is retried once with renewed token.
+// Source is looked up on every call as it is set after connection is created, nil source attaches nothing.
+func AuthUnaryInterceptor(source func() TokenSource, log zerolog.Logger) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		tokens := source()
+		if tokens == nil || isPublicMethod(method) {
+			return invoker(ctx, method, req, reply, conn, opts...)
+		}
+
+		token, err := tokens.Token(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = invoker(withToken(ctx, token), method, req, reply, conn, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+
+		log.Info().Str("method", method).Msg("Access token rejected, renewing")
+
+		token, err = tokens.Renew(ctx)
+		if err != nil {
+			return err
+		}
+
+		return invoker(withToken(ctx, token), method, req, reply, conn, opts...)
+	}
+}
+
+// AuthStreamInterceptor is the streaming counterpart of AuthUnaryInterceptor.
+// Server stream rejected as unauthenticated on the first receive is re-opened once with renewed token,
+// client streams are not retried as their requests are not kept.
+func AuthStreamInterceptor(source func() TokenSource, log zerolog.Logger) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		conn *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		tokens := source()
+		if tokens == nil || isPublicMethod(method) {
+			return streamer(ctx, desc, conn, method, opts...)
+		}
+
+		token, err := tokens.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		stream, err := streamer(withToken(ctx, token), desc, conn, method, opts...)
+		if err != nil || desc.ClientStreams {
+			return stream, err
+		}
+
+		return &renewingStream{
+			ClientStream: stream,
+			reopen: func() (grpc.ClientStream, error) {
+				log.Info().Str("method", method).Msg("Access token rejected, renewing")
+
+				token, err := tokens.Renew(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				return streamer(withToken(ctx, token), desc, conn, method, opts...)
+			},
+		}, nil
+	}
+}
+
+// renewingStream re-opens server stream rejected as unauthenticated before anything is received,
+// replaying its request.
+type renewingStream struct {
+	grpc.ClientStream
+	reopen   func() (grpc.ClientStream, error)
+	request  any
+	closed   bool
+	received bool
+}
+
+func (s *renewingStream) SendMsg(m any) error {
+	s.request = m
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *renewingStream) CloseSend() error {
+	s.closed = true
+	return s.ClientStream.CloseSend()
+}
+
+func (s *renewingStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if s.received || status.Code(err) != codes.Unauthenticated {
+		s.received = true
+		return err
+	}
+
+	s.received = true
+
+	stream, err := s.reopen()
+	if err != nil {
+		return err
+	}
+
+	if s.request != nil {
+		if err := stream.SendMsg(s.request); err != nil {
+			return err
+		}
+	}
+
+	if s.closed {
+		if err := stream.CloseSend(); err != nil {
+			return err
+		}
+	}
+
+	s.ClientStream = stream
+
+	return s.ClientStream.RecvMsg(m)
+}
+
+// withToken attaches user token to the outgoing request.
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
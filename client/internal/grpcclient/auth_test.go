@@ -0,0 +1,283 @@
+package grpcclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/grpcclient"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type stubTokenSource struct {
+	token    string
+	renewed  string
+	renewErr error
+	renews   int
+}
+
+func (s *stubTokenSource) Token(_ context.Context) (string, error) {
+	return s.token, nil
+}
+
+func (s *stubTokenSource) Renew(_ context.Context) (string, error) {
+	s.renews++
+	return s.renewed, s.renewErr
+}
+
+func authorization(ctx context.Context) []string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return md.Get("authorization")
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	unauthenticated := status.Error(codes.Unauthenticated, "Unauthorized")
+
+	tests := []struct {
+		name         string
+		method       string
+		source       *stubTokenSource
+		results      []error
+		expectCode   codes.Code
+		expectErr    error
+		expectTokens [][]string
+		expectRenews int
+	}{
+		{
+			name:         "token attached",
+			method:       pb.SecretService_ListSecrets_FullMethodName,
+			source:       &stubTokenSource{token: "access"},
+			results:      []error{nil},
+			expectCode:   codes.OK,
+			expectTokens: [][]string{{"Bearer access"}},
+		},
+		{
+			name:         "public method called without token",
+			method:       pb.UserService_Login_FullMethodName,
+			source:       &stubTokenSource{token: "access"},
+			results:      []error{nil},
+			expectCode:   codes.OK,
+			expectTokens: [][]string{nil},
+		},
+		{
+			name:         "rejected call retried with renewed token",
+			method:       pb.SecretService_ListSecrets_FullMethodName,
+			source:       &stubTokenSource{token: "access", renewed: "access2"},
+			results:      []error{unauthenticated, nil},
+			expectCode:   codes.OK,
+			expectTokens: [][]string{{"Bearer access"}, {"Bearer access2"}},
+			expectRenews: 1,
+		},
+		{
+			name:         "renewal failed",
+			method:       pb.SecretService_ListSecrets_FullMethodName,
+			source:       &stubTokenSource{token: "access", renewErr: e.ErrUnauthenticated},
+			results:      []error{unauthenticated},
+			expectErr:    e.ErrUnauthenticated,
+			expectTokens: [][]string{{"Bearer access"}},
+			expectRenews: 1,
+		},
+		{
+			name:         "other errors are not retried",
+			method:       pb.SecretService_ListSecrets_FullMethodName,
+			source:       &stubTokenSource{token: "access"},
+			results:      []error{status.Error(codes.NotFound, "Not found")},
+			expectCode:   codes.NotFound,
+			expectTokens: [][]string{{"Bearer access"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var tokens [][]string
+
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				tokens = append(tokens, authorization(ctx))
+				return tt.results[len(tokens)-1]
+			}
+
+			interceptor := grpcclient.AuthUnaryInterceptor(
+				func() grpcclient.TokenSource { return tt.source },
+				zerolog.Nop(),
+			)
+
+			err := interceptor(context.Background(), tt.method, nil, nil, nil, invoker)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.Equal(t, tt.expectCode, status.Code(err))
+			}
+
+			assert.Equal(t, tt.expectTokens, tokens)
+			assert.Equal(t, tt.expectRenews, tt.source.renews)
+		})
+	}
+}
+
+func TestAuthUnaryInterceptorWithoutSource(t *testing.T) {
+	t.Parallel()
+
+	var tokens []string
+
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		tokens = authorization(ctx)
+		return nil
+	}
+
+	interceptor := grpcclient.AuthUnaryInterceptor(func() grpcclient.TokenSource { return nil }, zerolog.Nop())
+
+	err := interceptor(context.Background(), pb.SecretService_ListSecrets_FullMethodName, nil, nil, nil, invoker)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+// stubClientStream records its token and request and fails receive with err.
+type stubClientStream struct {
+	grpc.ClientStream
+	token  []string
+	sent   []any
+	closed bool
+	err    error
+}
+
+func (s *stubClientStream) SendMsg(m any) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func (s *stubClientStream) CloseSend() error {
+	s.closed = true
+	return nil
+}
+
+func (s *stubClientStream) RecvMsg(_ any) error {
+	return s.err
+}
+
+func TestAuthStreamInterceptor(t *testing.T) {
+	t.Parallel()
+
+	unauthenticated := status.Error(codes.Unauthenticated, "Unauthorized")
+	serverStream := &grpc.StreamDesc{ServerStreams: true}
+	clientStream := &grpc.StreamDesc{ClientStreams: true}
+
+	tests := []struct {
+		name         string
+		desc         *grpc.StreamDesc
+		source       *stubTokenSource
+		results      []error
+		expectCode   codes.Code
+		expectErr    error
+		expectTokens [][]string
+		expectRenews int
+	}{
+		{
+			name:         "token attached",
+			desc:         serverStream,
+			source:       &stubTokenSource{token: "access"},
+			results:      []error{nil},
+			expectCode:   codes.OK,
+			expectTokens: [][]string{{"Bearer access"}},
+		},
+		{
+			name:         "rejected stream re-opened with renewed token",
+			desc:         serverStream,
+			source:       &stubTokenSource{token: "access", renewed: "access2"},
+			results:      []error{unauthenticated, nil},
+			expectCode:   codes.OK,
+			expectTokens: [][]string{{"Bearer access"}, {"Bearer access2"}},
+			expectRenews: 1,
+		},
+		{
+			name:         "re-opened stream rejected again",
+			desc:         serverStream,
+			source:       &stubTokenSource{token: "access", renewed: "access2"},
+			results:      []error{unauthenticated, unauthenticated},
+			expectCode:   codes.Unauthenticated,
+			expectTokens: [][]string{{"Bearer access"}, {"Bearer access2"}},
+			expectRenews: 1,
+		},
+		{
+			name:         "renewal failed",
+			desc:         serverStream,
+			source:       &stubTokenSource{token: "access", renewErr: e.ErrUnauthenticated},
+			results:      []error{unauthenticated},
+			expectErr:    e.ErrUnauthenticated,
+			expectTokens: [][]string{{"Bearer access"}},
+			expectRenews: 1,
+		},
+		{
+			name:         "client stream is not retried",
+			desc:         clientStream,
+			source:       &stubTokenSource{token: "access", renewed: "access2"},
+			results:      []error{unauthenticated},
+			expectCode:   codes.Unauthenticated,
+			expectTokens: [][]string{{"Bearer access"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var streams []*stubClientStream
+
+			streamer := func(
+				ctx context.Context,
+				_ *grpc.StreamDesc,
+				_ *grpc.ClientConn,
+				_ string,
+				_ ...grpc.CallOption,
+			) (grpc.ClientStream, error) {
+				stream := &stubClientStream{token: authorization(ctx), err: tt.results[len(streams)]}
+				streams = append(streams, stream)
+
+				return stream, nil
+			}
+
+			interceptor := grpcclient.AuthStreamInterceptor(
+				func() grpcclient.TokenSource { return tt.source },
+				zerolog.Nop(),
+			)
+
+			method := pb.SecretService_WatchSecrets_FullMethodName
+
+			stream, err := interceptor(context.Background(), tt.desc, nil, method, streamer)
+			require.NoError(t, err)
+
+			req := &pb.WatchSecretsRequest{Cursor: 42}
+			require.NoError(t, stream.SendMsg(req))
+			require.NoError(t, stream.CloseSend())
+
+			err = stream.RecvMsg(&pb.SecretEvent{})
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.Equal(t, tt.expectCode, status.Code(err))
+			}
+
+			tokens := make([][]string, 0, len(streams))
+			for _, opened := range streams {
+				tokens = append(tokens, opened.token)
+
+				// request of every opened stream is sent, the re-opened one gets it replayed.
+				assert.Equal(t, []any{req}, opened.sent)
+				assert.True(t, opened.closed)
+			}
+
+			assert.Equal(t, tt.expectTokens, tokens)
+			assert.Equal(t, tt.expectRenews, tt.source.renews)
+		})
+	}
+}
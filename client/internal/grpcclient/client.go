@@ -5,15 +5,22 @@ import (
 	"crypto/x509"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
 	clientinfo "github.com/patraden/ya-practicum-gophkeeper/client/internal/systeminfo"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Client wraps the gRPC connection and service clients.
@@ -21,6 +28,7 @@ type Client struct {
 	Conn          *grpc.ClientConn
 	UserService   pb.UserServiceClient
 	SecretService pb.SecretServiceClient
+	tokens        TokenSource
 	cfg           *config.Config
 	log           zerolog.Logger
 }
@@ -46,23 +54,52 @@ func New(cfg *config.Config, log zerolog.Logger) (*Client, error) {
 	}
 
 	creds := credentials.NewClientTLSFromCert(certPool, cfg.ServerHost)
+	client := &Client{
+		log: log,
+		cfg: cfg,
+	}
 
 	conn, err := grpc.NewClient(
 		fmt.Sprintf("%s:%d", cfg.ServerHost, cfg.ServerPort),
 		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(
+			requestTimeoutInterceptor(cfg.RequestsTimeout),
+			AuthUnaryInterceptor(client.tokenSource, log),
+		),
+		grpc.WithStreamInterceptor(AuthStreamInterceptor(client.tokenSource, log)),
 	)
 	if err != nil {
 		logCtx.Error().Err(err).Msg("server connection error")
 		return nil, fmt.Errorf("[%w]connect to gRPC", e.ErrUnavailable)
 	}
 
-	return &Client{
-		Conn:          conn,
-		UserService:   pb.NewUserServiceClient(conn),
-		SecretService: pb.NewSecretServiceClient(conn),
-		log:           log,
-		cfg:           cfg,
-	}, nil
+	client.Conn = conn
+	client.UserService = pb.NewUserServiceClient(conn)
+	client.SecretService = pb.NewSecretServiceClient(conn)
+
+	return client, nil
+}
+
+// requestTimeoutInterceptor limits calls made without deadline by requests timeout,
+// so that commands running long transfers still bound each server call.
+func requestTimeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		return invoker(ctx, method, req, reply, conn, opts...)
+	}
 }
 
 func (c *Client) Close() error {
@@ -74,20 +111,136 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// Register registers user with auth key derived from the password and new random salt.
 func (c *Client) Register(ctx context.Context) (*pb.RegisterResponse, error) {
+	salt, err := user.NewSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	authKey, err := keys.DeriveAuthKey(c.cfg.Password, salt)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &pb.RegisterRequest{
-		Username: c.cfg.Username,
-		Password: c.cfg.Password,
-		Role:     pb.UserRole_USER_ROLE_USER,
+		Username:   c.cfg.Username,
+		AuthKey:    authKey,
+		Salt:       salt,
+		Role:       pb.UserRole_USER_ROLE_USER,
+		DeviceId:   c.cfg.DeviceID,
+		ClientInfo: clientinfo.GenerateClientInfo(),
 	}
 
 	return c.UserService.Register(ctx, req)
 }
 
+// Login logs user in from this device with auth key derived from the password,
+// the password itself is only sent once by legacy user to migrate to auth key.
+func (c *Client) Login(ctx context.Context) (*pb.LoginResponse, error) {
+	params, err := c.GetLoginParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	authKey, err := keys.DeriveAuthKey(c.cfg.Password, params.GetSalt())
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.LoginRequest{
+		Username:     c.cfg.Username,
+		AuthKey:      authKey,
+		DeviceId:     c.cfg.DeviceID,
+		ClientInfo:   clientinfo.GenerateClientInfo(),
+		TotpCode:     c.cfg.TOTPCode,
+		RecoveryCode: c.cfg.RecoveryCode,
+	}
+
+	if params.GetPasswordRequired() {
+		c.log.Info().Msg("Migrating user to auth key login, password is sent to server for the last time")
+
+		req.Password = c.cfg.Password
+	}
+
+	return c.UserService.Login(ctx, req)
+}
+
+// GetLoginParams gets salt the user auth key is derived with.
+func (c *Client) GetLoginParams(ctx context.Context) (*pb.GetLoginParamsResponse, error) {
+	return c.UserService.GetLoginParams(ctx, &pb.GetLoginParamsRequest{Username: c.cfg.Username})
+}
+
+// ChangePassword replaces user auth key with the one derived from the new password
+// along with DEKs of all user secret versions re-wrapped with the new KEK.
+func (c *Client) ChangePassword(
+	ctx context.Context,
+	authKey, newAuthKey, newSalt []byte,
+	keys []*secret.WrappedKey,
+) (*pb.ChangePasswordResponse, error) {
+	req := &pb.ChangePasswordRequest{
+		AuthKey:    authKey,
+		NewAuthKey: newAuthKey,
+		NewSalt:    newSalt,
+		Keys:       make([]*pb.SecretKey, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		wrapped := dto.SecretKeyFromDomain(key)
+		req.Keys = append(req.Keys, wrapped.ToProto())
+	}
+
+	return c.UserService.ChangePassword(ctx, req)
+}
+
+// ListDevices lists devices of the user.
+func (c *Client) ListDevices(ctx context.Context) (*pb.ListDevicesResponse, error) {
+	return c.UserService.ListDevices(ctx, &pb.ListDevicesRequest{})
+}
+
+// RevokeDevice revokes device of the user.
+func (c *Client) RevokeDevice(ctx context.Context, deviceID string) (*pb.RevokeDeviceResponse, error) {
+	return c.UserService.RevokeDevice(ctx, &pb.RevokeDeviceRequest{DeviceId: deviceID})
+}
+
+// RefreshToken exchanges refresh token issued to the device for a new access and refresh token pair.
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*pb.RefreshTokenResponse, error) {
+	return c.UserService.RefreshToken(ctx, &pb.RefreshTokenRequest{
+		RefreshToken: refreshToken,
+		DeviceId:     c.cfg.DeviceID,
+	})
+}
+
+// Logout revokes refresh tokens of this device or of all user devices.
+func (c *Client) Logout(ctx context.Context, allDevices bool) (*pb.LogoutResponse, error) {
+	return c.UserService.Logout(ctx, &pb.LogoutRequest{AllDevices: allDevices})
+}
+
+// EnrollTOTP requests new TOTP seed of the user.
+func (c *Client) EnrollTOTP(ctx context.Context) (*pb.EnrollTOTPResponse, error) {
+	return c.UserService.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{})
+}
+
+// ConfirmTOTP enables enrolled TOTP with a code from authenticator app.
+func (c *Client) ConfirmTOTP(ctx context.Context, code string) (*pb.ConfirmTOTPResponse, error) {
+	return c.UserService.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{Code: code})
+}
+
 func (c *Client) SecretUpdateInitRequest(
 	ctx context.Context,
 	scrt *dto.Secret,
 ) (*pb.SecretUpdateInitResponse, error) {
+	metaData := []byte("{}")
+
+	if len(scrt.MetaData) > 0 {
+		var err error
+
+		metaData, err = scrt.MetaData.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("[%w] secret metadata", e.ErrMarshal)
+		}
+	}
+
 	req := &pb.SecretUpdateInitRequest{
 		UserId:          scrt.UserID,
 		SecretId:        scrt.ID,
@@ -98,8 +251,156 @@ func (c *Client) SecretUpdateInitRequest(
 		Size:            scrt.SecretSize,
 		Hash:            scrt.SecretHash,
 		EncryptedDek:    scrt.SecretDek,
-		MetadataJson:    "{}",
+		MetadataJson:    string(metaData),
 	}
 
 	return c.SecretService.SecretUpdateInit(ctx, req)
 }
+
+func (c *Client) SecretUpdateCommitRequest(
+	ctx context.Context,
+	scrt *dto.Secret,
+	token int64,
+	partHashes [][]byte,
+) (*pb.SecretUpdateCommitResponse, error) {
+	req := &pb.SecretUpdateCommitRequest{
+		UserId:          scrt.UserID,
+		SecretId:        scrt.ID,
+		VersionId:       scrt.VersionID,
+		ParentVersionId: scrt.ParentVersionID,
+		ClientInfo:      clientinfo.GenerateClientInfo(),
+		Size:            scrt.SecretSize,
+		Hash:            scrt.SecretHash,
+		EncryptedDek:    scrt.SecretDek,
+		Token:           token,
+		PartHashes:      partHashes,
+	}
+
+	return c.SecretService.SecretUpdateCommit(ctx, req)
+}
+
+func (c *Client) RenewUploadCredentials(
+	ctx context.Context,
+	userID, secretID string,
+	token int64,
+) (*pb.RenewUploadCredentialsResponse, error) {
+	req := &pb.RenewUploadCredentialsRequest{
+		UserId:   userID,
+		SecretId: secretID,
+		Token:    token,
+	}
+
+	return c.SecretService.RenewUploadCredentials(ctx, req)
+}
+
+func (c *Client) SecretGetInitRequest(
+	ctx context.Context,
+	userID, secretName string,
+) (*pb.SecretGetInitResponse, error) {
+	req := &pb.SecretGetInitRequest{
+		UserId:     userID,
+		SecretName: secretName,
+		ClientInfo: clientinfo.GenerateClientInfo(),
+	}
+
+	return c.SecretService.SecretGetInit(ctx, req)
+}
+
+func (c *Client) ListSecrets(
+	ctx context.Context,
+	userID, namePrefix, pageToken string,
+) (*pb.ListSecretsResponse, error) {
+	req := &pb.ListSecretsRequest{
+		UserId:     userID,
+		NamePrefix: namePrefix,
+		PageToken:  pageToken,
+	}
+
+	return c.SecretService.ListSecrets(ctx, req)
+}
+
+func (c *Client) ListSecretVersions(
+	ctx context.Context,
+	userID, secretName string,
+) (*pb.ListSecretVersionsResponse, error) {
+	req := &pb.ListSecretVersionsRequest{
+		UserId:     userID,
+		SecretName: secretName,
+	}
+
+	return c.SecretService.ListSecretVersions(ctx, req)
+}
+
+func (c *Client) GetSecretVersion(
+	ctx context.Context,
+	userID, secretName, versionID string,
+) (*pb.GetSecretVersionResponse, error) {
+	req := &pb.GetSecretVersionRequest{
+		UserId:     userID,
+		SecretName: secretName,
+		VersionId:  versionID,
+		ClientInfo: clientinfo.GenerateClientInfo(),
+	}
+
+	return c.SecretService.GetSecretVersion(ctx, req)
+}
+
+func (c *Client) SecretDelete(
+	ctx context.Context,
+	userID, secretName, versionID string,
+) (*pb.SecretDeleteResponse, error) {
+	req := &pb.SecretDeleteRequest{
+		UserId:     userID,
+		SecretName: secretName,
+		VersionId:  versionID,
+		ClientInfo: clientinfo.GenerateClientInfo(),
+	}
+
+	return c.SecretService.SecretDelete(ctx, req)
+}
+
+func (c *Client) ListSecretTombstones(
+	ctx context.Context,
+	userID string,
+	since time.Time,
+) (*pb.ListSecretTombstonesResponse, error) {
+	req := &pb.ListSecretTombstonesRequest{UserId: userID}
+	if !since.IsZero() {
+		req.Since = timestamppb.New(since)
+	}
+
+	return c.SecretService.ListSecretTombstones(ctx, req)
+}
+
+// ListSecretKeys lists wrapped DEKs of all user secret versions stored on server.
+func (c *Client) ListSecretKeys(ctx context.Context, userID string) (*pb.ListSecretKeysResponse, error) {
+	return c.SecretService.ListSecretKeys(ctx, &pb.ListSecretKeysRequest{UserId: userID})
+}
+
+// WatchSecrets opens stream of user secret events recorded after cursor.
+// Stream stays open until ctx is cancelled or connection is lost.
+func (c *Client) WatchSecrets(
+	ctx context.Context,
+	userID string,
+	cursor int64,
+) (grpc.ServerStreamingClient[pb.SecretEvent], error) {
+	return c.SecretService.WatchSecrets(ctx, &pb.WatchSecretsRequest{UserId: userID, Cursor: cursor})
+}
+
+// VersionConflictFromError extracts version conflict details from the server error
+// if upload was rejected because of outdated parent version.
+func VersionConflictFromError(err error) (*dto.SecretVersionConflict, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		return nil, false
+	}
+
+	for _, detail := range st.Details() {
+		if pbConflict, ok := detail.(*pb.SecretVersionConflict); ok {
+			conflict := dto.SecretVersionConflictFromProto(pbConflict)
+			return &conflict, true
+		}
+	}
+
+	return nil, false
+}
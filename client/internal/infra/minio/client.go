@@ -17,13 +17,40 @@ import (
 // and logging capabilities for interacting with an S3-compatible MinIO backend.
 type Client struct {
 	s3.ClientOperator
+	s3.MultipartOperator
+	s3.ChunkOperator
 	minio *minio.Client
+	creds *credentials.Credentials
 	cfg   *s3.ClientConfig
 	log   zerolog.Logger
 }
 
 // NewClient initializes a new MinIO S3 client using the provided configuration.
 func NewClient(cfg *s3.ClientConfig, log zerolog.Logger) (*Client, error) {
+	return newClient(cfg, credentials.NewStaticV4(cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3Token), log)
+}
+
+// NewRenewableClient initializes a new MinIO S3 client which renews temporary credentials
+// before they expire, so that long transfers survive expiration of the initially issued ones.
+func NewRenewableClient(
+	cfg *s3.ClientConfig,
+	expiration string,
+	renew RenewFunc,
+	log zerolog.Logger,
+) (*Client, error) {
+	creds := credentials.New(newRenewableProvider(cfg, expiration, renew, log))
+
+	client, err := newClient(cfg, creds, log)
+	if err != nil {
+		return nil, err
+	}
+
+	client.creds = creds
+
+	return client, nil
+}
+
+func newClient(cfg *s3.ClientConfig, creds *credentials.Credentials, log zerolog.Logger) (*Client, error) {
 	builder := transport.NewHTTPTransportBuilder(cfg.S3TLSCertPath, nil, log)
 
 	httptrprt, err := builder.Build()
@@ -32,7 +59,7 @@ func NewClient(cfg *s3.ClientConfig, log zerolog.Logger) (*Client, error) {
 	}
 
 	client, err := minio.New(cfg.S3Endpoint, &minio.Options{
-		Creds:     credentials.NewStaticV4(cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3Token),
+		Creds:     creds,
 		Secure:    true,
 		Transport: httptrprt,
 	})
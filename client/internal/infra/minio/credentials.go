@@ -0,0 +1,97 @@
+package minio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// renewWindow is how long before expiration credentials are renewed.
+	renewWindow = time.Minute
+	// unknownExpiration is the lifetime assumed for credentials without parsable expiration.
+	unknownExpiration = 365 * 24 * time.Hour
+)
+
+// RenewFunc requests fresh temporary credentials from the server.
+type RenewFunc func() (*s3.TemporaryCredentials, error)
+
+// renewableProvider serves temporary credentials issued by the server
+// and renews them with RenewFunc once they are about to expire.
+type renewableProvider struct {
+	credentials.Expiry
+	mu      sync.Mutex
+	initial *s3.TemporaryCredentials
+	renew   RenewFunc
+	log     zerolog.Logger
+}
+
+func newRenewableProvider(
+	cfg *s3.ClientConfig,
+	expiration string,
+	renew RenewFunc,
+	log zerolog.Logger,
+) *renewableProvider {
+	return &renewableProvider{
+		initial: &s3.TemporaryCredentials{
+			AccessKeyID:     cfg.S3AccessKey,
+			SecretAccessKey: cfg.S3SecretKey,
+			SessionToken:    cfg.S3Token,
+			Expiration:      expiration,
+		},
+		renew: renew,
+		log:   log,
+	}
+}
+
+// RetrieveWithCredContext returns initially issued credentials first and renewed ones afterwards.
+func (p *renewableProvider) RetrieveWithCredContext(_ *credentials.CredContext) (credentials.Value, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	creds := p.initial
+	p.initial = nil
+
+	if creds == nil {
+		p.log.Info().Msg("renewing temporary storage credentials")
+
+		renewed, err := p.renew()
+		if err != nil {
+			p.log.Error().Err(err).Msg("failed to renew temporary storage credentials")
+			return credentials.Value{}, err
+		}
+
+		if renewed == nil {
+			return credentials.Value{}, fmt.Errorf("[%w] storage credentials", e.ErrUnavailable)
+		}
+
+		creds = renewed
+	}
+
+	value := credentials.Value{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		SignerType:      credentials.SignatureV4,
+	}
+
+	// credentials without known expiration are renewed only when storage rejects them.
+	expiration, err := time.Parse(time.RFC3339, creds.Expiration)
+	if err != nil {
+		expiration = time.Now().Add(unknownExpiration)
+	}
+
+	value.Expiration = expiration
+	p.SetExpiration(expiration, renewWindow)
+
+	return value, nil
+}
+
+func (p *renewableProvider) Retrieve() (credentials.Value, error) {
+	return p.RetrieveWithCredContext(nil)
+}
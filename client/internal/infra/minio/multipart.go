@@ -0,0 +1,267 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/checksum"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
+)
+
+const (
+	// errCodeExpiredToken is returned by storage for requests signed with expired temporary credentials.
+	errCodeExpiredToken = "ExpiredToken"
+	// errCodeNoSuchUpload is returned by storage for aborted or expired multipart uploads.
+	errCodeNoSuchUpload = "NoSuchUpload"
+	// errCodeNoSuchKey is returned by storage for objects which do not exist.
+	errCodeNoSuchKey = "NoSuchKey"
+
+	headerChecksumAlgorithm = "X-Amz-Checksum-Algorithm"
+	headerChecksumSHA256    = "X-Amz-Checksum-Sha256"
+	checksumAlgorithmSHA256 = "SHA256"
+)
+
+// NewMultipartUpload starts multipart upload of the object and returns its upload id.
+// Upload expects SHA-256 checksums of its parts, so that S3 stores checksum of the assembled object.
+func (c *Client) NewMultipartUpload(
+	ctx context.Context,
+	bucketName, objectName string,
+	opts s3.PutObjectOptions,
+) (string, error) {
+	var uploadID string
+
+	userMetadata := make(map[string]string, len(opts.UserMetadata)+1)
+	for k, v := range opts.UserMetadata {
+		userMetadata[k] = v
+	}
+
+	userMetadata[headerChecksumAlgorithm] = checksumAlgorithmSHA256
+	opts.UserMetadata = userMetadata
+
+	err := c.withRenewal(func() error {
+		var err error
+
+		uploadID, err = c.core().NewMultipartUpload(ctx, bucketName, objectName, opts)
+
+		return err
+	})
+	if err != nil {
+		c.log.Error().Err(err).
+			Str("bucket", bucketName).
+			Str("object", objectName).
+			Msg("failed to start multipart upload")
+
+		return "", e.InternalErr(err)
+	}
+
+	return uploadID, nil
+}
+
+// PutObjectPart uploads a single part of multipart upload with its SHA-256 checksum,
+// part which content does not match the checksum is rejected by S3.
+// Data must be re-readable from the start since part is retried once credentials are renewed.
+func (c *Client) PutObjectPart(
+	ctx context.Context,
+	bucketName, objectName, uploadID string,
+	partNumber int,
+	data io.Reader,
+	size int64,
+	sum []byte,
+) (s3.ObjectPart, error) {
+	ctxLog := c.log.With().
+		Str("bucket", bucketName).
+		Str("object", objectName).
+		Int("part", partNumber).Logger()
+
+	var part s3.ObjectPart
+
+	start := time.Now()
+	err := c.withRenewal(func() error {
+		if seeker, ok := data.(io.Seeker); ok {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return fmt.Errorf("[%w] object part", e.ErrRead)
+			}
+		}
+
+		var err error
+
+		part, err = c.core().PutObjectPart(ctx, bucketName, objectName, uploadID, partNumber, data, size,
+			minio.PutObjectPartOptions{CustomHeader: http.Header{headerChecksumSHA256: []string{checksum.S3(sum)}}})
+
+		return err
+	})
+	duration := time.Since(start)
+
+	if err != nil {
+		ctxLog.Error().Err(err).
+			Dur("duration", duration).
+			Msg("failed to upload object part")
+
+		return s3.ObjectPart{}, e.InternalErr(err)
+	}
+
+	ctxLog.Debug().
+		Dur("duration", duration).
+		Int64("size", part.Size).
+		Msg("uploaded object part")
+
+	return part, nil
+}
+
+// CompleteMultipartUpload assembles uploaded parts into the object.
+// Returns ErrNotFound if multipart upload no longer exists on storage.
+func (c *Client) CompleteMultipartUpload(
+	ctx context.Context,
+	bucketName, objectName, uploadID string,
+	parts []s3.CompletePart,
+) (s3.UploadInfo, error) {
+	var info s3.UploadInfo
+
+	err := c.withRenewal(func() error {
+		var err error
+
+		info, err = c.core().CompleteMultipartUpload(ctx, bucketName, objectName, uploadID, parts,
+			minio.PutObjectOptions{})
+
+		return err
+	})
+
+	if minio.ToErrorResponse(err).Code == errCodeNoSuchUpload {
+		return s3.UploadInfo{}, fmt.Errorf("[%w] multipart upload %s", e.ErrNotFound, uploadID)
+	}
+
+	if err != nil {
+		c.log.Error().Err(err).
+			Str("bucket", bucketName).
+			Str("object", objectName).
+			Msg("failed to complete multipart upload")
+
+		return s3.UploadInfo{}, e.InternalErr(err)
+	}
+
+	return info, nil
+}
+
+// AbortMultipartUpload discards multipart upload together with its uploaded parts.
+func (c *Client) AbortMultipartUpload(ctx context.Context, bucketName, objectName, uploadID string) error {
+	err := c.withRenewal(func() error {
+		return c.core().AbortMultipartUpload(ctx, bucketName, objectName, uploadID)
+	})
+	if err != nil && minio.ToErrorResponse(err).Code != errCodeNoSuchUpload {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// GetObjectRange writes length bytes of the object starting from offset to dest.
+func (c *Client) GetObjectRange(
+	ctx context.Context,
+	bucketName, objectName string,
+	offset, length int64,
+	dest io.Writer,
+) (int64, error) {
+	opts := minio.GetObjectOptions{}
+	if err := opts.SetRange(offset, offset+length-1); err != nil {
+		return 0, fmt.Errorf("[%w] object range", e.ErrInvalidInput)
+	}
+
+	var body io.ReadCloser
+
+	err := c.withRenewal(func() error {
+		var err error
+
+		body, _, _, err = c.core().GetObject(ctx, bucketName, objectName, opts)
+
+		return err
+	})
+	if err != nil {
+		c.log.Error().Err(err).
+			Str("bucket", bucketName).
+			Str("object", objectName).
+			Int64("offset", offset).
+			Msg("failed to download object range")
+
+		return 0, e.InternalErr(err)
+	}
+	defer body.Close()
+
+	written, err := io.Copy(dest, body)
+	if err != nil {
+		return written, fmt.Errorf("[%w] object range", e.ErrWrite)
+	}
+
+	return written, nil
+}
+
+// ObjectExists reports whether object is stored in the bucket.
+func (c *Client) ObjectExists(ctx context.Context, bucketName, objectName string) (bool, error) {
+	err := c.withRenewal(func() error {
+		_, err := c.minio.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{})
+		return err
+	})
+
+	if minio.ToErrorResponse(err).Code == errCodeNoSuchKey {
+		return false, nil
+	}
+
+	if err != nil {
+		c.log.Error().Err(err).
+			Str("bucket", bucketName).
+			Str("object", objectName).
+			Msg("failed to stat object")
+
+		return false, e.InternalErr(err)
+	}
+
+	return true, nil
+}
+
+// PutObjectBytes uploads object from memory together with its SHA-256 checksum verified by S3.
+func (c *Client) PutObjectBytes(ctx context.Context, bucketName, objectName string, data []byte) error {
+	opts := minio.PutObjectOptions{
+		UserMetadata: map[string]string{headerChecksumSHA256: checksum.S3(checksum.Sum(data))},
+	}
+
+	err := c.withRenewal(func() error {
+		_, err := c.minio.PutObject(ctx, bucketName, objectName, bytes.NewReader(data), int64(len(data)), opts)
+
+		return err
+	})
+	if err != nil {
+		c.log.Error().Err(err).
+			Str("bucket", bucketName).
+			Str("object", objectName).
+			Msg("failed to upload object")
+
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+func (c *Client) core() minio.Core {
+	return minio.Core{Client: c.minio}
+}
+
+// withRenewal runs storage operation and repeats it once with renewed credentials
+// if storage rejected credentials as expired before client noticed it.
+func (c *Client) withRenewal(op func() error) error {
+	err := op()
+
+	var errResp minio.ErrorResponse
+	if c.creds == nil || !errors.As(err, &errResp) || errResp.Code != errCodeExpiredToken {
+		return err
+	}
+
+	c.creds.Expire()
+
+	return op()
+}
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE secret_conflicts (
+    user_id                     TEXT NOT NULL CHECK (length(user_id) = 36),
+    secret_id                   TEXT NOT NULL CHECK (length(secret_id) = 36),
+    secret_name                 TEXT NOT NULL CHECK (length(secret_name) <= 64),
+    local_version_id            TEXT NOT NULL CHECK (length(local_version_id) = 36),
+    remote_secret_id            TEXT NOT NULL CHECK (length(remote_secret_id) = 36),
+    remote_version_id           TEXT NOT NULL CHECK (length(remote_version_id) = 36),
+    remote_parent_version_id    TEXT NOT NULL,
+    remote_file_path            TEXT NOT NULL,
+    remote_secret_size          INTEGER NOT NULL,
+    remote_secret_hash          BLOB NOT NULL,
+    remote_secret_dek           BLOB NOT NULL,
+    remote_meta                 TEXT NOT NULL DEFAULT '{}',
+    detected_at                 DATETIME NOT NULL,
+
+    PRIMARY KEY (user_id, secret_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS secret_conflicts;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE transfers (
+    user_id         TEXT NOT NULL CHECK (length(user_id) = 36),
+    secret_id       TEXT NOT NULL CHECK (length(secret_id) = 36),
+    direction       TEXT NOT NULL CHECK (direction IN ('upload', 'download')),
+    secret_name     TEXT NOT NULL CHECK (length(secret_name) <= 64),
+    version_id      TEXT NOT NULL CHECK (length(version_id) = 36),
+    object_key      TEXT NOT NULL,
+    upload_id       TEXT NOT NULL DEFAULT '',
+    token           INTEGER NOT NULL DEFAULT 0,
+    file_path       TEXT NOT NULL,
+    file_size       INTEGER NOT NULL,
+    part_size       INTEGER NOT NULL,
+    created_at      DATETIME NOT NULL,
+    updated_at      DATETIME NOT NULL,
+
+    PRIMARY KEY (user_id, secret_id, direction)
+);
+
+CREATE TABLE transfer_parts (
+    upload_id       TEXT NOT NULL,
+    part_number     INTEGER NOT NULL,
+    etag            TEXT NOT NULL,
+    part_size       INTEGER NOT NULL,
+    created_at      DATETIME NOT NULL,
+
+    PRIMARY KEY (upload_id, part_number)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS transfer_parts;
+DROP TABLE IF EXISTS transfers;
+-- +goose StatementEnd
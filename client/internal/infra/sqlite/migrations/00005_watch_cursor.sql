@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Cursor of the last applied server secret event, so that watch resumes where it stopped.
+CREATE TABLE watch_cursors (
+    user_id     TEXT NOT NULL CHECK (length(user_id) = 36),
+    cursor      INTEGER NOT NULL,
+    updated_at  DATETIME NOT NULL,
+
+    PRIMARY KEY (user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS watch_cursors;
+-- +goose StatementEnd
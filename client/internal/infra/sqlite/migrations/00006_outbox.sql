@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Secret changes made while offline, replayed to the server in seq order.
+-- Operation is identified by the client generated version it pushes or deletes.
+CREATE TABLE outbox (
+    seq             INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id         TEXT NOT NULL CHECK (length(user_id) = 36),
+    secret_id       TEXT NOT NULL CHECK (length(secret_id) = 36),
+    secret_name     TEXT NOT NULL CHECK (length(secret_name) <= 64),
+    version_id      TEXT NOT NULL CHECK (length(version_id) = 36),
+    operation       TEXT NOT NULL CHECK (operation IN ('create', 'update', 'delete')),
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      DATETIME NOT NULL,
+    updated_at      DATETIME NOT NULL,
+
+    UNIQUE (user_id, version_id, operation)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
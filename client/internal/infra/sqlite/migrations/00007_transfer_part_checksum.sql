@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Parts are uploaded with SHA-256 checksum, completion of multipart upload requires checksums of all parts.
+ALTER TABLE transfer_parts ADD COLUMN checksum BLOB NOT NULL DEFAULT x'';
+
+-- Interrupted uploads started without checksums start over.
+DELETE FROM transfer_parts;
+UPDATE transfers SET upload_id = '' WHERE direction = 'upload';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transfer_parts DROP COLUMN checksum;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Access token is short-lived and renewed with refresh token, which is rotated on every renewal.
+-- Tokens issued before keep zero expiration and are used until server rejects them.
+ALTER TABLE users_server_tokens ADD COLUMN expires_at DATETIME NOT NULL DEFAULT '0001-01-01 00:00:00+00:00';
+ALTER TABLE users_server_tokens ADD COLUMN refresh_token TEXT NOT NULL DEFAULT '';
+ALTER TABLE users_server_tokens ADD COLUMN refresh_expires_at DATETIME NOT NULL DEFAULT '0001-01-01 00:00:00+00:00';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users_server_tokens DROP COLUMN refresh_expires_at;
+ALTER TABLE users_server_tokens DROP COLUMN refresh_token;
+ALTER TABLE users_server_tokens DROP COLUMN expires_at;
+-- +goose StatementEnd
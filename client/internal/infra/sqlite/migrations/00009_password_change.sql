@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Password change in progress: salt and verifier of the new password are kept until
+-- secret keys are re-wrapped both on server and locally, so that interrupted change is resumed.
+CREATE TABLE password_changes (
+    user_id      TEXT PRIMARY KEY CHECK (length(user_id) = 36) REFERENCES users(id) ON DELETE CASCADE,
+    salt         BLOB NOT NULL,
+    verifier     BLOB NOT NULL,
+    created_at   DATETIME NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS password_changes;
+-- +goose StatementEnd
@@ -10,6 +10,26 @@ import (
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 )
 
+type Outbox struct {
+	Seq        int64
+	UserID     string
+	SecretID   string
+	SecretName string
+	VersionID  string
+	Operation  string
+	Attempts   int64
+	LastError  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type PasswordChange struct {
+	UserID    string
+	Salt      []byte
+	Verifier  []byte
+	CreatedAt time.Time
+}
+
 type Secret struct {
 	UserID          string
 	SecretID        string
@@ -25,6 +45,22 @@ type Secret struct {
 	InSync          int64
 }
 
+type SecretConflict struct {
+	UserID                string
+	SecretID              string
+	SecretName            string
+	LocalVersionID        string
+	RemoteSecretID        string
+	RemoteVersionID       string
+	RemoteParentVersionID string
+	RemoteFilePath        string
+	RemoteSecretSize      int64
+	RemoteSecretHash      []byte
+	RemoteSecretDek       []byte
+	RemoteMeta            string
+	DetectedAt            time.Time
+}
+
 type SecretMetum struct {
 	UserID    string
 	SecretID  string
@@ -33,6 +69,31 @@ type SecretMetum struct {
 	UpdatedAt time.Time
 }
 
+type Transfer struct {
+	UserID     string
+	SecretID   string
+	Direction  string
+	SecretName string
+	VersionID  string
+	ObjectKey  string
+	UploadID   string
+	Token      int64
+	FilePath   string
+	FileSize   int64
+	PartSize   int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type TransferPart struct {
+	UploadID   string
+	PartNumber int64
+	Etag       string
+	PartSize   int64
+	CreatedAt  time.Time
+	Checksum   []byte
+}
+
 type User struct {
 	ID         string
 	Username   string
@@ -45,7 +106,16 @@ type User struct {
 }
 
 type UsersServerToken struct {
-	UserID string
-	Token  string
-	Ttl    int64
+	UserID           string
+	Token            string
+	Ttl              int64
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+type WatchCursor struct {
+	UserID    string
+	Cursor    int64
+	UpdatedAt time.Time
 }
@@ -12,6 +12,37 @@ import (
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 )
 
+const createOutboxOperation = `-- name: CreateOutboxOperation :exec
+INSERT INTO outbox (
+    user_id, secret_id, secret_name, version_id, operation, created_at, updated_at
+)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (user_id, version_id, operation) DO NOTHING
+`
+
+type CreateOutboxOperationParams struct {
+	UserID     string
+	SecretID   string
+	SecretName string
+	VersionID  string
+	Operation  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (q *Queries) CreateOutboxOperation(ctx context.Context, arg CreateOutboxOperationParams) error {
+	_, err := q.db.ExecContext(ctx, createOutboxOperation,
+		arg.UserID,
+		arg.SecretID,
+		arg.SecretName,
+		arg.VersionID,
+		arg.Operation,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
 const createSecret = `-- name: CreateSecret :exec
 INSERT INTO secrets (
     user_id,
@@ -63,6 +94,164 @@ func (q *Queries) CreateSecret(ctx context.Context, arg CreateSecretParams) erro
 	return err
 }
 
+const createSecretConflict = `-- name: CreateSecretConflict :exec
+INSERT INTO secret_conflicts (
+    user_id,
+    secret_id,
+    secret_name,
+    local_version_id,
+    remote_secret_id,
+    remote_version_id,
+    remote_parent_version_id,
+    remote_file_path,
+    remote_secret_size,
+    remote_secret_hash,
+    remote_secret_dek,
+    remote_meta,
+    detected_at
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateSecretConflictParams struct {
+	UserID                string
+	SecretID              string
+	SecretName            string
+	LocalVersionID        string
+	RemoteSecretID        string
+	RemoteVersionID       string
+	RemoteParentVersionID string
+	RemoteFilePath        string
+	RemoteSecretSize      int64
+	RemoteSecretHash      []byte
+	RemoteSecretDek       []byte
+	RemoteMeta            string
+	DetectedAt            time.Time
+}
+
+func (q *Queries) CreateSecretConflict(ctx context.Context, arg CreateSecretConflictParams) error {
+	_, err := q.db.ExecContext(ctx, createSecretConflict,
+		arg.UserID,
+		arg.SecretID,
+		arg.SecretName,
+		arg.LocalVersionID,
+		arg.RemoteSecretID,
+		arg.RemoteVersionID,
+		arg.RemoteParentVersionID,
+		arg.RemoteFilePath,
+		arg.RemoteSecretSize,
+		arg.RemoteSecretHash,
+		arg.RemoteSecretDek,
+		arg.RemoteMeta,
+		arg.DetectedAt,
+	)
+	return err
+}
+
+const createSecretMeta = `-- name: CreateSecretMeta :exec
+INSERT INTO secret_meta (user_id, secret_id, meta, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateSecretMetaParams struct {
+	UserID    string
+	SecretID  string
+	Meta      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (q *Queries) CreateSecretMeta(ctx context.Context, arg CreateSecretMetaParams) error {
+	_, err := q.db.ExecContext(ctx, createSecretMeta,
+		arg.UserID,
+		arg.SecretID,
+		arg.Meta,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const createTransfer = `-- name: CreateTransfer :exec
+INSERT INTO transfers (
+    user_id,
+    secret_id,
+    direction,
+    secret_name,
+    version_id,
+    object_key,
+    upload_id,
+    token,
+    file_path,
+    file_size,
+    part_size,
+    created_at,
+    updated_at
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateTransferParams struct {
+	UserID     string
+	SecretID   string
+	Direction  string
+	SecretName string
+	VersionID  string
+	ObjectKey  string
+	UploadID   string
+	Token      int64
+	FilePath   string
+	FileSize   int64
+	PartSize   int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) error {
+	_, err := q.db.ExecContext(ctx, createTransfer,
+		arg.UserID,
+		arg.SecretID,
+		arg.Direction,
+		arg.SecretName,
+		arg.VersionID,
+		arg.ObjectKey,
+		arg.UploadID,
+		arg.Token,
+		arg.FilePath,
+		arg.FileSize,
+		arg.PartSize,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const createTransferPart = `-- name: CreateTransferPart :exec
+INSERT OR REPLACE INTO transfer_parts (upload_id, part_number, etag, part_size, created_at, checksum)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateTransferPartParams struct {
+	UploadID   string
+	PartNumber int64
+	Etag       string
+	PartSize   int64
+	CreatedAt  time.Time
+	Checksum   []byte
+}
+
+func (q *Queries) CreateTransferPart(ctx context.Context, arg CreateTransferPartParams) error {
+	_, err := q.db.ExecContext(ctx, createTransferPart,
+		arg.UploadID,
+		arg.PartNumber,
+		arg.Etag,
+		arg.PartSize,
+		arg.CreatedAt,
+		arg.Checksum,
+	)
+	return err
+}
+
 const createUser = `-- name: CreateUser :exec
 INSERT INTO users (id, username, verifier, role, salt, bucketname, created_at, updated_at)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
@@ -94,21 +283,150 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 }
 
 const createUserToken = `-- name: CreateUserToken :exec
-INSERT INTO users_server_tokens (user_id, token, ttl)
-VALUES (?, ?, ?)
+INSERT INTO users_server_tokens (user_id, token, ttl, expires_at, refresh_token, refresh_expires_at)
+VALUES (?, ?, ?, ?, ?, ?)
 `
 
 type CreateUserTokenParams struct {
-	UserID string
-	Token  string
-	Ttl    int64
+	UserID           string
+	Token            string
+	Ttl              int64
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
 }
 
 func (q *Queries) CreateUserToken(ctx context.Context, arg CreateUserTokenParams) error {
-	_, err := q.db.ExecContext(ctx, createUserToken, arg.UserID, arg.Token, arg.Ttl)
+	_, err := q.db.ExecContext(ctx, createUserToken,
+		arg.UserID,
+		arg.Token,
+		arg.Ttl,
+		arg.ExpiresAt,
+		arg.RefreshToken,
+		arg.RefreshExpiresAt,
+	)
+	return err
+}
+
+const deleteOutboxOperation = `-- name: DeleteOutboxOperation :exec
+DELETE FROM outbox
+WHERE seq = ?
+`
+
+func (q *Queries) DeleteOutboxOperation(ctx context.Context, seq int64) error {
+	_, err := q.db.ExecContext(ctx, deleteOutboxOperation, seq)
+	return err
+}
+
+const deletePasswordChange = `-- name: DeletePasswordChange :exec
+DELETE FROM password_changes
+WHERE user_id = ?
+`
+
+func (q *Queries) DeletePasswordChange(ctx context.Context, userID string) error {
+	_, err := q.db.ExecContext(ctx, deletePasswordChange, userID)
+	return err
+}
+
+const deleteSecret = `-- name: DeleteSecret :exec
+DELETE FROM secrets
+WHERE user_id = ? AND secret_id = ?
+`
+
+type DeleteSecretParams struct {
+	UserID   string
+	SecretID string
+}
+
+func (q *Queries) DeleteSecret(ctx context.Context, arg DeleteSecretParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSecret, arg.UserID, arg.SecretID)
+	return err
+}
+
+const deleteSecretConflict = `-- name: DeleteSecretConflict :exec
+DELETE FROM secret_conflicts
+WHERE user_id = ? AND secret_id = ?
+`
+
+type DeleteSecretConflictParams struct {
+	UserID   string
+	SecretID string
+}
+
+func (q *Queries) DeleteSecretConflict(ctx context.Context, arg DeleteSecretConflictParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSecretConflict, arg.UserID, arg.SecretID)
+	return err
+}
+
+const deleteSecretMeta = `-- name: DeleteSecretMeta :exec
+DELETE FROM secret_meta
+WHERE user_id = ? AND secret_id = ?
+`
+
+type DeleteSecretMetaParams struct {
+	UserID   string
+	SecretID string
+}
+
+func (q *Queries) DeleteSecretMeta(ctx context.Context, arg DeleteSecretMetaParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSecretMeta, arg.UserID, arg.SecretID)
+	return err
+}
+
+const deleteTransfer = `-- name: DeleteTransfer :exec
+DELETE FROM transfers
+WHERE user_id = ? AND secret_id = ? AND direction = ?
+`
+
+type DeleteTransferParams struct {
+	UserID    string
+	SecretID  string
+	Direction string
+}
+
+func (q *Queries) DeleteTransfer(ctx context.Context, arg DeleteTransferParams) error {
+	_, err := q.db.ExecContext(ctx, deleteTransfer, arg.UserID, arg.SecretID, arg.Direction)
+	return err
+}
+
+const deleteTransferParts = `-- name: DeleteTransferParts :exec
+DELETE FROM transfer_parts
+WHERE upload_id = ?
+`
+
+func (q *Queries) DeleteTransferParts(ctx context.Context, uploadID string) error {
+	_, err := q.db.ExecContext(ctx, deleteTransferParts, uploadID)
+	return err
+}
+
+const deleteUserToken = `-- name: DeleteUserToken :exec
+DELETE FROM users_server_tokens
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteUserToken(ctx context.Context, userID string) error {
+	_, err := q.db.ExecContext(ctx, deleteUserToken, userID)
 	return err
 }
 
+const getPasswordChange = `-- name: GetPasswordChange :one
+SELECT user_id, salt, verifier, created_at
+FROM password_changes
+WHERE user_id = ?
+`
+
+func (q *Queries) GetPasswordChange(ctx context.Context, userID string) (PasswordChange, error) {
+	row := q.db.QueryRowContext(ctx, getPasswordChange, userID)
+	var i PasswordChange
+	err := row.Scan(
+		&i.UserID,
+		&i.Salt,
+		&i.Verifier,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getSecret = `-- name: GetSecret :one
 SELECT
     secrets.user_id,
@@ -153,6 +471,115 @@ func (q *Queries) GetSecret(ctx context.Context, arg GetSecretParams) (Secret, e
 	return i, err
 }
 
+const getSecretConflict = `-- name: GetSecretConflict :one
+SELECT
+    user_id,
+    secret_id,
+    secret_name,
+    local_version_id,
+    remote_secret_id,
+    remote_version_id,
+    remote_parent_version_id,
+    remote_file_path,
+    remote_secret_size,
+    remote_secret_hash,
+    remote_secret_dek,
+    remote_meta,
+    detected_at
+FROM secret_conflicts
+WHERE user_id = ? AND secret_name = ?
+`
+
+type GetSecretConflictParams struct {
+	UserID     string
+	SecretName string
+}
+
+func (q *Queries) GetSecretConflict(ctx context.Context, arg GetSecretConflictParams) (SecretConflict, error) {
+	row := q.db.QueryRowContext(ctx, getSecretConflict, arg.UserID, arg.SecretName)
+	var i SecretConflict
+	err := row.Scan(
+		&i.UserID,
+		&i.SecretID,
+		&i.SecretName,
+		&i.LocalVersionID,
+		&i.RemoteSecretID,
+		&i.RemoteVersionID,
+		&i.RemoteParentVersionID,
+		&i.RemoteFilePath,
+		&i.RemoteSecretSize,
+		&i.RemoteSecretHash,
+		&i.RemoteSecretDek,
+		&i.RemoteMeta,
+		&i.DetectedAt,
+	)
+	return i, err
+}
+
+const getSecretMeta = `-- name: GetSecretMeta :one
+SELECT meta
+FROM secret_meta
+WHERE user_id = ? AND secret_id = ?
+`
+
+type GetSecretMetaParams struct {
+	UserID   string
+	SecretID string
+}
+
+func (q *Queries) GetSecretMeta(ctx context.Context, arg GetSecretMetaParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getSecretMeta, arg.UserID, arg.SecretID)
+	var meta string
+	err := row.Scan(&meta)
+	return meta, err
+}
+
+const getTransfer = `-- name: GetTransfer :one
+SELECT
+    user_id,
+    secret_id,
+    direction,
+    secret_name,
+    version_id,
+    object_key,
+    upload_id,
+    token,
+    file_path,
+    file_size,
+    part_size,
+    created_at,
+    updated_at
+FROM transfers
+WHERE user_id = ? AND secret_id = ? AND direction = ?
+`
+
+type GetTransferParams struct {
+	UserID    string
+	SecretID  string
+	Direction string
+}
+
+func (q *Queries) GetTransfer(ctx context.Context, arg GetTransferParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransfer, arg.UserID, arg.SecretID, arg.Direction)
+	var i Transfer
+	err := row.Scan(
+		&i.UserID,
+		&i.SecretID,
+		&i.Direction,
+		&i.SecretName,
+		&i.VersionID,
+		&i.ObjectKey,
+		&i.UploadID,
+		&i.Token,
+		&i.FilePath,
+		&i.FileSize,
+		&i.PartSize,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getUser = `-- name: GetUser :one
 SELECT
     id,
@@ -183,6 +610,514 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 	return i, err
 }
 
+const getUserToken = `-- name: GetUserToken :one
+SELECT user_id, token, ttl, expires_at, refresh_token, refresh_expires_at
+FROM users_server_tokens
+WHERE user_id = ?
+`
+
+func (q *Queries) GetUserToken(ctx context.Context, userID string) (UsersServerToken, error) {
+	row := q.db.QueryRowContext(ctx, getUserToken, userID)
+	var i UsersServerToken
+	err := row.Scan(
+		&i.UserID,
+		&i.Token,
+		&i.Ttl,
+		&i.ExpiresAt,
+		&i.RefreshToken,
+		&i.RefreshExpiresAt,
+	)
+	return i, err
+}
+
+const getWatchCursor = `-- name: GetWatchCursor :one
+SELECT cursor
+FROM watch_cursors
+WHERE user_id = ?
+`
+
+func (q *Queries) GetWatchCursor(ctx context.Context, userID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getWatchCursor, userID)
+	var cursor int64
+	err := row.Scan(&cursor)
+	return cursor, err
+}
+
+const listOutboxOperations = `-- name: ListOutboxOperations :many
+SELECT seq, user_id, secret_id, secret_name, version_id, operation,
+       attempts, last_error, created_at, updated_at
+FROM outbox
+WHERE user_id = ?
+ORDER BY seq
+`
+
+func (q *Queries) ListOutboxOperations(ctx context.Context, userID string) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, listOutboxOperations, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Outbox
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.Seq,
+			&i.UserID,
+			&i.SecretID,
+			&i.SecretName,
+			&i.VersionID,
+			&i.Operation,
+			&i.Attempts,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSecretConflictKeys = `-- name: ListSecretConflictKeys :many
+SELECT remote_secret_id, remote_version_id, remote_secret_dek
+FROM secret_conflicts
+WHERE user_id = ?
+ORDER BY secret_id
+`
+
+type ListSecretConflictKeysRow struct {
+	RemoteSecretID  string
+	RemoteVersionID string
+	RemoteSecretDek []byte
+}
+
+func (q *Queries) ListSecretConflictKeys(ctx context.Context, userID string) ([]ListSecretConflictKeysRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSecretConflictKeys, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSecretConflictKeysRow
+	for rows.Next() {
+		var i ListSecretConflictKeysRow
+		if err := rows.Scan(&i.RemoteSecretID, &i.RemoteVersionID, &i.RemoteSecretDek); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSecretConflicts = `-- name: ListSecretConflicts :many
+SELECT
+    user_id,
+    secret_id,
+    secret_name,
+    local_version_id,
+    remote_secret_id,
+    remote_version_id,
+    remote_parent_version_id,
+    remote_file_path,
+    remote_secret_size,
+    remote_secret_hash,
+    remote_secret_dek,
+    remote_meta,
+    detected_at
+FROM secret_conflicts
+WHERE user_id = ?
+ORDER BY secret_name
+`
+
+func (q *Queries) ListSecretConflicts(ctx context.Context, userID string) ([]SecretConflict, error) {
+	rows, err := q.db.QueryContext(ctx, listSecretConflicts, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SecretConflict
+	for rows.Next() {
+		var i SecretConflict
+		if err := rows.Scan(
+			&i.UserID,
+			&i.SecretID,
+			&i.SecretName,
+			&i.LocalVersionID,
+			&i.RemoteSecretID,
+			&i.RemoteVersionID,
+			&i.RemoteParentVersionID,
+			&i.RemoteFilePath,
+			&i.RemoteSecretSize,
+			&i.RemoteSecretHash,
+			&i.RemoteSecretDek,
+			&i.RemoteMeta,
+			&i.DetectedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSecretKeys = `-- name: ListSecretKeys :many
+SELECT secret_id, version_id, secret_dek
+FROM secrets
+WHERE user_id = ?
+ORDER BY secret_id
+`
+
+type ListSecretKeysRow struct {
+	SecretID  string
+	VersionID string
+	SecretDek []byte
+}
+
+func (q *Queries) ListSecretKeys(ctx context.Context, userID string) ([]ListSecretKeysRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSecretKeys, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSecretKeysRow
+	for rows.Next() {
+		var i ListSecretKeysRow
+		if err := rows.Scan(&i.SecretID, &i.VersionID, &i.SecretDek); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSecrets = `-- name: ListSecrets :many
+SELECT
+    secrets.user_id,
+    secrets.secret_id,
+    secrets.secret_name,
+    secrets.version_id,
+    secrets.parent_version_id,
+    secrets.file_path,
+    secrets.secret_size,
+    secrets.secret_hash,
+    secrets.secret_dek,
+    secrets.created_at,
+    secrets.updated_at,
+    secrets.in_sync
+FROM secrets
+JOIN users ON users.id = secrets.user_id
+WHERE users.username = ?
+ORDER BY secrets.secret_name
+`
+
+func (q *Queries) ListSecrets(ctx context.Context, username string) ([]Secret, error) {
+	rows, err := q.db.QueryContext(ctx, listSecrets, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Secret
+	for rows.Next() {
+		var i Secret
+		if err := rows.Scan(
+			&i.UserID,
+			&i.SecretID,
+			&i.SecretName,
+			&i.VersionID,
+			&i.ParentVersionID,
+			&i.FilePath,
+			&i.SecretSize,
+			&i.SecretHash,
+			&i.SecretDek,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.InSync,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransferParts = `-- name: ListTransferParts :many
+SELECT
+    upload_id,
+    part_number,
+    etag,
+    part_size,
+    created_at,
+    checksum
+FROM transfer_parts
+WHERE upload_id = ?
+ORDER BY part_number
+`
+
+func (q *Queries) ListTransferParts(ctx context.Context, uploadID string) ([]TransferPart, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferParts, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TransferPart
+	for rows.Next() {
+		var i TransferPart
+		if err := rows.Scan(
+			&i.UploadID,
+			&i.PartNumber,
+			&i.Etag,
+			&i.PartSize,
+			&i.CreatedAt,
+			&i.Checksum,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfers = `-- name: ListTransfers :many
+SELECT
+    user_id,
+    secret_id,
+    direction,
+    secret_name,
+    version_id,
+    object_key,
+    upload_id,
+    token,
+    file_path,
+    file_size,
+    part_size,
+    created_at,
+    updated_at
+FROM transfers
+WHERE user_id = ?
+ORDER BY secret_name, direction
+`
+
+func (q *Queries) ListTransfers(ctx context.Context, userID string) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfers, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transfer
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.UserID,
+			&i.SecretID,
+			&i.Direction,
+			&i.SecretName,
+			&i.VersionID,
+			&i.ObjectKey,
+			&i.UploadID,
+			&i.Token,
+			&i.FilePath,
+			&i.FileSize,
+			&i.PartSize,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const savePasswordChange = `-- name: SavePasswordChange :exec
+INSERT INTO password_changes (user_id, salt, verifier, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE
+SET salt = excluded.salt,
+    verifier = excluded.verifier,
+    created_at = excluded.created_at
+`
+
+type SavePasswordChangeParams struct {
+	UserID    string
+	Salt      []byte
+	Verifier  []byte
+	CreatedAt time.Time
+}
+
+func (q *Queries) SavePasswordChange(ctx context.Context, arg SavePasswordChangeParams) error {
+	_, err := q.db.ExecContext(ctx, savePasswordChange,
+		arg.UserID,
+		arg.Salt,
+		arg.Verifier,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const saveUserToken = `-- name: SaveUserToken :exec
+INSERT INTO users_server_tokens (user_id, token, ttl, expires_at, refresh_token, refresh_expires_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE
+SET token = excluded.token,
+    ttl = excluded.ttl,
+    expires_at = excluded.expires_at,
+    refresh_token = excluded.refresh_token,
+    refresh_expires_at = excluded.refresh_expires_at
+`
+
+type SaveUserTokenParams struct {
+	UserID           string
+	Token            string
+	Ttl              int64
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+func (q *Queries) SaveUserToken(ctx context.Context, arg SaveUserTokenParams) error {
+	_, err := q.db.ExecContext(ctx, saveUserToken,
+		arg.UserID,
+		arg.Token,
+		arg.Ttl,
+		arg.ExpiresAt,
+		arg.RefreshToken,
+		arg.RefreshExpiresAt,
+	)
+	return err
+}
+
+const setOutboxOperationFailed = `-- name: SetOutboxOperationFailed :exec
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = ?,
+    updated_at = ?
+WHERE seq = ?
+`
+
+type SetOutboxOperationFailedParams struct {
+	LastError string
+	UpdatedAt time.Time
+	Seq       int64
+}
+
+func (q *Queries) SetOutboxOperationFailed(ctx context.Context, arg SetOutboxOperationFailedParams) error {
+	_, err := q.db.ExecContext(ctx, setOutboxOperationFailed, arg.LastError, arg.UpdatedAt, arg.Seq)
+	return err
+}
+
+const setSecretInSync = `-- name: SetSecretInSync :exec
+UPDATE secrets
+SET
+    in_sync = ?,
+    updated_at = ?
+WHERE user_id = ? AND secret_id = ?
+`
+
+type SetSecretInSyncParams struct {
+	InSync    int64
+	UpdatedAt time.Time
+	UserID    string
+	SecretID  string
+}
+
+func (q *Queries) SetSecretInSync(ctx context.Context, arg SetSecretInSyncParams) error {
+	_, err := q.db.ExecContext(ctx, setSecretInSync,
+		arg.InSync,
+		arg.UpdatedAt,
+		arg.UserID,
+		arg.SecretID,
+	)
+	return err
+}
+
+const setTransferUploadID = `-- name: SetTransferUploadID :exec
+UPDATE transfers
+SET
+    upload_id = ?,
+    updated_at = ?
+WHERE user_id = ? AND secret_id = ? AND direction = ?
+`
+
+type SetTransferUploadIDParams struct {
+	UploadID  string
+	UpdatedAt time.Time
+	UserID    string
+	SecretID  string
+	Direction string
+}
+
+func (q *Queries) SetTransferUploadID(ctx context.Context, arg SetTransferUploadIDParams) error {
+	_, err := q.db.ExecContext(ctx, setTransferUploadID,
+		arg.UploadID,
+		arg.UpdatedAt,
+		arg.UserID,
+		arg.SecretID,
+		arg.Direction,
+	)
+	return err
+}
+
+const setWatchCursor = `-- name: SetWatchCursor :exec
+INSERT INTO watch_cursors (user_id, cursor, updated_at)
+VALUES (?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE SET
+    cursor = excluded.cursor,
+    updated_at = excluded.updated_at
+`
+
+type SetWatchCursorParams struct {
+	UserID    string
+	Cursor    int64
+	UpdatedAt time.Time
+}
+
+func (q *Queries) SetWatchCursor(ctx context.Context, arg SetWatchCursorParams) error {
+	_, err := q.db.ExecContext(ctx, setWatchCursor, arg.UserID, arg.Cursor, arg.UpdatedAt)
+	return err
+}
+
 const updateSecret = `-- name: UpdateSecret :exec
 UPDATE secrets
 SET
@@ -225,3 +1160,60 @@ func (q *Queries) UpdateSecret(ctx context.Context, arg UpdateSecretParams) erro
 	)
 	return err
 }
+
+const updateSecretConflictKey = `-- name: UpdateSecretConflictKey :exec
+UPDATE secret_conflicts
+SET remote_secret_dek = ?
+WHERE user_id = ? AND remote_version_id = ?
+`
+
+type UpdateSecretConflictKeyParams struct {
+	RemoteSecretDek []byte
+	UserID          string
+	RemoteVersionID string
+}
+
+func (q *Queries) UpdateSecretConflictKey(ctx context.Context, arg UpdateSecretConflictKeyParams) error {
+	_, err := q.db.ExecContext(ctx, updateSecretConflictKey, arg.RemoteSecretDek, arg.UserID, arg.RemoteVersionID)
+	return err
+}
+
+const updateSecretKey = `-- name: UpdateSecretKey :exec
+UPDATE secrets
+SET secret_dek = ?
+WHERE user_id = ? AND version_id = ?
+`
+
+type UpdateSecretKeyParams struct {
+	SecretDek []byte
+	UserID    string
+	VersionID string
+}
+
+func (q *Queries) UpdateSecretKey(ctx context.Context, arg UpdateSecretKeyParams) error {
+	_, err := q.db.ExecContext(ctx, updateSecretKey, arg.SecretDek, arg.UserID, arg.VersionID)
+	return err
+}
+
+const updateUserVerifier = `-- name: UpdateUserVerifier :exec
+UPDATE users
+SET salt = ?, verifier = ?, updated_at = ?
+WHERE id = ?
+`
+
+type UpdateUserVerifierParams struct {
+	Salt      []byte
+	Verifier  []byte
+	UpdatedAt time.Time
+	ID        string
+}
+
+func (q *Queries) UpdateUserVerifier(ctx context.Context, arg UpdateUserVerifierParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserVerifier,
+		arg.Salt,
+		arg.Verifier,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
@@ -15,9 +15,33 @@ SELECT
 FROM users
 WHERE username = ?;
 
+-- name: UpdateUserVerifier :exec
+UPDATE users
+SET salt = ?, verifier = ?, updated_at = ?
+WHERE id = ?;
+
 -- name: CreateUserToken :exec
-INSERT INTO users_server_tokens (user_id, token, ttl)
-VALUES (?, ?, ?);
+INSERT INTO users_server_tokens (user_id, token, ttl, expires_at, refresh_token, refresh_expires_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: SaveUserToken :exec
+INSERT INTO users_server_tokens (user_id, token, ttl, expires_at, refresh_token, refresh_expires_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE
+SET token = excluded.token,
+    ttl = excluded.ttl,
+    expires_at = excluded.expires_at,
+    refresh_token = excluded.refresh_token,
+    refresh_expires_at = excluded.refresh_expires_at;
+
+-- name: GetUserToken :one
+SELECT user_id, token, ttl, expires_at, refresh_token, refresh_expires_at
+FROM users_server_tokens
+WHERE user_id = ?;
+
+-- name: DeleteUserToken :exec
+DELETE FROM users_server_tokens
+WHERE user_id = ?;
 
 -- name: CreateSecret :exec
 INSERT INTO secrets (
@@ -65,4 +89,268 @@ SELECT
     secrets.in_sync
 FROM secrets
 JOIN users ON users.id = secrets.user_id
-WHERE users.username = ? AND secret_name = ?;
\ No newline at end of file
+WHERE users.username = ? AND secret_name = ?;
+
+-- name: SetSecretInSync :exec
+UPDATE secrets
+SET
+    in_sync = ?,
+    updated_at = ?
+WHERE user_id = ? AND secret_id = ?;
+
+-- name: ListSecrets :many
+SELECT
+    secrets.user_id,
+    secrets.secret_id,
+    secrets.secret_name,
+    secrets.version_id,
+    secrets.parent_version_id,
+    secrets.file_path,
+    secrets.secret_size,
+    secrets.secret_hash,
+    secrets.secret_dek,
+    secrets.created_at,
+    secrets.updated_at,
+    secrets.in_sync
+FROM secrets
+JOIN users ON users.id = secrets.user_id
+WHERE users.username = ?
+ORDER BY secrets.secret_name;
+
+-- name: DeleteSecret :exec
+DELETE FROM secrets
+WHERE user_id = ? AND secret_id = ?;
+
+-- name: CreateSecretMeta :exec
+INSERT INTO secret_meta (user_id, secret_id, meta, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: DeleteSecretMeta :exec
+DELETE FROM secret_meta
+WHERE user_id = ? AND secret_id = ?;
+
+-- name: GetSecretMeta :one
+SELECT meta
+FROM secret_meta
+WHERE user_id = ? AND secret_id = ?;
+
+-- name: CreateSecretConflict :exec
+INSERT INTO secret_conflicts (
+    user_id,
+    secret_id,
+    secret_name,
+    local_version_id,
+    remote_secret_id,
+    remote_version_id,
+    remote_parent_version_id,
+    remote_file_path,
+    remote_secret_size,
+    remote_secret_hash,
+    remote_secret_dek,
+    remote_meta,
+    detected_at
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetSecretConflict :one
+SELECT
+    user_id,
+    secret_id,
+    secret_name,
+    local_version_id,
+    remote_secret_id,
+    remote_version_id,
+    remote_parent_version_id,
+    remote_file_path,
+    remote_secret_size,
+    remote_secret_hash,
+    remote_secret_dek,
+    remote_meta,
+    detected_at
+FROM secret_conflicts
+WHERE user_id = ? AND secret_name = ?;
+
+-- name: ListSecretConflicts :many
+SELECT
+    user_id,
+    secret_id,
+    secret_name,
+    local_version_id,
+    remote_secret_id,
+    remote_version_id,
+    remote_parent_version_id,
+    remote_file_path,
+    remote_secret_size,
+    remote_secret_hash,
+    remote_secret_dek,
+    remote_meta,
+    detected_at
+FROM secret_conflicts
+WHERE user_id = ?
+ORDER BY secret_name;
+
+-- name: DeleteSecretConflict :exec
+DELETE FROM secret_conflicts
+WHERE user_id = ? AND secret_id = ?;
+
+-- name: CreateTransfer :exec
+INSERT INTO transfers (
+    user_id,
+    secret_id,
+    direction,
+    secret_name,
+    version_id,
+    object_key,
+    upload_id,
+    token,
+    file_path,
+    file_size,
+    part_size,
+    created_at,
+    updated_at
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetTransfer :one
+SELECT
+    user_id,
+    secret_id,
+    direction,
+    secret_name,
+    version_id,
+    object_key,
+    upload_id,
+    token,
+    file_path,
+    file_size,
+    part_size,
+    created_at,
+    updated_at
+FROM transfers
+WHERE user_id = ? AND secret_id = ? AND direction = ?;
+
+-- name: ListTransfers :many
+SELECT
+    user_id,
+    secret_id,
+    direction,
+    secret_name,
+    version_id,
+    object_key,
+    upload_id,
+    token,
+    file_path,
+    file_size,
+    part_size,
+    created_at,
+    updated_at
+FROM transfers
+WHERE user_id = ?
+ORDER BY secret_name, direction;
+
+-- name: SetTransferUploadID :exec
+UPDATE transfers
+SET
+    upload_id = ?,
+    updated_at = ?
+WHERE user_id = ? AND secret_id = ? AND direction = ?;
+
+-- name: DeleteTransfer :exec
+DELETE FROM transfers
+WHERE user_id = ? AND secret_id = ? AND direction = ?;
+
+-- name: CreateTransferPart :exec
+INSERT OR REPLACE INTO transfer_parts (upload_id, part_number, etag, part_size, created_at, checksum)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListTransferParts :many
+SELECT
+    upload_id,
+    part_number,
+    etag,
+    part_size,
+    created_at,
+    checksum
+FROM transfer_parts
+WHERE upload_id = ?
+ORDER BY part_number;
+
+-- name: DeleteTransferParts :exec
+DELETE FROM transfer_parts
+WHERE upload_id = ?;
+
+-- name: GetWatchCursor :one
+SELECT cursor
+FROM watch_cursors
+WHERE user_id = ?;
+
+-- name: SetWatchCursor :exec
+INSERT INTO watch_cursors (user_id, cursor, updated_at)
+VALUES (?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE SET
+    cursor = excluded.cursor,
+    updated_at = excluded.updated_at;
+
+-- name: CreateOutboxOperation :exec
+INSERT INTO outbox (
+    user_id, secret_id, secret_name, version_id, operation, created_at, updated_at
+)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (user_id, version_id, operation) DO NOTHING;
+
+-- name: ListOutboxOperations :many
+SELECT seq, user_id, secret_id, secret_name, version_id, operation,
+       attempts, last_error, created_at, updated_at
+FROM outbox
+WHERE user_id = ?
+ORDER BY seq;
+
+-- name: SetOutboxOperationFailed :exec
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = ?,
+    updated_at = ?
+WHERE seq = ?;
+
+-- name: DeleteOutboxOperation :exec
+DELETE FROM outbox
+WHERE seq = ?;
+
+-- name: SavePasswordChange :exec
+INSERT INTO password_changes (user_id, salt, verifier, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE
+SET salt = excluded.salt,
+    verifier = excluded.verifier,
+    created_at = excluded.created_at;
+
+-- name: GetPasswordChange :one
+SELECT user_id, salt, verifier, created_at
+FROM password_changes
+WHERE user_id = ?;
+
+-- name: DeletePasswordChange :exec
+DELETE FROM password_changes
+WHERE user_id = ?;
+
+-- name: ListSecretKeys :many
+SELECT secret_id, version_id, secret_dek
+FROM secrets
+WHERE user_id = ?
+ORDER BY secret_id;
+
+-- name: UpdateSecretKey :exec
+UPDATE secrets
+SET secret_dek = ?
+WHERE user_id = ? AND version_id = ?;
+
+-- name: ListSecretConflictKeys :many
+SELECT remote_secret_id, remote_version_id, remote_secret_dek
+FROM secret_conflicts
+WHERE user_id = ?
+ORDER BY secret_id;
+
+-- name: UpdateSecretConflictKey :exec
+UPDATE secret_conflicts
+SET remote_secret_dek = ?
+WHERE user_id = ? AND remote_version_id = ?;
@@ -1,8 +1,14 @@
 package repository
 
 import (
+	"fmt"
+
+	"github.com/google/uuid"
+
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 )
 
@@ -21,3 +27,122 @@ func FromSQLUser(usql sqlite.User) (*user.User, error) {
 
 	return usr, nil
 }
+
+// FromSQLPasswordChange maps a sqlite.PasswordChange (returned by sqlc) to a domain-level PasswordChange.
+func FromSQLPasswordChange(csql sqlite.PasswordChange) (*user.PasswordChange, error) {
+	userID, err := uuid.Parse(csql.UserID)
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	return &user.PasswordChange{
+		UserID:    userID,
+		Salt:      csql.Salt,
+		Verifier:  csql.Verifier,
+		CreatedAt: csql.CreatedAt,
+	}, nil
+}
+
+// FromSQLSecretKey maps wrapped DEK of the secret version stored locally to a domain-level WrappedKey.
+func FromSQLSecretKey(secretID, versionID string, dek []byte) (*secret.WrappedKey, error) {
+	sid, err := uuid.Parse(secretID)
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	vid, err := uuid.Parse(versionID)
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	return &secret.WrappedKey{SecretID: sid, VersionID: vid, DEK: dek}, nil
+}
+
+// FromSQLSecret maps a sqlite.Secret (returned by sqlc) to a dto.Secret.
+func FromSQLSecret(ssql sqlite.Secret) *dto.Secret {
+	return &dto.Secret{
+		ID:              ssql.SecretID,
+		UserID:          ssql.UserID,
+		SecretName:      ssql.SecretName,
+		VersionID:       ssql.VersionID,
+		ParentVersionID: ssql.ParentVersionID,
+		FilePath:        ssql.FilePath,
+		SecretSize:      ssql.SecretSize,
+		SecretHash:      ssql.SecretHash,
+		SecretDek:       ssql.SecretDek,
+		CreatedAt:       ssql.CreatedAt,
+		UpdatedAt:       ssql.UpdatedAt,
+		InSync:          ssql.InSync > 0,
+	}
+}
+
+// FromSQLSecretConflict maps a sqlite.SecretConflict (returned by sqlc) to a dto.SecretConflict.
+func FromSQLSecretConflict(csql sqlite.SecretConflict) (*dto.SecretConflict, error) {
+	metaData := secret.MetaData{}
+	if err := metaData.UnmarshalJSON([]byte(csql.RemoteMeta)); err != nil {
+		return nil, fmt.Errorf("[%w] secret conflict metadata", e.ErrUnmarshal)
+	}
+
+	return &dto.SecretConflict{
+		UserID:                csql.UserID,
+		SecretID:              csql.SecretID,
+		SecretName:            csql.SecretName,
+		LocalVersionID:        csql.LocalVersionID,
+		RemoteSecretID:        csql.RemoteSecretID,
+		RemoteVersionID:       csql.RemoteVersionID,
+		RemoteParentVersionID: csql.RemoteParentVersionID,
+		RemoteFilePath:        csql.RemoteFilePath,
+		RemoteSecretSize:      csql.RemoteSecretSize,
+		RemoteSecretHash:      csql.RemoteSecretHash,
+		RemoteSecretDek:       csql.RemoteSecretDek,
+		RemoteMetaData:        metaData,
+		DetectedAt:            csql.DetectedAt,
+	}, nil
+}
+
+// FromSQLTransfer maps a sqlite.Transfer (returned by sqlc) to a dto.Transfer.
+func FromSQLTransfer(tsql sqlite.Transfer) *dto.Transfer {
+	return &dto.Transfer{
+		UserID:     tsql.UserID,
+		SecretID:   tsql.SecretID,
+		Direction:  dto.TransferDirection(tsql.Direction),
+		SecretName: tsql.SecretName,
+		VersionID:  tsql.VersionID,
+		ObjectKey:  tsql.ObjectKey,
+		UploadID:   tsql.UploadID,
+		Token:      tsql.Token,
+		FilePath:   tsql.FilePath,
+		FileSize:   tsql.FileSize,
+		PartSize:   tsql.PartSize,
+		CreatedAt:  tsql.CreatedAt,
+		UpdatedAt:  tsql.UpdatedAt,
+	}
+}
+
+// FromSQLTransferPart maps a sqlite.TransferPart (returned by sqlc) to a dto.TransferPart.
+func FromSQLTransferPart(psql sqlite.TransferPart) dto.TransferPart {
+	return dto.TransferPart{
+		UploadID:   psql.UploadID,
+		PartNumber: int(psql.PartNumber),
+		ETag:       psql.Etag,
+		Size:       psql.PartSize,
+		Checksum:   psql.Checksum,
+		CreatedAt:  psql.CreatedAt,
+	}
+}
+
+// FromSQLOutbox maps a sqlite.Outbox (returned by sqlc) to a dto.OutboxOperation.
+func FromSQLOutbox(osql sqlite.Outbox) *dto.OutboxOperation {
+	return &dto.OutboxOperation{
+		Seq:        osql.Seq,
+		UserID:     osql.UserID,
+		SecretID:   osql.SecretID,
+		SecretName: osql.SecretName,
+		VersionID:  osql.VersionID,
+		Operation:  dto.OutboxOperationType(osql.Operation),
+		Attempts:   osql.Attempts,
+		LastError:  osql.LastError,
+		CreatedAt:  osql.CreatedAt,
+		UpdatedAt:  osql.UpdatedAt,
+	}
+}
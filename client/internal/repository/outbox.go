@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// CreateOutboxOperation queues secret change for the server.
+// Operation already queued for the same version is kept in its original place.
+func (repo *SecretRepo) CreateOutboxOperation(ctx context.Context, op *dto.OutboxOperation) error {
+	err := repo.queries.CreateOutboxOperation(ctx, sqlite.CreateOutboxOperationParams{
+		UserID:     op.UserID,
+		SecretID:   op.SecretID,
+		SecretName: op.SecretName,
+		VersionID:  op.VersionID,
+		Operation:  string(op.Operation),
+		CreatedAt:  op.CreatedAt,
+		UpdatedAt:  op.UpdatedAt,
+	})
+	if err != nil {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// ListOutboxOperations returns queued secret changes of the user in the order they were made.
+func (repo *SecretRepo) ListOutboxOperations(ctx context.Context, userID string) ([]*dto.OutboxOperation, error) {
+	dbOps, err := repo.queries.ListOutboxOperations(ctx, userID)
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	ops := make([]*dto.OutboxOperation, 0, len(dbOps))
+	for _, dbOp := range dbOps {
+		ops = append(ops, FromSQLOutbox(dbOp))
+	}
+
+	return ops, nil
+}
+
+// SetOutboxOperationFailed records failed replay attempt of the queued operation.
+func (repo *SecretRepo) SetOutboxOperationFailed(ctx context.Context, op *dto.OutboxOperation, cause error) error {
+	op.Attempts++
+	op.LastError = cause.Error()
+	op.UpdatedAt = time.Now().UTC()
+
+	err := repo.queries.SetOutboxOperationFailed(ctx, sqlite.SetOutboxOperationFailedParams{
+		LastError: op.LastError,
+		UpdatedAt: op.UpdatedAt,
+		Seq:       op.Seq,
+	})
+	if err != nil {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// DeleteOutboxOperation removes operation accepted by the server or no longer relevant from the queue.
+func (repo *SecretRepo) DeleteOutboxOperation(ctx context.Context, op *dto.OutboxOperation) error {
+	if err := repo.queries.DeleteOutboxOperation(ctx, op.Seq); err != nil {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/rs/zerolog"
@@ -16,6 +19,27 @@ import (
 type SecretRepository interface {
 	CreateSecret(ctx context.Context, secret *dto.Secret) error
 	GetSecret(ctx context.Context, userName, secretName string) (*dto.Secret, error)
+	SetSecretInSync(ctx context.Context, scrt *dto.Secret, inSync bool) error
+	ListSecrets(ctx context.Context, userName string) ([]*dto.Secret, error)
+	UpdateSecret(ctx context.Context, scrt *dto.Secret) error
+	DeleteSecret(ctx context.Context, scrt *dto.Secret) error
+	CreateSecretConflict(ctx context.Context, conflict *dto.SecretConflict) error
+	GetSecretConflict(ctx context.Context, userID, secretName string) (*dto.SecretConflict, error)
+	ListSecretConflicts(ctx context.Context, userID string) ([]*dto.SecretConflict, error)
+	DeleteSecretConflict(ctx context.Context, conflict *dto.SecretConflict) error
+	CreateTransfer(ctx context.Context, transfer *dto.Transfer) error
+	GetTransfer(ctx context.Context, userID, secretID string, direction dto.TransferDirection) (*dto.Transfer, error)
+	ListTransfers(ctx context.Context, userID string) ([]*dto.Transfer, error)
+	SetTransferUploadID(ctx context.Context, transfer *dto.Transfer, uploadID string) error
+	CreateTransferPart(ctx context.Context, part *dto.TransferPart) error
+	ListTransferParts(ctx context.Context, uploadID string) ([]dto.TransferPart, error)
+	DeleteTransfer(ctx context.Context, transfer *dto.Transfer) error
+	GetWatchCursor(ctx context.Context, userID string) (int64, error)
+	SetWatchCursor(ctx context.Context, userID string, cursor int64) error
+	CreateOutboxOperation(ctx context.Context, op *dto.OutboxOperation) error
+	ListOutboxOperations(ctx context.Context, userID string) ([]*dto.OutboxOperation, error)
+	SetOutboxOperationFailed(ctx context.Context, op *dto.OutboxOperation, cause error) error
+	DeleteOutboxOperation(ctx context.Context, op *dto.OutboxOperation) error
 }
 
 // SecretRepo is a SQLite-backed implementation of SecretRepository.
@@ -51,40 +75,46 @@ func (repo *SecretRepo) GetSecret(ctx context.Context, userName, secretName stri
 		return nil, e.InternalErr(err)
 	}
 
-	return &dto.Secret{
-		ID:              dbSecret.SecretID,
-		UserID:          dbSecret.UserID,
-		SecretName:      dbSecret.SecretName,
-		VersionID:       dbSecret.VersionID,
-		ParentVersionID: dbSecret.ParentVersionID,
-		FilePath:        dbSecret.FilePath,
-		SecretSize:      dbSecret.SecretSize,
-		SecretHash:      dbSecret.SecretHash,
-		SecretDek:       dbSecret.SecretDek,
-		CreatedAt:       dbSecret.CreatedAt,
-		UpdatedAt:       dbSecret.UpdatedAt,
-		InSync:          dbSecret.InSync > 0,
-	}, nil
+	scrt := FromSQLSecret(dbSecret)
+	if err := repo.getSecretMeta(ctx, scrt); err != nil {
+		return nil, err
+	}
+
+	return scrt, nil
 }
 
-// CreateSecret attempts to insert a new secret into the database.
+// CreateSecret attempts to insert a new secret together with its metadata into the database.
 // Returns ErrExists if a conflict on (user_id, secret_id) or (user_id, secret_name) occurs.
 func (repo *SecretRepo) CreateSecret(ctx context.Context, scrt *dto.Secret) error {
-	err := repo.queries.CreateSecret(ctx, sqlite.CreateSecretParams{
-		SecretID:        scrt.ID,
-		UserID:          scrt.UserID,
-		SecretName:      scrt.SecretName,
-		VersionID:       scrt.VersionID,
-		ParentVersionID: scrt.ParentVersionID,
-		FilePath:        scrt.FilePath,
-		SecretSize:      scrt.SecretSize,
-		SecretHash:      scrt.SecretHash,
-		SecretDek:       scrt.SecretDek,
-		CreatedAt:       scrt.CreatedAt,
-		UpdatedAt:       scrt.UpdatedAt,
-		InSync:          0,
+	var inSync int64
+	if scrt.InSync {
+		inSync = 1
+	}
+
+	queryFn := sqlite.WithinTrx(ctx, repo.conn, &sql.TxOptions{}, func(queries *sqlite.Queries) error {
+		err := queries.CreateSecret(ctx, sqlite.CreateSecretParams{
+			SecretID:        scrt.ID,
+			UserID:          scrt.UserID,
+			SecretName:      scrt.SecretName,
+			VersionID:       scrt.VersionID,
+			ParentVersionID: scrt.ParentVersionID,
+			FilePath:        scrt.FilePath,
+			SecretSize:      scrt.SecretSize,
+			SecretHash:      scrt.SecretHash,
+			SecretDek:       scrt.SecretDek,
+			CreatedAt:       scrt.CreatedAt,
+			UpdatedAt:       scrt.UpdatedAt,
+			InSync:          inSync,
+		})
+		if err != nil {
+			return err
+		}
+
+		return replaceSecretMeta(ctx, queries, scrt)
 	})
 
+	err := queryFn(repo.queries)
+
 	if sqlite.IsUniqueViolation(err) {
 		return fmt.Errorf("[%w] db secret", e.ErrExists)
 	}
@@ -95,3 +125,256 @@ func (repo *SecretRepo) CreateSecret(ctx context.Context, scrt *dto.Secret) erro
 
 	return nil
 }
+
+// replaceSecretMeta stores secret metadata replacing the previous one.
+func replaceSecretMeta(ctx context.Context, queries *sqlite.Queries, scrt *dto.Secret) error {
+	err := queries.DeleteSecretMeta(ctx, sqlite.DeleteSecretMetaParams{
+		UserID:   scrt.UserID,
+		SecretID: scrt.ID,
+	})
+	if err != nil || len(scrt.MetaData) == 0 {
+		return err
+	}
+
+	meta, err := scrt.MetaData.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("[%w] secret metadata", e.ErrMarshal)
+	}
+
+	return queries.CreateSecretMeta(ctx, sqlite.CreateSecretMetaParams{
+		UserID:    scrt.UserID,
+		SecretID:  scrt.ID,
+		Meta:      string(meta),
+		CreatedAt: scrt.UpdatedAt,
+		UpdatedAt: scrt.UpdatedAt,
+	})
+}
+
+// getSecretMeta loads metadata of the secret, secrets without metadata get an empty one.
+func (repo *SecretRepo) getSecretMeta(ctx context.Context, scrt *dto.Secret) error {
+	meta, err := repo.queries.GetSecretMeta(ctx, sqlite.GetSecretMetaParams{
+		UserID:   scrt.UserID,
+		SecretID: scrt.ID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		scrt.MetaData = secret.MetaData{}
+		return nil
+	}
+
+	if err != nil {
+		return e.InternalErr(err)
+	}
+
+	if err := scrt.MetaData.UnmarshalJSON([]byte(meta)); err != nil {
+		return fmt.Errorf("[%w] secret metadata", e.ErrUnmarshal)
+	}
+
+	return nil
+}
+
+// SetSecretInSync marks secret as synchronized (or not) with the server.
+func (repo *SecretRepo) SetSecretInSync(ctx context.Context, scrt *dto.Secret, inSync bool) error {
+	var flag int64
+	if inSync {
+		flag = 1
+	}
+
+	err := repo.queries.SetSecretInSync(ctx, sqlite.SetSecretInSyncParams{
+		InSync:    flag,
+		UpdatedAt: time.Now().UTC(),
+		UserID:    scrt.UserID,
+		SecretID:  scrt.ID,
+	})
+	if err != nil {
+		return e.InternalErr(err)
+	}
+
+	scrt.InSync = inSync
+
+	return nil
+}
+
+// ListSecrets returns all local secrets of the user ordered by name.
+func (repo *SecretRepo) ListSecrets(ctx context.Context, userName string) ([]*dto.Secret, error) {
+	dbSecrets, err := repo.queries.ListSecrets(ctx, userName)
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	secrets := make([]*dto.Secret, 0, len(dbSecrets))
+	for _, dbSecret := range dbSecrets {
+		secrets = append(secrets, FromSQLSecret(dbSecret))
+	}
+
+	return secrets, nil
+}
+
+// UpdateSecret replaces current version details and metadata of the existing local secret.
+func (repo *SecretRepo) UpdateSecret(ctx context.Context, scrt *dto.Secret) error {
+	var inSync int64
+	if scrt.InSync {
+		inSync = 1
+	}
+
+	queryFn := sqlite.WithinTrx(ctx, repo.conn, &sql.TxOptions{}, func(queries *sqlite.Queries) error {
+		err := queries.UpdateSecret(ctx, sqlite.UpdateSecretParams{
+			VersionID:       scrt.VersionID,
+			ParentVersionID: scrt.ParentVersionID,
+			FilePath:        scrt.FilePath,
+			SecretSize:      scrt.SecretSize,
+			SecretHash:      scrt.SecretHash,
+			SecretDek:       scrt.SecretDek,
+			UpdatedAt:       scrt.UpdatedAt,
+			InSync:          inSync,
+			UserID:          scrt.UserID,
+			SecretID:        scrt.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return replaceSecretMeta(ctx, queries, scrt)
+	})
+
+	if err := queryFn(repo.queries); err != nil {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// DeleteSecret removes local secret record and metadata together with its encrypted file.
+func (repo *SecretRepo) DeleteSecret(ctx context.Context, scrt *dto.Secret) error {
+	queryFn := sqlite.WithinTrx(ctx, repo.conn, &sql.TxOptions{}, func(queries *sqlite.Queries) error {
+		err := queries.DeleteSecretMeta(ctx, sqlite.DeleteSecretMetaParams{
+			UserID:   scrt.UserID,
+			SecretID: scrt.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return queries.DeleteSecret(ctx, sqlite.DeleteSecretParams{
+			UserID:   scrt.UserID,
+			SecretID: scrt.ID,
+		})
+	})
+
+	if err := queryFn(repo.queries); err != nil {
+		return e.InternalErr(err)
+	}
+
+	if err := os.Remove(scrt.FilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		repo.log.Error().Err(err).
+			Str("path", scrt.FilePath).
+			Msg("failed to remove secret file")
+
+		return fmt.Errorf("[%w] secret file", e.ErrWrite)
+	}
+
+	return nil
+}
+
+// CreateSecretConflict records conflict of the local secret replacing the previously recorded one.
+func (repo *SecretRepo) CreateSecretConflict(ctx context.Context, conflict *dto.SecretConflict) error {
+	meta, err := conflict.RemoteMetaData.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("[%w] secret metadata", e.ErrMarshal)
+	}
+
+	queryFn := sqlite.WithinTrx(ctx, repo.conn, &sql.TxOptions{}, func(queries *sqlite.Queries) error {
+		err := queries.DeleteSecretConflict(ctx, sqlite.DeleteSecretConflictParams{
+			UserID:   conflict.UserID,
+			SecretID: conflict.SecretID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return queries.CreateSecretConflict(ctx, sqlite.CreateSecretConflictParams{
+			UserID:                conflict.UserID,
+			SecretID:              conflict.SecretID,
+			SecretName:            conflict.SecretName,
+			LocalVersionID:        conflict.LocalVersionID,
+			RemoteSecretID:        conflict.RemoteSecretID,
+			RemoteVersionID:       conflict.RemoteVersionID,
+			RemoteParentVersionID: conflict.RemoteParentVersionID,
+			RemoteFilePath:        conflict.RemoteFilePath,
+			RemoteSecretSize:      conflict.RemoteSecretSize,
+			RemoteSecretHash:      conflict.RemoteSecretHash,
+			RemoteSecretDek:       conflict.RemoteSecretDek,
+			RemoteMeta:            string(meta),
+			DetectedAt:            conflict.DetectedAt,
+		})
+	})
+
+	if err := queryFn(repo.queries); err != nil {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// GetSecretConflict returns recorded conflict of the user secret.
+// Returns ErrNotFound if secret has no conflict.
+func (repo *SecretRepo) GetSecretConflict(
+	ctx context.Context,
+	userID, secretName string,
+) (*dto.SecretConflict, error) {
+	dbConflict, err := repo.queries.GetSecretConflict(ctx, sqlite.GetSecretConflictParams{
+		UserID:     userID,
+		SecretName: secretName,
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] db secret conflict", e.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	return FromSQLSecretConflict(dbConflict)
+}
+
+// ListSecretConflicts returns all recorded conflicts of the user ordered by secret name.
+func (repo *SecretRepo) ListSecretConflicts(ctx context.Context, userID string) ([]*dto.SecretConflict, error) {
+	dbConflicts, err := repo.queries.ListSecretConflicts(ctx, userID)
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	conflicts := make([]*dto.SecretConflict, 0, len(dbConflicts))
+
+	for _, dbConflict := range dbConflicts {
+		conflict, err := FromSQLSecretConflict(dbConflict)
+		if err != nil {
+			return nil, err
+		}
+
+		conflicts = append(conflicts, conflict)
+	}
+
+	return conflicts, nil
+}
+
+// DeleteSecretConflict removes conflict record together with downloaded remote version file.
+func (repo *SecretRepo) DeleteSecretConflict(ctx context.Context, conflict *dto.SecretConflict) error {
+	err := repo.queries.DeleteSecretConflict(ctx, sqlite.DeleteSecretConflictParams{
+		UserID:   conflict.UserID,
+		SecretID: conflict.SecretID,
+	})
+	if err != nil {
+		return e.InternalErr(err)
+	}
+
+	if err := os.Remove(conflict.RemoteFilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		repo.log.Error().Err(err).
+			Str("path", conflict.RemoteFilePath).
+			Msg("failed to remove remote secret file")
+
+		return fmt.Errorf("[%w] remote secret file", e.ErrWrite)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// CreateTransfer records a new resumable transfer replacing the previous one
+// of the same secret and direction together with its uploaded parts.
+func (repo *SecretRepo) CreateTransfer(ctx context.Context, transfer *dto.Transfer) error {
+	queryFn := sqlite.WithinTrx(ctx, repo.conn, &sql.TxOptions{}, func(queries *sqlite.Queries) error {
+		if err := deleteTransfer(ctx, queries, transfer); err != nil {
+			return err
+		}
+
+		return queries.CreateTransfer(ctx, sqlite.CreateTransferParams{
+			UserID:     transfer.UserID,
+			SecretID:   transfer.SecretID,
+			Direction:  string(transfer.Direction),
+			SecretName: transfer.SecretName,
+			VersionID:  transfer.VersionID,
+			ObjectKey:  transfer.ObjectKey,
+			UploadID:   transfer.UploadID,
+			Token:      transfer.Token,
+			FilePath:   transfer.FilePath,
+			FileSize:   transfer.FileSize,
+			PartSize:   transfer.PartSize,
+			CreatedAt:  transfer.CreatedAt,
+			UpdatedAt:  transfer.UpdatedAt,
+		})
+	})
+
+	if err := queryFn(repo.queries); err != nil {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// GetTransfer returns transfer of the secret in the given direction.
+// Returns ErrNotFound if there is no transfer in progress.
+func (repo *SecretRepo) GetTransfer(
+	ctx context.Context,
+	userID, secretID string,
+	direction dto.TransferDirection,
+) (*dto.Transfer, error) {
+	dbTransfer, err := repo.queries.GetTransfer(ctx, sqlite.GetTransferParams{
+		UserID:    userID,
+		SecretID:  secretID,
+		Direction: string(direction),
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] db transfer", e.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	return FromSQLTransfer(dbTransfer), nil
+}
+
+// ListTransfers returns all interrupted transfers of the user.
+func (repo *SecretRepo) ListTransfers(ctx context.Context, userID string) ([]*dto.Transfer, error) {
+	dbTransfers, err := repo.queries.ListTransfers(ctx, userID)
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	transfers := make([]*dto.Transfer, 0, len(dbTransfers))
+	for _, dbTransfer := range dbTransfers {
+		transfers = append(transfers, FromSQLTransfer(dbTransfer))
+	}
+
+	return transfers, nil
+}
+
+// SetTransferUploadID binds transfer to the multipart upload dropping parts of the previous one.
+func (repo *SecretRepo) SetTransferUploadID(ctx context.Context, transfer *dto.Transfer, uploadID string) error {
+	now := time.Now().UTC()
+
+	queryFn := sqlite.WithinTrx(ctx, repo.conn, &sql.TxOptions{}, func(queries *sqlite.Queries) error {
+		if transfer.UploadID != "" {
+			if err := queries.DeleteTransferParts(ctx, transfer.UploadID); err != nil {
+				return err
+			}
+		}
+
+		return queries.SetTransferUploadID(ctx, sqlite.SetTransferUploadIDParams{
+			UploadID:  uploadID,
+			UpdatedAt: now,
+			UserID:    transfer.UserID,
+			SecretID:  transfer.SecretID,
+			Direction: string(transfer.Direction),
+		})
+	})
+
+	if err := queryFn(repo.queries); err != nil {
+		return e.InternalErr(err)
+	}
+
+	transfer.UploadID = uploadID
+	transfer.UpdatedAt = now
+
+	return nil
+}
+
+// CreateTransferPart records uploaded part of multipart upload.
+func (repo *SecretRepo) CreateTransferPart(ctx context.Context, part *dto.TransferPart) error {
+	err := repo.queries.CreateTransferPart(ctx, sqlite.CreateTransferPartParams{
+		UploadID:   part.UploadID,
+		PartNumber: int64(part.PartNumber),
+		Etag:       part.ETag,
+		PartSize:   part.Size,
+		Checksum:   part.Checksum,
+		CreatedAt:  part.CreatedAt,
+	})
+	if err != nil {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// ListTransferParts returns uploaded parts of multipart upload ordered by part number.
+func (repo *SecretRepo) ListTransferParts(ctx context.Context, uploadID string) ([]dto.TransferPart, error) {
+	dbParts, err := repo.queries.ListTransferParts(ctx, uploadID)
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	parts := make([]dto.TransferPart, 0, len(dbParts))
+	for _, dbPart := range dbParts {
+		parts = append(parts, FromSQLTransferPart(dbPart))
+	}
+
+	return parts, nil
+}
+
+// DeleteTransfer removes finished or abandoned transfer together with its uploaded parts.
+func (repo *SecretRepo) DeleteTransfer(ctx context.Context, transfer *dto.Transfer) error {
+	queryFn := sqlite.WithinTrx(ctx, repo.conn, &sql.TxOptions{}, func(queries *sqlite.Queries) error {
+		return deleteTransfer(ctx, queries, transfer)
+	})
+
+	if err := queryFn(repo.queries); err != nil {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+func deleteTransfer(ctx context.Context, queries *sqlite.Queries, transfer *dto.Transfer) error {
+	dbTransfer, err := queries.GetTransfer(ctx, sqlite.GetTransferParams{
+		UserID:    transfer.UserID,
+		SecretID:  transfer.SecretID,
+		Direction: string(transfer.Direction),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if dbTransfer.UploadID != "" {
+		if err := queries.DeleteTransferParts(ctx, dbTransfer.UploadID); err != nil {
+			return err
+		}
+	}
+
+	return queries.DeleteTransfer(ctx, sqlite.DeleteTransferParams{
+		UserID:    transfer.UserID,
+		SecretID:  transfer.SecretID,
+		Direction: string(transfer.Direction),
+	})
+}
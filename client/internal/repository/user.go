@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/config"
 	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
@@ -28,6 +31,22 @@ type UserRepository interface {
 	GetUser(ctx context.Context, username string) (*user.User, error)
 	// ValidateUser checks credentials during login.
 	ValidateUser(ctx context.Context, creds *dto.UserCredentials) (*user.User, error)
+	// UpdateUserVerifier stores salt and verifier issued by server, e.g. after auth key migration.
+	UpdateUserVerifier(ctx context.Context, usr *user.User) error
+	// GetUserToken gets server token issued to the user.
+	GetUserToken(ctx context.Context, usr *user.User) (*dto.ServerToken, error)
+	// SaveUserToken stores server token issued to the user replacing the previous one.
+	SaveUserToken(ctx context.Context, token *dto.ServerToken) error
+	// DeleteUserToken deletes server token of the user.
+	DeleteUserToken(ctx context.Context, usr *user.User) error
+	// GetPasswordChange gets password change of the user in progress.
+	GetPasswordChange(ctx context.Context, usr *user.User) (*user.PasswordChange, error)
+	// SavePasswordChange stores password change of the user in progress.
+	SavePasswordChange(ctx context.Context, change *user.PasswordChange) error
+	// ListSecretKeys lists wrapped DEKs of the user secret versions stored locally.
+	ListSecretKeys(ctx context.Context, usr *user.User) ([]*secret.WrappedKey, error)
+	// ChangeUserKeys stores salt and verifier of the user along with re-wrapped DEKs.
+	ChangeUserKeys(ctx context.Context, usr *user.User, keys []*secret.WrappedKey) error
 }
 
 type UserRepo struct {
@@ -46,23 +65,38 @@ func NewUserRepo(db *sqlite.DB, cfg *config.Config, log zerolog.Logger) *UserRep
 	}
 }
 
+// ValidateUser checks password of the local user against verifier issued by server.
 func (repo *UserRepo) ValidateUser(ctx context.Context, creds *dto.UserCredentials) (*user.User, error) {
 	usr, err := repo.GetUser(ctx, creds.Username)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = usr.SetPassword(creds.Password); err != nil {
-		return nil, err
-	}
-
-	if !usr.CheckPassword(creds.Password) {
+	if _, err = keys.KEK(usr, creds.Password); err != nil {
 		return nil, fmt.Errorf("[%w] bad password", e.ErrInvalidInput)
 	}
 
 	return usr, nil
 }
 
+// UpdateUserVerifier stores salt and verifier of the user issued by server.
+func (repo *UserRepo) UpdateUserVerifier(ctx context.Context, usr *user.User) error {
+	err := repo.queries.UpdateUserVerifier(ctx, sqlite.UpdateUserVerifierParams{
+		ID:        usr.ID.String(),
+		Salt:      usr.Salt,
+		Verifier:  usr.Verifier,
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		logCtx := repo.logWithUserContext(usr, "UpdateUserVerifier")
+		logCtx.Error().Err(err).Msg("Failed to update user verifier")
+
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
 func (repo *UserRepo) GetUser(ctx context.Context, username string) (*user.User, error) {
 	dbUsr, err := repo.queries.GetUser(ctx, username)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -81,6 +115,187 @@ func (repo *UserRepo) GetUser(ctx context.Context, username string) (*user.User,
 	return usr, nil
 }
 
+// GetUserToken gets server token issued to the user at registration.
+func (repo *UserRepo) GetUserToken(ctx context.Context, usr *user.User) (*dto.ServerToken, error) {
+	dbToken, err := repo.queries.GetUserToken(ctx, usr.ID.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] user token", e.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	return &dto.ServerToken{
+		UserID:           dbToken.UserID,
+		Token:            dbToken.Token,
+		TTL:              uint32(dbToken.Ttl), //nolint:gosec // reason: ttl is stored from uint32
+		ExpiresAt:        dbToken.ExpiresAt,
+		RefreshToken:     dbToken.RefreshToken,
+		RefreshExpiresAt: dbToken.RefreshExpiresAt,
+	}, nil
+}
+
+// SaveUserToken stores server token issued to the user replacing the previous one,
+// refresh token is rotated by server on every renewal so only the latest one is kept.
+func (repo *UserRepo) SaveUserToken(ctx context.Context, token *dto.ServerToken) error {
+	err := repo.queries.SaveUserToken(ctx, sqlite.SaveUserTokenParams{
+		UserID:           token.UserID,
+		Token:            token.Token,
+		Ttl:              int64(token.TTL),
+		ExpiresAt:        token.ExpiresAt,
+		RefreshToken:     token.RefreshToken,
+		RefreshExpiresAt: token.RefreshExpiresAt,
+	})
+	if err != nil {
+		repo.log.Error().Err(err).
+			Str("repo", "UserRepo").
+			Str("operation", "SaveUserToken").
+			Str("user_id", token.UserID).
+			Msg("Failed to save user token")
+
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// DeleteUserToken deletes server token of the user, e.g. on logout.
+func (repo *UserRepo) DeleteUserToken(ctx context.Context, usr *user.User) error {
+	if err := repo.queries.DeleteUserToken(ctx, usr.ID.String()); err != nil {
+		logCtx := repo.logWithUserContext(usr, "DeleteUserToken")
+		logCtx.Error().Err(err).Msg("Failed to delete user token")
+
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// GetPasswordChange gets password change of the user started earlier and not completed yet.
+// Returns ErrNotFound if there is none.
+func (repo *UserRepo) GetPasswordChange(ctx context.Context, usr *user.User) (*user.PasswordChange, error) {
+	dbChange, err := repo.queries.GetPasswordChange(ctx, usr.ID.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] password change", e.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	return FromSQLPasswordChange(dbChange)
+}
+
+// SavePasswordChange stores password change of the user replacing the previous one.
+func (repo *UserRepo) SavePasswordChange(ctx context.Context, change *user.PasswordChange) error {
+	err := repo.queries.SavePasswordChange(ctx, sqlite.SavePasswordChangeParams{
+		UserID:    change.UserID.String(),
+		Salt:      change.Salt,
+		Verifier:  change.Verifier,
+		CreatedAt: change.CreatedAt,
+	})
+	if err != nil {
+		repo.log.Error().Err(err).
+			Str("repo", "UserRepo").
+			Str("operation", "SavePasswordChange").
+			Str("user_id", change.UserID.String()).
+			Msg("Failed to save password change")
+
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
+// ListSecretKeys lists wrapped DEKs of local secrets and of remote versions of their conflicts.
+func (repo *UserRepo) ListSecretKeys(ctx context.Context, usr *user.User) ([]*secret.WrappedKey, error) {
+	logCtx := repo.logWithUserContext(usr, "ListSecretKeys")
+
+	secretRows, err := repo.queries.ListSecretKeys(ctx, usr.ID.String())
+	if err != nil {
+		logCtx.Error().Err(err).Msg("Failed to list secret keys")
+		return nil, e.InternalErr(err)
+	}
+
+	conflictRows, err := repo.queries.ListSecretConflictKeys(ctx, usr.ID.String())
+	if err != nil {
+		logCtx.Error().Err(err).Msg("Failed to list secret conflict keys")
+		return nil, e.InternalErr(err)
+	}
+
+	keys := make([]*secret.WrappedKey, 0, len(secretRows)+len(conflictRows))
+
+	for _, row := range secretRows {
+		key, err := FromSQLSecretKey(row.SecretID, row.VersionID, row.SecretDek)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	for _, row := range conflictRows {
+		key, err := FromSQLSecretKey(row.RemoteSecretID, row.RemoteVersionID, row.RemoteSecretDek)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// ChangeUserKeys replaces wrapped DEKs of local secret versions and of their conflicts with keys,
+// stores salt and verifier of the user and drops password change in progress in one transaction.
+// Local versions missing from keys are left as is.
+func (repo *UserRepo) ChangeUserKeys(ctx context.Context, usr *user.User, keys []*secret.WrappedKey) error {
+	logCtx := repo.logWithUserContext(usr, "ChangeUserKeys")
+	userID := usr.ID.String()
+
+	queryFn := sqlite.WithinTrx(ctx, repo.conn, &sql.TxOptions{}, func(queries *sqlite.Queries) error {
+		for _, key := range keys {
+			err := queries.UpdateSecretKey(ctx, sqlite.UpdateSecretKeyParams{
+				SecretDek: key.DEK,
+				UserID:    userID,
+				VersionID: key.VersionID.String(),
+			})
+			if err != nil {
+				return err
+			}
+
+			err = queries.UpdateSecretConflictKey(ctx, sqlite.UpdateSecretConflictKeyParams{
+				RemoteSecretDek: key.DEK,
+				UserID:          userID,
+				RemoteVersionID: key.VersionID.String(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err := queries.UpdateUserVerifier(ctx, sqlite.UpdateUserVerifierParams{
+			ID:        userID,
+			Salt:      usr.Salt,
+			Verifier:  usr.Verifier,
+			UpdatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return queries.DeletePasswordChange(ctx, userID)
+	})
+
+	if err := queryFn(repo.queries); err != nil {
+		logCtx.Error().Err(err).Msg("Failed to change user keys")
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
+
 func (repo *UserRepo) logWithUserContext(usr *user.User, op string) zerolog.Logger {
 	return repo.log.With().
 		Str("repo", "UserRepo").
@@ -116,9 +331,12 @@ func (repo *UserRepo) CreateUser(ctx context.Context, usr *user.User, token *dto
 		}
 
 		return queries.CreateUserToken(ctx, sqlite.CreateUserTokenParams{
-			UserID: token.UserID,
-			Token:  token.Token,
-			Ttl:    int64(token.TTL),
+			UserID:           token.UserID,
+			Token:            token.Token,
+			Ttl:              int64(token.TTL),
+			ExpiresAt:        token.ExpiresAt,
+			RefreshToken:     token.RefreshToken,
+			RefreshExpiresAt: token.RefreshExpiresAt,
 		})
 	})
 
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/client/internal/infra/sqlite"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// GetWatchCursor returns cursor of the last applied server secret event of the user
+// or zero if user has never watched secrets on this device.
+func (repo *SecretRepo) GetWatchCursor(ctx context.Context, userID string) (int64, error) {
+	cursor, err := repo.queries.GetWatchCursor(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, e.InternalErr(err)
+	}
+
+	return cursor, nil
+}
+
+// SetWatchCursor records cursor of the last applied server secret event of the user.
+func (repo *SecretRepo) SetWatchCursor(ctx context.Context, userID string, cursor int64) error {
+	err := repo.queries.SetWatchCursor(ctx, sqlite.SetWatchCursorParams{
+		UserID:    userID,
+		Cursor:    cursor,
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return e.InternalErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+#!/usr/bin/env bash
+# Sourced by dev scripts: derives auth key the way gkcli does, so that password never leaves the host.
+
+# gk_salt prints new random base64 salt of the KEK.
+gk_salt() {
+  openssl rand -base64 16
+}
+
+# gk_auth_key <password> <base64 salt> prints base64 auth key: HMAC(PBKDF2(password, salt), label).
+gk_auth_key() {
+  local hexsalt kek
+  hexsalt=$(printf '%s' "$2" | base64 -d | od -An -tx1 | tr -d ' \n')
+  kek=$(openssl kdf -keylen 32 \
+    -kdfopt digest:SHA256 \
+    -kdfopt "pass:$1" \
+    -kdfopt "hexsalt:$hexsalt" \
+    -kdfopt iter:100000 \
+    PBKDF2 | tr -d ':')
+  printf '%s' "gophkeeper auth key" \
+    | openssl dgst -sha256 -mac HMAC -macopt "hexkey:$kek" -binary \
+    | base64
+}
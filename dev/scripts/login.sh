@@ -2,6 +2,8 @@
 
 set -euo pipefail
 
+source "$(dirname "$0")/authkey.sh"
+
 if [[ $# -ne 2 ]]; then
   echo "Usage: $0 <username> <password>"
   exit 1
@@ -14,14 +16,32 @@ SERVER_HOST="localhost"
 SERVER_PORT="3300"
 CA_CERT="./deployments/.certs/ca.cert"
 API_PATH="./api"
+DEVICE_ID="${GK_DEVICE_ID:-$(uuidgen | tr '[:upper:]' '[:lower:]')}"
+CLIENT_INFO="host=$(hostname);client=buf-curl"
+TOTP_CODE="${GK_TOTP_CODE:-}" # required once two-factor authentication is enabled
 
 echo "🔐 Logging in as user: $USERNAME..."
 
+PARAMS=$(buf curl \
+  --schema "$API_PATH" \
+  --protocol grpc \
+  --cacert "$CA_CERT" \
+  --data "{\"username\":\"$USERNAME\"}" \
+  --header "authority: $SERVER_HOST" \
+  "https://$SERVER_HOST:$SERVER_PORT/gophkeeper.v1.UserService/GetLoginParams")
+
+AUTH_KEY=$(gk_auth_key "$PASSWORD" "$(echo "$PARAMS" | jq -r '.salt')")
+# password is only sent once by user registered before auth keys to migrate
+LEGACY_PASSWORD=""
+if [[ "$(echo "$PARAMS" | jq -r '.passwordRequired // false')" == "true" ]]; then
+  LEGACY_PASSWORD="$PASSWORD"
+fi
+
 GK_TOKEN=$(buf curl \
   --schema "$API_PATH" \
   --protocol grpc \
   --cacert "$CA_CERT" \
-  --data "{\"username\":\"$USERNAME\",\"password\":\"$PASSWORD\"}" \
+  --data "{\"username\":\"$USERNAME\",\"password\":\"$LEGACY_PASSWORD\",\"auth_key\":\"$AUTH_KEY\",\"device_id\":\"$DEVICE_ID\",\"client_info\":\"$CLIENT_INFO\",\"totp_code\":\"$TOTP_CODE\"}" \
   --header "authority: $SERVER_HOST" \
   "https://$SERVER_HOST:$SERVER_PORT/gophkeeper.v1.UserService/Login" \
   | jq -r '.token')
@@ -2,13 +2,25 @@
 
 set -euo pipefail
 
+source "$(dirname "$0")/authkey.sh"
+
 SERVER_HOST="localhost"
 SERVER_PORT="3300"
 CA_CERT="./deployments/.certs/ca.cert"
 API_PATH="./api"
-USERNAME="devuser"
-PASSWORD="devpassword"
-ROLE="USER_ROLE_USER"
+DEVICE_ID="${GK_DEVICE_ID:-$(uuidgen | tr '[:upper:]' '[:lower:]')}"
+CLIENT_INFO="host=$(hostname);client=buf-curl"
+USERNAME="${GK_USERNAME:-devuser}"
+PASSWORD="${GK_PASSWORD:-devpassword}"
+# staff roles (USER_ROLE_ADMIN, USER_ROLE_AUDITOR, USER_ROLE_OPERATOR) require admin token in GK_ADMIN_TOKEN
+ROLE="${GK_ROLE:-USER_ROLE_USER}"
+AUTH_HEADER=()
+if [[ -n "${GK_ADMIN_TOKEN:-}" ]]; then
+  AUTH_HEADER=(--header "authorization: Bearer $GK_ADMIN_TOKEN")
+fi
+
+SALT=$(gk_salt)
+AUTH_KEY=$(gk_auth_key "$PASSWORD" "$SALT")
 
 echo "Registering user '$USERNAME' with role '$ROLE'..."
 
@@ -16,8 +28,9 @@ RESP=$(buf curl \
   --schema "$API_PATH" \
   --protocol grpc \
   --cacert "$CA_CERT" \
-  --data "{\"username\":\"$USERNAME\",\"password\":\"$PASSWORD\",\"role\":\"$ROLE\"}" \
+  --data "{\"username\":\"$USERNAME\",\"auth_key\":\"$AUTH_KEY\",\"salt\":\"$SALT\",\"role\":\"$ROLE\",\"device_id\":\"$DEVICE_ID\",\"client_info\":\"$CLIENT_INFO\"}" \
   --header "authority: $SERVER_HOST" \
+  ${AUTH_HEADER[@]+"${AUTH_HEADER[@]}"} \
   "https://$SERVER_HOST:$SERVER_PORT/gophkeeper.v1.UserService/Register")
 
 TOKEN=$(echo "$RESP" | jq -r '.token // empty')
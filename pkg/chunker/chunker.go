@@ -0,0 +1,146 @@
+// Package chunker splits a stream into content-defined chunks.
+//
+// Chunk boundaries are found with a gear rolling hash, so that they depend only on the content
+// around them: data inserted into or removed from a stream changes only the chunks next to
+// the edit and the rest of the chunks, and therefore their hashes, stay the same.
+package chunker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// Default chunk size bounds of binary secrets.
+const (
+	DefaultMinSize = 256 * 1024
+	DefaultAvgSize = 1024 * 1024
+	DefaultMaxSize = 4 * 1024 * 1024
+)
+
+// gearSeed seeds the gear table, all clients must use the same one to produce the same chunks.
+const gearSeed uint64 = 0x6770686b65657072
+
+//nolint:gochecknoglobals // reason: immutable lookup table
+var gear = newGearTable(gearSeed)
+
+// Chunker reads a stream and returns its content-defined chunks one by one.
+type Chunker struct {
+	src     io.Reader
+	buf     []byte
+	start   int
+	end     int
+	eof     bool
+	minSize int
+	maxSize int
+	mask    uint64
+}
+
+// New creates chunker with default chunk sizes.
+func New(src io.Reader) *Chunker {
+	chunker, _ := NewWithSizes(src, DefaultMinSize, DefaultAvgSize, DefaultMaxSize)
+	return chunker
+}
+
+// NewWithSizes creates chunker producing chunks between minSize and maxSize bytes
+// which are avgSize bytes long on average.
+func NewWithSizes(src io.Reader, minSize, avgSize, maxSize int) (*Chunker, error) {
+	if minSize <= 0 || avgSize <= minSize || maxSize <= avgSize {
+		return nil, fmt.Errorf("[%w] chunk sizes", e.ErrInvalidInput)
+	}
+
+	// boundary is expected every 2^n bytes after minimal chunk size.
+	n := bits.Len(uint(avgSize-minSize)) - 1
+
+	return &Chunker{
+		src:     src,
+		buf:     make([]byte, maxSize),
+		minSize: minSize,
+		maxSize: maxSize,
+		// the most significant bits of gear hash depend on the widest window of the input.
+		mask: ^uint64(0) << (64 - n),
+	}, nil
+}
+
+// Next returns the next chunk of the stream or io.EOF when stream is over.
+// Returned slice is only valid until the next call.
+func (c *Chunker) Next() ([]byte, error) {
+	if err := c.fill(); err != nil {
+		return nil, err
+	}
+
+	if c.start == c.end {
+		return nil, io.EOF
+	}
+
+	size := c.cutPoint(c.buf[c.start:c.end])
+	chunk := c.buf[c.start : c.start+size]
+	c.start += size
+
+	return chunk, nil
+}
+
+// fill reads the stream until the buffer holds maximal chunk or the stream is over.
+func (c *Chunker) fill() error {
+	if c.eof || c.end-c.start >= c.maxSize {
+		return nil
+	}
+
+	c.end = copy(c.buf, c.buf[c.start:c.end])
+	c.start = 0
+
+	for c.end < len(c.buf) {
+		n, err := c.src.Read(c.buf[c.end:])
+		c.end += n
+
+		if errors.Is(err, io.EOF) {
+			c.eof = true
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("[%w] chunker input", e.ErrRead)
+		}
+	}
+
+	return nil
+}
+
+// cutPoint returns size of the chunk at the beginning of data.
+func (c *Chunker) cutPoint(data []byte) int {
+	if len(data) <= c.minSize {
+		return len(data)
+	}
+
+	limit := min(len(data), c.maxSize)
+
+	var hash uint64
+
+	for i := c.minSize; i < limit; i++ {
+		hash = (hash << 1) + gear[data[i]]
+		if hash&c.mask == 0 {
+			return i + 1
+		}
+	}
+
+	return limit
+}
+
+// newGearTable fills gear table with pseudo random values produced by splitmix64.
+func newGearTable(seed uint64) [256]uint64 {
+	var table [256]uint64
+
+	state := seed
+	for i := range table {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+
+	return table
+}
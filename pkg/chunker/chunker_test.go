@@ -0,0 +1,108 @@
+package chunker_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/chunker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMinSize = 2 * 1024
+	testAvgSize = 8 * 1024
+	testMaxSize = 32 * 1024
+)
+
+func randomData(t *testing.T, size int) []byte {
+	t.Helper()
+
+	data := make([]byte, size)
+	//nolint:gosec // reason: deterministic test data
+	_, err := rand.New(rand.NewSource(1)).Read(data)
+	require.NoError(t, err)
+
+	return data
+}
+
+func split(t *testing.T, data []byte) [][]byte {
+	t.Helper()
+
+	c, err := chunker.NewWithSizes(bytes.NewReader(data), testMinSize, testAvgSize, testMaxSize)
+	require.NoError(t, err)
+
+	var chunks [][]byte
+
+	for {
+		chunk, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			return chunks
+		}
+
+		require.NoError(t, err)
+
+		chunks = append(chunks, bytes.Clone(chunk))
+	}
+}
+
+func TestChunkerSplit(t *testing.T) {
+	t.Parallel()
+
+	data := randomData(t, 1024*1024)
+	chunks := split(t, data)
+
+	require.Greater(t, len(chunks), 1)
+	assert.Equal(t, data, bytes.Join(chunks, nil))
+
+	for i, chunk := range chunks {
+		assert.LessOrEqual(t, len(chunk), testMaxSize)
+
+		if i < len(chunks)-1 {
+			assert.GreaterOrEqual(t, len(chunk), testMinSize)
+		}
+	}
+
+	assert.Equal(t, chunks, split(t, data), "chunking must be deterministic")
+}
+
+func TestChunkerShiftResistance(t *testing.T) {
+	t.Parallel()
+
+	data := randomData(t, 1024*1024)
+	edited := append([]byte("inserted at the beginning"), data...)
+
+	original := make(map[[32]byte]struct{})
+	for _, chunk := range split(t, data) {
+		original[sha256.Sum256(chunk)] = struct{}{}
+	}
+
+	chunks := split(t, edited)
+	shared := 0
+
+	for _, chunk := range chunks {
+		if _, ok := original[sha256.Sum256(chunk)]; ok {
+			shared++
+		}
+	}
+
+	assert.GreaterOrEqual(t, shared, len(chunks)-2, "only chunks next to the edit may change")
+}
+
+func TestChunkerEmptyStream(t *testing.T) {
+	t.Parallel()
+
+	_, err := chunker.New(bytes.NewReader(nil)).Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestNewWithSizesInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := chunker.NewWithSizes(bytes.NewReader(nil), testAvgSize, testMinSize, testMaxSize)
+	require.Error(t, err)
+}
@@ -8,6 +8,7 @@ import (
 
 // GenerateVerifier returns an HMAC-based verifier using the user's password and a salt.
 // This verifier is a byte slice and can be sent to the client for authentication challenge/response.
+// It is only kept for users registered before auth keys, see GenerateKeyVerifier.
 func GenerateVerifier(password string, salt []byte) []byte {
 	mac := hmac.New(sha256.New, []byte(password))
 	mac.Write(salt)
@@ -19,3 +20,17 @@ func GenerateVerifier(password string, salt []byte) []byte {
 func VerifyVerifier(password string, salt []byte, expected []byte) bool {
 	return bytes.Equal(GenerateVerifier(password, salt), expected)
 }
+
+// GenerateKeyVerifier returns an HMAC-based verifier of the client derived auth key and salt.
+// Server stores the verifier only, so that neither password nor auth key are kept at rest.
+func GenerateKeyVerifier(authKey, salt []byte) []byte {
+	mac := hmac.New(sha256.New, authKey)
+	mac.Write(salt)
+
+	return mac.Sum(nil)
+}
+
+// VerifyKeyVerifier checks in constant time whether the given auth key and salt generate the expected verifier.
+func VerifyKeyVerifier(authKey, salt, expected []byte) bool {
+	return hmac.Equal(GenerateKeyVerifier(authKey, salt), expected)
+}
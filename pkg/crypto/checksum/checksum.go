@@ -0,0 +1,98 @@
+// Package checksum computes SHA-256 integrity checksums of encrypted secret objects.
+package checksum
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // reason: verifies versions hashed before SHA-256 was introduced
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// Size is the size of checksum in bytes.
+const Size = sha256.Size
+
+// New returns hash computing checksum of the streamed content,
+// so that checksum is computed in the same pass the content is written.
+func New() hash.Hash {
+	return sha256.New()
+}
+
+// Sum returns checksum of data.
+func Sum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// File computes checksum of the file at the given path.
+func File(path string) ([]byte, error) {
+	return fileHash(path, sha256.New())
+}
+
+// FileMatches reports whether file at the given path matches expected checksum.
+// Versions uploaded before SHA-256 was introduced carry MD5 hash and are verified against it.
+func FileMatches(path string, expected []byte) (bool, error) {
+	hasher := sha256.New()
+	if len(expected) == md5.Size {
+		hasher = md5.New() //nolint:gosec // reason: see above
+	}
+
+	sum, err := fileHash(path, hasher)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(sum, expected), nil
+}
+
+func fileHash(path string, hasher hash.Hash) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] checksum file", e.ErrRead)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(hasher, file); err != nil {
+		return nil, fmt.Errorf("[%w] checksum file", e.ErrRead)
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// S3 returns value of S3 SHA-256 additional checksum of the object or part with the given checksum.
+func S3(sum []byte) string {
+	return base64.StdEncoding.EncodeToString(sum)
+}
+
+// S3Composite returns SHA-256 checksum S3 stores for multipart object: checksum of its parts checksums
+// suffixed with the number of parts.
+func S3Composite(partSums ...[]byte) string {
+	hasher := sha256.New()
+	for _, sum := range partSums {
+		hasher.Write(sum)
+	}
+
+	return fmt.Sprintf("%s-%d", S3(hasher.Sum(nil)), len(partSums))
+}
+
+// MatchS3 compares SHA-256 checksum stored by S3 with checksum of the whole object.
+// Checksum of object uploaded in more than one part can not be compared without checksums of its parts,
+// then verifiable is false.
+func MatchS3(stored string, sum []byte) (matched, verifiable bool) {
+	switch {
+	case stored == "":
+		return false, false
+	case !strings.Contains(stored, "-"):
+		return stored == S3(sum), true
+	case strings.HasSuffix(stored, "-1"):
+		return stored == S3Composite(sum), true
+	default:
+		return false, false
+	}
+}
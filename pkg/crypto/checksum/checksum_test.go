@@ -0,0 +1,78 @@
+package checksum_test
+
+import (
+	"crypto/md5" //nolint:gosec // reason: legacy hashes are verified
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/checksum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testContent       = "hello world"
+	expectedSHA256Hex = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+)
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	tmpFile, err := os.CreateTemp("", "hash_test_*.txt")
+	require.NoError(t, err, "failed to create temp file")
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString(testContent)
+	require.NoError(t, err, "failed to write to temp file")
+
+	require.NoError(t, tmpFile.Close(), "failed to close temp file")
+
+	hashBytes, err := checksum.File(tmpFile.Name())
+	require.NoError(t, err, "File should not return error")
+	assert.Equal(t, expectedSHA256Hex, hex.EncodeToString(hashBytes), "SHA-256 hash mismatch")
+	assert.Equal(t, hashBytes, checksum.Sum([]byte(testContent)))
+
+	matched, err := checksum.FileMatches(tmpFile.Name(), hashBytes)
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	legacy := md5.Sum([]byte(testContent)) //nolint:gosec // reason: legacy hashes are verified
+	matched, err = checksum.FileMatches(tmpFile.Name(), legacy[:])
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, err = checksum.FileMatches(tmpFile.Name(), checksum.Sum([]byte("other")))
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestMatchS3(t *testing.T) {
+	t.Parallel()
+
+	sum := checksum.Sum([]byte(testContent))
+	other := checksum.Sum([]byte("other"))
+
+	tests := []struct {
+		name           string
+		stored         string
+		wantMatched    bool
+		wantVerifiable bool
+	}{
+		{name: "no checksum", stored: "", wantMatched: false, wantVerifiable: false},
+		{name: "single part object", stored: checksum.S3(sum), wantMatched: true, wantVerifiable: true},
+		{name: "corrupted object", stored: checksum.S3(other), wantMatched: false, wantVerifiable: true},
+		{name: "multipart single part", stored: checksum.S3Composite(sum), wantMatched: true, wantVerifiable: true},
+		{name: "multipart many parts", stored: checksum.S3Composite(sum, other), wantMatched: false, wantVerifiable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matched, verifiable := checksum.MatchS3(tt.stored, sum)
+			assert.Equal(t, tt.wantMatched, matched)
+			assert.Equal(t, tt.wantVerifiable, verifiable)
+		})
+	}
+}
@@ -3,12 +3,15 @@ package keys
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/pbkdf2"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/auth"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 )
@@ -21,6 +24,9 @@ const (
 	kekIter        = 100_000
 	NonceSize      = 12 // Recommended nonce size for AES-GCM
 	EncryptionAlgo = "AES-GCM"
+	AuthKeyLength  = 32 // Auth key presented to server instead of password
+	chunkIDLabel   = "gophkeeper chunk id"
+	authKeyLabel   = "gophkeeper auth key"
 )
 
 // REK generates a secure random Root Encryption Key (REK).
@@ -38,7 +44,8 @@ func REK() ([]byte, error) {
 	return rek, nil
 }
 
-// KEK derives a Key Encryption Key (KEK) from the user's password and stored salt.
+// KEK derives a Key Encryption Key (KEK) from the user's password and stored salt
+// and checks it against the user verifier.
 // In the context of GophKeeper, the KEK serves as the user's master key —
 // a symmetric cryptographic key deterministically derived from the user's password.
 // It is never stored and is re-derived at runtime when needed.
@@ -47,12 +54,30 @@ func REK() ([]byte, error) {
 //   - Decrypt Data Encryption Keys (DEKs), which are used to encrypt/decrypt actual user data.
 //   - Secure data at rest before uploading to the server.
 //   - Ensure end-to-end encryption, where the server stores encrypted data but cannot decrypt it.
+//
+// Neither password nor KEK are sent to the server, the user authenticates with AuthKey instead.
 func KEK(u *user.User, password string) ([]byte, error) {
-	if !u.CheckPassword(password) {
+	kek, err := DeriveKEK(password, u.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	// verifier of legacy user is derived from the password itself until user logs in again.
+	if !u.CheckAuthKey(AuthKey(kek)) && !auth.VerifyVerifier(password, u.Salt, u.Verifier) {
+		return nil, e.ErrInvalidInput
+	}
+
+	return kek, nil
+}
+
+// DeriveKEK derives KEK from the password and salt with PBKDF2 without checking user verifier,
+// e.g. when user is registered or logs in from a new device.
+func DeriveKEK(password string, salt []byte) ([]byte, error) {
+	if len(salt) == 0 {
 		return nil, e.ErrInvalidInput
 	}
 
-	kek, err := pbkdf2.Key(sha256.New, password, u.Salt, kekIter, KEKLength)
+	kek, err := pbkdf2.Key(sha256.New, password, salt, kekIter, KEKLength)
 	if err != nil {
 		return nil, fmt.Errorf("[%w] KEK", e.ErrGenerate)
 	}
@@ -60,6 +85,25 @@ func KEK(u *user.User, password string) ([]byte, error) {
 	return kek, nil
 }
 
+// AuthKey derives the key user authenticates to the server with from the KEK.
+// HMAC is one-way, so that the server can not recover KEK from the auth key.
+func AuthKey(kek []byte) []byte {
+	mac := hmac.New(sha256.New, kek)
+	mac.Write([]byte(authKeyLabel))
+
+	return mac.Sum(nil)
+}
+
+// DeriveAuthKey derives auth key from the password and salt, see DeriveKEK and AuthKey.
+func DeriveAuthKey(password string, salt []byte) ([]byte, error) {
+	kek, err := DeriveKEK(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return AuthKey(kek), nil
+}
+
 // DEK generates a new random 256-bit (32-byte) Data Encryption Key (DEK).
 // In the context of GophKeeper, the DEK is used by the client to encrypt user secrets
 // before they are uploaded to the server.
@@ -100,26 +144,42 @@ func UnwrapKEK(rek, wrapped []byte) ([]byte, error) {
 // This function uses AES-GCM for authenticated encryption, ensuring both confidentiality and integrity.
 // The resulting ciphertext includes a randomly generated nonce prepended to the encrypted DEK.
 func WrapDEK(kek, dek []byte) ([]byte, error) {
-	if len(kek) != KEKLength || len(dek) != DEKLength {
+	if len(dek) != DEKLength {
 		return nil, e.ErrInvalidInput
 	}
 
-	block, err := aes.NewCipher(kek)
+	return WrapSecret(kek, dek)
+}
+
+// UnwrapDEK decrypts a wrapped DEK using the given KEK.
+// It expects the input to be nonce || ciphertext as returned by WrapDEK.
+func UnwrapDEK(kek, wrapped []byte) ([]byte, error) {
+	return UnwrapSecret(kek, wrapped)
+}
+
+// WrapSecret encrypts secret of arbitrary length, e.g. TOTP seed, with 256-bit key
+// using the same AES-GCM mechanism as WrapDEK.
+func WrapSecret(key, secret []byte) ([]byte, error) {
+	if len(key) != KEKLength {
+		return nil, e.ErrInvalidInput
+	}
+
+	block, err := aes.NewCipher(key)
 	if err != nil {
-		return nil, fmt.Errorf("encrypt dek(cipher): %w", e.ErrEncrypt)
+		return nil, fmt.Errorf("encrypt secret(cipher): %w", e.ErrEncrypt)
 	}
 
 	aesgcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return nil, fmt.Errorf("encrypt dek(gcm): %w", e.ErrEncrypt)
+		return nil, fmt.Errorf("encrypt secret(gcm): %w", e.ErrEncrypt)
 	}
 
 	nonce := make([]byte, NonceSize)
 	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return nil, fmt.Errorf("encrypt dek(nonce): %w", e.ErrEncrypt)
+		return nil, fmt.Errorf("encrypt secret(nonce): %w", e.ErrEncrypt)
 	}
 
-	ciphertext := aesgcm.Seal(nil, nonce, dek, nil)
+	ciphertext := aesgcm.Seal(nil, nonce, secret, nil)
 	result := make([]byte, NonceSize+len(ciphertext))
 
 	copy(result, nonce)
@@ -128,10 +188,9 @@ func WrapDEK(kek, dek []byte) ([]byte, error) {
 	return result, nil
 }
 
-// UnwrapDEK decrypts a wrapped DEK using the given KEK.
-// It expects the input to be nonce || ciphertext as returned by WrapDEK.
-func UnwrapDEK(kek, wrapped []byte) ([]byte, error) {
-	if len(kek) != KEKLength {
+// UnwrapSecret decrypts secret wrapped by WrapSecret.
+func UnwrapSecret(key, wrapped []byte) ([]byte, error) {
+	if len(key) != KEKLength {
 		return nil, e.ErrInvalidInput
 	}
 
@@ -142,20 +201,39 @@ func UnwrapDEK(kek, wrapped []byte) ([]byte, error) {
 	nonce := wrapped[:NonceSize]
 	ciphertext := wrapped[NonceSize:]
 
-	block, err := aes.NewCipher(kek)
+	block, err := aes.NewCipher(key)
 	if err != nil {
-		return nil, fmt.Errorf("decrypt dek(cipher): %w", e.ErrDecrypt)
+		return nil, fmt.Errorf("decrypt secret(cipher): %w", e.ErrDecrypt)
 	}
 
 	aesgcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return nil, fmt.Errorf("decrypt dek(gcm): %w", e.ErrDecrypt)
+		return nil, fmt.Errorf("decrypt secret(gcm): %w", e.ErrDecrypt)
 	}
 
 	plaintext, err := aesgcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
-		return nil, fmt.Errorf("decrypt dek(gcm open): %w", e.ErrDecrypt)
+		return nil, fmt.Errorf("decrypt secret(gcm open): %w", e.ErrDecrypt)
 	}
 
 	return plaintext, nil
 }
+
+// ChunkIDKey derives the key of chunk identifiers from the secret DEK.
+// Separate key keeps DEK from being used for anything but encryption.
+func ChunkIDKey(dek []byte) []byte {
+	mac := hmac.New(sha256.New, dek)
+	mac.Write([]byte(chunkIDLabel))
+
+	return mac.Sum(nil)
+}
+
+// ChunkID returns keyed hash identifying plaintext chunk of the secret.
+// Identical chunks of the secret share identifier and are stored once,
+// while the server can not correlate content of different secrets or users.
+func ChunkID(idKey, chunk []byte) string {
+	mac := hmac.New(sha256.New, idKey)
+	mac.Write(chunk)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -130,3 +130,93 @@ func TestWrapUnwrap(t *testing.T) {
 		require.ErrorIs(t, e.ErrInvalidInput, err)
 	})
 }
+
+func TestChunkID(t *testing.T) {
+	t.Parallel()
+
+	dek, err := keys.DEK()
+	require.NoError(t, err)
+
+	otherDEK, err := keys.DEK()
+	require.NoError(t, err)
+
+	chunk := []byte("chunk content")
+	idKey := keys.ChunkIDKey(dek)
+
+	id := keys.ChunkID(idKey, chunk)
+	require.Len(t, id, 64)
+	require.Equal(t, id, keys.ChunkID(keys.ChunkIDKey(dek), chunk))
+	require.NotEqual(t, id, keys.ChunkID(idKey, []byte("other content")))
+	require.NotEqual(t, id, keys.ChunkID(keys.ChunkIDKey(otherDEK), chunk))
+}
+
+func TestWrapUnwrapSecret(t *testing.T) {
+	t.Parallel()
+
+	rek, err := keys.REK()
+	require.NoError(t, err)
+
+	secret := []byte("20 bytes totp seed!!")
+
+	wrapped, err := keys.WrapSecret(rek, secret)
+	require.NoError(t, err)
+	require.NotContains(t, string(wrapped), string(secret))
+
+	unwrapped, err := keys.UnwrapSecret(rek, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, secret, unwrapped)
+
+	_, err = keys.WrapDEK(rek, secret)
+	require.ErrorIs(t, err, e.ErrInvalidInput, "DEK of wrong length should be rejected")
+}
+
+func TestAuthKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("KEK of user authenticated with auth key", func(t *testing.T) {
+		t.Parallel()
+
+		salt, err := user.NewSalt()
+		require.NoError(t, err)
+
+		authKey, err := keys.DeriveAuthKey("user_password", salt)
+		require.NoError(t, err)
+		require.Len(t, authKey, keys.AuthKeyLength)
+
+		usr := user.New("test_user", user.RoleUser)
+		usr.SetAuthKey(authKey, salt)
+		require.False(t, usr.IsLegacy())
+		require.True(t, usr.CheckAuthKey(authKey))
+
+		kek, err := keys.KEK(usr, "user_password")
+		require.NoError(t, err)
+		require.NotEqual(t, authKey, kek)
+		require.Equal(t, authKey, keys.AuthKey(kek))
+
+		_, err = keys.KEK(usr, "wrong_password")
+		require.ErrorIs(t, err, e.ErrInvalidInput)
+	})
+
+	t.Run("migrated legacy user keeps KEK", func(t *testing.T) {
+		t.Parallel()
+
+		usr := user.New("test_user", user.RoleUser)
+		err := usr.SetPassword("user_password")
+		require.NoError(t, err)
+		require.True(t, usr.IsLegacy())
+
+		legacyKEK, err := keys.KEK(usr, "user_password")
+		require.NoError(t, err)
+
+		authKey, err := keys.DeriveAuthKey("user_password", usr.Salt)
+		require.NoError(t, err)
+		require.False(t, usr.CheckAuthKey(authKey))
+
+		usr.SetAuthKey(authKey, usr.Salt)
+		require.False(t, usr.IsLegacy())
+
+		kek, err := keys.KEK(usr, "user_password")
+		require.NoError(t, err)
+		require.Equal(t, legacyKEK, kek)
+	})
+}
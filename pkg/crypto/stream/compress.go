@@ -0,0 +1,236 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/sio"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// Codec is the compression applied to plaintext before encryption.
+type Codec byte
+
+const (
+	CodecNone Codec = iota
+	CodecGzip
+	CodecZstd
+)
+
+const (
+	// HeaderVersion is the version of the header written in front of compressed streams.
+	HeaderVersion = 1
+	// HeaderSize is the size of the header written in front of compressed streams.
+	HeaderSize = 4
+
+	// headerMagic starts header of compressed streams. It never starts encrypted stream itself,
+	// which starts with its format version, so streams without header are told apart.
+	headerMagic    = "GK"
+	streamKeyLabel = "gophkeeper stream key"
+
+	compressBufferSize = 64 * 1024
+)
+
+// ParseCodec returns codec by its name, empty name means no compression.
+func ParseCodec(name string) (Codec, error) {
+	switch name {
+	case "", "none":
+		return CodecNone, nil
+	case "gzip":
+		return CodecGzip, nil
+	case "zstd":
+		return CodecZstd, nil
+	default:
+		return CodecNone, fmt.Errorf("[%w] compression codec %q", e.ErrUnsupported, name)
+	}
+}
+
+func (c Codec) String() string {
+	switch c {
+	case CodecNone:
+		return "none"
+	case CodecGzip:
+		return "gzip"
+	case CodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("codec(%d)", byte(c))
+	}
+}
+
+func (c Codec) header() []byte {
+	return []byte{headerMagic[0], headerMagic[1], HeaderVersion, byte(c)}
+}
+
+// headerConfig returns config of the compressed stream. Its key is derived from DEK and the header,
+// so that altered header fails decryption instead of being silently trusted.
+func headerConfig(dek, header []byte) sio.Config {
+	mac := hmac.New(sha256.New, dek)
+	mac.Write([]byte(streamKeyLabel))
+	mac.Write(header)
+
+	return config(mac.Sum(nil))
+}
+
+// readHeader returns header of the compressed stream and the rest of the stream.
+// Stream without header is returned as is with nil header.
+func readHeader(input io.Reader) ([]byte, Codec, io.Reader, error) {
+	reader := bufio.NewReader(input)
+
+	first, err := reader.Peek(1)
+	if err != nil || first[0] != headerMagic[0] {
+		// empty or broken stream is reported by decryption.
+		return nil, CodecNone, reader, nil //nolint:nilerr // reason: see above
+	}
+
+	header := make([]byte, HeaderSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, CodecNone, nil, fmt.Errorf("[%w] secret stream header", e.ErrCorrupt)
+	}
+
+	if header[1] != headerMagic[1] {
+		return nil, CodecNone, nil, fmt.Errorf("[%w] secret stream header", e.ErrCorrupt)
+	}
+
+	if header[2] != HeaderVersion {
+		return nil, CodecNone, nil, fmt.Errorf("[%w] secret stream header version %d", e.ErrUnsupported, header[2])
+	}
+
+	codec := Codec(header[3])
+	if codec != CodecGzip && codec != CodecZstd {
+		return nil, CodecNone, nil, fmt.Errorf("[%w] secret stream codec %s", e.ErrUnsupported, codec)
+	}
+
+	return header, codec, reader, nil
+}
+
+func compressWriter(dst io.Writer, codec Codec) (io.WriteCloser, error) {
+	switch codec {
+	case CodecGzip:
+		return gzip.NewWriter(dst), nil
+	case CodecZstd:
+		zw, err := zstd.NewWriter(dst, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("[%w] zstd compression", e.ErrOpen)
+		}
+
+		return zw, nil
+	case CodecNone:
+	}
+
+	return nil, fmt.Errorf("[%w] compression codec %s", e.ErrUnsupported, codec)
+}
+
+func decompressReader(src io.Reader, codec Codec) (io.Reader, error) {
+	switch codec {
+	case CodecGzip:
+		zr, err := gzip.NewReader(src)
+		if err != nil {
+			return nil, fmt.Errorf("[%w] gzip stream", e.ErrDecrypt)
+		}
+
+		return zr, nil
+	case CodecZstd:
+		zr, err := zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("[%w] zstd stream", e.ErrDecrypt)
+		}
+
+		return zr.IOReadCloser(), nil
+	case CodecNone:
+	}
+
+	return nil, fmt.Errorf("[%w] compression codec %s", e.ErrUnsupported, codec)
+}
+
+// compress compresses data as a whole, the same data is always compressed into the same bytes.
+func compress(data []byte, codec Codec) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	zw, err := compressWriter(buf, codec)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("[%w] compress secret", e.ErrWrite)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("[%w] compress secret", e.ErrWrite)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// compressReader compresses plaintext read from src on demand.
+type compressReader struct {
+	src   io.Reader
+	zw    io.WriteCloser
+	buf   bytes.Buffer
+	chunk []byte
+	done  bool
+}
+
+func newCompressReader(src io.Reader, codec Codec) (*compressReader, error) {
+	reader := &compressReader{src: src, chunk: make([]byte, compressBufferSize)}
+
+	zw, err := compressWriter(&reader.buf, codec)
+	if err != nil {
+		return nil, err
+	}
+
+	reader.zw = zw
+
+	return reader, nil
+}
+
+func (r *compressReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 && !r.done {
+		n, err := r.src.Read(r.chunk)
+		if n > 0 {
+			if _, werr := r.zw.Write(r.chunk[:n]); werr != nil {
+				return 0, werr
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			if cerr := r.zw.Close(); cerr != nil {
+				return 0, cerr
+			}
+
+			r.done = true
+		} else if err != nil {
+			return 0, err
+		}
+	}
+
+	if r.buf.Len() == 0 {
+		return 0, io.EOF
+	}
+
+	return r.buf.Read(p)
+}
+
+// compressWriteCloser compresses plaintext into encrypted stream and closes both on Close.
+type compressWriteCloser struct {
+	io.Writer
+	zw  io.Closer
+	enc io.Closer
+}
+
+func (w *compressWriteCloser) Close() error {
+	if err := w.zw.Close(); err != nil {
+		w.enc.Close()
+		return err
+	}
+
+	return w.enc.Close()
+}
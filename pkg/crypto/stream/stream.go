@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 
@@ -34,27 +35,226 @@ func EncryptSecretStream(input io.Reader, dek []byte, log zerolog.Logger) (io.Re
 		return nil, fmt.Errorf("[%w] secret encryption stream", e.ErrOpen)
 	}
 
-	log.Info().Msg("Secret ecnryption stream created successfully")
+	log.Info().Msg("Secret encryption stream created successfully")
 
 	return encryptedReader, nil
 }
 
+// EncryptCompressedStream creates a streaming encryption reader like EncryptSecretStream compressing
+// plaintext with codec before encryption. Compressed stream starts with a header recording the codec,
+// so that DecryptSecretStream reverses compression automatically. No header is written without compression.
+func EncryptCompressedStream(input io.Reader, dek []byte, codec Codec, log zerolog.Logger) (io.Reader, error) {
+	if codec == CodecNone {
+		return EncryptSecretStream(input, dek, log)
+	}
+
+	if len(dek) != keys.DEKLength {
+		log.Error().Msg("invalid DEK used for encryption")
+		return nil, fmt.Errorf("[%w] invalid DEK length", e.ErrInvalidInput)
+	}
+
+	compressedReader, err := newCompressReader(input, codec)
+	if err != nil {
+		return nil, err
+	}
+
+	header := codec.header()
+
+	encryptedReader, err := sio.EncryptReader(compressedReader, headerConfig(dek, header))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create encryption stream")
+		return nil, fmt.Errorf("[%w] secret encryption stream", e.ErrOpen)
+	}
+
+	log.Info().
+		Str("codec", codec.String()).
+		Msg("Secret compressed encryption stream created successfully")
+
+	return io.MultiReader(bytes.NewReader(header), encryptedReader), nil
+}
+
 // DecryptSecretStream creates a streaming decryption reader that reads encrypted data from the input
 // and returns a decrypted io.Reader. Decryption is performed using the same DEK used for encryption.
 // The function validates the provided DEK and returns a wrapped reader that decrypts on-the-fly.
+// Stream compressed before encryption is decompressed according to its header.
 func DecryptSecretStream(input io.Reader, dek []byte, log zerolog.Logger) (io.Reader, error) {
 	if len(dek) != keys.DEKLength {
 		log.Error().Msg("invalid DEK used for decryption")
 		return nil, fmt.Errorf("[%w] invalid DEK length", e.ErrInvalidInput)
 	}
 
-	decryptedReader, err := sio.DecryptReader(input, config(dek))
+	header, codec, input, err := readHeader(input)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to read secret stream header")
+		return nil, err
+	}
+
+	cfg := config(dek)
+	if header != nil {
+		cfg = headerConfig(dek, header)
+	}
+
+	decryptedReader, err := sio.DecryptReader(input, cfg)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to create decryption stream")
 		return nil, fmt.Errorf("[%w] secret decryption stream", e.ErrOpen)
 	}
 
+	if header != nil {
+		decryptedReader, err = decompressReader(decryptedReader, codec)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create decompression stream")
+			return nil, err
+		}
+	}
+
 	log.Info().Msg("Secret decryption stream created successfully")
 
 	return decryptedReader, nil
 }
+
+// EncryptSecretWriter creates a streaming encryption writer which compresses plaintext written to it
+// with codec and encrypts it into dst. Writer must be closed to flush the last encrypted package.
+func EncryptSecretWriter(dst io.Writer, dek []byte, codec Codec, log zerolog.Logger) (io.WriteCloser, error) {
+	if len(dek) != keys.DEKLength {
+		log.Error().Msg("invalid DEK used for encryption")
+		return nil, fmt.Errorf("[%w] invalid DEK length", e.ErrInvalidInput)
+	}
+
+	cfg := config(dek)
+
+	if codec != CodecNone {
+		header := codec.header()
+		if _, err := dst.Write(header); err != nil {
+			return nil, fmt.Errorf("[%w] secret stream header", e.ErrWrite)
+		}
+
+		cfg = headerConfig(dek, header)
+	}
+
+	encryptedWriter, err := sio.EncryptWriter(dst, cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create encryption stream")
+		return nil, fmt.Errorf("[%w] secret encryption stream", e.ErrOpen)
+	}
+
+	if codec == CodecNone {
+		return encryptedWriter, nil
+	}
+
+	zw, err := compressWriter(encryptedWriter, codec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compressWriteCloser{Writer: zw, zw: zw, enc: encryptedWriter}, nil
+}
+
+// EncryptChunk encrypts chunk of the secret as a standalone stream, so that it can be stored
+// and decrypted independently of other chunks. Chunk is compressed with codec before encryption.
+func EncryptChunk(chunk, dek []byte, codec Codec) ([]byte, error) {
+	if len(dek) != keys.DEKLength {
+		return nil, fmt.Errorf("[%w] invalid DEK length", e.ErrInvalidInput)
+	}
+
+	var (
+		cfg    = config(dek)
+		header []byte
+	)
+
+	if codec != CodecNone {
+		compressed, err := compress(chunk, codec)
+		if err != nil {
+			return nil, err
+		}
+
+		chunk = compressed
+		header = codec.header()
+		cfg = headerConfig(dek, header)
+	}
+
+	size, err := EncryptedSize(int64(len(chunk)))
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := bytes.NewBuffer(make([]byte, 0, int64(len(header))+size))
+	encrypted.Write(header)
+
+	if _, err := sio.Encrypt(encrypted, bytes.NewReader(chunk), cfg); err != nil {
+		return nil, fmt.Errorf("[%w] secret chunk", e.ErrEncrypt)
+	}
+
+	return encrypted.Bytes(), nil
+}
+
+// EncryptedChunkSize returns size of the chunk encrypted by EncryptChunk.
+func EncryptedChunkSize(chunk []byte, codec Codec) (int64, error) {
+	if codec == CodecNone {
+		return EncryptedSize(int64(len(chunk)))
+	}
+
+	compressed, err := compress(chunk, codec)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := EncryptedSize(int64(len(compressed)))
+	if err != nil {
+		return 0, err
+	}
+
+	return HeaderSize + size, nil
+}
+
+// DecryptChunk decrypts chunk encrypted by EncryptChunk verifying its integrity.
+func DecryptChunk(encrypted, dek []byte) ([]byte, error) {
+	if len(dek) != keys.DEKLength {
+		return nil, fmt.Errorf("[%w] invalid DEK length", e.ErrInvalidInput)
+	}
+
+	header, codec, _, err := readHeader(bytes.NewReader(encrypted))
+	if err != nil {
+		return nil, err
+	}
+
+	if header == nil {
+		chunk, err := sio.DecryptBuffer(nil, encrypted, config(dek))
+		if err != nil {
+			return nil, fmt.Errorf("[%w] secret chunk", e.ErrDecrypt)
+		}
+
+		return chunk, nil
+	}
+
+	compressed, err := sio.DecryptBuffer(nil, encrypted[HeaderSize:], headerConfig(dek, header))
+	if err != nil {
+		return nil, fmt.Errorf("[%w] secret chunk", e.ErrDecrypt)
+	}
+
+	zr, err := decompressReader(bytes.NewReader(compressed), codec)
+	if err != nil {
+		return nil, err
+	}
+
+	chunk, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] secret chunk", e.ErrDecrypt)
+	}
+
+	return chunk, nil
+}
+
+// EncryptedSize returns size of the stream encrypting size bytes of plaintext.
+func EncryptedSize(size int64) (int64, error) {
+	if size < 0 {
+		return 0, fmt.Errorf("[%w] plaintext size", e.ErrInvalidInput)
+	}
+
+	encSize, err := sio.EncryptedSize(uint64(size))
+	if err != nil {
+		return 0, fmt.Errorf("[%w] plaintext size", e.ErrInvalidInput)
+	}
+
+	return int64(encSize), nil //nolint:gosec // reason: encrypted size of int64 plaintext fits int64
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/stream"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -111,3 +112,129 @@ func TestEncryptDecryptStreamStress5GB(t *testing.T) {
 	t.Logf("Decrypted %d bytes in %v", decryptedBytes, time.Since(startDec))
 	require.Equal(t, totalSize, decryptedBytes, "decrypted data size should match original")
 }
+
+func TestEncryptDecryptChunk(t *testing.T) {
+	t.Parallel()
+
+	for _, codec := range []stream.Codec{stream.CodecNone, stream.CodecGzip, stream.CodecZstd} {
+		t.Run(codec.String(), func(t *testing.T) {
+			t.Parallel()
+
+			dek, err := keys.DEK()
+			require.NoError(t, err)
+
+			chunk := bytes.Repeat([]byte("chunk of a large binary secret "), 4096)
+
+			encrypted, err := stream.EncryptChunk(chunk, dek, codec)
+			require.NoError(t, err)
+
+			size, err := stream.EncryptedChunkSize(chunk, codec)
+			require.NoError(t, err)
+			assert.Equal(t, size, int64(len(encrypted)))
+
+			decrypted, err := stream.DecryptChunk(encrypted, dek)
+			require.NoError(t, err)
+			assert.Equal(t, chunk, decrypted)
+
+			encrypted[len(encrypted)-1] ^= 0xff
+			_, err = stream.DecryptChunk(encrypted, dek)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestEncryptSecretWriter(t *testing.T) {
+	t.Parallel()
+
+	for _, codec := range []stream.Codec{stream.CodecNone, stream.CodecGzip, stream.CodecZstd} {
+		t.Run(codec.String(), func(t *testing.T) {
+			t.Parallel()
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			dek, err := keys.DEK()
+			require.NoError(t, err)
+
+			originalData := []byte("secret written in parts")
+
+			var encrypted bytes.Buffer
+
+			writer, err := stream.EncryptSecretWriter(&encrypted, dek, codec, log)
+			require.NoError(t, err)
+
+			_, err = writer.Write(originalData[:6])
+			require.NoError(t, err)
+			_, err = writer.Write(originalData[6:])
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			decryptedReader, err := stream.DecryptSecretStream(&encrypted, dek, log)
+			require.NoError(t, err)
+
+			decryptedData, err := io.ReadAll(decryptedReader)
+			require.NoError(t, err)
+			require.Equal(t, originalData, decryptedData)
+		})
+	}
+}
+
+func TestEncryptCompressedStream(t *testing.T) {
+	t.Parallel()
+
+	log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+	originalData := bytes.Repeat([]byte("INSERT INTO secrets VALUES ('dump', 'row');\n"), 100_000)
+
+	plainSize, err := stream.EncryptedSize(int64(len(originalData)))
+	require.NoError(t, err)
+
+	for _, codec := range []stream.Codec{stream.CodecGzip, stream.CodecZstd} {
+		t.Run(codec.String(), func(t *testing.T) {
+			t.Parallel()
+
+			dek, err := keys.DEK()
+			require.NoError(t, err)
+
+			encReader, err := stream.EncryptCompressedStream(bytes.NewReader(originalData), dek, codec, log)
+			require.NoError(t, err)
+
+			encrypted, err := io.ReadAll(encReader)
+			require.NoError(t, err)
+			assert.Less(t, int64(len(encrypted)), plainSize/10)
+
+			decReader, err := stream.DecryptSecretStream(bytes.NewReader(encrypted), dek, log)
+			require.NoError(t, err)
+
+			decrypted, err := io.ReadAll(decReader)
+			require.NoError(t, err)
+			assert.Equal(t, originalData, decrypted)
+
+			// header is bound to the key, so altered codec is not trusted.
+			tampered := append([]byte{}, encrypted...)
+			tampered[stream.HeaderSize-1] = byte(stream.CodecGzip + stream.CodecZstd - codec)
+
+			decReader, err = stream.DecryptSecretStream(bytes.NewReader(tampered), dek, log)
+			if err == nil {
+				_, err = io.ReadAll(decReader)
+			}
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseCodec(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]stream.Codec{
+		"":     stream.CodecNone,
+		"none": stream.CodecNone,
+		"gzip": stream.CodecGzip,
+		"zstd": stream.CodecZstd,
+	} {
+		codec, err := stream.ParseCodec(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, codec)
+	}
+
+	_, err := stream.ParseCodec("lz4")
+	require.ErrorIs(t, err, e.ErrUnsupported)
+}
@@ -0,0 +1,71 @@
+package totp_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/totp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	// RFC 6238 appendix B test vectors of SHA1 truncated to 6 digits.
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		step := totp.Step(time.Unix(tt.unix, 0))
+		require.Equal(t, tt.code, totp.Code(secret, step))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	secret, err := totp.NewSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, totp.SecretSize)
+
+	now := time.Now()
+	step := totp.Step(now)
+
+	got, ok := totp.Validate(secret, totp.Code(secret, step), now)
+	require.True(t, ok)
+	require.Equal(t, step, got)
+
+	got, ok = totp.Validate(secret, totp.Code(secret, step-1), now)
+	require.True(t, ok, "previous period code is accepted")
+	require.Equal(t, step-1, got)
+
+	_, ok = totp.Validate(secret, totp.Code(secret, step-3), now)
+	require.False(t, ok, "stale code is rejected")
+
+	_, ok = totp.Validate(secret, "12345", now)
+	require.False(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("12345678901234567890")
+	uri, err := url.Parse(totp.URI("GophKeeper", "alice", secret))
+	require.NoError(t, err)
+
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/GophKeeper:alice", uri.Path)
+	require.Equal(t, totp.EncodeSecret(secret), uri.Query().Get("secret"))
+	require.Equal(t, "GophKeeper", uri.Query().Get("issuer"))
+}
@@ -1,12 +1,26 @@
 package card
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/hamba/avro/v2"
 	uavro "github.com/patraden/ya-practicum-gophkeeper/pkg/avro"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 )
 
-const cardNumberLength = 16
+const (
+	minCardNumberLength  = 12
+	maxCardNumberLength  = 19
+	amexCardNumberLength = 15
+	cvvLength            = 3
+	amexCVVLength        = 4
+	maskedDigits         = 4
+	monthsInYear         = 12
+	centuryYears         = 100
+)
 
 // UnmarshalBankCard deserializes BankCard from avro binary.
 func UnmarshalBankCard(schemaFile *uavro.SchemaFile, val []byte) (*BankCard, error) {
@@ -24,11 +38,130 @@ func UnmarshalBankCard(schemaFile *uavro.SchemaFile, val []byte) (*BankCard, err
 	return card, nil
 }
 
-// IsValid validates BankCard data consistency.
+// IsValid validates BankCard data consistency:
+// card number of 12 to 19 digits must pass Luhn check, expiry month and cvv must be in range.
 func (b *BankCard) IsValid() bool {
-	// optionally can add here luhn algo
-	// https://github.com/ShiraazMoollatjie/goluhn
-	return len(b.CardNumber) == cardNumberLength
+	maxCVV := 1
+	for range b.CVVLength() {
+		maxCVV *= 10
+	}
+
+	return len(b.CardNumber) >= minCardNumberLength && len(b.CardNumber) <= maxCardNumberLength &&
+		luhnValid(b.CardNumber) &&
+		b.ExpiryMonth >= 1 && b.ExpiryMonth <= monthsInYear &&
+		b.Cvv >= 0 && b.Cvv < maxCVV
+}
+
+// CVVLength returns number of CVV digits of the card: American Express cards
+// have 15 digit numbers starting with 34 or 37 and 4 digit CVV, other cards have 3 digit CVV.
+func (b *BankCard) CVVLength() int {
+	return CVVLength(b.CardNumber)
+}
+
+// CVVLength returns number of CVV digits of the card with the given number.
+func CVVLength(number string) int {
+	if len(number) == amexCardNumberLength && (strings.HasPrefix(number, "34") || strings.HasPrefix(number, "37")) {
+		return amexCVVLength
+	}
+
+	return cvvLength
+}
+
+// IsExpired reports whether card is expired at the given time.
+// Card stays valid until the end of its expiry month.
+func (b *BankCard) IsExpired(now time.Time) bool {
+	expiresAt := time.Date(b.ExpiryYear, time.Month(b.ExpiryMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+
+	return !now.UTC().Before(expiresAt)
+}
+
+// Validate checks that card data is consistent and card is not expired.
+func (b *BankCard) Validate(now time.Time) error {
+	if !b.IsValid() {
+		return fmt.Errorf("[%w] invalid card data", e.ErrValidation)
+	}
+
+	if b.IsExpired(now) {
+		return fmt.Errorf("[%w] card is expired", e.ErrValidation)
+	}
+
+	return nil
+}
+
+// MaskedNumber returns card number with all digits but last four hidden.
+func (b *BankCard) MaskedNumber() string {
+	if len(b.CardNumber) <= maskedDigits {
+		return b.CardNumber
+	}
+
+	hidden := len(b.CardNumber) - maskedDigits
+
+	return strings.Repeat("*", hidden) + b.CardNumber[hidden:]
+}
+
+// Format renders card as human readable text with masked number unless reveal is set.
+func (b *BankCard) Format(reveal bool) string {
+	number, cvv := b.MaskedNumber(), strings.Repeat("*", b.CVVLength())
+	if reveal {
+		number, cvv = b.CardNumber, fmt.Sprintf("%0*d", b.CVVLength(), b.Cvv)
+	}
+
+	return fmt.Sprintf("Number: %s\nHolder: %s\nExpiry: %02d/%d\nCVV:    %s\n",
+		number, b.CardholderName, b.ExpiryMonth, b.ExpiryYear, cvv)
+}
+
+// ParseExpiry parses card expiry date in MM/YY or MM/YYYY format.
+func ParseExpiry(expiry string) (int, int, error) {
+	monthStr, yearStr, found := strings.Cut(expiry, "/")
+	if !found {
+		return 0, 0, fmt.Errorf("[%w] card expiry must be MM/YY", e.ErrValidation)
+	}
+
+	month, err := strconv.Atoi(strings.TrimSpace(monthStr))
+	if err != nil || month < 1 || month > monthsInYear {
+		return 0, 0, fmt.Errorf("[%w] card expiry month", e.ErrValidation)
+	}
+
+	year, err := strconv.Atoi(strings.TrimSpace(yearStr))
+	if err != nil || year < 0 {
+		return 0, 0, fmt.Errorf("[%w] card expiry year", e.ErrValidation)
+	}
+
+	if year < centuryYears {
+		year += time.Now().UTC().Year() / centuryYears * centuryYears
+	}
+
+	return month, year, nil
+}
+
+// luhnValid checks number with Luhn (mod 10) algorithm.
+func luhnValid(number string) bool {
+	const (
+		base     = 10
+		maxDigit = 9
+	)
+
+	sum := 0
+	double := false
+
+	for i := len(number) - 1; i >= 0; i-- {
+		if number[i] < '0' || number[i] > '9' {
+			return false
+		}
+
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > maxDigit {
+				digit -= maxDigit
+			}
+		}
+
+		sum += digit
+		double = !double
+	}
+
+	return sum%base == 0
 }
 
 // Marshal serializes BankCard to avro binary.
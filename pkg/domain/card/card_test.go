@@ -2,10 +2,12 @@ package card_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	uavro "github.com/patraden/ya-practicum-gophkeeper/pkg/avro"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/card"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,7 +19,7 @@ func TestBankCardSerializeDeserialize(t *testing.T) {
 	original := &card.BankCard{
 		UserID:         uuid.NewString(),
 		CardholderName: "Denis Patrakhin",
-		CardNumber:     "1234567812345678",
+		CardNumber:     "4111111111111111",
 		ExpiryMonth:    12,
 		ExpiryYear:     2029,
 		Cvv:            123,
@@ -44,3 +46,121 @@ func TestBankCardSerializeDeserialize(t *testing.T) {
 	require.Equal(t, original.ExpiryYear, card.ExpiryYear)
 	require.Equal(t, original.Cvv, card.Cvv)
 }
+
+func TestBankCardValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		card      card.BankCard
+		expectErr error
+	}{
+		{
+			name:      "valid",
+			card:      card.BankCard{CardNumber: "4111111111111111", ExpiryMonth: 6, ExpiryYear: 2025, Cvv: 123},
+			expectErr: nil,
+		},
+		{
+			name:      "luhn check failed",
+			card:      card.BankCard{CardNumber: "1234567812345678", ExpiryMonth: 12, ExpiryYear: 2029, Cvv: 123},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "not digits",
+			card:      card.BankCard{CardNumber: "4111-1111-1111-1", ExpiryMonth: 12, ExpiryYear: 2029, Cvv: 123},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "invalid month",
+			card:      card.BankCard{CardNumber: "4111111111111111", ExpiryMonth: 13, ExpiryYear: 2029, Cvv: 123},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "valid 15 digit american express",
+			card:      card.BankCard{CardNumber: "378282246310005", ExpiryMonth: 12, ExpiryYear: 2029, Cvv: 1234},
+			expectErr: nil,
+		},
+		{
+			name:      "valid 19 digit number",
+			card:      card.BankCard{CardNumber: "4111111111111111110", ExpiryMonth: 12, ExpiryYear: 2029, Cvv: 123},
+			expectErr: nil,
+		},
+		{
+			name:      "number too short",
+			card:      card.BankCard{CardNumber: "41111111113", ExpiryMonth: 12, ExpiryYear: 2029, Cvv: 123},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "4 digit cvv of 3 digit card",
+			card:      card.BankCard{CardNumber: "4111111111111111", ExpiryMonth: 12, ExpiryYear: 2029, Cvv: 1234},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "expired",
+			card:      card.BankCard{CardNumber: "4111111111111111", ExpiryMonth: 5, ExpiryYear: 2025, Cvv: 123},
+			expectErr: e.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.card.Validate(now)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBankCardFormat(t *testing.T) {
+	t.Parallel()
+
+	bankCard := card.BankCard{
+		CardholderName: "Denis Patrakhin",
+		CardNumber:     "4111111111111111",
+		ExpiryMonth:    1,
+		ExpiryYear:     2029,
+		Cvv:            7,
+	}
+
+	require.Equal(t, "************1111", bankCard.MaskedNumber())
+
+	masked := bankCard.Format(false)
+	require.Contains(t, masked, "************1111")
+	require.NotContains(t, masked, "4111111111111111")
+	require.Contains(t, masked, "01/2029")
+
+	revealed := bankCard.Format(true)
+	require.Contains(t, revealed, "4111111111111111")
+	require.Contains(t, revealed, "007")
+
+	amex := card.BankCard{CardNumber: "378282246310005", ExpiryMonth: 1, ExpiryYear: 2029, Cvv: 123}
+	require.Contains(t, amex.Format(true), "CVV:    0123")
+	require.Contains(t, amex.Format(false), "CVV:    ****")
+}
+
+func TestParseExpiry(t *testing.T) {
+	t.Parallel()
+
+	month, year, err := card.ParseExpiry("07/2030")
+	require.NoError(t, err)
+	require.Equal(t, 7, month)
+	require.Equal(t, 2030, year)
+
+	month, year, err = card.ParseExpiry("12/31")
+	require.NoError(t, err)
+	require.Equal(t, 12, month)
+	require.Equal(t, 2031, year)
+
+	_, _, err = card.ParseExpiry("13/31")
+	require.ErrorIs(t, err, e.ErrValidation)
+
+	_, _, err = card.ParseExpiry("1231")
+	require.ErrorIs(t, err, e.ErrValidation)
+}
@@ -3,7 +3,10 @@ package creds
 
 // UserCredentials is a generated struct.
 type UserCredentials struct {
-	UserID         string `avro:"user_id" json:"user_id"`
-	Username       string `avro:"username" json:"username"`
-	HashedPassword []byte `avro:"hashed_password" json:"hashed_password"`
+	UserID     string   `avro:"user_id" json:"user_id"`
+	Username   string   `avro:"username" json:"username"`
+	Password   string   `avro:"password" json:"password"`
+	Urls       []string `avro:"urls" json:"urls"`
+	Notes      string   `avro:"notes" json:"notes"`
+	TotpSecret *string  `avro:"totp_secret" json:"totp_secret"`
 }
@@ -1,11 +1,18 @@
 package creds
 
 import (
+	"encoding/base32"
+	"fmt"
+	"net/url"
+	"strings"
+
 	"github.com/hamba/avro/v2"
 	uavro "github.com/patraden/ya-practicum-gophkeeper/pkg/avro"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 )
 
+const maskedValue = "********"
+
 // UnmarshalUserCreds deserializes UserCredentials from avro binary.
 func UnmarshalUserCreds(schemaFile *uavro.SchemaFile, val []byte) (*UserCredentials, error) {
 	creds := &UserCredentials{}
@@ -36,3 +43,70 @@ func (c *UserCredentials) Marshal(schemaFile *uavro.SchemaFile) ([]byte, error)
 
 	return avro, nil
 }
+
+// Validate checks that credentials have login and password,
+// urls are absolute and TOTP secret (if any) is a base32 encoded seed.
+func (c *UserCredentials) Validate() error {
+	if c.Username == "" {
+		return fmt.Errorf("[%w] empty credentials username", e.ErrValidation)
+	}
+
+	if c.Password == "" {
+		return fmt.Errorf("[%w] empty credentials password", e.ErrValidation)
+	}
+
+	for _, rawURL := range c.Urls {
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("[%w] invalid credentials url %q", e.ErrValidation, rawURL)
+		}
+	}
+
+	if c.TotpSecret != nil {
+		seed := NormalizeTOTPSecret(*c.TotpSecret)
+		if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(seed); err != nil || seed == "" {
+			return fmt.Errorf("[%w] invalid credentials totp secret", e.ErrValidation)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeTOTPSecret upper-cases base32 TOTP seed and strips spaces and padding.
+func NormalizeTOTPSecret(seed string) string {
+	seed = strings.ToUpper(strings.ReplaceAll(seed, " ", ""))
+
+	return strings.TrimRight(seed, "=")
+}
+
+// Format renders credentials as human readable text with masked password
+// and TOTP secret unless reveal is set.
+func (c *UserCredentials) Format(reveal bool) string {
+	password := maskedValue
+	if reveal {
+		password = c.Password
+	}
+
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "Username: %s\nPassword: %s\n", c.Username, password)
+
+	for _, u := range c.Urls {
+		fmt.Fprintf(&out, "URL:      %s\n", u)
+	}
+
+	if c.Notes != "" {
+		fmt.Fprintf(&out, "Notes:    %s\n", c.Notes)
+	}
+
+	if c.TotpSecret != nil {
+		seed := maskedValue
+		if reveal {
+			seed = *c.TotpSecret
+		}
+
+		fmt.Fprintf(&out, "TOTP:     %s\n", seed)
+	}
+
+	return out.String()
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	uavro "github.com/patraden/ya-practicum-gophkeeper/pkg/avro"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/creds"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,11 +14,15 @@ func TestUserCredsSerializeDeserialize(t *testing.T) {
 	t.Parallel()
 
 	schemaFile := uavro.NewSchemaFile("../../../avro/creds.avsc")
+	totp := "JBSWY3DPEHPK3PXP"
 
 	original := &creds.UserCredentials{
-		UserID:         uuid.NewString(),
-		Username:       "patraden",
-		HashedPassword: []byte("password"),
+		UserID:     uuid.NewString(),
+		Username:   "patraden",
+		Password:   "password",
+		Urls:       []string{"https://example.com/login"},
+		Notes:      "work account",
+		TotpSecret: &totp,
 	}
 
 	// Marshal the UserCredentials
@@ -34,5 +39,95 @@ func TestUserCredsSerializeDeserialize(t *testing.T) {
 
 	require.Equal(t, original.UserID, creds.UserID)
 	require.Equal(t, original.Username, creds.Username)
-	require.Equal(t, original.HashedPassword, creds.HashedPassword)
+	require.Equal(t, original.Password, creds.Password)
+	require.Equal(t, original.Urls, creds.Urls)
+	require.Equal(t, original.Notes, creds.Notes)
+	require.Equal(t, original.TotpSecret, creds.TotpSecret)
+}
+
+func TestUserCredsWithoutTOTP(t *testing.T) {
+	t.Parallel()
+
+	schemaFile := uavro.NewSchemaFile("../../../avro/creds.avsc")
+
+	original := &creds.UserCredentials{
+		UserID:   uuid.NewString(),
+		Username: "patraden",
+		Password: "password",
+	}
+
+	data, err := original.Marshal(schemaFile)
+	require.NoError(t, err)
+
+	restored, err := creds.UnmarshalUserCreds(schemaFile, data)
+	require.NoError(t, err)
+	require.Nil(t, restored.TotpSecret)
+	require.Empty(t, restored.Urls)
+}
+
+func TestUserCredsValidate(t *testing.T) {
+	t.Parallel()
+
+	validTOTP := "jbsw y3dp ehpk 3pxp"
+	invalidTOTP := "not-base32!"
+
+	tests := []struct {
+		name      string
+		creds     creds.UserCredentials
+		expectErr error
+	}{
+		{
+			name:  "valid",
+			creds: creds.UserCredentials{Username: "user", Password: "pass", Urls: []string{"https://a.b"}, TotpSecret: &validTOTP},
+		},
+		{
+			name:      "empty username",
+			creds:     creds.UserCredentials{Password: "pass"},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "empty password",
+			creds:     creds.UserCredentials{Username: "user"},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "relative url",
+			creds:     creds.UserCredentials{Username: "user", Password: "pass", Urls: []string{"example.com"}},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "invalid totp",
+			creds:     creds.UserCredentials{Username: "user", Password: "pass", TotpSecret: &invalidTOTP},
+			expectErr: e.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.creds.Validate()
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUserCredsFormat(t *testing.T) {
+	t.Parallel()
+
+	totp := "JBSWY3DPEHPK3PXP"
+	userCreds := creds.UserCredentials{Username: "user", Password: "s3cr3t", TotpSecret: &totp}
+
+	masked := userCreds.Format(false)
+	require.Contains(t, masked, "user")
+	require.NotContains(t, masked, "s3cr3t")
+	require.NotContains(t, masked, totp)
+
+	revealed := userCreds.Format(true)
+	require.Contains(t, revealed, "s3cr3t")
+	require.Contains(t, revealed, totp)
 }
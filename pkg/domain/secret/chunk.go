@@ -0,0 +1,120 @@
+package secret
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+const (
+	// MetaKeyStorage is the metadata key holding storage layout of secret versions.
+	MetaKeyStorage = "storage"
+	// StorageChunked marks secrets which versions are stored as manifests of content-defined chunks.
+	StorageChunked = "chunked"
+
+	ManifestVersion = 1
+	// MaxManifestSize limits manifest object read by the server.
+	MaxManifestSize = 16 * 1024 * 1024
+
+	// ChunkKeyPrefix is the key prefix of chunk objects in the user bucket.
+	ChunkKeyPrefix = "chunks/"
+
+	chunkIDLength  = 64
+	chunkKeySuffix = ".chunk"
+)
+
+// Chunked reports whether secret versions are stored as chunk manifests.
+func (m MetaData) Chunked() bool {
+	return m[MetaKeyStorage] == StorageChunked
+}
+
+// ChunkRef references encrypted chunk object from the manifest.
+type ChunkRef struct {
+	// ID is the keyed hash of the plaintext chunk.
+	ID string `json:"id"`
+	// Size is the size of the encrypted chunk object.
+	Size int64 `json:"size"`
+}
+
+// Manifest is the object of a chunked secret version.
+// Version content is concatenation of decrypted chunks in the manifest order.
+type Manifest struct {
+	Version int        `json:"version"`
+	Size    int64      `json:"size"`
+	Chunks  []ChunkRef `json:"chunks"`
+}
+
+// NewManifest creates empty manifest of the current version.
+func NewManifest() *Manifest {
+	return &Manifest{Version: ManifestVersion, Chunks: []ChunkRef{}}
+}
+
+// ChunkObjectKey returns key of the chunk object in the user bucket.
+func ChunkObjectKey(chunkID string) string {
+	return ChunkKeyPrefix + chunkID + chunkKeySuffix
+}
+
+// ChunkIDFromObjectKey returns id of the chunk stored in the object, false if object is not a chunk.
+func ChunkIDFromObjectKey(objectKey string) (string, bool) {
+	chunkID, ok := strings.CutPrefix(objectKey, ChunkKeyPrefix)
+	if !ok {
+		return "", false
+	}
+
+	chunkID, ok = strings.CutSuffix(chunkID, chunkKeySuffix)
+	if !ok || len(chunkID) != chunkIDLength {
+		return "", false
+	}
+
+	return chunkID, true
+}
+
+// ParseManifest decodes manifest and validates its chunk references.
+func ParseManifest(data []byte) (*Manifest, error) {
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("[%w] secret manifest", e.ErrUnmarshal)
+	}
+
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("[%w] secret manifest version %d", e.ErrUnsupported, manifest.Version)
+	}
+
+	for _, ref := range manifest.Chunks {
+		if _, err := hex.DecodeString(ref.ID); err != nil || len(ref.ID) != chunkIDLength || ref.Size <= 0 {
+			return nil, fmt.Errorf("[%w] secret manifest chunk %q", e.ErrValidation, ref.ID)
+		}
+	}
+
+	return manifest, nil
+}
+
+// Marshal encodes manifest, the same manifest is always encoded into the same bytes.
+func (m *Manifest) Marshal() ([]byte, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] secret manifest", e.ErrMarshal)
+	}
+
+	return data, nil
+}
+
+// UniqueChunks returns distinct chunks of the manifest in the order of their first reference.
+func (m *Manifest) UniqueChunks() []ChunkRef {
+	seen := make(map[string]struct{}, len(m.Chunks))
+	unique := make([]ChunkRef, 0, len(m.Chunks))
+
+	for _, ref := range m.Chunks {
+		if _, ok := seen[ref.ID]; ok {
+			continue
+		}
+
+		seen[ref.ID] = struct{}{}
+		unique = append(unique, ref)
+	}
+
+	return unique
+}
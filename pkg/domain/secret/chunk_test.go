@@ -0,0 +1,74 @@
+package secret_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifest(t *testing.T) {
+	t.Parallel()
+
+	idA := strings.Repeat("a", 64)
+	idB := strings.Repeat("b", 64)
+
+	manifest := secret.NewManifest()
+	manifest.Size = 30
+	manifest.Chunks = append(manifest.Chunks,
+		secret.ChunkRef{ID: idA, Size: 42},
+		secret.ChunkRef{ID: idB, Size: 52},
+		secret.ChunkRef{ID: idA, Size: 42},
+	)
+
+	data, err := manifest.Marshal()
+	require.NoError(t, err)
+
+	parsed, err := secret.ParseManifest(data)
+	require.NoError(t, err)
+	require.Equal(t, manifest, parsed)
+	require.Equal(t, []secret.ChunkRef{{ID: idA, Size: 42}, {ID: idB, Size: 52}}, parsed.UniqueChunks())
+	require.Equal(t, "chunks/"+idA+".chunk", secret.ChunkObjectKey(idA))
+
+	chunkID, ok := secret.ChunkIDFromObjectKey(secret.ChunkObjectKey(idA))
+	require.True(t, ok)
+	require.Equal(t, idA, chunkID)
+
+	_, ok = secret.ChunkIDFromObjectKey("chunks/" + idA)
+	require.False(t, ok)
+
+	_, ok = secret.ChunkIDFromObjectKey("binary5g_" + idA + ".secret")
+	require.False(t, ok)
+}
+
+func TestParseManifestInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{name: "not a manifest", data: "\x20encrypted", err: e.ErrUnmarshal},
+		{name: "unknown version", data: `{"version":2,"size":0,"chunks":[]}`, err: e.ErrUnsupported},
+		{name: "invalid chunk id", data: `{"version":1,"size":1,"chunks":[{"id":"zz","size":1}]}`, err: e.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := secret.ParseManifest([]byte(tt.data))
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestMetaDataChunked(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, secret.MetaData{secret.MetaKeyStorage: secret.StorageChunked}.Chunked())
+	require.False(t, secret.MetaData{secret.MetaKeyType: "binary"}.Chunked())
+}
@@ -0,0 +1,36 @@
+package secret
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// VersionConflictError reports that upload is based on a secret version which is no longer current.
+// It wraps ErrConflict.
+type VersionConflictError struct {
+	SecretID         uuid.UUID
+	SecretName       string
+	ParentVersionID  uuid.UUID
+	CurrentVersionID uuid.UUID
+}
+
+// NewVersionConflictError creates conflict error of the init request with the current secret version.
+func NewVersionConflictError(req *InitRequest, currentVersionID uuid.UUID) *VersionConflictError {
+	return &VersionConflictError{
+		SecretID:         req.SecretID,
+		SecretName:       req.SecretName,
+		ParentVersionID:  req.ParentVersionID,
+		CurrentVersionID: currentVersionID,
+	}
+}
+
+func (err *VersionConflictError) Error() string {
+	return fmt.Sprintf("[%s] secret %s parent version %s, current version %s",
+		e.ErrConflict, err.SecretName, err.ParentVersionID, err.CurrentVersionID)
+}
+
+func (err *VersionConflictError) Unwrap() error {
+	return e.ErrConflict
+}
@@ -0,0 +1,39 @@
+package secret
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+)
+
+// DeleteRequest asks to delete the secret provided its current version is still VersionID.
+type DeleteRequest struct {
+	UserID     uuid.UUID
+	SecretName string
+	VersionID  uuid.UUID
+	ClientInfo string
+	User       *user.User
+}
+
+// Tombstone records deletion of the secret so that other devices can apply it on sync.
+type Tombstone struct {
+	UserID     uuid.UUID
+	SecretID   uuid.UUID
+	SecretName string
+	VersionID  uuid.UUID
+	ClientInfo string
+	DeletedAt  time.Time
+}
+
+// NewTombstone creates tombstone for the secret deleted at its version.
+func NewTombstone(req *DeleteRequest, secretID uuid.UUID) *Tombstone {
+	return &Tombstone{
+		UserID:     req.UserID,
+		SecretID:   secretID,
+		SecretName: req.SecretName,
+		VersionID:  req.VersionID,
+		ClientInfo: req.ClientInfo,
+		DeletedAt:  time.Now().UTC(),
+	}
+}
@@ -0,0 +1,67 @@
+package secret
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventTypeCreate EventType = "create"
+	EventTypeUpdate EventType = "update"
+	EventTypeDelete EventType = "delete"
+
+	DefaultEventsBatchSize int32 = 100
+)
+
+// Event records a change of the user secret, so that other devices can follow changes
+// instead of polling. Cursor orders events of all users and is used to resume watching.
+type Event struct {
+	Cursor     int64
+	UserID     uuid.UUID
+	SecretID   uuid.UUID
+	SecretName string
+	VersionID  uuid.UUID
+	Type       EventType
+	ClientInfo string
+	CreatedAt  time.Time
+}
+
+// NewCommitEvent creates event of the committed secret version.
+func NewCommitEvent(req *CommitRequest) *Event {
+	eventType := EventTypeUpdate
+	if req.ParentVersionID == uuid.Nil {
+		eventType = EventTypeCreate
+	}
+
+	return &Event{
+		UserID:     req.UserID,
+		SecretID:   req.SecretID,
+		SecretName: req.SecretName,
+		VersionID:  req.VersionID,
+		Type:       eventType,
+		ClientInfo: req.ClientInfo,
+		CreatedAt:  req.FinishedAt,
+	}
+}
+
+// NewDeleteEvent creates event of the deleted secret.
+func NewDeleteEvent(tombstone *Tombstone) *Event {
+	return &Event{
+		UserID:     tombstone.UserID,
+		SecretID:   tombstone.SecretID,
+		SecretName: tombstone.SecretName,
+		VersionID:  tombstone.VersionID,
+		Type:       EventTypeDelete,
+		ClientInfo: tombstone.ClientInfo,
+		CreatedAt:  tombstone.DeletedAt,
+	}
+}
+
+// WatchRequest asks to stream events of user secrets recorded after Cursor.
+type WatchRequest struct {
+	UserID uuid.UUID
+	Cursor int64
+}
@@ -0,0 +1,52 @@
+package secret
+
+import "time"
+
+// ExpireReport summarizes one cleanup run of expired upload requests.
+type ExpireReport struct {
+	// Skipped is set when cleanup is already running on another server replica.
+	Skipped bool
+	// Expired is the number of requests moved to completed with expired status.
+	Expired int
+	// OrphanedObjects is the number of uploaded objects not referenced by any secret version.
+	OrphanedObjects int
+	// RemovedObjects is the number of orphaned objects successfully removed from S3.
+	RemovedObjects int
+	// AbortedUploads is the number of expired requests whose incomplete multipart uploads were aborted.
+	AbortedUploads int
+	// RemovedChunks is the number of chunk objects removed as not registered for any committed version.
+	RemovedChunks int
+}
+
+// Add accumulates another cleanup batch into the report.
+func (r *ExpireReport) Add(other *ExpireReport) {
+	r.Expired += other.Expired
+	r.OrphanedObjects += other.OrphanedObjects
+	r.RemovedObjects += other.RemovedObjects
+	r.AbortedUploads += other.AbortedUploads
+	r.RemovedChunks += other.RemovedChunks
+}
+
+// NewExpiredRequest closes upload request in progress as expired by server.
+func NewExpiredRequest(initReq *InitRequest) *CommitRequest {
+	return &CommitRequest{
+		UserID:          initReq.UserID,
+		SecretID:        initReq.SecretID,
+		SecretName:      initReq.SecretName,
+		S3URL:           initReq.S3URL,
+		VersionID:       initReq.VersionID,
+		ParentVersionID: initReq.ParentVersionID,
+		RequestType:     initReq.RequestType,
+		Token:           initReq.Token,
+		ClientInfo:      initReq.ClientInfo,
+		SecretSize:      initReq.SecretSize,
+		SecretHash:      initReq.SecretHash,
+		SecretDEK:       initReq.SecretDEK,
+		CreatedAt:       initReq.CreatedAt,
+		ExpiresAt:       initReq.ExpiresAt,
+		FinishedAt:      time.Now().UTC(),
+		Status:          RequestStatusExpired,
+		CommittedBy:     RequestCommitterServer,
+		MetaData:        initReq.MetaData,
+	}
+}
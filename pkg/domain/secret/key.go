@@ -0,0 +1,13 @@
+package secret
+
+import (
+	"github.com/google/uuid"
+)
+
+// WrappedKey is DEK of the secret version wrapped with the user KEK.
+// Server stores it as is, only the client can unwrap it.
+type WrappedKey struct {
+	SecretID  uuid.UUID
+	VersionID uuid.UUID
+	DEK       []byte
+}
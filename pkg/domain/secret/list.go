@@ -0,0 +1,27 @@
+package secret
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultListPageSize int32 = 100
+	MaxListPageSize     int32 = 1000
+)
+
+// ListRequest describes a page of user secrets ordered by name.
+type ListRequest struct {
+	UserID     uuid.UUID
+	NamePrefix string
+	AfterName  string
+	PageSize   int32
+}
+
+// Limit returns effective page size of the request.
+func (req *ListRequest) Limit() int32 {
+	if req.PageSize <= 0 {
+		return DefaultListPageSize
+	}
+
+	return min(req.PageSize, MaxListPageSize)
+}
@@ -6,9 +6,25 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	// MetaKeyType is the metadata key holding type of the secret content.
+	MetaKeyType = "type"
+	// MetaKeyCompression is the metadata key holding compression codec applied before encryption.
+	MetaKeyCompression = "compression"
+)
+
 //easyjson:json
 type MetaData map[string]string
 
+// Type returns type of the secret content, secrets without type are binary.
+func (m MetaData) Type() Type {
+	if t, ok := m[MetaKeyType]; ok && t != "" {
+		return Type(t)
+	}
+
+	return TypeBinary
+}
+
 //easyjson:json
 type Meta struct {
 	UserID    uuid.UUID `json:"user_id"`
@@ -0,0 +1,27 @@
+package secret
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// RenewRequest asks for fresh S3 credentials of the upload request in progress,
+// so that long multipart uploads can outlive initially issued credentials.
+type RenewRequest struct {
+	UserID   uuid.UUID
+	SecretID uuid.UUID
+	Token    int64
+	User     *user.User
+}
+
+// Validate checks that renew request belongs to the upload request in progress.
+func (req *RenewRequest) Validate(initReq *InitRequest) error {
+	if req.Token != initReq.Token {
+		return fmt.Errorf("[%w] upload token", e.ErrInvalidInput)
+	}
+
+	return nil
+}
@@ -7,12 +7,18 @@ import (
 )
 
 type (
+	Type             string
 	RequestType      string
 	RequestStatus    string
 	RequestCommitter string
 )
 
 const (
+	TypeBinary      Type = "binary"
+	TypeCard        Type = "card"
+	TypeCredentials Type = "credentials"
+	TypeText        Type = "text"
+
 	RequestTypePut RequestType = "put"
 	RequestTypeGet RequestType = "get"
 
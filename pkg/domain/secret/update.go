@@ -1,6 +1,7 @@
 package secret
 
 import (
+	"bytes"
 	"fmt"
 	"time"
 
@@ -12,28 +13,29 @@ import (
 )
 
 type InitRequest struct {
-	UserID          uuid.UUID
-	SecretID        uuid.UUID
-	SecretName      string
-	S3URL           string
-	VersionID       uuid.UUID
-	ParentVersionID uuid.UUID
-	RequestType     RequestType
-	Token           int64
-	ClientInfo      string
-	SecretSize      int64
-	SecretHash      []byte
-	SecretDEK       []byte
-	MetaData        MetaData
-	CreatedAt       time.Time
-	ExpiresAt       time.Time
-	S3Creds         *s3.TemporaryCredentials
-	User            *user.User
-	Version         *Version
+	UserID           uuid.UUID
+	SecretID         uuid.UUID
+	SecretName       string
+	S3URL            string
+	VersionID        uuid.UUID
+	ParentVersionID  uuid.UUID
+	CurrentVersionID uuid.UUID
+	RequestType      RequestType
+	Token            int64
+	ClientInfo       string
+	SecretSize       int64
+	SecretHash       []byte
+	SecretDEK        []byte
+	MetaData         MetaData
+	CreatedAt        time.Time
+	ExpiresAt        time.Time
+	S3Creds          *s3.TemporaryCredentials
+	User             *user.User
+	Version          *Version
 }
 
 func (req *InitRequest) SetExpiration() {
-	req.ExpiresAt = time.Now().UTC().Add(time.Duration(req.UploadDuration()))
+	req.ExpiresAt = time.Now().UTC().Add(time.Duration(req.UploadDuration()) * time.Second)
 }
 
 func (req *InitRequest) SetS3URL(url string) {
@@ -70,9 +72,15 @@ func (req *InitRequest) Validate(kek []byte) error {
 	return nil
 }
 
+// IsExpired reports whether upload window of the request has already passed.
+func (req *InitRequest) IsExpired() bool {
+	return time.Now().UTC().After(req.ExpiresAt)
+}
+
 type CommitRequest struct {
 	UserID          uuid.UUID
 	SecretID        uuid.UUID
+	SecretName      string
 	S3URL           string
 	VersionID       uuid.UUID
 	ParentVersionID uuid.UUID
@@ -87,4 +95,61 @@ type CommitRequest struct {
 	FinishedAt      time.Time
 	Status          RequestStatus
 	CommittedBy     RequestCommitter
+	MetaData        MetaData
+	User            *user.User
+	// PartHashes are SHA-256 checksums of multipart upload parts reported by client,
+	// server committed uploads have none.
+	PartHashes [][]byte
+}
+
+// NewCommitRequest creates request which commits upload request in progress on behalf of committer,
+// e.g. when upload is finalized by S3 notification rather than by the client.
+func NewCommitRequest(initReq *InitRequest, committer RequestCommitter) *CommitRequest {
+	return &CommitRequest{
+		UserID:          initReq.UserID,
+		SecretID:        initReq.SecretID,
+		VersionID:       initReq.VersionID,
+		ParentVersionID: initReq.ParentVersionID,
+		Token:           initReq.Token,
+		SecretSize:      initReq.SecretSize,
+		SecretHash:      initReq.SecretHash,
+		SecretDEK:       initReq.SecretDEK,
+		CommittedBy:     committer,
+		User:            initReq.User,
+	}
+}
+
+// Validate checks that commit request matches the upload request in progress.
+func (req *CommitRequest) Validate(initReq *InitRequest) error {
+	switch {
+	case req.Token != initReq.Token:
+		return fmt.Errorf("[%w] upload token", e.ErrInvalidInput)
+	case req.VersionID != initReq.VersionID:
+		return fmt.Errorf("[%w] secret version", e.ErrInvalidInput)
+	case req.ParentVersionID != initReq.ParentVersionID:
+		return fmt.Errorf("[%w] secret parent version", e.ErrInvalidInput)
+	case req.SecretSize != initReq.SecretSize:
+		return fmt.Errorf("[%w] secret size", e.ErrInvalidInput)
+	case !bytes.Equal(req.SecretHash, initReq.SecretHash):
+		return fmt.Errorf("[%w] secret hash", e.ErrInvalidInput)
+	case !bytes.Equal(req.SecretDEK, initReq.SecretDEK):
+		return fmt.Errorf("[%w] secret dek", e.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// Complete fills commit request with upload request details
+// and marks it as completed by the given committer.
+func (req *CommitRequest) Complete(initReq *InitRequest, committer RequestCommitter) {
+	req.SecretName = initReq.SecretName
+	req.S3URL = initReq.S3URL
+	req.RequestType = initReq.RequestType
+	req.ClientInfo = initReq.ClientInfo
+	req.MetaData = initReq.MetaData
+	req.CreatedAt = initReq.CreatedAt
+	req.ExpiresAt = initReq.ExpiresAt
+	req.FinishedAt = time.Now().UTC()
+	req.Status = RequestStatusCompleted
+	req.CommittedBy = committer
 }
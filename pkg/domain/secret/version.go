@@ -42,3 +42,11 @@ func NewVersion(
 		CreatedAt:  now,
 	}
 }
+
+// History is the chain of secret versions ordered from newest to oldest.
+type History struct {
+	SecretID         uuid.UUID
+	SecretName       string
+	CurrentVersionID uuid.UUID
+	Versions         []*Version
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Device is a client installation user tokens are issued to.
+// Device ID is generated by client at installation and stays the same for its lifetime.
+type Device struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	ClientInfo  string
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+	RevokedAt   time.Time // zero for active device
+}
+
+// NewDevice creates device of the user seen for the first time now.
+func NewDevice(userID, deviceID uuid.UUID, clientInfo string) *Device {
+	now := time.Now().UTC()
+
+	return &Device{
+		ID:          deviceID,
+		UserID:      userID,
+		ClientInfo:  clientInfo,
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	}
+}
+
+// IsRevoked reports whether tokens issued to the device are no longer accepted.
+func (d *Device) IsRevoked() bool {
+	return !d.RevokedAt.IsZero()
+}
@@ -0,0 +1,40 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/auth"
+)
+
+// PasswordChange is the password change started by client and not completed yet.
+// It keeps salt and verifier of the new password, so that interrupted change is resumed
+// with the same salt server may have already accepted.
+type PasswordChange struct {
+	UserID    uuid.UUID
+	Salt      []byte
+	Verifier  []byte
+	CreatedAt time.Time
+}
+
+// NewPasswordChange starts password change of the user to the auth key derived with salt.
+func NewPasswordChange(userID uuid.UUID, authKey, salt []byte) *PasswordChange {
+	return &PasswordChange{
+		UserID:    userID,
+		Salt:      salt,
+		Verifier:  auth.GenerateKeyVerifier(authKey, salt),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// CheckAuthKey verifies that the auth key is derived from the new password.
+func (c *PasswordChange) CheckAuthKey(authKey []byte) bool {
+	return auth.VerifyKeyVerifier(authKey, c.Salt, c.Verifier)
+}
+
+// Apply sets salt and verifier of the new password to the user.
+func (c *PasswordChange) Apply(u *User) {
+	u.Salt = c.Salt
+	u.Verifier = c.Verifier
+	u.UpdatedAt = time.Now().UTC()
+}
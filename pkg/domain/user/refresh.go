@@ -0,0 +1,66 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// refreshTokenSize is the number of random bytes in refresh token.
+const refreshTokenSize = 32
+
+// RefreshToken is an opaque token issued to the device along with short-lived access token,
+// it is exchanged for a new access and refresh token pair once, so that tokens rotate on every refresh.
+// Only hash of the token is kept, token itself is handed to the client once.
+type RefreshToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	DeviceID  uuid.UUID
+	TokenHash []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt time.Time // zero for token not yet used or revoked
+}
+
+// NewRefreshToken generates refresh token of the user device valid for ttl.
+// Returns token record to store along with token string to hand to the client.
+func NewRefreshToken(userID, deviceID uuid.UUID, ttl time.Duration) (*RefreshToken, string, error) {
+	var raw [refreshTokenSize]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return nil, "", fmt.Errorf("[%w] refresh token", e.ErrGenerate)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw[:])
+	now := time.Now().UTC()
+
+	return &RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		DeviceID:  deviceID,
+		TokenHash: HashRefreshToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, token, nil
+}
+
+// HashRefreshToken returns hash refresh token is looked up by.
+// Token carries enough entropy for a plain SHA-256 to be sufficient.
+func HashRefreshToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// IsRevoked reports whether token has been rotated or revoked.
+func (t *RefreshToken) IsRevoked() bool {
+	return !t.RevokedAt.IsZero()
+}
+
+// IsExpired reports whether token is expired at the given time.
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package user_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	userID, deviceID := uuid.New(), uuid.New()
+
+	token, tokenString, err := user.NewRefreshToken(userID, deviceID, time.Hour)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, tokenString)
+	assert.NotContains(t, string(token.TokenHash), tokenString, "Token itself should not be stored")
+	assert.Equal(t, user.HashRefreshToken(tokenString), token.TokenHash)
+	assert.Equal(t, userID, token.UserID)
+	assert.Equal(t, deviceID, token.DeviceID)
+	assert.False(t, token.IsRevoked())
+	assert.False(t, token.IsExpired(time.Now().UTC()))
+	assert.True(t, token.IsExpired(token.ExpiresAt))
+
+	other, otherString, err := user.NewRefreshToken(userID, deviceID, time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, tokenString, otherString, "Tokens should be random")
+	assert.NotEqual(t, token.TokenHash, other.TokenHash)
+}
@@ -1,11 +1,41 @@
 package user
 
-import pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+import (
+	"fmt"
+
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+)
 
 const (
 	RoleUser        = pb.UserRole_USER_ROLE_USER
 	RoleAdmin       = pb.UserRole_USER_ROLE_ADMIN
+	RoleAuditor     = pb.UserRole_USER_ROLE_AUDITOR
+	RoleOperator    = pb.UserRole_USER_ROLE_OPERATOR
 	RoleUnspecified = pb.UserRole_USER_ROLE_UNSPECIFIED
 )
 
 type Role = pb.UserRole
+
+// ParseRole parses role name as stored in token claims, e.g. USER_ROLE_ADMIN.
+func ParseRole(name string) (Role, error) {
+	value, ok := pb.UserRole_value[name]
+	if !ok || Role(value) == RoleUnspecified {
+		return RoleUnspecified, fmt.Errorf("[%w] user role %q", e.ErrInvalidInput, name)
+	}
+
+	return Role(value), nil
+}
+
+// IsStaff reports whether role administers the server rather than stores secrets.
+// Staff users have no bucket.
+func IsStaff(role Role) bool {
+	switch role {
+	case RoleAdmin, RoleAuditor, RoleOperator:
+		return true
+	case RoleUser, RoleUnspecified:
+		return false
+	}
+
+	return false
+}
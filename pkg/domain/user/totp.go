@@ -0,0 +1,74 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+const (
+	// RecoveryCodesCount is the number of recovery codes issued when TOTP is enabled.
+	RecoveryCodesCount = 10
+	// recoveryCodeSize is the number of random bytes in recovery code (80 bits).
+	recoveryCodeSize = 10
+)
+
+// TOTP is the time-based one-time password second factor of the user.
+// Seed is kept wrapped with REK so it is unusable while the server is sealed.
+type TOTP struct {
+	UserID        uuid.UUID
+	WrappedSecret []byte
+	Enabled       bool
+	LastStep      int64 // last accepted time step, codes of earlier steps are replays
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewTOTP returns pending TOTP enrollment of the user with seed wrapped with REK.
+// It is enabled once the user confirms it with a valid code.
+func NewTOTP(userID uuid.UUID, wrappedSecret []byte) *TOTP {
+	now := time.Now().UTC()
+
+	return &TOTP{
+		UserID:        userID,
+		WrappedSecret: wrappedSecret,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// NewRecoveryCodes generates single-use recovery codes replacing TOTP code on login
+// when authenticator device is lost. Returns codes to hand to the user along with hashes to store.
+func NewRecoveryCodes() ([]string, [][]byte, error) {
+	codes := make([]string, RecoveryCodesCount)
+	hashes := make([][]byte, RecoveryCodesCount)
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	for i := range codes {
+		var raw [recoveryCodeSize]byte
+		if _, err := rand.Read(raw[:]); err != nil {
+			return nil, nil, fmt.Errorf("[%w] recovery code", e.ErrGenerate)
+		}
+
+		code := strings.ToLower(encoding.EncodeToString(raw[:]))
+		codes[i] = code[:8] + "-" + code[8:]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns hash recovery code is looked up by.
+// Code is normalized so that case and dashes typed by the user do not matter.
+func HashRecoveryCode(code string) []byte {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+
+	return sum[:]
+}
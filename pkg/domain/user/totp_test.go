@@ -0,0 +1,32 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	codes, hashes, err := user.NewRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, user.RecoveryCodesCount)
+	require.Len(t, hashes, user.RecoveryCodesCount)
+
+	seen := make(map[string]bool, len(codes))
+
+	for i, code := range codes {
+		assert.Len(t, code, 17, "Code should be two dash separated groups")
+		assert.Equal(t, hashes[i], user.HashRecoveryCode(code))
+		assert.False(t, seen[code], "Codes should be unique")
+
+		seen[code] = true
+	}
+
+	typed := strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))
+	assert.Equal(t, hashes[0], user.HashRecoveryCode(" "+typed+" "), "Case and dashes should not matter")
+}
@@ -13,7 +13,8 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-const passHashLength = 16
+// SaltLength is the length of the salt KEK and auth key are derived with.
+const SaltLength = 16
 
 // User represents an application user with credentials and metadata.
 type User struct {
@@ -22,9 +23,9 @@ type User struct {
 	Role       Role      `json:"role"`        // Role assigned to the user
 	CreatedAt  time.Time `json:"created_at"`  // Timestamp of user creation
 	UpdatedAt  time.Time `json:"updated_at"`  // Timestamp of last user update
-	Password   []byte    `json:"-"`           // Bcrypt-hashed password (not exposed in JSON)
-	Salt       []byte    `json:"salt"`        // Random salt used for verifier generation
-	Verifier   []byte    `json:"verifier"`    // HMAC-based verifier derived from password and salt
+	Password   []byte    `json:"-"`           // Bcrypt-hashed password of legacy user (not exposed in JSON)
+	Salt       []byte    `json:"salt"`        // Random salt used for KEK derivation
+	Verifier   []byte    `json:"verifier"`    // HMAC-based verifier derived from auth key and salt
 	BucketName string    `json:"bucket_name"` // Name of the user's S3 bucket
 	IdentityID string    `json:"identity_id"` // External identity provider user ID
 	mu         sync.Mutex
@@ -72,12 +73,46 @@ func NewWithID(id string, username string, role Role) (*User, error) {
 	}, nil
 }
 
-// SetPassword hashes the given password, generates a salt and verifier, and updates the user.
-func (u *User) SetPassword(password string) error {
-	salt := make([]byte, passHashLength)
+// NewSalt generates random salt of the user KEK.
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, SaltLength)
 
 	if _, err := rand.Read(salt); err != nil {
-		return e.ErrGenerate
+		return nil, e.ErrGenerate
+	}
+
+	return salt, nil
+}
+
+// SetAuthKey sets salt and verifier of the auth key derived by client, password hash is dropped.
+// Server authenticates user with the auth key and never learns the password or KEK.
+func (u *User) SetAuthKey(authKey, salt []byte) {
+	u.Password = []byte{}
+	u.Salt = salt
+	u.Verifier = auth.GenerateKeyVerifier(authKey, salt)
+}
+
+// CheckAuthKey verifies that the provided auth key matches the stored verifier.
+func (u *User) CheckAuthKey(authKey []byte) bool {
+	if len(u.Salt) == 0 || len(u.Verifier) == 0 || len(authKey) == 0 {
+		return false
+	}
+
+	return auth.VerifyKeyVerifier(authKey, u.Salt, u.Verifier)
+}
+
+// IsLegacy reports whether user was registered before auth keys, i.e. server keeps password hash
+// and verifier is derived from the password. Legacy user is migrated to auth key on the next login.
+func (u *User) IsLegacy() bool {
+	return len(u.Password) != 0
+}
+
+// SetPassword hashes the given password, generates a salt and verifier, and updates the user.
+// It sets up legacy user, see SetAuthKey.
+func (u *User) SetPassword(password string) error {
+	salt, err := NewSalt()
+	if err != nil {
+		return err
 	}
 
 	hashedPass, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -51,4 +51,19 @@ func TestUser(t *testing.T) {
 		_, err = user.NewWithID("invalid-uuid", "denis", user.RoleUser)
 		require.Error(t, err, "Should return error on invalid UUID")
 	})
+	t.Run("PasswordChange applies verifier of the new auth key", func(t *testing.T) {
+		t.Parallel()
+
+		usr := user.New("changer", user.RoleUser)
+		usr.SetAuthKey([]byte("old auth key"), []byte("old salt"))
+
+		change := user.NewPasswordChange(usr.ID, []byte("new auth key"), []byte("new salt"))
+		assert.True(t, change.CheckAuthKey([]byte("new auth key")))
+		assert.False(t, change.CheckAuthKey([]byte("old auth key")))
+
+		change.Apply(usr)
+
+		assert.True(t, usr.CheckAuthKey([]byte("new auth key")), "New auth key should match")
+		assert.False(t, usr.CheckAuthKey([]byte("old auth key")), "Old auth key should not match")
+	})
 }
@@ -5,12 +5,21 @@ import "github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 //easyjson:json
 type UserCredentials struct {
 	Username string `json:"login"`
-	Password string `json:"password"`
+	Password string `json:"password,omitempty"` // Only presented by legacy user to migrate to auth key
+	AuthKey  []byte `json:"auth_key"`
 }
 
 //easyjson:json
 type RegisterUserCredentials struct {
 	Username string    `json:"login"`
-	Password string    `json:"password"`
+	AuthKey  []byte    `json:"auth_key"`
+	Salt     []byte    `json:"salt"`
 	Role     user.Role `json:"role"`
 }
+
+//easyjson:json
+type ChangePasswordCredentials struct {
+	AuthKey    []byte `json:"auth_key"`
+	NewAuthKey []byte `json:"new_auth_key"`
+	NewSalt    []byte `json:"new_salt"`
+}
@@ -8,6 +8,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+
 	_v1 "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
 )
 
@@ -42,6 +43,13 @@ func easyjson5b679028DecodeGithubComPatradenYaPracticumGophkeeperPkgDto(in *jlex
 			out.Username = string(in.String())
 		case "password":
 			out.Password = string(in.String())
+		case "auth_key":
+			if in.IsNull() {
+				in.Skip()
+				out.AuthKey = nil
+			} else {
+				out.AuthKey = in.Bytes()
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -61,11 +69,16 @@ func easyjson5b679028EncodeGithubComPatradenYaPracticumGophkeeperPkgDto(out *jwr
 		out.RawString(prefix[1:])
 		out.String(string(in.Username))
 	}
-	{
+	if in.Password != "" {
 		const prefix string = ",\"password\":"
 		out.RawString(prefix)
 		out.String(string(in.Password))
 	}
+	{
+		const prefix string = ",\"auth_key\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.AuthKey)
+	}
 	out.RawByte('}')
 }
 
@@ -113,8 +126,20 @@ func easyjson5b679028DecodeGithubComPatradenYaPracticumGophkeeperPkgDto1(in *jle
 		switch key {
 		case "login":
 			out.Username = string(in.String())
-		case "password":
-			out.Password = string(in.String())
+		case "auth_key":
+			if in.IsNull() {
+				in.Skip()
+				out.AuthKey = nil
+			} else {
+				out.AuthKey = in.Bytes()
+			}
+		case "salt":
+			if in.IsNull() {
+				in.Skip()
+				out.Salt = nil
+			} else {
+				out.Salt = in.Bytes()
+			}
 		case "role":
 			out.Role = _v1.UserRole(in.Int32())
 		default:
@@ -137,9 +162,14 @@ func easyjson5b679028EncodeGithubComPatradenYaPracticumGophkeeperPkgDto1(out *jw
 		out.String(string(in.Username))
 	}
 	{
-		const prefix string = ",\"password\":"
+		const prefix string = ",\"auth_key\":"
 		out.RawString(prefix)
-		out.String(string(in.Password))
+		out.Base64Bytes(in.AuthKey)
+	}
+	{
+		const prefix string = ",\"salt\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Salt)
 	}
 	{
 		const prefix string = ",\"role\":"
@@ -172,3 +202,98 @@ func (v *RegisterUserCredentials) UnmarshalJSON(data []byte) error {
 func (v *RegisterUserCredentials) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson5b679028DecodeGithubComPatradenYaPracticumGophkeeperPkgDto1(l, v)
 }
+func easyjson5b679028DecodeGithubComPatradenYaPracticumGophkeeperPkgDto2(in *jlexer.Lexer, out *ChangePasswordCredentials) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "auth_key":
+			if in.IsNull() {
+				in.Skip()
+				out.AuthKey = nil
+			} else {
+				out.AuthKey = in.Bytes()
+			}
+		case "new_auth_key":
+			if in.IsNull() {
+				in.Skip()
+				out.NewAuthKey = nil
+			} else {
+				out.NewAuthKey = in.Bytes()
+			}
+		case "new_salt":
+			if in.IsNull() {
+				in.Skip()
+				out.NewSalt = nil
+			} else {
+				out.NewSalt = in.Bytes()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5b679028EncodeGithubComPatradenYaPracticumGophkeeperPkgDto2(out *jwriter.Writer, in ChangePasswordCredentials) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"auth_key\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.AuthKey)
+	}
+	{
+		const prefix string = ",\"new_auth_key\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.NewAuthKey)
+	}
+	{
+		const prefix string = ",\"new_salt\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.NewSalt)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChangePasswordCredentials) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5b679028EncodeGithubComPatradenYaPracticumGophkeeperPkgDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChangePasswordCredentials) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5b679028EncodeGithubComPatradenYaPracticumGophkeeperPkgDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChangePasswordCredentials) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5b679028DecodeGithubComPatradenYaPracticumGophkeeperPkgDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChangePasswordCredentials) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5b679028DecodeGithubComPatradenYaPracticumGophkeeperPkgDto2(l, v)
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DeviceInfo represents device user tokens are issued to.
+type DeviceInfo struct {
+	DeviceID    string    `json:"device_id"`
+	ClientInfo  string    `json:"client_info"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	RevokedAt   time.Time `json:"revoked_at,omitempty"`
+	Current     bool      `json:"current"`
+}
+
+// DeviceInfoFromDomain converts domain device, currentDeviceID marks the caller device.
+func DeviceInfoFromDomain(device *user.Device, currentDeviceID string) DeviceInfo {
+	return DeviceInfo{
+		DeviceID:    device.ID.String(),
+		ClientInfo:  device.ClientInfo,
+		FirstSeenAt: device.FirstSeenAt,
+		LastSeenAt:  device.LastSeenAt,
+		RevokedAt:   device.RevokedAt,
+		Current:     device.ID.String() == currentDeviceID,
+	}
+}
+
+// DeviceInfoFromProto converts protobuf device.
+func DeviceInfoFromProto(device *pb.Device) DeviceInfo {
+	info := DeviceInfo{
+		DeviceID:    device.GetDeviceId(),
+		ClientInfo:  device.GetClientInfo(),
+		FirstSeenAt: device.GetFirstSeenAt().AsTime(),
+		LastSeenAt:  device.GetLastSeenAt().AsTime(),
+		Current:     device.GetCurrent(),
+	}
+
+	if device.GetRevokedAt() != nil {
+		info.RevokedAt = device.GetRevokedAt().AsTime()
+	}
+
+	return info
+}
+
+// ToProto converts device info to protobuf message.
+func (info *DeviceInfo) ToProto() *pb.Device {
+	device := &pb.Device{
+		DeviceId:    info.DeviceID,
+		ClientInfo:  info.ClientInfo,
+		FirstSeenAt: timestamppb.New(info.FirstSeenAt),
+		LastSeenAt:  timestamppb.New(info.LastSeenAt),
+		Current:     info.Current,
+	}
+
+	if !info.RevokedAt.IsZero() {
+		device.RevokedAt = timestamppb.New(info.RevokedAt)
+	}
+
+	return device
+}
+
+// IsRevoked reports whether tokens issued to the device are no longer accepted.
+func (info *DeviceInfo) IsRevoked() bool {
+	return !info.RevokedAt.IsZero()
+}
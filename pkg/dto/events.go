@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SecretEvent represents change of the user secret in the change feed.
+type SecretEvent struct {
+	Cursor     int64            `json:"cursor"`
+	Type       secret.EventType `json:"type"`
+	SecretID   string           `json:"secret_id"`
+	SecretName string           `json:"secret_name"`
+	VersionID  string           `json:"version_id"`
+	ClientInfo string           `json:"client_info"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+var (
+	eventTypeToProto = map[secret.EventType]pb.SecretEventType{
+		secret.EventTypeCreate: pb.SecretEventType_SECRET_EVENT_TYPE_CREATE,
+		secret.EventTypeUpdate: pb.SecretEventType_SECRET_EVENT_TYPE_UPDATE,
+		secret.EventTypeDelete: pb.SecretEventType_SECRET_EVENT_TYPE_DELETE,
+	}
+	eventTypeFromProto = map[pb.SecretEventType]secret.EventType{
+		pb.SecretEventType_SECRET_EVENT_TYPE_CREATE: secret.EventTypeCreate,
+		pb.SecretEventType_SECRET_EVENT_TYPE_UPDATE: secret.EventTypeUpdate,
+		pb.SecretEventType_SECRET_EVENT_TYPE_DELETE: secret.EventTypeDelete,
+	}
+)
+
+func SecretEventFromDomain(ev *secret.Event) SecretEvent {
+	return SecretEvent{
+		Cursor:     ev.Cursor,
+		Type:       ev.Type,
+		SecretID:   ev.SecretID.String(),
+		SecretName: ev.SecretName,
+		VersionID:  ev.VersionID.String(),
+		ClientInfo: ev.ClientInfo,
+		CreatedAt:  ev.CreatedAt,
+	}
+}
+
+func SecretEventFromProto(ev *pb.SecretEvent) SecretEvent {
+	return SecretEvent{
+		Cursor:     ev.GetCursor(),
+		Type:       eventTypeFromProto[ev.GetType()],
+		SecretID:   ev.GetSecretId(),
+		SecretName: ev.GetSecretName(),
+		VersionID:  ev.GetVersionId(),
+		ClientInfo: ev.GetClientInfo(),
+		CreatedAt:  ev.GetCreatedAt().AsTime(),
+	}
+}
+
+func (ev *SecretEvent) ToProto() *pb.SecretEvent {
+	return &pb.SecretEvent{
+		Cursor:     ev.Cursor,
+		Type:       eventTypeToProto[ev.Type],
+		SecretId:   ev.SecretID,
+		SecretName: ev.SecretName,
+		VersionId:  ev.VersionID,
+		ClientInfo: ev.ClientInfo,
+		CreatedAt:  timestamppb.New(ev.CreatedAt),
+	}
+}
+
+// WatchSecretsRequest represents request to stream user secret events recorded after Cursor.
+type WatchSecretsRequest struct {
+	UserID string `json:"user_id"`
+	Cursor int64  `json:"cursor"`
+}
+
+func WatchSecretsRequestFromProto(req *pb.WatchSecretsRequest) *WatchSecretsRequest {
+	return &WatchSecretsRequest{
+		UserID: req.GetUserId(),
+		Cursor: req.GetCursor(),
+	}
+}
+
+func (r *WatchSecretsRequest) ToDomain() (*secret.WatchRequest, error) {
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid userID", e.ErrValidation)
+	}
+
+	return &secret.WatchRequest{
+		UserID: userID,
+		Cursor: r.Cursor,
+	}, nil
+}
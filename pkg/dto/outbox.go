@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// OutboxOperationType is a kind of secret change waiting to be pushed to the server.
+type OutboxOperationType string
+
+const (
+	OutboxCreate OutboxOperationType = "create"
+	OutboxUpdate OutboxOperationType = "update"
+	OutboxDelete OutboxOperationType = "delete"
+)
+
+// OutboxOperation is a secret change made while offline and kept by the client until the server accepts it.
+// VersionID is the client generated version pushed by create and update or the version deleted by delete,
+// so that replaying the same operation more than once has no additional effect.
+type OutboxOperation struct {
+	Seq        int64
+	UserID     string
+	SecretID   string
+	SecretName string
+	VersionID  string
+	Operation  OutboxOperationType
+	Attempts   int64
+	LastError  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewOutboxOperation creates operation pushing the current version of the local secret
+// or deleting it on the server if op is OutboxDelete.
+func NewOutboxOperation(scrt *Secret, op OutboxOperationType) *OutboxOperation {
+	now := time.Now().UTC()
+
+	return &OutboxOperation{
+		UserID:     scrt.UserID,
+		SecretID:   scrt.ID,
+		SecretName: scrt.SecretName,
+		VersionID:  scrt.VersionID,
+		Operation:  op,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
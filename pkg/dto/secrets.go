@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/base64"
 	"fmt"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // SecretUploadInitRequest represents an upload request that is in progress.
@@ -116,15 +118,16 @@ func (resp *SecretUploadInitResponse) ToProto() *pb.SecretUpdateInitResponse {
 
 // SecretUploadCommitRequest represents a finalized and committed upload.
 type SecretUploadCommitRequest struct {
-	UserID          string `json:"user_id"`
-	SecretID        string `json:"secret_id"`
-	VersionID       string `json:"version_id"`
-	ParentVersionID string `json:"parent_version_id,omitempty"`
-	ClientInfo      string `json:"client_info"`
-	SecretSize      int64  `json:"secret_size"`
-	SecretHash      []byte `json:"secret_hash,omitempty"`
-	SecretDEK       []byte `json:"secret_dek,omitempty"`
-	Token           int64  `json:"token"`
+	UserID          string   `json:"user_id"`
+	SecretID        string   `json:"secret_id"`
+	VersionID       string   `json:"version_id"`
+	ParentVersionID string   `json:"parent_version_id,omitempty"`
+	ClientInfo      string   `json:"client_info"`
+	SecretSize      int64    `json:"secret_size"`
+	SecretHash      []byte   `json:"secret_hash,omitempty"`
+	SecretDEK       []byte   `json:"secret_dek,omitempty"`
+	Token           int64    `json:"token"`
+	PartHashes      [][]byte `json:"part_hashes,omitempty"`
 }
 
 func SecretUploadCommitRequestFromProto(req *pb.SecretUpdateCommitRequest) *SecretUploadCommitRequest {
@@ -138,6 +141,7 @@ func SecretUploadCommitRequestFromProto(req *pb.SecretUpdateCommitRequest) *Secr
 		SecretHash:      req.GetHash(),
 		SecretDEK:       req.GetEncryptedDek(),
 		Token:           req.GetToken(),
+		PartHashes:      req.GetPartHashes(),
 	}
 }
 
@@ -179,9 +183,626 @@ func (r *SecretUploadCommitRequest) ToDomain() (*secret.CommitRequest, error) {
 		SecretHash:      r.SecretHash,
 		SecretDEK:       r.SecretDEK,
 		Token:           r.Token,
+		PartHashes:      r.PartHashes,
+	}, nil
+}
+
+// SecretUploadCommitResponse represents response to secret upload commit request.
+type SecretUploadCommitResponse struct {
+	UserID     string `json:"user_id"`
+	SecretID   string `json:"secret_id"`
+	SecretName string `json:"secret_name"`
+	VersionID  string `json:"version_id"`
+}
+
+func (resp *SecretUploadCommitResponse) ToProto() *pb.SecretUpdateCommitResponse {
+	return &pb.SecretUpdateCommitResponse{
+		UserId:     resp.UserID,
+		SecretId:   resp.SecretID,
+		SecretName: resp.SecretName,
+		VersionId:  resp.VersionID,
+	}
+}
+
+// RenewUploadCredentialsRequest represents request for fresh S3 credentials of the upload in progress.
+type RenewUploadCredentialsRequest struct {
+	UserID   string `json:"user_id"`
+	SecretID string `json:"secret_id"`
+	Token    int64  `json:"token"`
+}
+
+func RenewUploadCredentialsRequestFromProto(req *pb.RenewUploadCredentialsRequest) *RenewUploadCredentialsRequest {
+	return &RenewUploadCredentialsRequest{
+		UserID:   req.GetUserId(),
+		SecretID: req.GetSecretId(),
+		Token:    req.GetToken(),
+	}
+}
+
+func (r *RenewUploadCredentialsRequest) ToDomain() (*secret.RenewRequest, error) {
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid userID", e.ErrValidation)
+	}
+
+	secretID, err := uuid.Parse(r.SecretID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid secretID", e.ErrValidation)
+	}
+
+	return &secret.RenewRequest{
+		UserID:   userID,
+		SecretID: secretID,
+		Token:    r.Token,
+	}, nil
+}
+
+// SecretDownloadInitRequest represents request to read a version of the secret.
+// Empty VersionID means current version.
+type SecretDownloadInitRequest struct {
+	UserID     string `json:"user_id"`
+	SecretName string `json:"secret_name"`
+	VersionID  string `json:"version_id,omitempty"`
+	ClientInfo string `json:"client_info"`
+}
+
+func SecretDownloadInitRequestFromProto(req *pb.SecretGetInitRequest) *SecretDownloadInitRequest {
+	return &SecretDownloadInitRequest{
+		UserID:     req.GetUserId(),
+		SecretName: req.GetSecretName(),
+		ClientInfo: req.GetClientInfo(),
+	}
+}
+
+func GetSecretVersionRequestFromProto(req *pb.GetSecretVersionRequest) *SecretDownloadInitRequest {
+	return &SecretDownloadInitRequest{
+		UserID:     req.GetUserId(),
+		SecretName: req.GetSecretName(),
+		VersionID:  req.GetVersionId(),
+		ClientInfo: req.GetClientInfo(),
+	}
+}
+
+func (r *SecretDownloadInitRequest) ToDomain() (*secret.InitRequest, error) {
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid userID", e.ErrValidation)
+	}
+
+	if r.SecretName == "" {
+		return nil, fmt.Errorf("[%w] empty secret name", e.ErrValidation)
+	}
+
+	var versionID uuid.UUID
+
+	if r.VersionID != "" {
+		versionID, err = uuid.Parse(r.VersionID)
+		if err != nil {
+			return nil, fmt.Errorf("[%w] invalid versionID", e.ErrValidation)
+		}
+	}
+
+	return &secret.InitRequest{
+		UserID:      userID,
+		SecretName:  r.SecretName,
+		VersionID:   versionID,
+		RequestType: secret.RequestTypeGet,
+		ClientInfo:  r.ClientInfo,
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// SecretDownloadInitResponse represents response to secret read request.
+type SecretDownloadInitResponse struct {
+	UserID           string `json:"user_id"`
+	SecretID         string `json:"secret_id"`
+	SecretName       string `json:"secret_name"`
+	VersionID        string `json:"version_id"`
+	ParentVersionID  string `json:"parent_version_id,omitempty"`
+	S3URL            string `json:"s3_url"`
+	SecretSize       int64  `json:"secret_size"`
+	SecretHash       []byte `json:"secret_hash,omitempty"`
+	SecretDEK        []byte `json:"secret_dek,omitempty"`
+	MetaData         string `json:"meta,omitempty"`
+	CurrentVersionID string `json:"current_version_id"`
+	S3Creds          s3.TemporaryCredentials
+}
+
+func SecretDownloadInitResponseFromProto(resp *pb.SecretGetInitResponse) *SecretDownloadInitResponse {
+	return &SecretDownloadInitResponse{
+		UserID:           resp.GetUserId(),
+		SecretID:         resp.GetSecretId(),
+		SecretName:       resp.GetSecretName(),
+		VersionID:        resp.GetVersionId(),
+		ParentVersionID:  resp.GetParentVersionId(),
+		S3URL:            resp.GetS3Url(),
+		SecretSize:       resp.GetSize(),
+		SecretHash:       resp.GetHash(),
+		SecretDEK:        resp.GetEncryptedDek(),
+		MetaData:         resp.GetMetadataJson(),
+		CurrentVersionID: resp.GetVersionId(),
+		S3Creds:          s3.TemporaryCredentialsFromProto(resp.GetCredentials()),
+	}
+}
+
+func GetSecretVersionResponseFromProto(resp *pb.GetSecretVersionResponse) *SecretDownloadInitResponse {
+	return &SecretDownloadInitResponse{
+		UserID:           resp.GetUserId(),
+		SecretID:         resp.GetSecretId(),
+		SecretName:       resp.GetSecretName(),
+		VersionID:        resp.GetVersionId(),
+		ParentVersionID:  resp.GetParentVersionId(),
+		S3URL:            resp.GetS3Url(),
+		SecretSize:       resp.GetSize(),
+		SecretHash:       resp.GetHash(),
+		SecretDEK:        resp.GetEncryptedDek(),
+		MetaData:         resp.GetMetadataJson(),
+		CurrentVersionID: resp.GetCurrentVersionId(),
+		S3Creds:          s3.TemporaryCredentialsFromProto(resp.GetCredentials()),
+	}
+}
+
+func (resp *SecretDownloadInitResponse) ToVersionProto() *pb.GetSecretVersionResponse {
+	return &pb.GetSecretVersionResponse{
+		UserId:           resp.UserID,
+		SecretId:         resp.SecretID,
+		SecretName:       resp.SecretName,
+		VersionId:        resp.VersionID,
+		ParentVersionId:  resp.ParentVersionID,
+		S3Url:            resp.S3URL,
+		Size:             resp.SecretSize,
+		Hash:             resp.SecretHash,
+		EncryptedDek:     resp.SecretDEK,
+		MetadataJson:     resp.MetaData,
+		Credentials:      resp.S3Creds.ToProto(),
+		CurrentVersionId: resp.CurrentVersionID,
+	}
+}
+
+func (resp *SecretDownloadInitResponse) ToProto() *pb.SecretGetInitResponse {
+	return &pb.SecretGetInitResponse{
+		UserId:          resp.UserID,
+		SecretId:        resp.SecretID,
+		SecretName:      resp.SecretName,
+		VersionId:       resp.VersionID,
+		ParentVersionId: resp.ParentVersionID,
+		S3Url:           resp.S3URL,
+		Size:            resp.SecretSize,
+		Hash:            resp.SecretHash,
+		EncryptedDek:    resp.SecretDEK,
+		MetadataJson:    resp.MetaData,
+		Credentials:     resp.S3Creds.ToProto(),
+	}
+}
+
+// ListSecretsRequest represents request for a page of user secrets.
+type ListSecretsRequest struct {
+	UserID     string `json:"user_id"`
+	NamePrefix string `json:"name_prefix,omitempty"`
+	PageSize   int32  `json:"page_size,omitempty"`
+	PageToken  string `json:"page_token,omitempty"`
+}
+
+func ListSecretsRequestFromProto(req *pb.ListSecretsRequest) *ListSecretsRequest {
+	return &ListSecretsRequest{
+		UserID:     req.GetUserId(),
+		NamePrefix: req.GetNamePrefix(),
+		PageSize:   req.GetPageSize(),
+		PageToken:  req.GetPageToken(),
+	}
+}
+
+func (r *ListSecretsRequest) ToDomain() (*secret.ListRequest, error) {
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid userID", e.ErrValidation)
+	}
+
+	afterName, err := DecodePageToken(r.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret.ListRequest{
+		UserID:     userID,
+		NamePrefix: r.NamePrefix,
+		AfterName:  afterName,
+		PageSize:   r.PageSize,
+	}, nil
+}
+
+// EncodePageToken builds opaque pagination cursor from the last listed secret name.
+func EncodePageToken(lastName string) string {
+	if lastName == "" {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(lastName))
+}
+
+// DecodePageToken restores last listed secret name from pagination cursor.
+func DecodePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	name, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("[%w] invalid page token", e.ErrValidation)
+	}
+
+	return string(name), nil
+}
+
+// SecretInfo represents listed secret with its current version details.
+type SecretInfo struct {
+	SecretID        string    `json:"secret_id"`
+	SecretName      string    `json:"secret_name"`
+	VersionID       string    `json:"version_id"`
+	ParentVersionID string    `json:"parent_version_id,omitempty"`
+	SecretSize      int64     `json:"secret_size"`
+	SecretHash      []byte    `json:"secret_hash,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func SecretInfoFromDomain(scrt *secret.Secret) SecretInfo {
+	info := SecretInfo{
+		SecretID:   scrt.ID.String(),
+		SecretName: scrt.Name,
+		VersionID:  scrt.CurrentVersionID.String(),
+		CreatedAt:  scrt.CreatedAt,
+		UpdatedAt:  scrt.UpdatedAt,
+	}
+
+	if scrt.CurrentVersion != nil {
+		info.SecretSize = scrt.CurrentVersion.SecretSize
+		info.SecretHash = scrt.CurrentVersion.SecretHash
+
+		if scrt.CurrentVersion.ParentID != uuid.Nil {
+			info.ParentVersionID = scrt.CurrentVersion.ParentID.String()
+		}
+	}
+
+	return info
+}
+
+func SecretInfoFromProto(info *pb.SecretInfo) SecretInfo {
+	return SecretInfo{
+		SecretID:        info.GetSecretId(),
+		SecretName:      info.GetSecretName(),
+		VersionID:       info.GetVersionId(),
+		ParentVersionID: info.GetParentVersionId(),
+		SecretSize:      info.GetSize(),
+		SecretHash:      info.GetHash(),
+		CreatedAt:       info.GetCreatedAt().AsTime(),
+		UpdatedAt:       info.GetUpdatedAt().AsTime(),
+	}
+}
+
+func (info *SecretInfo) ToProto() *pb.SecretInfo {
+	return &pb.SecretInfo{
+		SecretId:        info.SecretID,
+		SecretName:      info.SecretName,
+		VersionId:       info.VersionID,
+		ParentVersionId: info.ParentVersionID,
+		Size:            info.SecretSize,
+		Hash:            info.SecretHash,
+		CreatedAt:       timestamppb.New(info.CreatedAt),
+		UpdatedAt:       timestamppb.New(info.UpdatedAt),
+	}
+}
+
+// ListSecretsResponse represents a page of user secrets.
+type ListSecretsResponse struct {
+	Secrets       []SecretInfo `json:"secrets"`
+	NextPageToken string       `json:"next_page_token,omitempty"`
+}
+
+func (resp *ListSecretsResponse) ToProto() *pb.ListSecretsResponse {
+	secrets := make([]*pb.SecretInfo, 0, len(resp.Secrets))
+	for i := range resp.Secrets {
+		secrets = append(secrets, resp.Secrets[i].ToProto())
+	}
+
+	return &pb.ListSecretsResponse{
+		Secrets:       secrets,
+		NextPageToken: resp.NextPageToken,
+	}
+}
+
+// ListSecretVersionsRequest represents request for history of the secret.
+type ListSecretVersionsRequest struct {
+	UserID     string `json:"user_id"`
+	SecretName string `json:"secret_name"`
+}
+
+func ListSecretVersionsRequestFromProto(req *pb.ListSecretVersionsRequest) *ListSecretVersionsRequest {
+	return &ListSecretVersionsRequest{
+		UserID:     req.GetUserId(),
+		SecretName: req.GetSecretName(),
+	}
+}
+
+// ToDomain returns secret identified by the request.
+func (r *ListSecretVersionsRequest) ToDomain() (*secret.Secret, error) {
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid userID", e.ErrValidation)
+	}
+
+	if r.SecretName == "" {
+		return nil, fmt.Errorf("[%w] empty secret name", e.ErrValidation)
+	}
+
+	return &secret.Secret{
+		UserID: userID,
+		Name:   r.SecretName,
 	}, nil
 }
 
+// SecretVersionInfo represents a single secret version in history.
+type SecretVersionInfo struct {
+	VersionID       string    `json:"version_id"`
+	ParentVersionID string    `json:"parent_version_id,omitempty"`
+	SecretSize      int64     `json:"secret_size"`
+	SecretHash      []byte    `json:"secret_hash,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ListSecretVersionsResponse represents history of the secret.
+type ListSecretVersionsResponse struct {
+	SecretID         string              `json:"secret_id"`
+	SecretName       string              `json:"secret_name"`
+	CurrentVersionID string              `json:"current_version_id"`
+	Versions         []SecretVersionInfo `json:"versions"`
+}
+
+func ListSecretVersionsResponseFromDomain(history *secret.History) *ListSecretVersionsResponse {
+	resp := &ListSecretVersionsResponse{
+		SecretID:         history.SecretID.String(),
+		SecretName:       history.SecretName,
+		CurrentVersionID: history.CurrentVersionID.String(),
+		Versions:         make([]SecretVersionInfo, 0, len(history.Versions)),
+	}
+
+	for _, ver := range history.Versions {
+		info := SecretVersionInfo{
+			VersionID:  ver.ID.String(),
+			SecretSize: ver.SecretSize,
+			SecretHash: ver.SecretHash,
+			CreatedAt:  ver.CreatedAt,
+		}
+
+		if ver.ParentID != uuid.Nil {
+			info.ParentVersionID = ver.ParentID.String()
+		}
+
+		resp.Versions = append(resp.Versions, info)
+	}
+
+	return resp
+}
+
+func ListSecretVersionsResponseFromProto(resp *pb.ListSecretVersionsResponse) *ListSecretVersionsResponse {
+	versions := make([]SecretVersionInfo, 0, len(resp.GetVersions()))
+	for _, ver := range resp.GetVersions() {
+		versions = append(versions, SecretVersionInfo{
+			VersionID:       ver.GetVersionId(),
+			ParentVersionID: ver.GetParentVersionId(),
+			SecretSize:      ver.GetSize(),
+			SecretHash:      ver.GetHash(),
+			CreatedAt:       ver.GetCreatedAt().AsTime(),
+		})
+	}
+
+	return &ListSecretVersionsResponse{
+		SecretID:         resp.GetSecretId(),
+		SecretName:       resp.GetSecretName(),
+		CurrentVersionID: resp.GetCurrentVersionId(),
+		Versions:         versions,
+	}
+}
+
+func (resp *ListSecretVersionsResponse) ToProto() *pb.ListSecretVersionsResponse {
+	versions := make([]*pb.SecretVersionInfo, 0, len(resp.Versions))
+	for _, ver := range resp.Versions {
+		versions = append(versions, &pb.SecretVersionInfo{
+			VersionId:       ver.VersionID,
+			ParentVersionId: ver.ParentVersionID,
+			Size:            ver.SecretSize,
+			Hash:            ver.SecretHash,
+			CreatedAt:       timestamppb.New(ver.CreatedAt),
+		})
+	}
+
+	return &pb.ListSecretVersionsResponse{
+		SecretId:         resp.SecretID,
+		SecretName:       resp.SecretName,
+		CurrentVersionId: resp.CurrentVersionID,
+		Versions:         versions,
+	}
+}
+
+// SecretDeleteRequest represents request to delete the secret at its expected current version.
+type SecretDeleteRequest struct {
+	UserID     string `json:"user_id"`
+	SecretName string `json:"secret_name"`
+	VersionID  string `json:"version_id"`
+	ClientInfo string `json:"client_info"`
+}
+
+func SecretDeleteRequestFromProto(req *pb.SecretDeleteRequest) *SecretDeleteRequest {
+	return &SecretDeleteRequest{
+		UserID:     req.GetUserId(),
+		SecretName: req.GetSecretName(),
+		VersionID:  req.GetVersionId(),
+		ClientInfo: req.GetClientInfo(),
+	}
+}
+
+func (r *SecretDeleteRequest) ToDomain() (*secret.DeleteRequest, error) {
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid userID", e.ErrValidation)
+	}
+
+	versionID, err := uuid.Parse(r.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid versionID", e.ErrValidation)
+	}
+
+	if r.SecretName == "" {
+		return nil, fmt.Errorf("[%w] empty secret name", e.ErrValidation)
+	}
+
+	return &secret.DeleteRequest{
+		UserID:     userID,
+		SecretName: r.SecretName,
+		VersionID:  versionID,
+		ClientInfo: r.ClientInfo,
+	}, nil
+}
+
+// SecretTombstone represents deleted secret.
+type SecretTombstone struct {
+	SecretID   string    `json:"secret_id"`
+	SecretName string    `json:"secret_name"`
+	VersionID  string    `json:"version_id"`
+	DeletedAt  time.Time `json:"deleted_at"`
+}
+
+func SecretTombstoneFromDomain(t *secret.Tombstone) SecretTombstone {
+	return SecretTombstone{
+		SecretID:   t.SecretID.String(),
+		SecretName: t.SecretName,
+		VersionID:  t.VersionID.String(),
+		DeletedAt:  t.DeletedAt,
+	}
+}
+
+func SecretTombstoneFromProto(t *pb.SecretTombstone) SecretTombstone {
+	return SecretTombstone{
+		SecretID:   t.GetSecretId(),
+		SecretName: t.GetSecretName(),
+		VersionID:  t.GetVersionId(),
+		DeletedAt:  t.GetDeletedAt().AsTime(),
+	}
+}
+
+func (t *SecretTombstone) ToProto() *pb.SecretTombstone {
+	return &pb.SecretTombstone{
+		SecretId:   t.SecretID,
+		SecretName: t.SecretName,
+		VersionId:  t.VersionID,
+		DeletedAt:  timestamppb.New(t.DeletedAt),
+	}
+}
+
+// ListSecretTombstonesRequest represents request for secrets deleted after Since.
+type ListSecretTombstonesRequest struct {
+	UserID string    `json:"user_id"`
+	Since  time.Time `json:"since"`
+}
+
+func ListSecretTombstonesRequestFromProto(req *pb.ListSecretTombstonesRequest) *ListSecretTombstonesRequest {
+	var since time.Time
+	if req.GetSince() != nil {
+		since = req.GetSince().AsTime()
+	}
+
+	return &ListSecretTombstonesRequest{
+		UserID: req.GetUserId(),
+		Since:  since,
+	}
+}
+
+// ParseUserID returns validated id of the user requesting tombstones.
+func (r *ListSecretTombstonesRequest) ParseUserID() (uuid.UUID, error) {
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[%w] invalid userID", e.ErrValidation)
+	}
+
+	return userID, nil
+}
+
+// ListSecretTombstonesResponse represents secrets deleted by the user.
+type ListSecretTombstonesResponse struct {
+	Tombstones []SecretTombstone `json:"tombstones"`
+}
+
+func ListSecretTombstonesResponseFromProto(resp *pb.ListSecretTombstonesResponse) *ListSecretTombstonesResponse {
+	tombstones := make([]SecretTombstone, 0, len(resp.GetTombstones()))
+	for _, t := range resp.GetTombstones() {
+		tombstones = append(tombstones, SecretTombstoneFromProto(t))
+	}
+
+	return &ListSecretTombstonesResponse{Tombstones: tombstones}
+}
+
+func (resp *ListSecretTombstonesResponse) ToProto() *pb.ListSecretTombstonesResponse {
+	tombstones := make([]*pb.SecretTombstone, 0, len(resp.Tombstones))
+	for i := range resp.Tombstones {
+		tombstones = append(tombstones, resp.Tombstones[i].ToProto())
+	}
+
+	return &pb.ListSecretTombstonesResponse{Tombstones: tombstones}
+}
+
+// SecretVersionConflict represents rejection of the upload based on outdated secret version.
+type SecretVersionConflict struct {
+	SecretID         string `json:"secret_id"`
+	SecretName       string `json:"secret_name"`
+	ParentVersionID  string `json:"parent_version_id"`
+	CurrentVersionID string `json:"current_version_id"`
+}
+
+func SecretVersionConflictFromDomain(err *secret.VersionConflictError) SecretVersionConflict {
+	return SecretVersionConflict{
+		SecretID:         err.SecretID.String(),
+		SecretName:       err.SecretName,
+		ParentVersionID:  err.ParentVersionID.String(),
+		CurrentVersionID: err.CurrentVersionID.String(),
+	}
+}
+
+func SecretVersionConflictFromProto(c *pb.SecretVersionConflict) SecretVersionConflict {
+	return SecretVersionConflict{
+		SecretID:         c.GetSecretId(),
+		SecretName:       c.GetSecretName(),
+		ParentVersionID:  c.GetParentVersionId(),
+		CurrentVersionID: c.GetCurrentVersionId(),
+	}
+}
+
+func (c *SecretVersionConflict) ToProto() *pb.SecretVersionConflict {
+	return &pb.SecretVersionConflict{
+		SecretId:         c.SecretID,
+		SecretName:       c.SecretName,
+		ParentVersionId:  c.ParentVersionID,
+		CurrentVersionId: c.CurrentVersionID,
+	}
+}
+
+// SecretConflict is a local secret which changes diverged from the server
+// together with the remote version downloaded next to it.
+type SecretConflict struct {
+	UserID                string
+	SecretID              string
+	SecretName            string
+	LocalVersionID        string
+	RemoteSecretID        string
+	RemoteVersionID       string
+	RemoteParentVersionID string
+	RemoteFilePath        string
+	RemoteSecretSize      int64
+	RemoteSecretHash      []byte
+	RemoteSecretDek       []byte
+	RemoteMetaData        secret.MetaData
+	DetectedAt            time.Time
+}
+
 type Secret struct {
 	ID              string
 	UserID          string
@@ -195,6 +816,7 @@ type Secret struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	InSync          bool
+	MetaData        secret.MetaData
 }
 
 func (r *Secret) ToDomain() (*secret.Secret, error) {
@@ -247,3 +869,93 @@ func (r *Secret) ToDomain() (*secret.Secret, error) {
 		Meta:             nil,
 	}, nil
 }
+
+// SecretKey represents wrapped DEK of the secret version.
+type SecretKey struct {
+	SecretID     string `json:"secret_id"`
+	VersionID    string `json:"version_id"`
+	EncryptedDEK []byte `json:"encrypted_dek"`
+}
+
+func SecretKeyFromDomain(k *secret.WrappedKey) SecretKey {
+	return SecretKey{
+		SecretID:     k.SecretID.String(),
+		VersionID:    k.VersionID.String(),
+		EncryptedDEK: k.DEK,
+	}
+}
+
+func SecretKeyFromProto(k *pb.SecretKey) SecretKey {
+	return SecretKey{
+		SecretID:     k.GetSecretId(),
+		VersionID:    k.GetVersionId(),
+		EncryptedDEK: k.GetEncryptedDek(),
+	}
+}
+
+func (k *SecretKey) ToProto() *pb.SecretKey {
+	return &pb.SecretKey{
+		SecretId:     k.SecretID,
+		VersionId:    k.VersionID,
+		EncryptedDek: k.EncryptedDEK,
+	}
+}
+
+func (k *SecretKey) ToDomain() (*secret.WrappedKey, error) {
+	secretID, err := uuid.Parse(k.SecretID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid secretID", e.ErrValidation)
+	}
+
+	versionID, err := uuid.Parse(k.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] invalid versionID", e.ErrValidation)
+	}
+
+	return &secret.WrappedKey{
+		SecretID:  secretID,
+		VersionID: versionID,
+		DEK:       k.EncryptedDEK,
+	}, nil
+}
+
+// SecretKeysFromProto maps wrapped DEKs of the request to domain keys.
+func SecretKeysFromProto(keys []*pb.SecretKey) ([]*secret.WrappedKey, error) {
+	result := make([]*secret.WrappedKey, 0, len(keys))
+
+	for _, k := range keys {
+		key := SecretKeyFromProto(k)
+
+		wrapped, err := key.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, wrapped)
+	}
+
+	return result, nil
+}
+
+// ListSecretKeysResponse represents wrapped DEKs of all versions of user secrets.
+type ListSecretKeysResponse struct {
+	Keys []SecretKey `json:"keys"`
+}
+
+func ListSecretKeysResponseFromProto(resp *pb.ListSecretKeysResponse) *ListSecretKeysResponse {
+	keys := make([]SecretKey, 0, len(resp.GetKeys()))
+	for _, k := range resp.GetKeys() {
+		keys = append(keys, SecretKeyFromProto(k))
+	}
+
+	return &ListSecretKeysResponse{Keys: keys}
+}
+
+func (resp *ListSecretKeysResponse) ToProto() *pb.ListSecretKeysResponse {
+	keys := make([]*pb.SecretKey, 0, len(resp.Keys))
+	for i := range resp.Keys {
+		keys = append(keys, resp.Keys[i].ToProto())
+	}
+
+	return &pb.ListSecretKeysResponse{Keys: keys}
+}
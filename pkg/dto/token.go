@@ -1,7 +1,41 @@
 package dto
 
+import "time"
+
+// tokenRefreshBuffer is the time before access token expiration it is refreshed at,
+// so that token does not expire while request is in flight.
+const tokenRefreshBuffer = time.Minute
+
+// ServerToken is access token issued by server along with refresh token it is renewed with.
 type ServerToken struct {
-	UserID string
-	Token  string
-	TTL    uint32
+	UserID           string
+	Token            string
+	TTL              uint32
+	ExpiresAt        time.Time // zero for tokens issued before refresh tokens were introduced
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// NewServerToken creates server token issued now.
+func NewServerToken(userID, token string, ttl uint32, refreshToken string, refreshTTL uint32) *ServerToken {
+	now := time.Now().UTC()
+
+	return &ServerToken{
+		UserID:           userID,
+		Token:            token,
+		TTL:              ttl,
+		ExpiresAt:        now.Add(time.Duration(ttl) * time.Second),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: now.Add(time.Duration(refreshTTL) * time.Second),
+	}
+}
+
+// NeedsRefresh reports whether access token is about to expire and can be renewed with refresh token.
+func (t *ServerToken) NeedsRefresh() bool {
+	return t.RefreshToken != "" && !time.Now().UTC().Add(tokenRefreshBuffer).Before(t.ExpiresAt)
+}
+
+// IsRefreshable reports whether refresh token has not expired yet.
+func (t *ServerToken) IsRefreshable() bool {
+	return t.RefreshToken != "" && time.Now().UTC().Before(t.RefreshExpiresAt)
 }
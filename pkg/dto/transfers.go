@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// TransferDirection tells whether secret object is uploaded to or downloaded from S3.
+type TransferDirection string
+
+const (
+	TransferUpload   TransferDirection = "upload"
+	TransferDownload TransferDirection = "download"
+)
+
+// Transfer is a state of the resumable secret object transfer kept by the client.
+// Upload progress is tracked by uploaded parts, download progress by size of the partially downloaded file.
+type Transfer struct {
+	UserID     string
+	SecretID   string
+	Direction  TransferDirection
+	SecretName string
+	VersionID  string
+	ObjectKey  string
+	UploadID   string
+	Token      int64
+	FilePath   string
+	FileSize   int64
+	PartSize   int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TransferPart is an uploaded part of multipart upload.
+type TransferPart struct {
+	UploadID   string
+	PartNumber int
+	ETag       string
+	Size       int64
+	Checksum   []byte
+	CreatedAt  time.Time
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -44,11 +45,21 @@ func Stdout(level zerolog.Level) Logger {
 
 // StdoutConsole initializes a new Logger with human-readable console output (suitable for CLI clients).
 func StdoutConsole(level zerolog.Level) Logger {
+	return console(os.Stdout, level)
+}
+
+// StderrConsole initializes a new Logger with human-readable console output written to stderr
+// (suitable for CLI commands which print their results to stdout).
+func StderrConsole(level zerolog.Level) Logger {
+	return console(os.Stderr, level)
+}
+
+func console(out io.Writer, level zerolog.Level) Logger {
 	zerolog.CallerMarshalFunc = shortCallerMarshalFunc
 	zerolog.CallerSkipFrameCount = 2
 
 	output := zerolog.ConsoleWriter{
-		Out:        os.Stdout,
+		Out:        out,
 		TimeFormat: "15:04:05",
 		NoColor:    false,
 	}
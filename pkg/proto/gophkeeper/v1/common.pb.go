@@ -29,6 +29,8 @@ const (
 	UserRole_USER_ROLE_UNSPECIFIED UserRole = 0
 	UserRole_USER_ROLE_USER        UserRole = 1
 	UserRole_USER_ROLE_ADMIN       UserRole = 2
+	UserRole_USER_ROLE_AUDITOR     UserRole = 3 // Read-only access to secret metadata of any user
+	UserRole_USER_ROLE_OPERATOR    UserRole = 4 // Operates server lifecycle, e.g. unseal
 )
 
 // Enum value maps for UserRole.
@@ -37,11 +39,15 @@ var (
 		0: "USER_ROLE_UNSPECIFIED",
 		1: "USER_ROLE_USER",
 		2: "USER_ROLE_ADMIN",
+		3: "USER_ROLE_AUDITOR",
+		4: "USER_ROLE_OPERATOR",
 	}
 	UserRole_value = map[string]int32{
 		"USER_ROLE_UNSPECIFIED": 0,
 		"USER_ROLE_USER":        1,
 		"USER_ROLE_ADMIN":       2,
+		"USER_ROLE_AUDITOR":     3,
+		"USER_ROLE_OPERATOR":    4,
 	}
 )
 
@@ -189,6 +195,66 @@ func (x *TemporaryCredentials) GetExpiration() string {
 	return ""
 }
 
+type SecretKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SecretId      string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
+	VersionId     string                 `protobuf:"bytes,2,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	EncryptedDek  []byte                 `protobuf:"bytes,3,opt,name=encrypted_dek,json=encryptedDek,proto3" json:"encrypted_dek,omitempty"` // DEK of the version wrapped with user KEK
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretKey) Reset() {
+	*x = SecretKey{}
+	mi := &file_gophkeeper_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretKey) ProtoMessage() {}
+
+func (x *SecretKey) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretKey.ProtoReflect.Descriptor instead.
+func (*SecretKey) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SecretKey) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *SecretKey) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *SecretKey) GetEncryptedDek() []byte {
+	if x != nil {
+		return x.EncryptedDek
+	}
+	return nil
+}
+
 var File_gophkeeper_v1_common_proto protoreflect.FileDescriptor
 
 const file_gophkeeper_v1_common_proto_rawDesc = "" +
@@ -200,11 +266,18 @@ const file_gophkeeper_v1_common_proto_rawDesc = "" +
 	"\rsession_token\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\fsessionToken\x12'\n" +
 	"\n" +
 	"expiration\x18\x04 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
-	"expiration*N\n" +
+	"expiration\"\x89\x01\n" +
+	"\tSecretKey\x12%\n" +
+	"\tsecret_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12'\n" +
+	"\n" +
+	"version_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12,\n" +
+	"\rencrypted_dek\x18\x03 \x01(\fB\a\xbaH\x04z\x02\x10\x01R\fencryptedDek*}\n" +
 	"\bUserRole\x12\x19\n" +
 	"\x15USER_ROLE_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0eUSER_ROLE_USER\x10\x01\x12\x13\n" +
-	"\x0fUSER_ROLE_ADMIN\x10\x02*[\n" +
+	"\x0fUSER_ROLE_ADMIN\x10\x02\x12\x15\n" +
+	"\x11USER_ROLE_AUDITOR\x10\x03\x12\x16\n" +
+	"\x12USER_ROLE_OPERATOR\x10\x04*[\n" +
 	"\n" +
 	"SealStatus\x12\x1b\n" +
 	"\x17SEAL_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
@@ -225,11 +298,12 @@ func file_gophkeeper_v1_common_proto_rawDescGZIP() []byte {
 }
 
 var file_gophkeeper_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_gophkeeper_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_gophkeeper_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_gophkeeper_v1_common_proto_goTypes = []any{
 	(UserRole)(0),                // 0: gophkeeper.v1.UserRole
 	(SealStatus)(0),              // 1: gophkeeper.v1.SealStatus
 	(*TemporaryCredentials)(nil), // 2: gophkeeper.v1.TemporaryCredentials
+	(*SecretKey)(nil),            // 3: gophkeeper.v1.SecretKey
 }
 var file_gophkeeper_v1_common_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -250,7 +324,7 @@ func file_gophkeeper_v1_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gophkeeper_v1_common_proto_rawDesc), len(file_gophkeeper_v1_common_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
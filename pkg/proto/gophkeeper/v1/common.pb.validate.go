@@ -144,3 +144,108 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = TemporaryCredentialsValidationError{}
+
+// Validate checks the field values on SecretKey with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SecretKey) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretKey with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SecretKeyMultiError, or nil
+// if none found.
+func (m *SecretKey) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretKey) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SecretId
+
+	// no validation rules for VersionId
+
+	// no validation rules for EncryptedDek
+
+	if len(errors) > 0 {
+		return SecretKeyMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretKeyMultiError is an error wrapping multiple validation errors returned
+// by SecretKey.ValidateAll() if the designated constraints aren't met.
+type SecretKeyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretKeyMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretKeyMultiError) AllErrors() []error { return m }
+
+// SecretKeyValidationError is the validation error returned by
+// SecretKey.Validate if the designated constraints aren't met.
+type SecretKeyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretKeyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretKeyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretKeyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretKeyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretKeyValidationError) ErrorName() string { return "SecretKeyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SecretKeyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretKey.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretKeyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretKeyValidationError{}
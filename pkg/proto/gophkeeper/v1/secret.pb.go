@@ -14,6 +14,7 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -23,6 +24,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SecretEventType int32
+
+const (
+	SecretEventType_SECRET_EVENT_TYPE_UNSPECIFIED SecretEventType = 0
+	SecretEventType_SECRET_EVENT_TYPE_CREATE      SecretEventType = 1
+	SecretEventType_SECRET_EVENT_TYPE_UPDATE      SecretEventType = 2
+	SecretEventType_SECRET_EVENT_TYPE_DELETE      SecretEventType = 3
+)
+
+// Enum value maps for SecretEventType.
+var (
+	SecretEventType_name = map[int32]string{
+		0: "SECRET_EVENT_TYPE_UNSPECIFIED",
+		1: "SECRET_EVENT_TYPE_CREATE",
+		2: "SECRET_EVENT_TYPE_UPDATE",
+		3: "SECRET_EVENT_TYPE_DELETE",
+	}
+	SecretEventType_value = map[string]int32{
+		"SECRET_EVENT_TYPE_UNSPECIFIED": 0,
+		"SECRET_EVENT_TYPE_CREATE":      1,
+		"SECRET_EVENT_TYPE_UPDATE":      2,
+		"SECRET_EVENT_TYPE_DELETE":      3,
+	}
+)
+
+func (x SecretEventType) Enum() *SecretEventType {
+	p := new(SecretEventType)
+	*p = x
+	return p
+}
+
+func (x SecretEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SecretEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_gophkeeper_v1_secret_proto_enumTypes[0].Descriptor()
+}
+
+func (SecretEventType) Type() protoreflect.EnumType {
+	return &file_gophkeeper_v1_secret_proto_enumTypes[0]
+}
+
+func (x SecretEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SecretEventType.Descriptor instead.
+func (SecretEventType) EnumDescriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{0}
+}
+
 type SecretUpdateInitRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                              // Required: ID of the user performing the operation
@@ -32,7 +85,7 @@ type SecretUpdateInitRequest struct {
 	ParentVersionId string                 `protobuf:"bytes,5,opt,name=parent_version_id,json=parentVersionId,proto3" json:"parent_version_id,omitempty"` // Optional: Expected current version; empty for new secret
 	ClientInfo      string                 `protobuf:"bytes,6,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`                  // Required: Info about client/device (agent, version, etc.)
 	Size            int64                  `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`                                               // Required: Size of encrypted content
-	Hash            []byte                 `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`                                                // Required: Hash of encrypted content
+	Hash            []byte                 `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`                                                // Required: SHA-256 of encrypted content
 	EncryptedDek    []byte                 `protobuf:"bytes,9,opt,name=encrypted_dek,json=encryptedDek,proto3" json:"encrypted_dek,omitempty"`            // Required: Encrypted Data Encryption Key (DEK)
 	MetadataJson    string                 `protobuf:"bytes,10,opt,name=metadata_json,json=metadataJson,proto3" json:"metadata_json,omitempty"`           // Optional: JSON string with user-defined metadata
 	unknownFields   protoimpl.UnknownFields
@@ -231,6 +284,76 @@ func (x *SecretUpdateInitResponse) GetCredentials() *TemporaryCredentials {
 	return nil
 }
 
+// SecretVersionConflict is attached to FailedPrecondition status of SecretUpdateInit
+// when the upload is based on a version which is no longer current.
+type SecretVersionConflict struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SecretId         string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`                           // Secret which has been changed concurrently
+	SecretName       string                 `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`                     // Name of the secret
+	ParentVersionId  string                 `protobuf:"bytes,3,opt,name=parent_version_id,json=parentVersionId,proto3" json:"parent_version_id,omitempty"`    // Version the rejected upload was based on
+	CurrentVersionId string                 `protobuf:"bytes,4,opt,name=current_version_id,json=currentVersionId,proto3" json:"current_version_id,omitempty"` // Current version of the secret on the server
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SecretVersionConflict) Reset() {
+	*x = SecretVersionConflict{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretVersionConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretVersionConflict) ProtoMessage() {}
+
+func (x *SecretVersionConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretVersionConflict.ProtoReflect.Descriptor instead.
+func (*SecretVersionConflict) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SecretVersionConflict) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *SecretVersionConflict) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *SecretVersionConflict) GetParentVersionId() string {
+	if x != nil {
+		return x.ParentVersionId
+	}
+	return ""
+}
+
+func (x *SecretVersionConflict) GetCurrentVersionId() string {
+	if x != nil {
+		return x.CurrentVersionId
+	}
+	return ""
+}
+
 type SecretUpdateCommitRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                              // Required: ID of the user performing the operation
@@ -239,16 +362,17 @@ type SecretUpdateCommitRequest struct {
 	ParentVersionId string                 `protobuf:"bytes,4,opt,name=parent_version_id,json=parentVersionId,proto3" json:"parent_version_id,omitempty"` // Optional: Expected current version; empty for new secret
 	ClientInfo      string                 `protobuf:"bytes,5,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`                  // Required: Info about client/device (agent, version, etc.)
 	Size            int64                  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`                                               // Required: Size of encrypted content
-	Hash            []byte                 `protobuf:"bytes,7,opt,name=hash,proto3" json:"hash,omitempty"`                                                // Required: Hash of encrypted content
+	Hash            []byte                 `protobuf:"bytes,7,opt,name=hash,proto3" json:"hash,omitempty"`                                                // Required: SHA-256 of encrypted content
 	EncryptedDek    []byte                 `protobuf:"bytes,8,opt,name=encrypted_dek,json=encryptedDek,proto3" json:"encrypted_dek,omitempty"`            // Required: Encrypted Data Encryption Key (DEK)
 	Token           int64                  `protobuf:"varint,9,opt,name=token,proto3" json:"token,omitempty"`                                             // Required: Token from UpdateInit for validation
+	PartHashes      [][]byte               `protobuf:"bytes,10,rep,name=part_hashes,json=partHashes,proto3" json:"part_hashes,omitempty"`                 // Optional: SHA-256 of each part of multipart upload in part order
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *SecretUpdateCommitRequest) Reset() {
 	*x = SecretUpdateCommitRequest{}
-	mi := &file_gophkeeper_v1_secret_proto_msgTypes[2]
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -260,7 +384,7 @@ func (x *SecretUpdateCommitRequest) String() string {
 func (*SecretUpdateCommitRequest) ProtoMessage() {}
 
 func (x *SecretUpdateCommitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gophkeeper_v1_secret_proto_msgTypes[2]
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -273,7 +397,7 @@ func (x *SecretUpdateCommitRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecretUpdateCommitRequest.ProtoReflect.Descriptor instead.
 func (*SecretUpdateCommitRequest) Descriptor() ([]byte, []int) {
-	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{2}
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SecretUpdateCommitRequest) GetUserId() string {
@@ -339,6 +463,13 @@ func (x *SecretUpdateCommitRequest) GetToken() int64 {
 	return 0
 }
 
+func (x *SecretUpdateCommitRequest) GetPartHashes() [][]byte {
+	if x != nil {
+		return x.PartHashes
+	}
+	return nil
+}
+
 type SecretUpdateCommitResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // Required: ID of the user performing the operation
@@ -351,7 +482,7 @@ type SecretUpdateCommitResponse struct {
 
 func (x *SecretUpdateCommitResponse) Reset() {
 	*x = SecretUpdateCommitResponse{}
-	mi := &file_gophkeeper_v1_secret_proto_msgTypes[3]
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -363,7 +494,7 @@ func (x *SecretUpdateCommitResponse) String() string {
 func (*SecretUpdateCommitResponse) ProtoMessage() {}
 
 func (x *SecretUpdateCommitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gophkeeper_v1_secret_proto_msgTypes[3]
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -376,7 +507,7 @@ func (x *SecretUpdateCommitResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecretUpdateCommitResponse.ProtoReflect.Descriptor instead.
 func (*SecretUpdateCommitResponse) Descriptor() ([]byte, []int) {
-	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{3}
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SecretUpdateCommitResponse) GetUserId() string {
@@ -407,57 +538,1639 @@ func (x *SecretUpdateCommitResponse) GetVersionId() string {
 	return ""
 }
 
-var File_gophkeeper_v1_secret_proto protoreflect.FileDescriptor
+type RenewUploadCredentialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // Required: ID of the user performing the operation
+	SecretId      string                 `protobuf:"bytes,2,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"` // Required: Secret UUID of the upload in progress
+	Token         int64                  `protobuf:"varint,3,opt,name=token,proto3" json:"token,omitempty"`                      // Required: Token from UpdateInit for validation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_gophkeeper_v1_secret_proto_rawDesc = "" +
-	"\n" +
-	"\x1agophkeeper/v1/secret.proto\x12\rgophkeeper.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1agophkeeper/v1/common.proto\"\x9b\x03\n" +
-	"\x17SecretUpdateInitRequest\x12!\n" +
-	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
-	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12*\n" +
-	"\vsecret_name\x18\x03 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
-	"secretName\x12'\n" +
-	"\n" +
-	"version_id\x18\x04 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
-	"\x11parent_version_id\x18\x05 \x01(\tR\x0fparentVersionId\x12(\n" +
-	"\vclient_info\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
-	"clientInfo\x12\x1b\n" +
-	"\x04size\x18\a \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x04size\x12\x1b\n" +
-	"\x04hash\x18\b \x01(\fB\a\xbaH\x04z\x02\x10\x01R\x04hash\x12,\n" +
-	"\rencrypted_dek\x18\t \x01(\fB\a\xbaH\x04z\x02\x10\x01R\fencryptedDek\x12#\n" +
-	"\rmetadata_json\x18\n" +
-	" \x01(\tR\fmetadataJson\"\xc0\x02\n" +
-	"\x18SecretUpdateInitResponse\x12!\n" +
-	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
-	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12'\n" +
-	"\n" +
-	"version_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
-	"\x11parent_version_id\x18\x04 \x01(\tR\x0fparentVersionId\x12\x1f\n" +
-	"\x06s3_url\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x88\x01\x01R\x05s3Url\x12\x1d\n" +
-	"\x05token\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x05token\x12E\n" +
-	"\vcredentials\x18\a \x01(\v2#.gophkeeper.v1.TemporaryCredentialsR\vcredentials\"\xeb\x02\n" +
-	"\x19SecretUpdateCommitRequest\x12!\n" +
-	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
-	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12'\n" +
-	"\n" +
-	"version_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
-	"\x11parent_version_id\x18\x04 \x01(\tR\x0fparentVersionId\x12(\n" +
-	"\vclient_info\x18\x05 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
-	"clientInfo\x12\x1b\n" +
-	"\x04size\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x04size\x12\x1b\n" +
-	"\x04hash\x18\a \x01(\fB\a\xbaH\x04z\x02\x10\x01R\x04hash\x12,\n" +
-	"\rencrypted_dek\x18\b \x01(\fB\a\xbaH\x04z\x02\x10\x01R\fencryptedDek\x12\x1d\n" +
-	"\x05token\x18\t \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x05token\"\xbb\x01\n" +
-	"\x1aSecretUpdateCommitResponse\x12!\n" +
-	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
-	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12*\n" +
-	"\vsecret_name\x18\x03 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
-	"secretName\x12'\n" +
+func (x *RenewUploadCredentialsRequest) Reset() {
+	*x = RenewUploadCredentialsRequest{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewUploadCredentialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewUploadCredentialsRequest) ProtoMessage() {}
+
+func (x *RenewUploadCredentialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewUploadCredentialsRequest.ProtoReflect.Descriptor instead.
+func (*RenewUploadCredentialsRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RenewUploadCredentialsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RenewUploadCredentialsRequest) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *RenewUploadCredentialsRequest) GetToken() int64 {
+	if x != nil {
+		return x.Token
+	}
+	return 0
+}
+
+type RenewUploadCredentialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Credentials   *TemporaryCredentials  `protobuf:"bytes,1,opt,name=credentials,proto3" json:"credentials,omitempty"` // Fresh STS credentials to continue upload with
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewUploadCredentialsResponse) Reset() {
+	*x = RenewUploadCredentialsResponse{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewUploadCredentialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewUploadCredentialsResponse) ProtoMessage() {}
+
+func (x *RenewUploadCredentialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewUploadCredentialsResponse.ProtoReflect.Descriptor instead.
+func (*RenewUploadCredentialsResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RenewUploadCredentialsResponse) GetCredentials() *TemporaryCredentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+type SecretGetInitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // Required: ID of the user performing the operation
+	SecretName    string                 `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"` // Required: Name of the secret to read
+	ClientInfo    string                 `protobuf:"bytes,3,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"` // Required: Info about client/device (agent, version, etc.)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretGetInitRequest) Reset() {
+	*x = SecretGetInitRequest{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretGetInitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretGetInitRequest) ProtoMessage() {}
+
+func (x *SecretGetInitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretGetInitRequest.ProtoReflect.Descriptor instead.
+func (*SecretGetInitRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SecretGetInitRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SecretGetInitRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *SecretGetInitRequest) GetClientInfo() string {
+	if x != nil {
+		return x.ClientInfo
+	}
+	return ""
+}
+
+type SecretGetInitResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                              // Echoed back user ID
+	SecretId        string                 `protobuf:"bytes,2,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`                        // Secret ID being read
+	SecretName      string                 `protobuf:"bytes,3,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`                  // Secret name
+	VersionId       string                 `protobuf:"bytes,4,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`                     // Current version UUID
+	ParentVersionId string                 `protobuf:"bytes,5,opt,name=parent_version_id,json=parentVersionId,proto3" json:"parent_version_id,omitempty"` // Optional: Parent of current version; empty for first version
+	S3Url           string                 `protobuf:"bytes,6,opt,name=s3_url,json=s3Url,proto3" json:"s3_url,omitempty"`                                 // Object key of encrypted content in user bucket
+	Size            int64                  `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`                                               // Size of encrypted content
+	Hash            []byte                 `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`                                                // Hash of encrypted content
+	EncryptedDek    []byte                 `protobuf:"bytes,9,opt,name=encrypted_dek,json=encryptedDek,proto3" json:"encrypted_dek,omitempty"`            // Encrypted Data Encryption Key (DEK)
+	MetadataJson    string                 `protobuf:"bytes,10,opt,name=metadata_json,json=metadataJson,proto3" json:"metadata_json,omitempty"`           // Optional: JSON string with user-defined metadata
+	Credentials     *TemporaryCredentials  `protobuf:"bytes,11,opt,name=credentials,proto3" json:"credentials,omitempty"`                                 // Read-only STS credentials to be used with S3
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SecretGetInitResponse) Reset() {
+	*x = SecretGetInitResponse{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretGetInitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretGetInitResponse) ProtoMessage() {}
+
+func (x *SecretGetInitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretGetInitResponse.ProtoReflect.Descriptor instead.
+func (*SecretGetInitResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SecretGetInitResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SecretGetInitResponse) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *SecretGetInitResponse) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *SecretGetInitResponse) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *SecretGetInitResponse) GetParentVersionId() string {
+	if x != nil {
+		return x.ParentVersionId
+	}
+	return ""
+}
+
+func (x *SecretGetInitResponse) GetS3Url() string {
+	if x != nil {
+		return x.S3Url
+	}
+	return ""
+}
+
+func (x *SecretGetInitResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SecretGetInitResponse) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *SecretGetInitResponse) GetEncryptedDek() []byte {
+	if x != nil {
+		return x.EncryptedDek
+	}
+	return nil
+}
+
+func (x *SecretGetInitResponse) GetMetadataJson() string {
+	if x != nil {
+		return x.MetadataJson
+	}
+	return ""
+}
+
+func (x *SecretGetInitResponse) GetCredentials() *TemporaryCredentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+type ListSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // Required: ID of the user performing the operation
+	NamePrefix    string                 `protobuf:"bytes,2,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"` // Optional: Return only secrets which names start with prefix
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`      // Optional: Max number of secrets per page; server default if zero
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`    // Optional: Token from previous response to fetch next page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretsRequest) Reset() {
+	*x = ListSecretsRequest{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretsRequest) ProtoMessage() {}
+
+func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListSecretsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSecretsRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListSecretsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSecretsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SecretInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SecretId        string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`                        // Secret UUID
+	SecretName      string                 `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`                  // Secret name
+	VersionId       string                 `protobuf:"bytes,3,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`                     // Current version UUID
+	ParentVersionId string                 `protobuf:"bytes,4,opt,name=parent_version_id,json=parentVersionId,proto3" json:"parent_version_id,omitempty"` // Optional: Parent of current version; empty for first version
+	Size            int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`                                               // Size of encrypted content
+	Hash            []byte                 `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash,omitempty"`                                                // Hash of encrypted content
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                     // Secret creation time
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                     // Last time current version has changed
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SecretInfo) Reset() {
+	*x = SecretInfo{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretInfo) ProtoMessage() {}
+
+func (x *SecretInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretInfo.ProtoReflect.Descriptor instead.
+func (*SecretInfo) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SecretInfo) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *SecretInfo) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *SecretInfo) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *SecretInfo) GetParentVersionId() string {
+	if x != nil {
+		return x.ParentVersionId
+	}
+	return ""
+}
+
+func (x *SecretInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SecretInfo) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *SecretInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SecretInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secrets       []*SecretInfo          `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"`                                    // Page of secrets ordered by name
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token to fetch next page; empty if this is the last one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretsResponse) Reset() {
+	*x = ListSecretsResponse{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretsResponse) ProtoMessage() {}
+
+func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListSecretsResponse) GetSecrets() []*SecretInfo {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *ListSecretsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListSecretVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // Required: ID of the user performing the operation
+	SecretName    string                 `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"` // Required: Name of the secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretVersionsRequest) Reset() {
+	*x = ListSecretVersionsRequest{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretVersionsRequest) ProtoMessage() {}
+
+func (x *ListSecretVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecretVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListSecretVersionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSecretVersionsRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+type SecretVersionInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	VersionId       string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`                     // Version UUID
+	ParentVersionId string                 `protobuf:"bytes,2,opt,name=parent_version_id,json=parentVersionId,proto3" json:"parent_version_id,omitempty"` // Optional: Parent version; empty for first version
+	Size            int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`                                               // Size of encrypted content
+	Hash            []byte                 `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`                                                // Hash of encrypted content
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                     // Version creation time
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SecretVersionInfo) Reset() {
+	*x = SecretVersionInfo{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretVersionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretVersionInfo) ProtoMessage() {}
+
+func (x *SecretVersionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretVersionInfo.ProtoReflect.Descriptor instead.
+func (*SecretVersionInfo) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SecretVersionInfo) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *SecretVersionInfo) GetParentVersionId() string {
+	if x != nil {
+		return x.ParentVersionId
+	}
+	return ""
+}
+
+func (x *SecretVersionInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SecretVersionInfo) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *SecretVersionInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListSecretVersionsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SecretId         string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`                           // Secret UUID
+	SecretName       string                 `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`                     // Secret name
+	CurrentVersionId string                 `protobuf:"bytes,3,opt,name=current_version_id,json=currentVersionId,proto3" json:"current_version_id,omitempty"` // Current version UUID
+	Versions         []*SecretVersionInfo   `protobuf:"bytes,4,rep,name=versions,proto3" json:"versions,omitempty"`                                           // Versions ordered from newest to oldest
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListSecretVersionsResponse) Reset() {
+	*x = ListSecretVersionsResponse{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretVersionsResponse) ProtoMessage() {}
+
+func (x *ListSecretVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecretVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListSecretVersionsResponse) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *ListSecretVersionsResponse) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *ListSecretVersionsResponse) GetCurrentVersionId() string {
+	if x != nil {
+		return x.CurrentVersionId
+	}
+	return ""
+}
+
+func (x *ListSecretVersionsResponse) GetVersions() []*SecretVersionInfo {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type GetSecretVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // Required: ID of the user performing the operation
+	SecretName    string                 `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"` // Required: Name of the secret to read
+	VersionId     string                 `protobuf:"bytes,3,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`    // Required: Version UUID to read
+	ClientInfo    string                 `protobuf:"bytes,4,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"` // Required: Info about client/device (agent, version, etc.)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretVersionRequest) Reset() {
+	*x = GetSecretVersionRequest{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretVersionRequest) ProtoMessage() {}
+
+func (x *GetSecretVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetSecretVersionRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSecretVersionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetSecretVersionRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *GetSecretVersionRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *GetSecretVersionRequest) GetClientInfo() string {
+	if x != nil {
+		return x.ClientInfo
+	}
+	return ""
+}
+
+type GetSecretVersionResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                  // Echoed back user ID
+	SecretId         string                 `protobuf:"bytes,2,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`                            // Secret ID being read
+	SecretName       string                 `protobuf:"bytes,3,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`                      // Secret name
+	VersionId        string                 `protobuf:"bytes,4,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`                         // Requested version UUID
+	ParentVersionId  string                 `protobuf:"bytes,5,opt,name=parent_version_id,json=parentVersionId,proto3" json:"parent_version_id,omitempty"`     // Optional: Parent of requested version; empty for first version
+	S3Url            string                 `protobuf:"bytes,6,opt,name=s3_url,json=s3Url,proto3" json:"s3_url,omitempty"`                                     // Object key of encrypted content in user bucket
+	Size             int64                  `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`                                                   // Size of encrypted content
+	Hash             []byte                 `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`                                                    // Hash of encrypted content
+	EncryptedDek     []byte                 `protobuf:"bytes,9,opt,name=encrypted_dek,json=encryptedDek,proto3" json:"encrypted_dek,omitempty"`                // Encrypted Data Encryption Key (DEK)
+	MetadataJson     string                 `protobuf:"bytes,10,opt,name=metadata_json,json=metadataJson,proto3" json:"metadata_json,omitempty"`               // Optional: JSON string with user-defined metadata
+	Credentials      *TemporaryCredentials  `protobuf:"bytes,11,opt,name=credentials,proto3" json:"credentials,omitempty"`                                     // Read-only STS credentials to be used with S3
+	CurrentVersionId string                 `protobuf:"bytes,12,opt,name=current_version_id,json=currentVersionId,proto3" json:"current_version_id,omitempty"` // Current version of the secret
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetSecretVersionResponse) Reset() {
+	*x = GetSecretVersionResponse{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretVersionResponse) ProtoMessage() {}
+
+func (x *GetSecretVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetSecretVersionResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetSecretVersionResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetSecretVersionResponse) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *GetSecretVersionResponse) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *GetSecretVersionResponse) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *GetSecretVersionResponse) GetParentVersionId() string {
+	if x != nil {
+		return x.ParentVersionId
+	}
+	return ""
+}
+
+func (x *GetSecretVersionResponse) GetS3Url() string {
+	if x != nil {
+		return x.S3Url
+	}
+	return ""
+}
+
+func (x *GetSecretVersionResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetSecretVersionResponse) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *GetSecretVersionResponse) GetEncryptedDek() []byte {
+	if x != nil {
+		return x.EncryptedDek
+	}
+	return nil
+}
+
+func (x *GetSecretVersionResponse) GetMetadataJson() string {
+	if x != nil {
+		return x.MetadataJson
+	}
+	return ""
+}
+
+func (x *GetSecretVersionResponse) GetCredentials() *TemporaryCredentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *GetSecretVersionResponse) GetCurrentVersionId() string {
+	if x != nil {
+		return x.CurrentVersionId
+	}
+	return ""
+}
+
+type SecretDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // Required: ID of the user performing the operation
+	SecretName    string                 `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"` // Required: Name of the secret to delete
+	VersionId     string                 `protobuf:"bytes,3,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`    // Required: Expected current version of the secret
+	ClientInfo    string                 `protobuf:"bytes,4,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"` // Required: Info about client/device (agent, version, etc.)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretDeleteRequest) Reset() {
+	*x = SecretDeleteRequest{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretDeleteRequest) ProtoMessage() {}
+
+func (x *SecretDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretDeleteRequest.ProtoReflect.Descriptor instead.
+func (*SecretDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SecretDeleteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SecretDeleteRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *SecretDeleteRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *SecretDeleteRequest) GetClientInfo() string {
+	if x != nil {
+		return x.ClientInfo
+	}
+	return ""
+}
+
+type SecretTombstone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SecretId      string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`       // Deleted secret ID
+	SecretName    string                 `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"` // Deleted secret name
+	VersionId     string                 `protobuf:"bytes,3,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`    // Last version of the secret before deletion
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`    // Time of deletion
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretTombstone) Reset() {
+	*x = SecretTombstone{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretTombstone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretTombstone) ProtoMessage() {}
+
+func (x *SecretTombstone) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretTombstone.ProtoReflect.Descriptor instead.
+func (*SecretTombstone) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SecretTombstone) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *SecretTombstone) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *SecretTombstone) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *SecretTombstone) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type SecretDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Echoed back user ID
+	Tombstone     *SecretTombstone       `protobuf:"bytes,2,opt,name=tombstone,proto3" json:"tombstone,omitempty"`         // Tombstone recorded for the deleted secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretDeleteResponse) Reset() {
+	*x = SecretDeleteResponse{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretDeleteResponse) ProtoMessage() {}
+
+func (x *SecretDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretDeleteResponse.ProtoReflect.Descriptor instead.
+func (*SecretDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SecretDeleteResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SecretDeleteResponse) GetTombstone() *SecretTombstone {
+	if x != nil {
+		return x.Tombstone
+	}
+	return nil
+}
+
+type ListSecretTombstonesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Required: ID of the user performing the operation
+	Since         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`                 // Optional: Return only secrets deleted after this time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretTombstonesRequest) Reset() {
+	*x = ListSecretTombstonesRequest{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretTombstonesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretTombstonesRequest) ProtoMessage() {}
+
+func (x *ListSecretTombstonesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretTombstonesRequest.ProtoReflect.Descriptor instead.
+func (*ListSecretTombstonesRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListSecretTombstonesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSecretTombstonesRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type ListSecretTombstonesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tombstones    []*SecretTombstone     `protobuf:"bytes,1,rep,name=tombstones,proto3" json:"tombstones,omitempty"` // Deleted secrets ordered by deletion time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretTombstonesResponse) Reset() {
+	*x = ListSecretTombstonesResponse{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretTombstonesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretTombstonesResponse) ProtoMessage() {}
+
+func (x *ListSecretTombstonesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretTombstonesResponse.ProtoReflect.Descriptor instead.
+func (*ListSecretTombstonesResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListSecretTombstonesResponse) GetTombstones() []*SecretTombstone {
+	if x != nil {
+		return x.Tombstones
+	}
+	return nil
+}
+
+type ListSecretKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Required: ID of the user performing the operation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretKeysRequest) Reset() {
+	*x = ListSecretKeysRequest{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretKeysRequest) ProtoMessage() {}
+
+func (x *ListSecretKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListSecretKeysRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListSecretKeysRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListSecretKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*SecretKey           `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"` // Wrapped DEKs of all versions of user secrets
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretKeysResponse) Reset() {
+	*x = ListSecretKeysResponse{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretKeysResponse) ProtoMessage() {}
+
+func (x *ListSecretKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListSecretKeysResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListSecretKeysResponse) GetKeys() []*SecretKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type WatchSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Required: ID of the user performing the operation
+	Cursor        int64                  `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`              // Optional: Stream events recorded after this cursor
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSecretsRequest) Reset() {
+	*x = WatchSecretsRequest{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSecretsRequest) ProtoMessage() {}
+
+func (x *WatchSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSecretsRequest.ProtoReflect.Descriptor instead.
+func (*WatchSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WatchSecretsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WatchSecretsRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+type SecretEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        int64                  `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`                                // Event cursor to resume watching from
+	Type          SecretEventType        `protobuf:"varint,2,opt,name=type,proto3,enum=gophkeeper.v1.SecretEventType" json:"type,omitempty"` // Kind of change
+	SecretId      string                 `protobuf:"bytes,3,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`             // Changed secret ID
+	SecretName    string                 `protobuf:"bytes,4,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`       // Changed secret name
+	VersionId     string                 `protobuf:"bytes,5,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`          // Created version or last version before deletion
+	ClientInfo    string                 `protobuf:"bytes,6,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`       // Info about client/device which made the change
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // Time of the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretEvent) Reset() {
+	*x = SecretEvent{}
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretEvent) ProtoMessage() {}
+
+func (x *SecretEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_secret_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretEvent.ProtoReflect.Descriptor instead.
+func (*SecretEvent) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_secret_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SecretEvent) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *SecretEvent) GetType() SecretEventType {
+	if x != nil {
+		return x.Type
+	}
+	return SecretEventType_SECRET_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *SecretEvent) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *SecretEvent) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *SecretEvent) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *SecretEvent) GetClientInfo() string {
+	if x != nil {
+		return x.ClientInfo
+	}
+	return ""
+}
+
+func (x *SecretEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_gophkeeper_v1_secret_proto protoreflect.FileDescriptor
+
+const file_gophkeeper_v1_secret_proto_rawDesc = "" +
+	"\n" +
+	"\x1agophkeeper/v1/secret.proto\x12\rgophkeeper.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1agophkeeper/v1/common.proto\"\x9b\x03\n" +
+	"\x17SecretUpdateInitRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
+	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12*\n" +
+	"\vsecret_name\x18\x03 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12'\n" +
+	"\n" +
+	"version_id\x18\x04 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
+	"\x11parent_version_id\x18\x05 \x01(\tR\x0fparentVersionId\x12(\n" +
+	"\vclient_info\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"clientInfo\x12\x1b\n" +
+	"\x04size\x18\a \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x04size\x12\x1b\n" +
+	"\x04hash\x18\b \x01(\fB\a\xbaH\x04z\x02h R\x04hash\x12,\n" +
+	"\rencrypted_dek\x18\t \x01(\fB\a\xbaH\x04z\x02\x10\x01R\fencryptedDek\x12#\n" +
+	"\rmetadata_json\x18\n" +
+	" \x01(\tR\fmetadataJson\"\xc0\x02\n" +
+	"\x18SecretUpdateInitResponse\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
+	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12'\n" +
+	"\n" +
+	"version_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
+	"\x11parent_version_id\x18\x04 \x01(\tR\x0fparentVersionId\x12\x1f\n" +
+	"\x06s3_url\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x88\x01\x01R\x05s3Url\x12\x1d\n" +
+	"\x05token\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x05token\x12E\n" +
+	"\vcredentials\x18\a \x01(\v2#.gophkeeper.v1.TemporaryCredentialsR\vcredentials\"\xaf\x01\n" +
+	"\x15SecretVersionConflict\x12\x1b\n" +
+	"\tsecret_id\x18\x01 \x01(\tR\bsecretId\x12\x1f\n" +
+	"\vsecret_name\x18\x02 \x01(\tR\n" +
+	"secretName\x12*\n" +
+	"\x11parent_version_id\x18\x03 \x01(\tR\x0fparentVersionId\x12,\n" +
+	"\x12current_version_id\x18\x04 \x01(\tR\x10currentVersionId\"\x9a\x03\n" +
+	"\x19SecretUpdateCommitRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
+	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12'\n" +
+	"\n" +
+	"version_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
+	"\x11parent_version_id\x18\x04 \x01(\tR\x0fparentVersionId\x12(\n" +
+	"\vclient_info\x18\x05 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"clientInfo\x12\x1b\n" +
+	"\x04size\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x04size\x12\x1b\n" +
+	"\x04hash\x18\a \x01(\fB\a\xbaH\x04z\x02h R\x04hash\x12,\n" +
+	"\rencrypted_dek\x18\b \x01(\fB\a\xbaH\x04z\x02\x10\x01R\fencryptedDek\x12\x1d\n" +
+	"\x05token\x18\t \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x05token\x12-\n" +
+	"\vpart_hashes\x18\n" +
+	" \x03(\fB\f\xbaH\t\x92\x01\x06\"\x04z\x02h R\n" +
+	"partHashes\"\xbb\x01\n" +
+	"\x1aSecretUpdateCommitResponse\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
+	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12*\n" +
+	"\vsecret_name\x18\x03 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12'\n" +
+	"\n" +
+	"version_id\x18\x04 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\"\x88\x01\n" +
+	"\x1dRenewUploadCredentialsRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
+	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12\x1d\n" +
+	"\x05token\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x05token\"g\n" +
+	"\x1eRenewUploadCredentialsResponse\x12E\n" +
+	"\vcredentials\x18\x01 \x01(\v2#.gophkeeper.v1.TemporaryCredentialsR\vcredentials\"\x8f\x01\n" +
+	"\x14SecretGetInitRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12*\n" +
+	"\vsecret_name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12(\n" +
+	"\vclient_info\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"clientInfo\"\xd6\x03\n" +
+	"\x15SecretGetInitResponse\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
+	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12*\n" +
+	"\vsecret_name\x18\x03 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12'\n" +
+	"\n" +
+	"version_id\x18\x04 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
+	"\x11parent_version_id\x18\x05 \x01(\tR\x0fparentVersionId\x12\x1e\n" +
+	"\x06s3_url\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05s3Url\x12\x1b\n" +
+	"\x04size\x18\a \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x04size\x12\x1b\n" +
+	"\x04hash\x18\b \x01(\fB\a\xbaH\x04z\x02\x10\x01R\x04hash\x12,\n" +
+	"\rencrypted_dek\x18\t \x01(\fB\a\xbaH\x04z\x02\x10\x01R\fencryptedDek\x12#\n" +
+	"\rmetadata_json\x18\n" +
+	" \x01(\tR\fmetadataJson\x12E\n" +
+	"\vcredentials\x18\v \x01(\v2#.gophkeeper.v1.TemporaryCredentialsR\vcredentials\"\xa9\x01\n" +
+	"\x12ListSecretsRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12(\n" +
+	"\vname_prefix\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x18@R\n" +
+	"namePrefix\x12'\n" +
+	"\tpage_size\x18\x03 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\xd2\x02\n" +
+	"\n" +
+	"SecretInfo\x12%\n" +
+	"\tsecret_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12*\n" +
+	"\vsecret_name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12'\n" +
+	"\n" +
+	"version_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
+	"\x11parent_version_id\x18\x04 \x01(\tR\x0fparentVersionId\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04hash\x18\x06 \x01(\fR\x04hash\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"r\n" +
+	"\x13ListSecretsResponse\x123\n" +
+	"\asecrets\x18\x01 \x03(\v2\x19.gophkeeper.v1.SecretInfoR\asecrets\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"j\n" +
+	"\x19ListSecretVersionsRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12*\n" +
+	"\vsecret_name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\"\xcb\x01\n" +
+	"\x11SecretVersionInfo\x12'\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
+	"\x11parent_version_id\x18\x02 \x01(\tR\x0fparentVersionId\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04hash\x18\x04 \x01(\fR\x04hash\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xda\x01\n" +
+	"\x1aListSecretVersionsResponse\x12%\n" +
+	"\tsecret_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12\x1f\n" +
+	"\vsecret_name\x18\x02 \x01(\tR\n" +
+	"secretName\x126\n" +
+	"\x12current_version_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x10currentVersionId\x12<\n" +
+	"\bversions\x18\x04 \x03(\v2 .gophkeeper.v1.SecretVersionInfoR\bversions\"\xbb\x01\n" +
+	"\x17GetSecretVersionRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12*\n" +
+	"\vsecret_name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12'\n" +
+	"\n" +
+	"version_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12(\n" +
+	"\vclient_info\x18\x04 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"clientInfo\"\x87\x04\n" +
+	"\x18GetSecretVersionResponse\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
+	"\tsecret_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12*\n" +
+	"\vsecret_name\x18\x03 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12'\n" +
+	"\n" +
+	"version_id\x18\x04 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12*\n" +
+	"\x11parent_version_id\x18\x05 \x01(\tR\x0fparentVersionId\x12\x1e\n" +
+	"\x06s3_url\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05s3Url\x12\x1b\n" +
+	"\x04size\x18\a \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x04size\x12\x1b\n" +
+	"\x04hash\x18\b \x01(\fB\a\xbaH\x04z\x02\x10\x01R\x04hash\x12,\n" +
+	"\rencrypted_dek\x18\t \x01(\fB\a\xbaH\x04z\x02\x10\x01R\fencryptedDek\x12#\n" +
+	"\rmetadata_json\x18\n" +
+	" \x01(\tR\fmetadataJson\x12E\n" +
+	"\vcredentials\x18\v \x01(\v2#.gophkeeper.v1.TemporaryCredentialsR\vcredentials\x12,\n" +
+	"\x12current_version_id\x18\f \x01(\tR\x10currentVersionId\"\xb7\x01\n" +
+	"\x13SecretDeleteRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12*\n" +
+	"\vsecret_name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12'\n" +
+	"\n" +
+	"version_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12(\n" +
+	"\vclient_info\x18\x04 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"clientInfo\"\xc8\x01\n" +
+	"\x0fSecretTombstone\x12%\n" +
+	"\tsecret_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12*\n" +
+	"\vsecret_name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12'\n" +
+	"\n" +
+	"version_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x129\n" +
+	"\n" +
+	"deleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"w\n" +
+	"\x14SecretDeleteResponse\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12<\n" +
+	"\ttombstone\x18\x02 \x01(\v2\x1e.gophkeeper.v1.SecretTombstoneR\ttombstone\"r\n" +
+	"\x1bListSecretTombstonesRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"^\n" +
+	"\x1cListSecretTombstonesResponse\x12>\n" +
+	"\n" +
+	"tombstones\x18\x01 \x03(\v2\x1e.gophkeeper.v1.SecretTombstoneR\n" +
+	"tombstones\":\n" +
+	"\x15ListSecretKeysRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\"F\n" +
+	"\x16ListSecretKeysResponse\x12,\n" +
+	"\x04keys\x18\x01 \x03(\v2\x18.gophkeeper.v1.SecretKeyR\x04keys\"Y\n" +
+	"\x13WatchSecretsRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12\x1f\n" +
+	"\x06cursor\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x06cursor\"\xb1\x02\n" +
+	"\vSecretEvent\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\x03R\x06cursor\x122\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1e.gophkeeper.v1.SecretEventTypeR\x04type\x12%\n" +
+	"\tsecret_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bsecretId\x12*\n" +
+	"\vsecret_name\x18\x04 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"secretName\x12'\n" +
+	"\n" +
+	"version_id\x18\x05 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId\x12\x1f\n" +
+	"\vclient_info\x18\x06 \x01(\tR\n" +
+	"clientInfo\x129\n" +
 	"\n" +
-	"version_id\x18\x04 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tversionId2\xdf\x01\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt*\x8e\x01\n" +
+	"\x0fSecretEventType\x12!\n" +
+	"\x1dSECRET_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18SECRET_EVENT_TYPE_CREATE\x10\x01\x12\x1c\n" +
+	"\x18SECRET_EVENT_TYPE_UPDATE\x10\x02\x12\x1c\n" +
+	"\x18SECRET_EVENT_TYPE_DELETE\x10\x032\xd3\b\n" +
 	"\rSecretService\x12c\n" +
 	"\x10SecretUpdateInit\x12&.gophkeeper.v1.SecretUpdateInitRequest\x1a'.gophkeeper.v1.SecretUpdateInitResponse\x12i\n" +
-	"\x12SecretUpdateCommit\x12(.gophkeeper.v1.SecretUpdateCommitRequest\x1a).gophkeeper.v1.SecretUpdateCommitResponseB\xba\x01\n" +
+	"\x12SecretUpdateCommit\x12(.gophkeeper.v1.SecretUpdateCommitRequest\x1a).gophkeeper.v1.SecretUpdateCommitResponse\x12u\n" +
+	"\x16RenewUploadCredentials\x12,.gophkeeper.v1.RenewUploadCredentialsRequest\x1a-.gophkeeper.v1.RenewUploadCredentialsResponse\x12Z\n" +
+	"\rSecretGetInit\x12#.gophkeeper.v1.SecretGetInitRequest\x1a$.gophkeeper.v1.SecretGetInitResponse\x12T\n" +
+	"\vListSecrets\x12!.gophkeeper.v1.ListSecretsRequest\x1a\".gophkeeper.v1.ListSecretsResponse\x12i\n" +
+	"\x12ListSecretVersions\x12(.gophkeeper.v1.ListSecretVersionsRequest\x1a).gophkeeper.v1.ListSecretVersionsResponse\x12c\n" +
+	"\x10GetSecretVersion\x12&.gophkeeper.v1.GetSecretVersionRequest\x1a'.gophkeeper.v1.GetSecretVersionResponse\x12W\n" +
+	"\fSecretDelete\x12\".gophkeeper.v1.SecretDeleteRequest\x1a#.gophkeeper.v1.SecretDeleteResponse\x12o\n" +
+	"\x14ListSecretTombstones\x12*.gophkeeper.v1.ListSecretTombstonesRequest\x1a+.gophkeeper.v1.ListSecretTombstonesResponse\x12]\n" +
+	"\x0eListSecretKeys\x12$.gophkeeper.v1.ListSecretKeysRequest\x1a%.gophkeeper.v1.ListSecretKeysResponse\x12P\n" +
+	"\fWatchSecrets\x12\".gophkeeper.v1.WatchSecretsRequest\x1a\x1a.gophkeeper.v1.SecretEvent0\x01B\xba\x01\n" +
 	"\x11com.gophkeeper.v1B\vSecretProtoP\x01ZCgithub.com/patraden/ya-practicum-gophkeeper/api/gophkeeper/v1;proto\xa2\x02\x03GXX\xaa\x02\rGophkeeper.V1\xca\x02\rGophkeeper\\V1\xe2\x02\x19Gophkeeper\\V1\\GPBMetadata\xea\x02\x0eGophkeeper::V1b\x06proto3"
 
 var (
@@ -472,25 +2185,84 @@ func file_gophkeeper_v1_secret_proto_rawDescGZIP() []byte {
 	return file_gophkeeper_v1_secret_proto_rawDescData
 }
 
-var file_gophkeeper_v1_secret_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_gophkeeper_v1_secret_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_gophkeeper_v1_secret_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_gophkeeper_v1_secret_proto_goTypes = []any{
-	(*SecretUpdateInitRequest)(nil),    // 0: gophkeeper.v1.SecretUpdateInitRequest
-	(*SecretUpdateInitResponse)(nil),   // 1: gophkeeper.v1.SecretUpdateInitResponse
-	(*SecretUpdateCommitRequest)(nil),  // 2: gophkeeper.v1.SecretUpdateCommitRequest
-	(*SecretUpdateCommitResponse)(nil), // 3: gophkeeper.v1.SecretUpdateCommitResponse
-	(*TemporaryCredentials)(nil),       // 4: gophkeeper.v1.TemporaryCredentials
+	(SecretEventType)(0),                   // 0: gophkeeper.v1.SecretEventType
+	(*SecretUpdateInitRequest)(nil),        // 1: gophkeeper.v1.SecretUpdateInitRequest
+	(*SecretUpdateInitResponse)(nil),       // 2: gophkeeper.v1.SecretUpdateInitResponse
+	(*SecretVersionConflict)(nil),          // 3: gophkeeper.v1.SecretVersionConflict
+	(*SecretUpdateCommitRequest)(nil),      // 4: gophkeeper.v1.SecretUpdateCommitRequest
+	(*SecretUpdateCommitResponse)(nil),     // 5: gophkeeper.v1.SecretUpdateCommitResponse
+	(*RenewUploadCredentialsRequest)(nil),  // 6: gophkeeper.v1.RenewUploadCredentialsRequest
+	(*RenewUploadCredentialsResponse)(nil), // 7: gophkeeper.v1.RenewUploadCredentialsResponse
+	(*SecretGetInitRequest)(nil),           // 8: gophkeeper.v1.SecretGetInitRequest
+	(*SecretGetInitResponse)(nil),          // 9: gophkeeper.v1.SecretGetInitResponse
+	(*ListSecretsRequest)(nil),             // 10: gophkeeper.v1.ListSecretsRequest
+	(*SecretInfo)(nil),                     // 11: gophkeeper.v1.SecretInfo
+	(*ListSecretsResponse)(nil),            // 12: gophkeeper.v1.ListSecretsResponse
+	(*ListSecretVersionsRequest)(nil),      // 13: gophkeeper.v1.ListSecretVersionsRequest
+	(*SecretVersionInfo)(nil),              // 14: gophkeeper.v1.SecretVersionInfo
+	(*ListSecretVersionsResponse)(nil),     // 15: gophkeeper.v1.ListSecretVersionsResponse
+	(*GetSecretVersionRequest)(nil),        // 16: gophkeeper.v1.GetSecretVersionRequest
+	(*GetSecretVersionResponse)(nil),       // 17: gophkeeper.v1.GetSecretVersionResponse
+	(*SecretDeleteRequest)(nil),            // 18: gophkeeper.v1.SecretDeleteRequest
+	(*SecretTombstone)(nil),                // 19: gophkeeper.v1.SecretTombstone
+	(*SecretDeleteResponse)(nil),           // 20: gophkeeper.v1.SecretDeleteResponse
+	(*ListSecretTombstonesRequest)(nil),    // 21: gophkeeper.v1.ListSecretTombstonesRequest
+	(*ListSecretTombstonesResponse)(nil),   // 22: gophkeeper.v1.ListSecretTombstonesResponse
+	(*ListSecretKeysRequest)(nil),          // 23: gophkeeper.v1.ListSecretKeysRequest
+	(*ListSecretKeysResponse)(nil),         // 24: gophkeeper.v1.ListSecretKeysResponse
+	(*WatchSecretsRequest)(nil),            // 25: gophkeeper.v1.WatchSecretsRequest
+	(*SecretEvent)(nil),                    // 26: gophkeeper.v1.SecretEvent
+	(*TemporaryCredentials)(nil),           // 27: gophkeeper.v1.TemporaryCredentials
+	(*timestamppb.Timestamp)(nil),          // 28: google.protobuf.Timestamp
+	(*SecretKey)(nil),                      // 29: gophkeeper.v1.SecretKey
 }
 var file_gophkeeper_v1_secret_proto_depIdxs = []int32{
-	4, // 0: gophkeeper.v1.SecretUpdateInitResponse.credentials:type_name -> gophkeeper.v1.TemporaryCredentials
-	0, // 1: gophkeeper.v1.SecretService.SecretUpdateInit:input_type -> gophkeeper.v1.SecretUpdateInitRequest
-	2, // 2: gophkeeper.v1.SecretService.SecretUpdateCommit:input_type -> gophkeeper.v1.SecretUpdateCommitRequest
-	1, // 3: gophkeeper.v1.SecretService.SecretUpdateInit:output_type -> gophkeeper.v1.SecretUpdateInitResponse
-	3, // 4: gophkeeper.v1.SecretService.SecretUpdateCommit:output_type -> gophkeeper.v1.SecretUpdateCommitResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	27, // 0: gophkeeper.v1.SecretUpdateInitResponse.credentials:type_name -> gophkeeper.v1.TemporaryCredentials
+	27, // 1: gophkeeper.v1.RenewUploadCredentialsResponse.credentials:type_name -> gophkeeper.v1.TemporaryCredentials
+	27, // 2: gophkeeper.v1.SecretGetInitResponse.credentials:type_name -> gophkeeper.v1.TemporaryCredentials
+	28, // 3: gophkeeper.v1.SecretInfo.created_at:type_name -> google.protobuf.Timestamp
+	28, // 4: gophkeeper.v1.SecretInfo.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 5: gophkeeper.v1.ListSecretsResponse.secrets:type_name -> gophkeeper.v1.SecretInfo
+	28, // 6: gophkeeper.v1.SecretVersionInfo.created_at:type_name -> google.protobuf.Timestamp
+	14, // 7: gophkeeper.v1.ListSecretVersionsResponse.versions:type_name -> gophkeeper.v1.SecretVersionInfo
+	27, // 8: gophkeeper.v1.GetSecretVersionResponse.credentials:type_name -> gophkeeper.v1.TemporaryCredentials
+	28, // 9: gophkeeper.v1.SecretTombstone.deleted_at:type_name -> google.protobuf.Timestamp
+	19, // 10: gophkeeper.v1.SecretDeleteResponse.tombstone:type_name -> gophkeeper.v1.SecretTombstone
+	28, // 11: gophkeeper.v1.ListSecretTombstonesRequest.since:type_name -> google.protobuf.Timestamp
+	19, // 12: gophkeeper.v1.ListSecretTombstonesResponse.tombstones:type_name -> gophkeeper.v1.SecretTombstone
+	29, // 13: gophkeeper.v1.ListSecretKeysResponse.keys:type_name -> gophkeeper.v1.SecretKey
+	0,  // 14: gophkeeper.v1.SecretEvent.type:type_name -> gophkeeper.v1.SecretEventType
+	28, // 15: gophkeeper.v1.SecretEvent.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 16: gophkeeper.v1.SecretService.SecretUpdateInit:input_type -> gophkeeper.v1.SecretUpdateInitRequest
+	4,  // 17: gophkeeper.v1.SecretService.SecretUpdateCommit:input_type -> gophkeeper.v1.SecretUpdateCommitRequest
+	6,  // 18: gophkeeper.v1.SecretService.RenewUploadCredentials:input_type -> gophkeeper.v1.RenewUploadCredentialsRequest
+	8,  // 19: gophkeeper.v1.SecretService.SecretGetInit:input_type -> gophkeeper.v1.SecretGetInitRequest
+	10, // 20: gophkeeper.v1.SecretService.ListSecrets:input_type -> gophkeeper.v1.ListSecretsRequest
+	13, // 21: gophkeeper.v1.SecretService.ListSecretVersions:input_type -> gophkeeper.v1.ListSecretVersionsRequest
+	16, // 22: gophkeeper.v1.SecretService.GetSecretVersion:input_type -> gophkeeper.v1.GetSecretVersionRequest
+	18, // 23: gophkeeper.v1.SecretService.SecretDelete:input_type -> gophkeeper.v1.SecretDeleteRequest
+	21, // 24: gophkeeper.v1.SecretService.ListSecretTombstones:input_type -> gophkeeper.v1.ListSecretTombstonesRequest
+	23, // 25: gophkeeper.v1.SecretService.ListSecretKeys:input_type -> gophkeeper.v1.ListSecretKeysRequest
+	25, // 26: gophkeeper.v1.SecretService.WatchSecrets:input_type -> gophkeeper.v1.WatchSecretsRequest
+	2,  // 27: gophkeeper.v1.SecretService.SecretUpdateInit:output_type -> gophkeeper.v1.SecretUpdateInitResponse
+	5,  // 28: gophkeeper.v1.SecretService.SecretUpdateCommit:output_type -> gophkeeper.v1.SecretUpdateCommitResponse
+	7,  // 29: gophkeeper.v1.SecretService.RenewUploadCredentials:output_type -> gophkeeper.v1.RenewUploadCredentialsResponse
+	9,  // 30: gophkeeper.v1.SecretService.SecretGetInit:output_type -> gophkeeper.v1.SecretGetInitResponse
+	12, // 31: gophkeeper.v1.SecretService.ListSecrets:output_type -> gophkeeper.v1.ListSecretsResponse
+	15, // 32: gophkeeper.v1.SecretService.ListSecretVersions:output_type -> gophkeeper.v1.ListSecretVersionsResponse
+	17, // 33: gophkeeper.v1.SecretService.GetSecretVersion:output_type -> gophkeeper.v1.GetSecretVersionResponse
+	20, // 34: gophkeeper.v1.SecretService.SecretDelete:output_type -> gophkeeper.v1.SecretDeleteResponse
+	22, // 35: gophkeeper.v1.SecretService.ListSecretTombstones:output_type -> gophkeeper.v1.ListSecretTombstonesResponse
+	24, // 36: gophkeeper.v1.SecretService.ListSecretKeys:output_type -> gophkeeper.v1.ListSecretKeysResponse
+	26, // 37: gophkeeper.v1.SecretService.WatchSecrets:output_type -> gophkeeper.v1.SecretEvent
+	27, // [27:38] is the sub-list for method output_type
+	16, // [16:27] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_gophkeeper_v1_secret_proto_init() }
@@ -504,13 +2276,14 @@ func file_gophkeeper_v1_secret_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gophkeeper_v1_secret_proto_rawDesc), len(file_gophkeeper_v1_secret_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_gophkeeper_v1_secret_proto_goTypes,
 		DependencyIndexes: file_gophkeeper_v1_secret_proto_depIdxs,
+		EnumInfos:         file_gophkeeper_v1_secret_proto_enumTypes,
 		MessageInfos:      file_gophkeeper_v1_secret_proto_msgTypes,
 	}.Build()
 	File_gophkeeper_v1_secret_proto = out.File
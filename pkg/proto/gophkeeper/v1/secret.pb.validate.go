@@ -300,6 +300,116 @@ var _ interface {
 	ErrorName() string
 } = SecretUpdateInitResponseValidationError{}
 
+// Validate checks the field values on SecretVersionConflict with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SecretVersionConflict) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretVersionConflict with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SecretVersionConflictMultiError, or nil if none found.
+func (m *SecretVersionConflict) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretVersionConflict) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SecretId
+
+	// no validation rules for SecretName
+
+	// no validation rules for ParentVersionId
+
+	// no validation rules for CurrentVersionId
+
+	if len(errors) > 0 {
+		return SecretVersionConflictMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretVersionConflictMultiError is an error wrapping multiple validation
+// errors returned by SecretVersionConflict.ValidateAll() if the designated
+// constraints aren't met.
+type SecretVersionConflictMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretVersionConflictMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretVersionConflictMultiError) AllErrors() []error { return m }
+
+// SecretVersionConflictValidationError is the validation error returned by
+// SecretVersionConflict.Validate if the designated constraints aren't met.
+type SecretVersionConflictValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretVersionConflictValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretVersionConflictValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretVersionConflictValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretVersionConflictValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretVersionConflictValidationError) ErrorName() string {
+	return "SecretVersionConflictValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SecretVersionConflictValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretVersionConflict.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretVersionConflictValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretVersionConflictValidationError{}
+
 // Validate checks the field values on SecretUpdateCommitRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -529,3 +639,2705 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = SecretUpdateCommitResponseValidationError{}
+
+// Validate checks the field values on RenewUploadCredentialsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RenewUploadCredentialsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RenewUploadCredentialsRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RenewUploadCredentialsRequestMultiError, or nil if none found.
+func (m *RenewUploadCredentialsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RenewUploadCredentialsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for SecretId
+
+	// no validation rules for Token
+
+	if len(errors) > 0 {
+		return RenewUploadCredentialsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RenewUploadCredentialsRequestMultiError is an error wrapping multiple
+// validation errors returned by RenewUploadCredentialsRequest.ValidateAll()
+// if the designated constraints aren't met.
+type RenewUploadCredentialsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RenewUploadCredentialsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RenewUploadCredentialsRequestMultiError) AllErrors() []error { return m }
+
+// RenewUploadCredentialsRequestValidationError is the validation error
+// returned by RenewUploadCredentialsRequest.Validate if the designated
+// constraints aren't met.
+type RenewUploadCredentialsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RenewUploadCredentialsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RenewUploadCredentialsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RenewUploadCredentialsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RenewUploadCredentialsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RenewUploadCredentialsRequestValidationError) ErrorName() string {
+	return "RenewUploadCredentialsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RenewUploadCredentialsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRenewUploadCredentialsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RenewUploadCredentialsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RenewUploadCredentialsRequestValidationError{}
+
+// Validate checks the field values on RenewUploadCredentialsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RenewUploadCredentialsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RenewUploadCredentialsResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RenewUploadCredentialsResponseMultiError, or nil if none found.
+func (m *RenewUploadCredentialsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RenewUploadCredentialsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCredentials()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RenewUploadCredentialsResponseValidationError{
+					field:  "Credentials",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RenewUploadCredentialsResponseValidationError{
+					field:  "Credentials",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCredentials()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RenewUploadCredentialsResponseValidationError{
+				field:  "Credentials",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RenewUploadCredentialsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RenewUploadCredentialsResponseMultiError is an error wrapping multiple
+// validation errors returned by RenewUploadCredentialsResponse.ValidateAll()
+// if the designated constraints aren't met.
+type RenewUploadCredentialsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RenewUploadCredentialsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RenewUploadCredentialsResponseMultiError) AllErrors() []error { return m }
+
+// RenewUploadCredentialsResponseValidationError is the validation error
+// returned by RenewUploadCredentialsResponse.Validate if the designated
+// constraints aren't met.
+type RenewUploadCredentialsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RenewUploadCredentialsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RenewUploadCredentialsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RenewUploadCredentialsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RenewUploadCredentialsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RenewUploadCredentialsResponseValidationError) ErrorName() string {
+	return "RenewUploadCredentialsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RenewUploadCredentialsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRenewUploadCredentialsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RenewUploadCredentialsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RenewUploadCredentialsResponseValidationError{}
+
+// Validate checks the field values on SecretGetInitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SecretGetInitRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretGetInitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SecretGetInitRequestMultiError, or nil if none found.
+func (m *SecretGetInitRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretGetInitRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for SecretName
+
+	// no validation rules for ClientInfo
+
+	if len(errors) > 0 {
+		return SecretGetInitRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretGetInitRequestMultiError is an error wrapping multiple validation
+// errors returned by SecretGetInitRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SecretGetInitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretGetInitRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretGetInitRequestMultiError) AllErrors() []error { return m }
+
+// SecretGetInitRequestValidationError is the validation error returned by
+// SecretGetInitRequest.Validate if the designated constraints aren't met.
+type SecretGetInitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretGetInitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretGetInitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretGetInitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretGetInitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretGetInitRequestValidationError) ErrorName() string {
+	return "SecretGetInitRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SecretGetInitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretGetInitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretGetInitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretGetInitRequestValidationError{}
+
+// Validate checks the field values on SecretGetInitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SecretGetInitResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretGetInitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SecretGetInitResponseMultiError, or nil if none found.
+func (m *SecretGetInitResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretGetInitResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for SecretId
+
+	// no validation rules for SecretName
+
+	// no validation rules for VersionId
+
+	// no validation rules for ParentVersionId
+
+	// no validation rules for S3Url
+
+	// no validation rules for Size
+
+	// no validation rules for Hash
+
+	// no validation rules for EncryptedDek
+
+	// no validation rules for MetadataJson
+
+	if all {
+		switch v := interface{}(m.GetCredentials()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SecretGetInitResponseValidationError{
+					field:  "Credentials",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SecretGetInitResponseValidationError{
+					field:  "Credentials",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCredentials()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SecretGetInitResponseValidationError{
+				field:  "Credentials",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SecretGetInitResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretGetInitResponseMultiError is an error wrapping multiple validation
+// errors returned by SecretGetInitResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SecretGetInitResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretGetInitResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretGetInitResponseMultiError) AllErrors() []error { return m }
+
+// SecretGetInitResponseValidationError is the validation error returned by
+// SecretGetInitResponse.Validate if the designated constraints aren't met.
+type SecretGetInitResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretGetInitResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretGetInitResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretGetInitResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretGetInitResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretGetInitResponseValidationError) ErrorName() string {
+	return "SecretGetInitResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SecretGetInitResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretGetInitResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretGetInitResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretGetInitResponseValidationError{}
+
+// Validate checks the field values on ListSecretsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListSecretsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSecretsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSecretsRequestMultiError, or nil if none found.
+func (m *ListSecretsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSecretsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for NamePrefix
+
+	// no validation rules for PageSize
+
+	// no validation rules for PageToken
+
+	if len(errors) > 0 {
+		return ListSecretsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSecretsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListSecretsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListSecretsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSecretsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSecretsRequestMultiError) AllErrors() []error { return m }
+
+// ListSecretsRequestValidationError is the validation error returned by
+// ListSecretsRequest.Validate if the designated constraints aren't met.
+type ListSecretsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSecretsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSecretsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSecretsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSecretsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSecretsRequestValidationError) ErrorName() string {
+	return "ListSecretsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSecretsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSecretsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSecretsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSecretsRequestValidationError{}
+
+// Validate checks the field values on SecretInfo with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SecretInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretInfo with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SecretInfoMultiError, or
+// nil if none found.
+func (m *SecretInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SecretId
+
+	// no validation rules for SecretName
+
+	// no validation rules for VersionId
+
+	// no validation rules for ParentVersionId
+
+	// no validation rules for Size
+
+	// no validation rules for Hash
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SecretInfoValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SecretInfoValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SecretInfoValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SecretInfoValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SecretInfoValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SecretInfoValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SecretInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretInfoMultiError is an error wrapping multiple validation errors
+// returned by SecretInfo.ValidateAll() if the designated constraints aren't met.
+type SecretInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretInfoMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretInfoMultiError) AllErrors() []error { return m }
+
+// SecretInfoValidationError is the validation error returned by
+// SecretInfo.Validate if the designated constraints aren't met.
+type SecretInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretInfoValidationError) ErrorName() string { return "SecretInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SecretInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretInfoValidationError{}
+
+// Validate checks the field values on ListSecretsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListSecretsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSecretsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSecretsResponseMultiError, or nil if none found.
+func (m *ListSecretsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSecretsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetSecrets() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListSecretsResponseValidationError{
+						field:  fmt.Sprintf("Secrets[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListSecretsResponseValidationError{
+						field:  fmt.Sprintf("Secrets[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListSecretsResponseValidationError{
+					field:  fmt.Sprintf("Secrets[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextPageToken
+
+	if len(errors) > 0 {
+		return ListSecretsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSecretsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListSecretsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListSecretsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSecretsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSecretsResponseMultiError) AllErrors() []error { return m }
+
+// ListSecretsResponseValidationError is the validation error returned by
+// ListSecretsResponse.Validate if the designated constraints aren't met.
+type ListSecretsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSecretsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSecretsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSecretsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSecretsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSecretsResponseValidationError) ErrorName() string {
+	return "ListSecretsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSecretsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSecretsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSecretsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSecretsResponseValidationError{}
+
+// Validate checks the field values on ListSecretVersionsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListSecretVersionsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSecretVersionsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSecretVersionsRequestMultiError, or nil if none found.
+func (m *ListSecretVersionsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSecretVersionsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for SecretName
+
+	if len(errors) > 0 {
+		return ListSecretVersionsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSecretVersionsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListSecretVersionsRequest.ValidateAll() if the
+// designated constraints aren't met.
+type ListSecretVersionsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSecretVersionsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSecretVersionsRequestMultiError) AllErrors() []error { return m }
+
+// ListSecretVersionsRequestValidationError is the validation error returned by
+// ListSecretVersionsRequest.Validate if the designated constraints aren't met.
+type ListSecretVersionsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSecretVersionsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSecretVersionsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSecretVersionsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSecretVersionsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSecretVersionsRequestValidationError) ErrorName() string {
+	return "ListSecretVersionsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSecretVersionsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSecretVersionsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSecretVersionsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSecretVersionsRequestValidationError{}
+
+// Validate checks the field values on SecretVersionInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SecretVersionInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretVersionInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SecretVersionInfoMultiError, or nil if none found.
+func (m *SecretVersionInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretVersionInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for VersionId
+
+	// no validation rules for ParentVersionId
+
+	// no validation rules for Size
+
+	// no validation rules for Hash
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SecretVersionInfoValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SecretVersionInfoValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SecretVersionInfoValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SecretVersionInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretVersionInfoMultiError is an error wrapping multiple validation errors
+// returned by SecretVersionInfo.ValidateAll() if the designated constraints
+// aren't met.
+type SecretVersionInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretVersionInfoMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretVersionInfoMultiError) AllErrors() []error { return m }
+
+// SecretVersionInfoValidationError is the validation error returned by
+// SecretVersionInfo.Validate if the designated constraints aren't met.
+type SecretVersionInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretVersionInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretVersionInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretVersionInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretVersionInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretVersionInfoValidationError) ErrorName() string {
+	return "SecretVersionInfoValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SecretVersionInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretVersionInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretVersionInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretVersionInfoValidationError{}
+
+// Validate checks the field values on ListSecretVersionsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListSecretVersionsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSecretVersionsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSecretVersionsResponseMultiError, or nil if none found.
+func (m *ListSecretVersionsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSecretVersionsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SecretId
+
+	// no validation rules for SecretName
+
+	// no validation rules for CurrentVersionId
+
+	for idx, item := range m.GetVersions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListSecretVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListSecretVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListSecretVersionsResponseValidationError{
+					field:  fmt.Sprintf("Versions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListSecretVersionsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSecretVersionsResponseMultiError is an error wrapping multiple
+// validation errors returned by ListSecretVersionsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ListSecretVersionsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSecretVersionsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSecretVersionsResponseMultiError) AllErrors() []error { return m }
+
+// ListSecretVersionsResponseValidationError is the validation error returned
+// by ListSecretVersionsResponse.Validate if the designated constraints aren't met.
+type ListSecretVersionsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSecretVersionsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSecretVersionsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSecretVersionsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSecretVersionsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSecretVersionsResponseValidationError) ErrorName() string {
+	return "ListSecretVersionsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSecretVersionsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSecretVersionsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSecretVersionsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSecretVersionsResponseValidationError{}
+
+// Validate checks the field values on GetSecretVersionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetSecretVersionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetSecretVersionRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetSecretVersionRequestMultiError, or nil if none found.
+func (m *GetSecretVersionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetSecretVersionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for SecretName
+
+	// no validation rules for VersionId
+
+	// no validation rules for ClientInfo
+
+	if len(errors) > 0 {
+		return GetSecretVersionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetSecretVersionRequestMultiError is an error wrapping multiple validation
+// errors returned by GetSecretVersionRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetSecretVersionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetSecretVersionRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetSecretVersionRequestMultiError) AllErrors() []error { return m }
+
+// GetSecretVersionRequestValidationError is the validation error returned by
+// GetSecretVersionRequest.Validate if the designated constraints aren't met.
+type GetSecretVersionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetSecretVersionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetSecretVersionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetSecretVersionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetSecretVersionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetSecretVersionRequestValidationError) ErrorName() string {
+	return "GetSecretVersionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetSecretVersionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetSecretVersionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetSecretVersionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetSecretVersionRequestValidationError{}
+
+// Validate checks the field values on GetSecretVersionResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetSecretVersionResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetSecretVersionResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetSecretVersionResponseMultiError, or nil if none found.
+func (m *GetSecretVersionResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetSecretVersionResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for SecretId
+
+	// no validation rules for SecretName
+
+	// no validation rules for VersionId
+
+	// no validation rules for ParentVersionId
+
+	// no validation rules for S3Url
+
+	// no validation rules for Size
+
+	// no validation rules for Hash
+
+	// no validation rules for EncryptedDek
+
+	// no validation rules for MetadataJson
+
+	if all {
+		switch v := interface{}(m.GetCredentials()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetSecretVersionResponseValidationError{
+					field:  "Credentials",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetSecretVersionResponseValidationError{
+					field:  "Credentials",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCredentials()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetSecretVersionResponseValidationError{
+				field:  "Credentials",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for CurrentVersionId
+
+	if len(errors) > 0 {
+		return GetSecretVersionResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetSecretVersionResponseMultiError is an error wrapping multiple validation
+// errors returned by GetSecretVersionResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetSecretVersionResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetSecretVersionResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetSecretVersionResponseMultiError) AllErrors() []error { return m }
+
+// GetSecretVersionResponseValidationError is the validation error returned by
+// GetSecretVersionResponse.Validate if the designated constraints aren't met.
+type GetSecretVersionResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetSecretVersionResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetSecretVersionResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetSecretVersionResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetSecretVersionResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetSecretVersionResponseValidationError) ErrorName() string {
+	return "GetSecretVersionResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetSecretVersionResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetSecretVersionResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetSecretVersionResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetSecretVersionResponseValidationError{}
+
+// Validate checks the field values on SecretDeleteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SecretDeleteRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretDeleteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SecretDeleteRequestMultiError, or nil if none found.
+func (m *SecretDeleteRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretDeleteRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for SecretName
+
+	// no validation rules for VersionId
+
+	// no validation rules for ClientInfo
+
+	if len(errors) > 0 {
+		return SecretDeleteRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretDeleteRequestMultiError is an error wrapping multiple validation
+// errors returned by SecretDeleteRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SecretDeleteRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretDeleteRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretDeleteRequestMultiError) AllErrors() []error { return m }
+
+// SecretDeleteRequestValidationError is the validation error returned by
+// SecretDeleteRequest.Validate if the designated constraints aren't met.
+type SecretDeleteRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretDeleteRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretDeleteRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretDeleteRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretDeleteRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretDeleteRequestValidationError) ErrorName() string {
+	return "SecretDeleteRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SecretDeleteRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretDeleteRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretDeleteRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretDeleteRequestValidationError{}
+
+// Validate checks the field values on SecretTombstone with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SecretTombstone) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretTombstone with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SecretTombstoneMultiError, or nil if none found.
+func (m *SecretTombstone) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretTombstone) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SecretId
+
+	// no validation rules for SecretName
+
+	// no validation rules for VersionId
+
+	if all {
+		switch v := interface{}(m.GetDeletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SecretTombstoneValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SecretTombstoneValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SecretTombstoneValidationError{
+				field:  "DeletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SecretTombstoneMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretTombstoneMultiError is an error wrapping multiple validation errors
+// returned by SecretTombstone.ValidateAll() if the designated constraints
+// aren't met.
+type SecretTombstoneMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretTombstoneMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretTombstoneMultiError) AllErrors() []error { return m }
+
+// SecretTombstoneValidationError is the validation error returned by
+// SecretTombstone.Validate if the designated constraints aren't met.
+type SecretTombstoneValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretTombstoneValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretTombstoneValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretTombstoneValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretTombstoneValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretTombstoneValidationError) ErrorName() string { return "SecretTombstoneValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SecretTombstoneValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretTombstone.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretTombstoneValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretTombstoneValidationError{}
+
+// Validate checks the field values on SecretDeleteResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SecretDeleteResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretDeleteResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SecretDeleteResponseMultiError, or nil if none found.
+func (m *SecretDeleteResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretDeleteResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	if all {
+		switch v := interface{}(m.GetTombstone()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SecretDeleteResponseValidationError{
+					field:  "Tombstone",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SecretDeleteResponseValidationError{
+					field:  "Tombstone",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTombstone()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SecretDeleteResponseValidationError{
+				field:  "Tombstone",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SecretDeleteResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretDeleteResponseMultiError is an error wrapping multiple validation
+// errors returned by SecretDeleteResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SecretDeleteResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretDeleteResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretDeleteResponseMultiError) AllErrors() []error { return m }
+
+// SecretDeleteResponseValidationError is the validation error returned by
+// SecretDeleteResponse.Validate if the designated constraints aren't met.
+type SecretDeleteResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretDeleteResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretDeleteResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretDeleteResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretDeleteResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretDeleteResponseValidationError) ErrorName() string {
+	return "SecretDeleteResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SecretDeleteResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretDeleteResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretDeleteResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretDeleteResponseValidationError{}
+
+// Validate checks the field values on ListSecretTombstonesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListSecretTombstonesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSecretTombstonesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSecretTombstonesRequestMultiError, or nil if none found.
+func (m *ListSecretTombstonesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSecretTombstonesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	if all {
+		switch v := interface{}(m.GetSince()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListSecretTombstonesRequestValidationError{
+					field:  "Since",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListSecretTombstonesRequestValidationError{
+					field:  "Since",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSince()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListSecretTombstonesRequestValidationError{
+				field:  "Since",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ListSecretTombstonesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSecretTombstonesRequestMultiError is an error wrapping multiple
+// validation errors returned by ListSecretTombstonesRequest.ValidateAll() if
+// the designated constraints aren't met.
+type ListSecretTombstonesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSecretTombstonesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSecretTombstonesRequestMultiError) AllErrors() []error { return m }
+
+// ListSecretTombstonesRequestValidationError is the validation error returned
+// by ListSecretTombstonesRequest.Validate if the designated constraints
+// aren't met.
+type ListSecretTombstonesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSecretTombstonesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSecretTombstonesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSecretTombstonesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSecretTombstonesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSecretTombstonesRequestValidationError) ErrorName() string {
+	return "ListSecretTombstonesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSecretTombstonesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSecretTombstonesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSecretTombstonesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSecretTombstonesRequestValidationError{}
+
+// Validate checks the field values on ListSecretTombstonesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListSecretTombstonesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSecretTombstonesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSecretTombstonesResponseMultiError, or nil if none found.
+func (m *ListSecretTombstonesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSecretTombstonesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetTombstones() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListSecretTombstonesResponseValidationError{
+						field:  fmt.Sprintf("Tombstones[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListSecretTombstonesResponseValidationError{
+						field:  fmt.Sprintf("Tombstones[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListSecretTombstonesResponseValidationError{
+					field:  fmt.Sprintf("Tombstones[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListSecretTombstonesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSecretTombstonesResponseMultiError is an error wrapping multiple
+// validation errors returned by ListSecretTombstonesResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ListSecretTombstonesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSecretTombstonesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSecretTombstonesResponseMultiError) AllErrors() []error { return m }
+
+// ListSecretTombstonesResponseValidationError is the validation error returned
+// by ListSecretTombstonesResponse.Validate if the designated constraints
+// aren't met.
+type ListSecretTombstonesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSecretTombstonesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSecretTombstonesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSecretTombstonesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSecretTombstonesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSecretTombstonesResponseValidationError) ErrorName() string {
+	return "ListSecretTombstonesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSecretTombstonesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSecretTombstonesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSecretTombstonesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSecretTombstonesResponseValidationError{}
+
+// Validate checks the field values on ListSecretKeysRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListSecretKeysRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSecretKeysRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSecretKeysRequestMultiError, or nil if none found.
+func (m *ListSecretKeysRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSecretKeysRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	if len(errors) > 0 {
+		return ListSecretKeysRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSecretKeysRequestMultiError is an error wrapping multiple validation
+// errors returned by ListSecretKeysRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListSecretKeysRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSecretKeysRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSecretKeysRequestMultiError) AllErrors() []error { return m }
+
+// ListSecretKeysRequestValidationError is the validation error returned by
+// ListSecretKeysRequest.Validate if the designated constraints aren't met.
+type ListSecretKeysRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSecretKeysRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSecretKeysRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSecretKeysRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSecretKeysRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSecretKeysRequestValidationError) ErrorName() string {
+	return "ListSecretKeysRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSecretKeysRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSecretKeysRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSecretKeysRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSecretKeysRequestValidationError{}
+
+// Validate checks the field values on ListSecretKeysResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListSecretKeysResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSecretKeysResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSecretKeysResponseMultiError, or nil if none found.
+func (m *ListSecretKeysResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSecretKeysResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetKeys() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListSecretKeysResponseValidationError{
+						field:  fmt.Sprintf("Keys[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListSecretKeysResponseValidationError{
+						field:  fmt.Sprintf("Keys[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListSecretKeysResponseValidationError{
+					field:  fmt.Sprintf("Keys[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListSecretKeysResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSecretKeysResponseMultiError is an error wrapping multiple validation
+// errors returned by ListSecretKeysResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListSecretKeysResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSecretKeysResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSecretKeysResponseMultiError) AllErrors() []error { return m }
+
+// ListSecretKeysResponseValidationError is the validation error returned by
+// ListSecretKeysResponse.Validate if the designated constraints aren't met.
+type ListSecretKeysResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSecretKeysResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSecretKeysResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSecretKeysResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSecretKeysResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSecretKeysResponseValidationError) ErrorName() string {
+	return "ListSecretKeysResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSecretKeysResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSecretKeysResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSecretKeysResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSecretKeysResponseValidationError{}
+
+// Validate checks the field values on WatchSecretsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *WatchSecretsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchSecretsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WatchSecretsRequestMultiError, or nil if none found.
+func (m *WatchSecretsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchSecretsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Cursor
+
+	if len(errors) > 0 {
+		return WatchSecretsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// WatchSecretsRequestMultiError is an error wrapping multiple validation
+// errors returned by WatchSecretsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type WatchSecretsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchSecretsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchSecretsRequestMultiError) AllErrors() []error { return m }
+
+// WatchSecretsRequestValidationError is the validation error returned by
+// WatchSecretsRequest.Validate if the designated constraints aren't met.
+type WatchSecretsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchSecretsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchSecretsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchSecretsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchSecretsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchSecretsRequestValidationError) ErrorName() string {
+	return "WatchSecretsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WatchSecretsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchSecretsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchSecretsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchSecretsRequestValidationError{}
+
+// Validate checks the field values on SecretEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SecretEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SecretEventMultiError, or
+// nil if none found.
+func (m *SecretEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Cursor
+
+	// no validation rules for Type
+
+	// no validation rules for SecretId
+
+	// no validation rules for SecretName
+
+	// no validation rules for VersionId
+
+	// no validation rules for ClientInfo
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SecretEventValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SecretEventValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SecretEventValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SecretEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretEventMultiError is an error wrapping multiple validation errors
+// returned by SecretEvent.ValidateAll() if the designated constraints aren't met.
+type SecretEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretEventMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretEventMultiError) AllErrors() []error { return m }
+
+// SecretEventValidationError is the validation error returned by
+// SecretEvent.Validate if the designated constraints aren't met.
+type SecretEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretEventValidationError) ErrorName() string { return "SecretEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SecretEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretEventValidationError{}
@@ -20,8 +20,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SecretService_SecretUpdateInit_FullMethodName   = "/gophkeeper.v1.SecretService/SecretUpdateInit"
-	SecretService_SecretUpdateCommit_FullMethodName = "/gophkeeper.v1.SecretService/SecretUpdateCommit"
+	SecretService_SecretUpdateInit_FullMethodName       = "/gophkeeper.v1.SecretService/SecretUpdateInit"
+	SecretService_SecretUpdateCommit_FullMethodName     = "/gophkeeper.v1.SecretService/SecretUpdateCommit"
+	SecretService_RenewUploadCredentials_FullMethodName = "/gophkeeper.v1.SecretService/RenewUploadCredentials"
+	SecretService_SecretGetInit_FullMethodName          = "/gophkeeper.v1.SecretService/SecretGetInit"
+	SecretService_ListSecrets_FullMethodName            = "/gophkeeper.v1.SecretService/ListSecrets"
+	SecretService_ListSecretVersions_FullMethodName     = "/gophkeeper.v1.SecretService/ListSecretVersions"
+	SecretService_GetSecretVersion_FullMethodName       = "/gophkeeper.v1.SecretService/GetSecretVersion"
+	SecretService_SecretDelete_FullMethodName           = "/gophkeeper.v1.SecretService/SecretDelete"
+	SecretService_ListSecretTombstones_FullMethodName   = "/gophkeeper.v1.SecretService/ListSecretTombstones"
+	SecretService_ListSecretKeys_FullMethodName         = "/gophkeeper.v1.SecretService/ListSecretKeys"
+	SecretService_WatchSecrets_FullMethodName           = "/gophkeeper.v1.SecretService/WatchSecrets"
 )
 
 // SecretServiceClient is the client API for SecretService service.
@@ -30,6 +39,15 @@ const (
 type SecretServiceClient interface {
 	SecretUpdateInit(ctx context.Context, in *SecretUpdateInitRequest, opts ...grpc.CallOption) (*SecretUpdateInitResponse, error)
 	SecretUpdateCommit(ctx context.Context, in *SecretUpdateCommitRequest, opts ...grpc.CallOption) (*SecretUpdateCommitResponse, error)
+	RenewUploadCredentials(ctx context.Context, in *RenewUploadCredentialsRequest, opts ...grpc.CallOption) (*RenewUploadCredentialsResponse, error)
+	SecretGetInit(ctx context.Context, in *SecretGetInitRequest, opts ...grpc.CallOption) (*SecretGetInitResponse, error)
+	ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error)
+	ListSecretVersions(ctx context.Context, in *ListSecretVersionsRequest, opts ...grpc.CallOption) (*ListSecretVersionsResponse, error)
+	GetSecretVersion(ctx context.Context, in *GetSecretVersionRequest, opts ...grpc.CallOption) (*GetSecretVersionResponse, error)
+	SecretDelete(ctx context.Context, in *SecretDeleteRequest, opts ...grpc.CallOption) (*SecretDeleteResponse, error)
+	ListSecretTombstones(ctx context.Context, in *ListSecretTombstonesRequest, opts ...grpc.CallOption) (*ListSecretTombstonesResponse, error)
+	ListSecretKeys(ctx context.Context, in *ListSecretKeysRequest, opts ...grpc.CallOption) (*ListSecretKeysResponse, error)
+	WatchSecrets(ctx context.Context, in *WatchSecretsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SecretEvent], error)
 }
 
 type secretServiceClient struct {
@@ -60,12 +78,120 @@ func (c *secretServiceClient) SecretUpdateCommit(ctx context.Context, in *Secret
 	return out, nil
 }
 
+func (c *secretServiceClient) RenewUploadCredentials(ctx context.Context, in *RenewUploadCredentialsRequest, opts ...grpc.CallOption) (*RenewUploadCredentialsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenewUploadCredentialsResponse)
+	err := c.cc.Invoke(ctx, SecretService_RenewUploadCredentials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) SecretGetInit(ctx context.Context, in *SecretGetInitRequest, opts ...grpc.CallOption) (*SecretGetInitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretGetInitResponse)
+	err := c.cc.Invoke(ctx, SecretService_SecretGetInit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecretsResponse)
+	err := c.cc.Invoke(ctx, SecretService_ListSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListSecretVersions(ctx context.Context, in *ListSecretVersionsRequest, opts ...grpc.CallOption) (*ListSecretVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecretVersionsResponse)
+	err := c.cc.Invoke(ctx, SecretService_ListSecretVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GetSecretVersion(ctx context.Context, in *GetSecretVersionRequest, opts ...grpc.CallOption) (*GetSecretVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSecretVersionResponse)
+	err := c.cc.Invoke(ctx, SecretService_GetSecretVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) SecretDelete(ctx context.Context, in *SecretDeleteRequest, opts ...grpc.CallOption) (*SecretDeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretDeleteResponse)
+	err := c.cc.Invoke(ctx, SecretService_SecretDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListSecretTombstones(ctx context.Context, in *ListSecretTombstonesRequest, opts ...grpc.CallOption) (*ListSecretTombstonesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecretTombstonesResponse)
+	err := c.cc.Invoke(ctx, SecretService_ListSecretTombstones_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListSecretKeys(ctx context.Context, in *ListSecretKeysRequest, opts ...grpc.CallOption) (*ListSecretKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecretKeysResponse)
+	err := c.cc.Invoke(ctx, SecretService_ListSecretKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) WatchSecrets(ctx context.Context, in *WatchSecretsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SecretEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SecretService_ServiceDesc.Streams[0], SecretService_WatchSecrets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchSecretsRequest, SecretEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SecretService_WatchSecretsClient = grpc.ServerStreamingClient[SecretEvent]
+
 // SecretServiceServer is the server API for SecretService service.
 // All implementations must embed UnimplementedSecretServiceServer
 // for forward compatibility.
 type SecretServiceServer interface {
 	SecretUpdateInit(context.Context, *SecretUpdateInitRequest) (*SecretUpdateInitResponse, error)
 	SecretUpdateCommit(context.Context, *SecretUpdateCommitRequest) (*SecretUpdateCommitResponse, error)
+	RenewUploadCredentials(context.Context, *RenewUploadCredentialsRequest) (*RenewUploadCredentialsResponse, error)
+	SecretGetInit(context.Context, *SecretGetInitRequest) (*SecretGetInitResponse, error)
+	ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error)
+	ListSecretVersions(context.Context, *ListSecretVersionsRequest) (*ListSecretVersionsResponse, error)
+	GetSecretVersion(context.Context, *GetSecretVersionRequest) (*GetSecretVersionResponse, error)
+	SecretDelete(context.Context, *SecretDeleteRequest) (*SecretDeleteResponse, error)
+	ListSecretTombstones(context.Context, *ListSecretTombstonesRequest) (*ListSecretTombstonesResponse, error)
+	ListSecretKeys(context.Context, *ListSecretKeysRequest) (*ListSecretKeysResponse, error)
+	WatchSecrets(*WatchSecretsRequest, grpc.ServerStreamingServer[SecretEvent]) error
 	mustEmbedUnimplementedSecretServiceServer()
 }
 
@@ -82,6 +208,33 @@ func (UnimplementedSecretServiceServer) SecretUpdateInit(context.Context, *Secre
 func (UnimplementedSecretServiceServer) SecretUpdateCommit(context.Context, *SecretUpdateCommitRequest) (*SecretUpdateCommitResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SecretUpdateCommit not implemented")
 }
+func (UnimplementedSecretServiceServer) RenewUploadCredentials(context.Context, *RenewUploadCredentialsRequest) (*RenewUploadCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewUploadCredentials not implemented")
+}
+func (UnimplementedSecretServiceServer) SecretGetInit(context.Context, *SecretGetInitRequest) (*SecretGetInitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretGetInit not implemented")
+}
+func (UnimplementedSecretServiceServer) ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecrets not implemented")
+}
+func (UnimplementedSecretServiceServer) ListSecretVersions(context.Context, *ListSecretVersionsRequest) (*ListSecretVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecretVersions not implemented")
+}
+func (UnimplementedSecretServiceServer) GetSecretVersion(context.Context, *GetSecretVersionRequest) (*GetSecretVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecretVersion not implemented")
+}
+func (UnimplementedSecretServiceServer) SecretDelete(context.Context, *SecretDeleteRequest) (*SecretDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretDelete not implemented")
+}
+func (UnimplementedSecretServiceServer) ListSecretTombstones(context.Context, *ListSecretTombstonesRequest) (*ListSecretTombstonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecretTombstones not implemented")
+}
+func (UnimplementedSecretServiceServer) ListSecretKeys(context.Context, *ListSecretKeysRequest) (*ListSecretKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecretKeys not implemented")
+}
+func (UnimplementedSecretServiceServer) WatchSecrets(*WatchSecretsRequest, grpc.ServerStreamingServer[SecretEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSecrets not implemented")
+}
 func (UnimplementedSecretServiceServer) mustEmbedUnimplementedSecretServiceServer() {}
 func (UnimplementedSecretServiceServer) testEmbeddedByValue()                       {}
 
@@ -139,6 +292,161 @@ func _SecretService_SecretUpdateCommit_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SecretService_RenewUploadCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewUploadCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).RenewUploadCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_RenewUploadCredentials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).RenewUploadCredentials(ctx, req.(*RenewUploadCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_SecretGetInit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretGetInitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).SecretGetInit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_SecretGetInit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).SecretGetInit(ctx, req.(*SecretGetInitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ListSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListSecrets(ctx, req.(*ListSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListSecretVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListSecretVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ListSecretVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListSecretVersions(ctx, req.(*ListSecretVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GetSecretVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GetSecretVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_GetSecretVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GetSecretVersion(ctx, req.(*GetSecretVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_SecretDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).SecretDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_SecretDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).SecretDelete(ctx, req.(*SecretDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListSecretTombstones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretTombstonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListSecretTombstones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ListSecretTombstones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListSecretTombstones(ctx, req.(*ListSecretTombstonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListSecretKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListSecretKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ListSecretKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListSecretKeys(ctx, req.(*ListSecretKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_WatchSecrets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSecretsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SecretServiceServer).WatchSecrets(m, &grpc.GenericServerStream[WatchSecretsRequest, SecretEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SecretService_WatchSecretsServer = grpc.ServerStreamingServer[SecretEvent]
+
 // SecretService_ServiceDesc is the grpc.ServiceDesc for SecretService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,7 +462,45 @@ var SecretService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SecretUpdateCommit",
 			Handler:    _SecretService_SecretUpdateCommit_Handler,
 		},
+		{
+			MethodName: "RenewUploadCredentials",
+			Handler:    _SecretService_RenewUploadCredentials_Handler,
+		},
+		{
+			MethodName: "SecretGetInit",
+			Handler:    _SecretService_SecretGetInit_Handler,
+		},
+		{
+			MethodName: "ListSecrets",
+			Handler:    _SecretService_ListSecrets_Handler,
+		},
+		{
+			MethodName: "ListSecretVersions",
+			Handler:    _SecretService_ListSecretVersions_Handler,
+		},
+		{
+			MethodName: "GetSecretVersion",
+			Handler:    _SecretService_GetSecretVersion_Handler,
+		},
+		{
+			MethodName: "SecretDelete",
+			Handler:    _SecretService_SecretDelete_Handler,
+		},
+		{
+			MethodName: "ListSecretTombstones",
+			Handler:    _SecretService_ListSecretTombstones_Handler,
+		},
+		{
+			MethodName: "ListSecretKeys",
+			Handler:    _SecretService_ListSecretKeys_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSecrets",
+			Handler:       _SecretService_WatchSecrets_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "gophkeeper/v1/secret.proto",
 }
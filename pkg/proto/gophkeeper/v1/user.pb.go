@@ -14,6 +14,7 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -23,17 +24,118 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type GetLoginParamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginParamsRequest) Reset() {
+	*x = GetLoginParamsRequest{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginParamsRequest) ProtoMessage() {}
+
+func (x *GetLoginParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginParamsRequest.ProtoReflect.Descriptor instead.
+func (*GetLoginParamsRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetLoginParamsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetLoginParamsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Salt             []byte                 `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`                                                  // Salt of the KEK the auth key is derived from, made up for unknown users
+	PasswordRequired bool                   `protobuf:"varint,2,opt,name=password_required,json=passwordRequired,proto3" json:"password_required,omitempty"` // User registered before auth keys, login must also send password once to migrate
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetLoginParamsResponse) Reset() {
+	*x = GetLoginParamsResponse{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginParamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginParamsResponse) ProtoMessage() {}
+
+func (x *GetLoginParamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginParamsResponse.ProtoReflect.Descriptor instead.
+func (*GetLoginParamsResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLoginParamsResponse) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *GetLoginParamsResponse) GetPasswordRequired() bool {
+	if x != nil {
+		return x.PasswordRequired
+	}
+	return false
+}
+
 type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`                 // Only sent when GetLoginParams requires it
+	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"` // Client generated ID of the device token is issued to
+	ClientInfo    string                 `protobuf:"bytes,4,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`
+	TotpCode      string                 `protobuf:"bytes,5,opt,name=totp_code,json=totpCode,proto3" json:"totp_code,omitempty"`             // Required once TOTP is enabled
+	RecoveryCode  string                 `protobuf:"bytes,6,opt,name=recovery_code,json=recoveryCode,proto3" json:"recovery_code,omitempty"` // Single-use replacement of totp_code
+	AuthKey       []byte                 `protobuf:"bytes,7,opt,name=auth_key,json=authKey,proto3" json:"auth_key,omitempty"`                // Derived from KEK by client, the password itself never leaves client
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LoginRequest) Reset() {
 	*x = LoginRequest{}
-	mi := &file_gophkeeper_v1_user_proto_msgTypes[0]
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -45,7 +147,7 @@ func (x *LoginRequest) String() string {
 func (*LoginRequest) ProtoMessage() {}
 
 func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gophkeeper_v1_user_proto_msgTypes[0]
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,7 +160,7 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
 func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{0}
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *LoginRequest) GetUsername() string {
@@ -75,19 +177,60 @@ func (x *LoginRequest) GetPassword() string {
 	return ""
 }
 
+func (x *LoginRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetClientInfo() string {
+	if x != nil {
+		return x.ClientInfo
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetTotpCode() string {
+	if x != nil {
+		return x.TotpCode
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetRecoveryCode() string {
+	if x != nil {
+		return x.RecoveryCode
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetAuthKey() []byte {
+	if x != nil {
+		return x.AuthKey
+	}
+	return nil
+}
+
 type LoginResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Role            UserRole               `protobuf:"varint,2,opt,name=role,proto3,enum=gophkeeper.v1.UserRole" json:"role,omitempty"`
-	Token           string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
-	TokenTtlSeconds uint32                 `protobuf:"varint,4,opt,name=token_ttl_seconds,json=tokenTtlSeconds,proto3" json:"token_ttl_seconds,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	UserId                 string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role                   UserRole               `protobuf:"varint,2,opt,name=role,proto3,enum=gophkeeper.v1.UserRole" json:"role,omitempty"`
+	Token                  string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	TokenTtlSeconds        uint32                 `protobuf:"varint,4,opt,name=token_ttl_seconds,json=tokenTtlSeconds,proto3" json:"token_ttl_seconds,omitempty"`
+	RefreshToken           string                 `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Exchanged for a new access token when the current one expires, empty for enrollment token
+	RefreshTokenTtlSeconds uint32                 `protobuf:"varint,6,opt,name=refresh_token_ttl_seconds,json=refreshTokenTtlSeconds,proto3" json:"refresh_token_ttl_seconds,omitempty"`
+	Salt                   []byte                 `protobuf:"bytes,7,opt,name=salt,proto3" json:"salt,omitempty"` // Lets client set up local user on a new device
+	Verifier               []byte                 `protobuf:"bytes,8,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	BucketName             string                 `protobuf:"bytes,9,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	TotpEnrollmentRequired bool                   `protobuf:"varint,10,opt,name=totp_enrollment_required,json=totpEnrollmentRequired,proto3" json:"totp_enrollment_required,omitempty"` // Token only allows TOTP enrollment as policy requires 2FA for the user role
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
 	*x = LoginResponse{}
-	mi := &file_gophkeeper_v1_user_proto_msgTypes[1]
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -99,7 +242,7 @@ func (x *LoginResponse) String() string {
 func (*LoginResponse) ProtoMessage() {}
 
 func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gophkeeper_v1_user_proto_msgTypes[1]
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -112,7 +255,7 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
 func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{1}
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LoginResponse) GetUserId() string {
@@ -143,18 +286,63 @@ func (x *LoginResponse) GetTokenTtlSeconds() uint32 {
 	return 0
 }
 
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetRefreshTokenTtlSeconds() uint32 {
+	if x != nil {
+		return x.RefreshTokenTtlSeconds
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetVerifier() []byte {
+	if x != nil {
+		return x.Verifier
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetBucketName() string {
+	if x != nil {
+		return x.BucketName
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetTotpEnrollmentRequired() bool {
+	if x != nil {
+		return x.TotpEnrollmentRequired
+	}
+	return false
+}
+
 type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	Role          UserRole               `protobuf:"varint,3,opt,name=role,proto3,enum=gophkeeper.v1.UserRole" json:"role,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"` // Client generated ID of the device token is issued to
+	ClientInfo    string                 `protobuf:"bytes,5,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`
+	AuthKey       []byte                 `protobuf:"bytes,6,opt,name=auth_key,json=authKey,proto3" json:"auth_key,omitempty"` // Derived by client from KEK, server only stores its verifier
+	Salt          []byte                 `protobuf:"bytes,7,opt,name=salt,proto3" json:"salt,omitempty"`                      // Client generated salt of the KEK
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegisterRequest) Reset() {
 	*x = RegisterRequest{}
-	mi := &file_gophkeeper_v1_user_proto_msgTypes[2]
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -166,7 +354,7 @@ func (x *RegisterRequest) String() string {
 func (*RegisterRequest) ProtoMessage() {}
 
 func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gophkeeper_v1_user_proto_msgTypes[2]
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -179,7 +367,7 @@ func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
 func (*RegisterRequest) Descriptor() ([]byte, []int) {
-	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{2}
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RegisterRequest) GetUsername() string {
@@ -189,36 +377,59 @@ func (x *RegisterRequest) GetUsername() string {
 	return ""
 }
 
-func (x *RegisterRequest) GetPassword() string {
+func (x *RegisterRequest) GetRole() UserRole {
 	if x != nil {
-		return x.Password
+		return x.Role
+	}
+	return UserRole_USER_ROLE_UNSPECIFIED
+}
+
+func (x *RegisterRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *RegisterRequest) GetRole() UserRole {
+func (x *RegisterRequest) GetClientInfo() string {
 	if x != nil {
-		return x.Role
+		return x.ClientInfo
 	}
-	return UserRole_USER_ROLE_UNSPECIFIED
+	return ""
+}
+
+func (x *RegisterRequest) GetAuthKey() []byte {
+	if x != nil {
+		return x.AuthKey
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
 }
 
 type RegisterResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Token           string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Role            UserRole               `protobuf:"varint,3,opt,name=role,proto3,enum=gophkeeper.v1.UserRole" json:"role,omitempty"`
-	Salt            []byte                 `protobuf:"bytes,4,opt,name=salt,proto3" json:"salt,omitempty"`
-	Verifier        []byte                 `protobuf:"bytes,5,opt,name=verifier,proto3" json:"verifier,omitempty"`
-	BucketName      string                 `protobuf:"bytes,6,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
-	TokenTtlSeconds uint32                 `protobuf:"varint,7,opt,name=token_ttl_seconds,json=tokenTtlSeconds,proto3" json:"token_ttl_seconds,omitempty"` // e.g., 3600 for 1 hour
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Token                  string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	UserId                 string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role                   UserRole               `protobuf:"varint,3,opt,name=role,proto3,enum=gophkeeper.v1.UserRole" json:"role,omitempty"`
+	Salt                   []byte                 `protobuf:"bytes,4,opt,name=salt,proto3" json:"salt,omitempty"`
+	Verifier               []byte                 `protobuf:"bytes,5,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	BucketName             string                 `protobuf:"bytes,6,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	TokenTtlSeconds        uint32                 `protobuf:"varint,7,opt,name=token_ttl_seconds,json=tokenTtlSeconds,proto3" json:"token_ttl_seconds,omitempty"` // e.g., 900 for 15 minutes
+	RefreshToken           string                 `protobuf:"bytes,8,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`             // Exchanged for a new access token when the current one expires
+	RefreshTokenTtlSeconds uint32                 `protobuf:"varint,9,opt,name=refresh_token_ttl_seconds,json=refreshTokenTtlSeconds,proto3" json:"refresh_token_ttl_seconds,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *RegisterResponse) Reset() {
 	*x = RegisterResponse{}
-	mi := &file_gophkeeper_v1_user_proto_msgTypes[3]
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -230,7 +441,7 @@ func (x *RegisterResponse) String() string {
 func (*RegisterResponse) ProtoMessage() {}
 
 func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gophkeeper_v1_user_proto_msgTypes[3]
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -243,7 +454,7 @@ func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
 func (*RegisterResponse) Descriptor() ([]byte, []int) {
-	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{3}
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RegisterResponse) GetToken() string {
@@ -295,36 +506,880 @@ func (x *RegisterResponse) GetTokenTtlSeconds() uint32 {
 	return 0
 }
 
-var File_gophkeeper_v1_user_proto protoreflect.FileDescriptor
+func (x *RegisterResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
 
-const file_gophkeeper_v1_user_proto_rawDesc = "" +
-	"\n" +
-	"\x18gophkeeper/v1/user.proto\x12\rgophkeeper.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1agophkeeper/v1/common.proto\"]\n" +
-	"\fLoginRequest\x12%\n" +
-	"\busername\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x03\x18@R\busername\x12&\n" +
-	"\bpassword\x18\x02 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\b\x18\x80\x01R\bpassword\"\xa0\x01\n" +
-	"\rLoginResponse\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12+\n" +
-	"\x04role\x18\x02 \x01(\x0e2\x17.gophkeeper.v1.UserRoleR\x04role\x12\x14\n" +
-	"\x05token\x18\x03 \x01(\tR\x05token\x123\n" +
-	"\x11token_ttl_seconds\x18\x04 \x01(\rB\a\xbaH\x04*\x02 \x00R\x0ftokenTtlSeconds\"\x92\x01\n" +
-	"\x0fRegisterRequest\x12#\n" +
-	"\busername\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x03R\busername\x12#\n" +
-	"\bpassword\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\bR\bpassword\x125\n" +
-	"\x04role\x18\x03 \x01(\x0e2\x17.gophkeeper.v1.UserRoleB\b\xbaH\x05\x82\x01\x02\x10\x01R\x04role\"\x8f\x02\n" +
-	"\x10RegisterResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12+\n" +
-	"\x04role\x18\x03 \x01(\x0e2\x17.gophkeeper.v1.UserRoleR\x04role\x12\x1b\n" +
-	"\x04salt\x18\x04 \x01(\fB\a\xbaH\x04z\x02\x10\x01R\x04salt\x12#\n" +
-	"\bverifier\x18\x05 \x01(\fB\a\xbaH\x04z\x02\x10\x01R\bverifier\x12(\n" +
-	"\vbucket_name\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
-	"bucketName\x123\n" +
-	"\x11token_ttl_seconds\x18\a \x01(\rB\a\xbaH\x04*\x02 \x00R\x0ftokenTtlSeconds2\x9e\x01\n" +
-	"\vUserService\x12B\n" +
-	"\x05Login\x12\x1b.gophkeeper.v1.LoginRequest\x1a\x1c.gophkeeper.v1.LoginResponse\x12K\n" +
-	"\bRegister\x12\x1e.gophkeeper.v1.RegisterRequest\x1a\x1f.gophkeeper.v1.RegisterResponseB\xb8\x01\n" +
+func (x *RegisterResponse) GetRefreshTokenTtlSeconds() uint32 {
+	if x != nil {
+		return x.RefreshTokenTtlSeconds
+	}
+	return 0
+}
+
+type Device struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	ClientInfo    string                 `protobuf:"bytes,2,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`
+	FirstSeenAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"` // Not set for active device
+	Current       bool                   `protobuf:"varint,6,opt,name=current,proto3" json:"current,omitempty"`                     // Device the request is made from
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Device) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Device) GetClientInfo() string {
+	if x != nil {
+		return x.ClientInfo
+	}
+	return ""
+}
+
+func (x *Device) GetFirstSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeenAt
+	}
+	return nil
+}
+
+func (x *Device) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *Device) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *Device) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{7}
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"` // Devices ordered by first seen time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type RevokeDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceRequest) Reset() {
+	*x = RevokeDeviceRequest{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceRequest) ProtoMessage() {}
+
+func (x *RevokeDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RevokeDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type RevokeDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceResponse) Reset() {
+	*x = RevokeDeviceResponse{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceResponse) ProtoMessage() {}
+
+func (x *RevokeDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RevokeDeviceResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"` // Device refresh token is issued to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type RefreshTokenResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Token                  string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TokenTtlSeconds        uint32                 `protobuf:"varint,2,opt,name=token_ttl_seconds,json=tokenTtlSeconds,proto3" json:"token_ttl_seconds,omitempty"`
+	RefreshToken           string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Replaces the presented one which is not accepted anymore
+	RefreshTokenTtlSeconds uint32                 `protobuf:"varint,4,opt,name=refresh_token_ttl_seconds,json=refreshTokenTtlSeconds,proto3" json:"refresh_token_ttl_seconds,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RefreshTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetTokenTtlSeconds() uint32 {
+	if x != nil {
+		return x.TokenTtlSeconds
+	}
+	return 0
+}
+
+func (x *RefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetRefreshTokenTtlSeconds() uint32 {
+	if x != nil {
+		return x.RefreshTokenTtlSeconds
+	}
+	return 0
+}
+
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AllDevices    bool                   `protobuf:"varint,1,opt,name=all_devices,json=allDevices,proto3" json:"all_devices,omitempty"` // Revoke refresh tokens of all user devices, not only the current one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LogoutRequest) GetAllDevices() bool {
+	if x != nil {
+		return x.AllDevices
+	}
+	return false
+}
+
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RevokedTokens uint32                 `protobuf:"varint,1,opt,name=revoked_tokens,json=revokedTokens,proto3" json:"revoked_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LogoutResponse) GetRevokedTokens() uint32 {
+	if x != nil {
+		return x.RevokedTokens
+	}
+	return 0
+}
+
+type EnrollTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{15}
+}
+
+type EnrollTOTPResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProvisioningUri string                 `protobuf:"bytes,1,opt,name=provisioning_uri,json=provisioningUri,proto3" json:"provisioning_uri,omitempty"` // otpauth:// URI to scan with authenticator app
+	Secret          string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`                                          // Base32 seed to enter manually
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPResponse) ProtoMessage() {}
+
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EnrollTOTPResponse) GetProvisioningUri() string {
+	if x != nil {
+		return x.ProvisioningUri
+	}
+	return ""
+}
+
+func (x *EnrollTOTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPRequest) ProtoMessage() {}
+
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // Single-use codes replacing TOTP code on login, shown once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPResponse) Reset() {
+	*x = ConfirmTOTPResponse{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPResponse) ProtoMessage() {}
+
+func (x *ConfirmTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConfirmTOTPResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthKey       []byte                 `protobuf:"bytes,1,opt,name=auth_key,json=authKey,proto3" json:"auth_key,omitempty"`            // Current auth key of the caller
+	NewAuthKey    []byte                 `protobuf:"bytes,2,opt,name=new_auth_key,json=newAuthKey,proto3" json:"new_auth_key,omitempty"` // Derived by client from the new KEK
+	NewSalt       []byte                 `protobuf:"bytes,3,opt,name=new_salt,json=newSalt,proto3" json:"new_salt,omitempty"`            // Client generated salt of the new KEK
+	Keys          []*SecretKey           `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`                                 // DEKs of all user secret versions re-wrapped with the new KEK
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ChangePasswordRequest) GetAuthKey() []byte {
+	if x != nil {
+		return x.AuthKey
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetNewAuthKey() []byte {
+	if x != nil {
+		return x.NewAuthKey
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetNewSalt() []byte {
+	if x != nil {
+		return x.NewSalt
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetKeys() []*SecretKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RewrappedKeys uint32                 `protobuf:"varint,1,opt,name=rewrapped_keys,json=rewrappedKeys,proto3" json:"rewrapped_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_v1_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_v1_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChangePasswordResponse) GetRewrappedKeys() uint32 {
+	if x != nil {
+		return x.RewrappedKeys
+	}
+	return 0
+}
+
+var File_gophkeeper_v1_user_proto protoreflect.FileDescriptor
+
+const file_gophkeeper_v1_user_proto_rawDesc = "" +
+	"\n" +
+	"\x18gophkeeper/v1/user.proto\x12\rgophkeeper.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1agophkeeper/v1/common.proto\">\n" +
+	"\x15GetLoginParamsRequest\x12%\n" +
+	"\busername\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x03\x18@R\busername\"Y\n" +
+	"\x16GetLoginParamsResponse\x12\x12\n" +
+	"\x04salt\x18\x01 \x01(\fR\x04salt\x12+\n" +
+	"\x11password_required\x18\x02 \x01(\bR\x10passwordRequired\"\xb4\x02\n" +
+	"\fLoginRequest\x12%\n" +
+	"\busername\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x03\x18@R\busername\x12$\n" +
+	"\bpassword\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\bpassword\x12%\n" +
+	"\tdevice_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bdeviceId\x12+\n" +
+	"\vclient_info\x18\x04 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\n" +
+	"clientInfo\x121\n" +
+	"\ttotp_code\x18\x05 \x01(\tB\x14\xbaH\x11r\x0f2\r^([0-9]{6})?$R\btotpCode\x12,\n" +
+	"\rrecovery_code\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x18 R\frecoveryCode\x12\"\n" +
+	"\bauth_key\x18\a \x01(\fB\a\xbaH\x04z\x02h R\aauthKey\"\xa6\x03\n" +
+	"\rLoginResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12+\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x17.gophkeeper.v1.UserRoleR\x04role\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x123\n" +
+	"\x11token_ttl_seconds\x18\x04 \x01(\rB\a\xbaH\x04*\x02 \x00R\x0ftokenTtlSeconds\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x129\n" +
+	"\x19refresh_token_ttl_seconds\x18\x06 \x01(\rR\x16refreshTokenTtlSeconds\x12\x1b\n" +
+	"\x04salt\x18\a \x01(\fB\a\xbaH\x04z\x02\x10\x01R\x04salt\x12#\n" +
+	"\bverifier\x18\b \x01(\fB\a\xbaH\x04z\x02\x10\x01R\bverifier\x12(\n" +
+	"\vbucket_name\x18\t \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"bucketName\x128\n" +
+	"\x18totp_enrollment_required\x18\n" +
+	" \x01(\bR\x16totpEnrollmentRequired\"\x92\x02\n" +
+	"\x0fRegisterRequest\x12#\n" +
+	"\busername\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x03R\busername\x125\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x17.gophkeeper.v1.UserRoleB\b\xbaH\x05\x82\x01\x02\x10\x01R\x04role\x12%\n" +
+	"\tdevice_id\x18\x04 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bdeviceId\x12+\n" +
+	"\vclient_info\x18\x05 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\n" +
+	"clientInfo\x12\"\n" +
+	"\bauth_key\x18\x06 \x01(\fB\a\xbaH\x04z\x02h R\aauthKey\x12\x1b\n" +
+	"\x04salt\x18\a \x01(\fB\a\xbaH\x04z\x02h\x10R\x04saltJ\x04\b\x02\x10\x03R\bpassword\"\xf8\x02\n" +
+	"\x10RegisterResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12+\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x17.gophkeeper.v1.UserRoleR\x04role\x12\x1b\n" +
+	"\x04salt\x18\x04 \x01(\fB\a\xbaH\x04z\x02\x10\x01R\x04salt\x12#\n" +
+	"\bverifier\x18\x05 \x01(\fB\a\xbaH\x04z\x02\x10\x01R\bverifier\x12(\n" +
+	"\vbucket_name\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"bucketName\x123\n" +
+	"\x11token_ttl_seconds\x18\a \x01(\rB\a\xbaH\x04*\x02 \x00R\x0ftokenTtlSeconds\x12#\n" +
+	"\rrefresh_token\x18\b \x01(\tR\frefreshToken\x12B\n" +
+	"\x19refresh_token_ttl_seconds\x18\t \x01(\rB\a\xbaH\x04*\x02 \x00R\x16refreshTokenTtlSeconds\"\x99\x02\n" +
+	"\x06Device\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1f\n" +
+	"\vclient_info\x18\x02 \x01(\tR\n" +
+	"clientInfo\x12>\n" +
+	"\rfirst_seen_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vfirstSeenAt\x12<\n" +
+	"\flast_seen_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12\x18\n" +
+	"\acurrent\x18\x06 \x01(\bR\acurrent\"\x14\n" +
+	"\x12ListDevicesRequest\"F\n" +
+	"\x13ListDevicesResponse\x12/\n" +
+	"\adevices\x18\x01 \x03(\v2\x15.gophkeeper.v1.DeviceR\adevices\"<\n" +
+	"\x13RevokeDeviceRequest\x12%\n" +
+	"\tdevice_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bdeviceId\"E\n" +
+	"\x14RevokeDeviceResponse\x12-\n" +
+	"\x06device\x18\x01 \x01(\v2\x15.gophkeeper.v1.DeviceR\x06device\"j\n" +
+	"\x13RefreshTokenRequest\x12,\n" +
+	"\rrefresh_token\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\frefreshToken\x12%\n" +
+	"\tdevice_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\bdeviceId\"\xca\x01\n" +
+	"\x14RefreshTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x123\n" +
+	"\x11token_ttl_seconds\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x0ftokenTtlSeconds\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\x12B\n" +
+	"\x19refresh_token_ttl_seconds\x18\x04 \x01(\rB\a\xbaH\x04*\x02 \x00R\x16refreshTokenTtlSeconds\"0\n" +
+	"\rLogoutRequest\x12\x1f\n" +
+	"\vall_devices\x18\x01 \x01(\bR\n" +
+	"allDevices\"7\n" +
+	"\x0eLogoutResponse\x12%\n" +
+	"\x0erevoked_tokens\x18\x01 \x01(\rR\rrevokedTokens\"\x13\n" +
+	"\x11EnrollTOTPRequest\"W\n" +
+	"\x12EnrollTOTPResponse\x12)\n" +
+	"\x10provisioning_uri\x18\x01 \x01(\tR\x0fprovisioningUri\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\";\n" +
+	"\x12ConfirmTOTPRequest\x12%\n" +
+	"\x04code\x18\x01 \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[0-9]{6}$R\x04code\"<\n" +
+	"\x13ConfirmTOTPResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"\xb8\x01\n" +
+	"\x15ChangePasswordRequest\x12\"\n" +
+	"\bauth_key\x18\x01 \x01(\fB\a\xbaH\x04z\x02h R\aauthKey\x12)\n" +
+	"\fnew_auth_key\x18\x02 \x01(\fB\a\xbaH\x04z\x02h R\n" +
+	"newAuthKey\x12\"\n" +
+	"\bnew_salt\x18\x03 \x01(\fB\a\xbaH\x04z\x02h\x10R\anewSalt\x12,\n" +
+	"\x04keys\x18\x04 \x03(\v2\x18.gophkeeper.v1.SecretKeyR\x04keys\"?\n" +
+	"\x16ChangePasswordResponse\x12%\n" +
+	"\x0erewrapped_keys\x18\x01 \x01(\rR\rrewrappedKeys2\xd4\x06\n" +
+	"\vUserService\x12]\n" +
+	"\x0eGetLoginParams\x12$.gophkeeper.v1.GetLoginParamsRequest\x1a%.gophkeeper.v1.GetLoginParamsResponse\x12B\n" +
+	"\x05Login\x12\x1b.gophkeeper.v1.LoginRequest\x1a\x1c.gophkeeper.v1.LoginResponse\x12K\n" +
+	"\bRegister\x12\x1e.gophkeeper.v1.RegisterRequest\x1a\x1f.gophkeeper.v1.RegisterResponse\x12T\n" +
+	"\vListDevices\x12!.gophkeeper.v1.ListDevicesRequest\x1a\".gophkeeper.v1.ListDevicesResponse\x12W\n" +
+	"\fRevokeDevice\x12\".gophkeeper.v1.RevokeDeviceRequest\x1a#.gophkeeper.v1.RevokeDeviceResponse\x12W\n" +
+	"\fRefreshToken\x12\".gophkeeper.v1.RefreshTokenRequest\x1a#.gophkeeper.v1.RefreshTokenResponse\x12E\n" +
+	"\x06Logout\x12\x1c.gophkeeper.v1.LogoutRequest\x1a\x1d.gophkeeper.v1.LogoutResponse\x12Q\n" +
+	"\n" +
+	"EnrollTOTP\x12 .gophkeeper.v1.EnrollTOTPRequest\x1a!.gophkeeper.v1.EnrollTOTPResponse\x12T\n" +
+	"\vConfirmTOTP\x12!.gophkeeper.v1.ConfirmTOTPRequest\x1a\".gophkeeper.v1.ConfirmTOTPResponse\x12]\n" +
+	"\x0eChangePassword\x12$.gophkeeper.v1.ChangePasswordRequest\x1a%.gophkeeper.v1.ChangePasswordResponseB\xb8\x01\n" +
 	"\x11com.gophkeeper.v1B\tUserProtoP\x01ZCgithub.com/patraden/ya-practicum-gophkeeper/api/gophkeeper/v1;proto\xa2\x02\x03GXX\xaa\x02\rGophkeeper.V1\xca\x02\rGophkeeper\\V1\xe2\x02\x19Gophkeeper\\V1\\GPBMetadata\xea\x02\x0eGophkeeper::V1b\x06proto3"
 
 var (
@@ -339,27 +1394,68 @@ func file_gophkeeper_v1_user_proto_rawDescGZIP() []byte {
 	return file_gophkeeper_v1_user_proto_rawDescData
 }
 
-var file_gophkeeper_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_gophkeeper_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_gophkeeper_v1_user_proto_goTypes = []any{
-	(*LoginRequest)(nil),     // 0: gophkeeper.v1.LoginRequest
-	(*LoginResponse)(nil),    // 1: gophkeeper.v1.LoginResponse
-	(*RegisterRequest)(nil),  // 2: gophkeeper.v1.RegisterRequest
-	(*RegisterResponse)(nil), // 3: gophkeeper.v1.RegisterResponse
-	(UserRole)(0),            // 4: gophkeeper.v1.UserRole
+	(*GetLoginParamsRequest)(nil),  // 0: gophkeeper.v1.GetLoginParamsRequest
+	(*GetLoginParamsResponse)(nil), // 1: gophkeeper.v1.GetLoginParamsResponse
+	(*LoginRequest)(nil),           // 2: gophkeeper.v1.LoginRequest
+	(*LoginResponse)(nil),          // 3: gophkeeper.v1.LoginResponse
+	(*RegisterRequest)(nil),        // 4: gophkeeper.v1.RegisterRequest
+	(*RegisterResponse)(nil),       // 5: gophkeeper.v1.RegisterResponse
+	(*Device)(nil),                 // 6: gophkeeper.v1.Device
+	(*ListDevicesRequest)(nil),     // 7: gophkeeper.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),    // 8: gophkeeper.v1.ListDevicesResponse
+	(*RevokeDeviceRequest)(nil),    // 9: gophkeeper.v1.RevokeDeviceRequest
+	(*RevokeDeviceResponse)(nil),   // 10: gophkeeper.v1.RevokeDeviceResponse
+	(*RefreshTokenRequest)(nil),    // 11: gophkeeper.v1.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),   // 12: gophkeeper.v1.RefreshTokenResponse
+	(*LogoutRequest)(nil),          // 13: gophkeeper.v1.LogoutRequest
+	(*LogoutResponse)(nil),         // 14: gophkeeper.v1.LogoutResponse
+	(*EnrollTOTPRequest)(nil),      // 15: gophkeeper.v1.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),     // 16: gophkeeper.v1.EnrollTOTPResponse
+	(*ConfirmTOTPRequest)(nil),     // 17: gophkeeper.v1.ConfirmTOTPRequest
+	(*ConfirmTOTPResponse)(nil),    // 18: gophkeeper.v1.ConfirmTOTPResponse
+	(*ChangePasswordRequest)(nil),  // 19: gophkeeper.v1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil), // 20: gophkeeper.v1.ChangePasswordResponse
+	(UserRole)(0),                  // 21: gophkeeper.v1.UserRole
+	(*timestamppb.Timestamp)(nil),  // 22: google.protobuf.Timestamp
+	(*SecretKey)(nil),              // 23: gophkeeper.v1.SecretKey
 }
 var file_gophkeeper_v1_user_proto_depIdxs = []int32{
-	4, // 0: gophkeeper.v1.LoginResponse.role:type_name -> gophkeeper.v1.UserRole
-	4, // 1: gophkeeper.v1.RegisterRequest.role:type_name -> gophkeeper.v1.UserRole
-	4, // 2: gophkeeper.v1.RegisterResponse.role:type_name -> gophkeeper.v1.UserRole
-	0, // 3: gophkeeper.v1.UserService.Login:input_type -> gophkeeper.v1.LoginRequest
-	2, // 4: gophkeeper.v1.UserService.Register:input_type -> gophkeeper.v1.RegisterRequest
-	1, // 5: gophkeeper.v1.UserService.Login:output_type -> gophkeeper.v1.LoginResponse
-	3, // 6: gophkeeper.v1.UserService.Register:output_type -> gophkeeper.v1.RegisterResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	21, // 0: gophkeeper.v1.LoginResponse.role:type_name -> gophkeeper.v1.UserRole
+	21, // 1: gophkeeper.v1.RegisterRequest.role:type_name -> gophkeeper.v1.UserRole
+	21, // 2: gophkeeper.v1.RegisterResponse.role:type_name -> gophkeeper.v1.UserRole
+	22, // 3: gophkeeper.v1.Device.first_seen_at:type_name -> google.protobuf.Timestamp
+	22, // 4: gophkeeper.v1.Device.last_seen_at:type_name -> google.protobuf.Timestamp
+	22, // 5: gophkeeper.v1.Device.revoked_at:type_name -> google.protobuf.Timestamp
+	6,  // 6: gophkeeper.v1.ListDevicesResponse.devices:type_name -> gophkeeper.v1.Device
+	6,  // 7: gophkeeper.v1.RevokeDeviceResponse.device:type_name -> gophkeeper.v1.Device
+	23, // 8: gophkeeper.v1.ChangePasswordRequest.keys:type_name -> gophkeeper.v1.SecretKey
+	0,  // 9: gophkeeper.v1.UserService.GetLoginParams:input_type -> gophkeeper.v1.GetLoginParamsRequest
+	2,  // 10: gophkeeper.v1.UserService.Login:input_type -> gophkeeper.v1.LoginRequest
+	4,  // 11: gophkeeper.v1.UserService.Register:input_type -> gophkeeper.v1.RegisterRequest
+	7,  // 12: gophkeeper.v1.UserService.ListDevices:input_type -> gophkeeper.v1.ListDevicesRequest
+	9,  // 13: gophkeeper.v1.UserService.RevokeDevice:input_type -> gophkeeper.v1.RevokeDeviceRequest
+	11, // 14: gophkeeper.v1.UserService.RefreshToken:input_type -> gophkeeper.v1.RefreshTokenRequest
+	13, // 15: gophkeeper.v1.UserService.Logout:input_type -> gophkeeper.v1.LogoutRequest
+	15, // 16: gophkeeper.v1.UserService.EnrollTOTP:input_type -> gophkeeper.v1.EnrollTOTPRequest
+	17, // 17: gophkeeper.v1.UserService.ConfirmTOTP:input_type -> gophkeeper.v1.ConfirmTOTPRequest
+	19, // 18: gophkeeper.v1.UserService.ChangePassword:input_type -> gophkeeper.v1.ChangePasswordRequest
+	1,  // 19: gophkeeper.v1.UserService.GetLoginParams:output_type -> gophkeeper.v1.GetLoginParamsResponse
+	3,  // 20: gophkeeper.v1.UserService.Login:output_type -> gophkeeper.v1.LoginResponse
+	5,  // 21: gophkeeper.v1.UserService.Register:output_type -> gophkeeper.v1.RegisterResponse
+	8,  // 22: gophkeeper.v1.UserService.ListDevices:output_type -> gophkeeper.v1.ListDevicesResponse
+	10, // 23: gophkeeper.v1.UserService.RevokeDevice:output_type -> gophkeeper.v1.RevokeDeviceResponse
+	12, // 24: gophkeeper.v1.UserService.RefreshToken:output_type -> gophkeeper.v1.RefreshTokenResponse
+	14, // 25: gophkeeper.v1.UserService.Logout:output_type -> gophkeeper.v1.LogoutResponse
+	16, // 26: gophkeeper.v1.UserService.EnrollTOTP:output_type -> gophkeeper.v1.EnrollTOTPResponse
+	18, // 27: gophkeeper.v1.UserService.ConfirmTOTP:output_type -> gophkeeper.v1.ConfirmTOTPResponse
+	20, // 28: gophkeeper.v1.UserService.ChangePassword:output_type -> gophkeeper.v1.ChangePasswordResponse
+	19, // [19:29] is the sub-list for method output_type
+	9,  // [9:19] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_gophkeeper_v1_user_proto_init() }
@@ -374,7 +1470,7 @@ func file_gophkeeper_v1_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gophkeeper_v1_user_proto_rawDesc), len(file_gophkeeper_v1_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
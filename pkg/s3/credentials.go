@@ -21,3 +21,12 @@ func (creds TemporaryCredentials) ToProto() *pb.TemporaryCredentials {
 		Expiration:      creds.Expiration,
 	}
 }
+
+func TemporaryCredentialsFromProto(creds *pb.TemporaryCredentials) TemporaryCredentials {
+	return TemporaryCredentials{
+		AccessKeyID:     creds.GetAccessKeyId(),
+		SecretAccessKey: creds.GetSecretAccessKey(),
+		SessionToken:    creds.GetSessionToken(),
+		Expiration:      creds.GetExpiration(),
+	}
+}
@@ -0,0 +1,8 @@
+package s3
+
+// ObjectEvent is a notification about object created in the bucket.
+type ObjectEvent struct {
+	BucketName string
+	ObjectKey  string
+	Size       int64
+}
@@ -2,6 +2,7 @@ package s3
 
 import (
 	"context"
+	"io"
 	"net/url"
 	"time"
 )
@@ -11,7 +12,31 @@ type BucketManager interface {
 	MakeBucket(ctx context.Context, bucketName string, tags map[string]string) error
 	BucketExists(ctx context.Context, bucketName string) (bool, error)
 	RemoveBucket(ctx context.Context, bucketName string) error
-	SetBucketNotification(ctx context.Context, bucketName string) error
+}
+
+// ObjectManager interface for S3 objects inspection on the server side.
+type ObjectManager interface {
+	// StatObject returns object metadata including its checksums or ErrNotFound if object does not exist.
+	StatObject(ctx context.Context, bucketName, objectKey string) (ObjectInfo, error)
+	// ObjectChecksum reads the whole object and returns its SHA-256 checksum
+	// or ErrNotFound if object does not exist.
+	ObjectChecksum(ctx context.Context, bucketName, objectKey string) ([]byte, error)
+	// ListObjects lists objects of the bucket which keys start with prefix.
+	ListObjects(ctx context.Context, bucketName, prefix string) ([]ObjectInfo, error)
+	// RemoveObjects deletes objects from the bucket. Missing objects are ignored.
+	RemoveObjects(ctx context.Context, bucketName string, objectKeys []string) error
+	// AbortMultipartUploads discards incomplete multipart uploads of the object together with their parts.
+	AbortMultipartUploads(ctx context.Context, bucketName, objectKey string) error
+	// ReadObject returns content of the small object or ErrNotFound if object does not exist.
+	// Objects larger than maxSize are rejected with ErrInvalidInput.
+	ReadObject(ctx context.Context, bucketName, objectKey string, maxSize int64) ([]byte, error)
+}
+
+// EventListener subscribes to bucket notifications on the server side.
+type EventListener interface {
+	// ListenObjectCreated streams events about objects created in any bucket until ctx is done.
+	// Every listening server replica receives every event, so consumers must be idempotent.
+	ListenObjectCreated(ctx context.Context) <-chan ObjectEvent
 }
 
 type URLManager interface {
@@ -27,14 +52,22 @@ type URLManager interface {
 type SecurityManager interface {
 	// AssumeRole performs a STS AssumeRoleWithWebIdentity and returns temporary credentials.
 	AssumeRole(ctx context.Context, identityToken string, durationSeconds int) (*TemporaryCredentials, error)
+	// AssumeReadOnlyRole performs a STS AssumeRoleWithWebIdentity restricted to reading objects of the bucket.
+	AssumeReadOnlyRole(
+		ctx context.Context,
+		identityToken string,
+		bucketName string,
+		durationSeconds int,
+	) (*TemporaryCredentials, error)
 	// AddCannedPolicy attaches a pre-defined policy by name.
 	AddCannedPolicy(ctx context.Context, name string, policyJSON []byte) error
 }
 
 // ServerOperator defines S3 operations required by the backend server.
-// It includes bucket lifecycle management and presigned URL generation.
+// It includes bucket lifecycle management, objects inspection and presigned URL generation.
 type ServerOperator interface {
 	BucketManager
+	ObjectManager
 	URLManager
 	SecurityManager
 }
@@ -52,3 +85,36 @@ type ClientOperator interface {
 		opts GetObjectOptions,
 	) error
 }
+
+// MultipartOperator defines resumable transfers used by clients for large objects.
+// Uploads are split into parts which can be uploaded independently and downloads
+// are performed by byte ranges, so that interrupted transfer continues where it stopped.
+// Parts are uploaded with their SHA-256 checksums verified and stored by S3.
+type MultipartOperator interface {
+	NewMultipartUpload(ctx context.Context, bucketName, objectName string, opts PutObjectOptions) (string, error)
+	PutObjectPart(
+		ctx context.Context,
+		bucketName, objectName, uploadID string,
+		partNumber int,
+		data io.Reader,
+		size int64,
+		checksum []byte,
+	) (ObjectPart, error)
+	CompleteMultipartUpload(
+		ctx context.Context,
+		bucketName, objectName, uploadID string,
+		parts []CompletePart,
+	) (UploadInfo, error)
+	AbortMultipartUpload(ctx context.Context, bucketName, objectName, uploadID string) error
+	// GetObjectRange writes length bytes of the object starting from offset to dest.
+	GetObjectRange(ctx context.Context, bucketName, objectName string, offset, length int64, dest io.Writer) (int64, error)
+}
+
+// ChunkOperator defines operations on content-defined chunks of secrets used by clients.
+// Chunks are small enough to be transferred in memory as a whole.
+type ChunkOperator interface {
+	// ObjectExists reports whether object is stored in the bucket.
+	ObjectExists(ctx context.Context, bucketName, objectName string) (bool, error)
+	// PutObjectBytes uploads object from memory together with its SHA-256 checksum verified by S3.
+	PutObjectBytes(ctx context.Context, bucketName, objectName string, data []byte) error
+}
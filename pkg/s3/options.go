@@ -3,7 +3,11 @@ package s3
 import "github.com/minio/minio-go/v7"
 
 type (
-	GetObjectOptions = minio.GetObjectOptions
-	PutObjectOptions = minio.PutObjectOptions
-	UploadInfo       = minio.UploadInfo
+	GetObjectOptions  = minio.GetObjectOptions
+	PutObjectOptions  = minio.PutObjectOptions
+	StatObjectOptions = minio.StatObjectOptions
+	UploadInfo        = minio.UploadInfo
+	ObjectInfo        = minio.ObjectInfo
+	ObjectPart        = minio.ObjectPart
+	CompletePart      = minio.CompletePart
 )
@@ -0,0 +1,44 @@
+package s3
+
+import (
+	"encoding/json"
+	"fmt"
+
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+const policyVersion = "2012-10-17"
+
+// Policy is a minimal IAM-compatible policy document accepted by S3 STS endpoints.
+type Policy struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is a single statement of the Policy.
+type PolicyStatement struct {
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource []string `json:"Resource"`
+}
+
+// ReadOnlyPolicy builds session policy which allows only reading objects of the bucket.
+func ReadOnlyPolicy(bucketName string) ([]byte, error) {
+	policy := Policy{
+		Version: policyVersion,
+		Statement: []PolicyStatement{
+			{
+				Effect:   "Allow",
+				Action:   []string{"s3:GetObject"},
+				Resource: []string{fmt.Sprintf("arn:aws:s3:::%s/*", bucketName)},
+			},
+		},
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] s3 policy", e.ErrMarshal)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// DeviceUseCase defines operations on devices user tokens are issued to.
+type DeviceUseCase interface {
+	// RegisterDevice records device user has logged in from.
+	RegisterDevice(ctx context.Context, device *user.Device) (*user.Device, error)
+	// VerifyDevice checks that device is known and not revoked.
+	VerifyDevice(ctx context.Context, userID, deviceID string) error
+	// ListDevices lists user devices.
+	ListDevices(ctx context.Context, userID uuid.UUID) ([]*user.Device, error)
+	// RevokeDevice revokes user device, so that tokens issued to it stop working.
+	RevokeDevice(ctx context.Context, userID, deviceID uuid.UUID) (*user.Device, error)
+}
+
+// DeviceUC implements the DeviceUseCase interface.
+type DeviceUC struct {
+	repo repository.DeviceRepository
+	log  zerolog.Logger
+}
+
+// NewDeviceUC returns a new instance of DeviceUC with dependencies injected.
+func NewDeviceUC(repo repository.DeviceRepository, log zerolog.Logger) *DeviceUC {
+	return &DeviceUC{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// RegisterDevice records device user has logged in from.
+// Returns ErrUnauthenticated if the device has been revoked, tokens are not issued to it anymore.
+func (uc *DeviceUC) RegisterDevice(ctx context.Context, device *user.Device) (*user.Device, error) {
+	dbDevice, err := uc.repo.RegisterDevice(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbDevice.IsRevoked() {
+		uc.log.Error().
+			Str("user_id", device.UserID.String()).
+			Str("device_id", device.ID.String()).
+			Msg("login from revoked device")
+
+		return nil, fmt.Errorf("[%w] device is revoked", e.ErrUnauthenticated)
+	}
+
+	return dbDevice, nil
+}
+
+// VerifyDevice checks that device is registered by the user and is not revoked,
+// its last seen time is updated on the way.
+// Returns ErrUnauthenticated for unknown or revoked device.
+func (uc *DeviceUC) VerifyDevice(ctx context.Context, userID, deviceID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("[%w] user id", e.ErrUnauthenticated)
+	}
+
+	did, err := uuid.Parse(deviceID)
+	if err != nil {
+		return fmt.Errorf("[%w] device id", e.ErrUnauthenticated)
+	}
+
+	device, err := uc.repo.TouchDevice(ctx, uid, did)
+	if errors.Is(err, e.ErrNotFound) {
+		return fmt.Errorf("[%w] unknown device", e.ErrUnauthenticated)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if device.IsRevoked() {
+		return fmt.Errorf("[%w] device is revoked", e.ErrUnauthenticated)
+	}
+
+	return nil
+}
+
+// ListDevices lists user devices ordered by first seen time.
+func (uc *DeviceUC) ListDevices(ctx context.Context, userID uuid.UUID) ([]*user.Device, error) {
+	return uc.repo.ListDevices(ctx, userID)
+}
+
+// RevokeDevice revokes user device. Returns ErrNotFound if user has no such device.
+func (uc *DeviceUC) RevokeDevice(ctx context.Context, userID, deviceID uuid.UUID) (*user.Device, error) {
+	return uc.repo.RevokeDevice(ctx, userID, deviceID)
+}
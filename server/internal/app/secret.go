@@ -4,37 +4,57 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/utils"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/crypto/keystore"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/watch"
 )
 
 // SecretUseCase defines the core operations related to user sercrets.
 type SecretUseCase interface {
 	InitUploadRequest(ctx context.Context, req *secret.InitRequest) (*dto.SecretUploadInitResponse, error)
+	CommitUploadRequest(ctx context.Context, req *secret.CommitRequest) (*dto.SecretUploadCommitResponse, error)
+	RenewUploadCredentials(ctx context.Context, req *secret.RenewRequest) (*s3.TemporaryCredentials, error)
+	InitDownloadRequest(ctx context.Context, req *secret.InitRequest) (*dto.SecretDownloadInitResponse, error)
+	ListSecrets(ctx context.Context, req *secret.ListRequest) (*dto.ListSecretsResponse, error)
+	ListSecretVersions(ctx context.Context, scrt *secret.Secret) (*dto.ListSecretVersionsResponse, error)
+	DeleteSecret(ctx context.Context, req *secret.DeleteRequest) (*dto.SecretTombstone, error)
+	ListSecretTombstones(ctx context.Context, userID uuid.UUID, since time.Time) (*dto.ListSecretTombstonesResponse, error)
+	ListSecretKeys(ctx context.Context, userID uuid.UUID) (*dto.ListSecretKeysResponse, error)
+	WatchSecrets(ctx context.Context, req *secret.WatchRequest, send func(*dto.SecretEvent) error) error
 }
 
 // SecretUC implements the SecretUseCase interface.
 type SecretUC struct {
 	SecretUseCase
-	repoSecret repository.SecretRepository
-	repoUser   repository.UserRepository
-	keyStore   keystore.Keystore
+	repoSecret        repository.SecretRepository
+	repoUser          repository.UserRepository
+	keyStore          keystore.Keystore
+	subscriber        watch.Subscriber
+	watchPollInterval time.Duration
 }
 
 func NewSecretUC(
+	cfg *config.Config,
 	repoSecret repository.SecretRepository,
 	repoUser repository.UserRepository,
 	keyStore keystore.Keystore,
+	subscriber watch.Subscriber,
 ) *SecretUC {
 	return &SecretUC{
-		repoSecret: repoSecret,
-		repoUser:   repoUser,
-		keyStore:   keyStore,
+		repoSecret:        repoSecret,
+		repoUser:          repoUser,
+		keyStore:          keyStore,
+		subscriber:        subscriber,
+		watchPollInterval: cfg.WatchPollInterval,
 	}
 }
 
@@ -47,8 +67,7 @@ func (uc *SecretUC) InitUploadRequest(
 		return nil, err
 	}
 
-	// here we need to get encrypted user kek
-	// and validate dek from requests
+	// user KEK never reaches server, so wrapped DEK of the request is stored as is.
 
 	uploadToken, err := utils.GenerateUploadToken()
 	if err != nil {
@@ -63,6 +82,7 @@ func (uc *SecretUC) InitUploadRequest(
 	req.User = usr
 	req.Token = uploadToken
 	req.S3URL = fmt.Sprintf("%s.%s.secret", req.SecretName, req.VersionID.String())
+	req.SetExpiration()
 
 	resReq, err := uc.repoSecret.CreateSecretInitRequest(ctx, req)
 	if errors.Is(err, e.ErrExists) {
@@ -92,3 +112,253 @@ func (uc *SecretUC) InitUploadRequest(
 		S3Creds:         *resReq.S3Creds,
 	}, nil
 }
+
+func (uc *SecretUC) CommitUploadRequest(
+	ctx context.Context,
+	req *secret.CommitRequest,
+) (*dto.SecretUploadCommitResponse, error) {
+	_, err := uc.keyStore.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	usr, err := uc.repoUser.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.User = usr
+	req.CommittedBy = secret.RequestCommitterUser
+
+	resReq, err := uc.repoSecret.CreateSecretCommitRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SecretUploadCommitResponse{
+		UserID:     resReq.UserID.String(),
+		SecretID:   resReq.SecretID.String(),
+		SecretName: resReq.SecretName,
+		VersionID:  resReq.VersionID.String(),
+	}, nil
+}
+
+// RenewUploadCredentials issues fresh S3 credentials for the upload in progress,
+// so that multipart upload can be continued after initial credentials expired.
+func (uc *SecretUC) RenewUploadCredentials(
+	ctx context.Context,
+	req *secret.RenewRequest,
+) (*s3.TemporaryCredentials, error) {
+	usr, err := uc.repoUser.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.User = usr
+
+	return uc.repoSecret.RenewUploadCredentials(ctx, req)
+}
+
+func (uc *SecretUC) InitDownloadRequest(
+	ctx context.Context,
+	req *secret.InitRequest,
+) (*dto.SecretDownloadInitResponse, error) {
+	_, err := uc.keyStore.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	downloadToken, err := utils.GenerateUploadToken()
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	usr, err := uc.repoUser.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.User = usr
+	req.Token = downloadToken
+
+	resReq, err := uc.repoSecret.CreateSecretGetRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resReq.S3Creds == nil {
+		return nil, fmt.Errorf("[%w] empty s3 credentials", e.ErrInternal)
+	}
+
+	metaData, err := resReq.MetaData.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("[%w] secret metadata", e.ErrMarshal)
+	}
+
+	var parentVersionID string
+	if resReq.ParentVersionID != uuid.Nil {
+		parentVersionID = resReq.ParentVersionID.String()
+	}
+
+	return &dto.SecretDownloadInitResponse{
+		UserID:           resReq.UserID.String(),
+		SecretID:         resReq.SecretID.String(),
+		SecretName:       resReq.SecretName,
+		VersionID:        resReq.VersionID.String(),
+		ParentVersionID:  parentVersionID,
+		S3URL:            resReq.S3URL,
+		SecretSize:       resReq.SecretSize,
+		SecretHash:       resReq.SecretHash,
+		SecretDEK:        resReq.SecretDEK,
+		MetaData:         string(metaData),
+		CurrentVersionID: resReq.CurrentVersionID.String(),
+		S3Creds:          *resReq.S3Creds,
+	}, nil
+}
+
+func (uc *SecretUC) ListSecrets(
+	ctx context.Context,
+	req *secret.ListRequest,
+) (*dto.ListSecretsResponse, error) {
+	limit := req.Limit()
+
+	// fetch one extra secret to find out whether there is a next page.
+	secrets, err := uc.repoSecret.ListSecrets(ctx, &secret.ListRequest{
+		UserID:     req.UserID,
+		NamePrefix: req.NamePrefix,
+		AfterName:  req.AfterName,
+		PageSize:   limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ListSecretsResponse{}
+
+	if len(secrets) > int(limit) {
+		secrets = secrets[:limit]
+		resp.NextPageToken = dto.EncodePageToken(secrets[len(secrets)-1].Name)
+	}
+
+	resp.Secrets = make([]dto.SecretInfo, 0, len(secrets))
+	for _, scrt := range secrets {
+		resp.Secrets = append(resp.Secrets, dto.SecretInfoFromDomain(scrt))
+	}
+
+	return resp, nil
+}
+
+func (uc *SecretUC) ListSecretVersions(
+	ctx context.Context,
+	scrt *secret.Secret,
+) (*dto.ListSecretVersionsResponse, error) {
+	history, err := uc.repoSecret.ListSecretVersions(ctx, scrt.UserID, scrt.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ListSecretVersionsResponseFromDomain(history), nil
+}
+
+func (uc *SecretUC) DeleteSecret(
+	ctx context.Context,
+	req *secret.DeleteRequest,
+) (*dto.SecretTombstone, error) {
+	usr, err := uc.repoUser.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.User = usr
+
+	tombstone, err := uc.repoSecret.DeleteSecret(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.SecretTombstoneFromDomain(tombstone)
+
+	return &resp, nil
+}
+
+func (uc *SecretUC) ListSecretTombstones(
+	ctx context.Context,
+	userID uuid.UUID,
+	since time.Time,
+) (*dto.ListSecretTombstonesResponse, error) {
+	tombstones, err := uc.repoSecret.ListSecretTombstones(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ListSecretTombstonesResponse{
+		Tombstones: make([]dto.SecretTombstone, 0, len(tombstones)),
+	}
+
+	for _, tombstone := range tombstones {
+		resp.Tombstones = append(resp.Tombstones, dto.SecretTombstoneFromDomain(tombstone))
+	}
+
+	return resp, nil
+}
+
+// ListSecretKeys returns wrapped DEKs of all user secret versions.
+func (uc *SecretUC) ListSecretKeys(ctx context.Context, userID uuid.UUID) (*dto.ListSecretKeysResponse, error) {
+	keys, err := uc.repoSecret.ListSecretKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ListSecretKeysResponse{
+		Keys: make([]dto.SecretKey, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, dto.SecretKeyFromDomain(key))
+	}
+
+	return resp, nil
+}
+
+// WatchSecrets sends events of user secrets recorded after the request cursor and then
+// keeps sending new ones as they are recorded until ctx is done or send fails.
+// Notifications only wake the watcher up, events are always read from the change feed,
+// which is also polled periodically in case a notification was missed.
+func (uc *SecretUC) WatchSecrets(
+	ctx context.Context,
+	req *secret.WatchRequest,
+	send func(*dto.SecretEvent) error,
+) error {
+	signal, unsubscribe := uc.subscriber.Subscribe(req.UserID)
+	defer unsubscribe()
+
+	ticker := time.NewTicker(uc.watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := uc.repoSecret.ListSecretEvents(ctx, req, secret.DefaultEventsBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, ev := range events {
+			event := dto.SecretEventFromDomain(ev)
+			if err := send(&event); err != nil {
+				return err
+			}
+
+			req.Cursor = ev.Cursor
+		}
+
+		if len(events) == int(secret.DefaultEventsBatchSize) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-signal:
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// Session is a refresh token issued to the user device, access tokens are issued for it.
+type Session struct {
+	User         *user.User
+	DeviceID     uuid.UUID
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// SessionUseCase defines operations on refresh tokens issued to user devices.
+type SessionUseCase interface {
+	// IssueSession issues refresh token to the user device on login.
+	IssueSession(ctx context.Context, usr *user.User, deviceID uuid.UUID) (*Session, error)
+	// RefreshSession exchanges refresh token for a new one.
+	RefreshSession(ctx context.Context, refreshToken string, deviceID uuid.UUID) (*Session, error)
+	// RevokeDeviceSessions revokes refresh tokens of the user device.
+	RevokeDeviceSessions(ctx context.Context, userID, deviceID uuid.UUID) (int64, error)
+	// RevokeUserSessions revokes refresh tokens of all user devices.
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// SessionUC implements the SessionUseCase interface.
+type SessionUC struct {
+	tokens  repository.RefreshTokenRepository
+	users   repository.UserRepository
+	devices DeviceUseCase
+	ttl     time.Duration
+	log     zerolog.Logger
+}
+
+// NewSessionUC returns a new instance of SessionUC with dependencies injected.
+func NewSessionUC(
+	cfg *config.Config,
+	tokens repository.RefreshTokenRepository,
+	users repository.UserRepository,
+	devices DeviceUseCase,
+	log zerolog.Logger,
+) *SessionUC {
+	return &SessionUC{
+		tokens:  tokens,
+		users:   users,
+		devices: devices,
+		ttl:     cfg.RefreshTokenTTL,
+		log:     log,
+	}
+}
+
+// IssueSession issues refresh token to the user device.
+func (uc *SessionUC) IssueSession(ctx context.Context, usr *user.User, deviceID uuid.UUID) (*Session, error) {
+	token, tokenString, err := user.NewRefreshToken(usr.ID, deviceID, uc.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.tokens.CreateRefreshToken(ctx, token); err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		User:         usr,
+		DeviceID:     deviceID,
+		RefreshToken: tokenString,
+		ExpiresAt:    token.ExpiresAt,
+	}, nil
+}
+
+// RefreshSession exchanges refresh token of the device for a new one, used token stops working.
+// Presenting already used token is treated as token theft: all refresh tokens of the device are revoked.
+// Returns ErrUnauthenticated for unknown, used, expired token or token of revoked device.
+func (uc *SessionUC) RefreshSession(ctx context.Context, refreshToken string, deviceID uuid.UUID) (*Session, error) {
+	used, err := uc.tokens.GetRefreshToken(ctx, user.HashRefreshToken(refreshToken))
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, fmt.Errorf("[%w] unknown refresh token", e.ErrUnauthenticated)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	logCtx := uc.log.With().
+		Str("user_id", used.UserID.String()).
+		Str("device_id", used.DeviceID.String()).Logger()
+
+	if used.DeviceID != deviceID {
+		logCtx.Error().Str("presented_device_id", deviceID.String()).Msg("refresh token of another device")
+		return nil, fmt.Errorf("[%w] refresh token of another device", e.ErrUnauthenticated)
+	}
+
+	if used.IsRevoked() {
+		return nil, uc.revokeReused(ctx, used, logCtx)
+	}
+
+	if used.IsExpired(time.Now().UTC()) {
+		return nil, fmt.Errorf("[%w] refresh token is expired", e.ErrUnauthenticated)
+	}
+
+	if err := uc.devices.VerifyDevice(ctx, used.UserID.String(), used.DeviceID.String()); err != nil {
+		return nil, err
+	}
+
+	usr, err := uc.users.GetUserByID(ctx, used.UserID)
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, fmt.Errorf("[%w] user not found", e.ErrUnauthenticated)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	next, tokenString, err := user.NewRefreshToken(used.UserID, used.DeviceID, uc.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.tokens.RotateRefreshToken(ctx, used, next)
+	if errors.Is(err, e.ErrConflict) {
+		return nil, uc.revokeReused(ctx, used, logCtx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		User:         usr,
+		DeviceID:     next.DeviceID,
+		RefreshToken: tokenString,
+		ExpiresAt:    next.ExpiresAt,
+	}, nil
+}
+
+// revokeReused revokes refresh tokens of the device which already used token has been presented for.
+func (uc *SessionUC) revokeReused(ctx context.Context, used *user.RefreshToken, logCtx zerolog.Logger) error {
+	logCtx.Error().Msg("refresh token reuse detected, revoking device refresh tokens")
+
+	if _, err := uc.tokens.RevokeDeviceRefreshTokens(ctx, used.UserID, used.DeviceID); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("[%w] refresh token is already used", e.ErrUnauthenticated)
+}
+
+// RevokeDeviceSessions revokes refresh tokens of the user device and returns number of revoked tokens.
+func (uc *SessionUC) RevokeDeviceSessions(ctx context.Context, userID, deviceID uuid.UUID) (int64, error) {
+	return uc.tokens.RevokeDeviceRefreshTokens(ctx, userID, deviceID)
+}
+
+// RevokeUserSessions revokes refresh tokens of all user devices and returns number of revoked tokens.
+func (uc *SessionUC) RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return uc.tokens.RevokeUserRefreshTokens(ctx, userID)
+}
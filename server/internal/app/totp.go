@@ -0,0 +1,221 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/totp"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/crypto/keystore"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// TOTPEnrollment is a pending TOTP seed handed to the user to set up authenticator app.
+type TOTPEnrollment struct {
+	URI    string
+	Secret string
+}
+
+// TwoFactorUseCase defines operations of TOTP second factor.
+type TwoFactorUseCase interface {
+	// EnrollTOTP generates new TOTP seed of the user.
+	EnrollTOTP(ctx context.Context, userID uuid.UUID, username string) (*TOTPEnrollment, error)
+	// ConfirmTOTP enables pending TOTP of the user and returns recovery codes.
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	// VerifyLogin checks second factor of the user logging in.
+	VerifyLogin(ctx context.Context, usr *user.User, code, recoveryCode string) (bool, error)
+}
+
+// TwoFactorUC implements the TwoFactorUseCase interface.
+type TwoFactorUC struct {
+	repo          repository.TOTPRepository
+	keyStore      keystore.Keystore
+	issuer        string
+	requiredRoles []user.Role
+	log           zerolog.Logger
+}
+
+// NewTwoFactorUC returns a new instance of TwoFactorUC with dependencies injected.
+// Roles listed in config TOTPRequiredRoles must enable TOTP before they get unrestricted tokens.
+func NewTwoFactorUC(
+	cfg *config.Config,
+	repo repository.TOTPRepository,
+	keyStore keystore.Keystore,
+	log zerolog.Logger,
+) *TwoFactorUC {
+	requiredRoles := make([]user.Role, 0, len(cfg.TOTPRequiredRoles))
+
+	for _, name := range cfg.TOTPRequiredRoles {
+		role, err := user.ParseRole(name)
+		if err != nil {
+			log.Warn().Err(err).
+				Msg("ignoring unknown role of totp policy")
+
+			continue
+		}
+
+		requiredRoles = append(requiredRoles, role)
+	}
+
+	return &TwoFactorUC{
+		repo:          repo,
+		keyStore:      keyStore,
+		issuer:        cfg.TOTPIssuer,
+		requiredRoles: requiredRoles,
+		log:           log,
+	}
+}
+
+// EnrollTOTP generates new TOTP seed of the user, stores it wrapped with REK as pending
+// and returns provisioning URI. Previous pending enrollment is replaced.
+// Returns ErrConflict if TOTP is already enabled.
+func (uc *TwoFactorUC) EnrollTOTP(ctx context.Context, userID uuid.UUID, username string) (*TOTPEnrollment, error) {
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	rek, err := uc.keyStore.Get()
+	if err != nil {
+		uc.log.Error().Err(err).
+			Msg("failed to get rek from keystore")
+
+		return nil, e.InternalErr(err)
+	}
+
+	wrapped, err := keys.WrapSecret(rek, secret)
+	if err != nil {
+		uc.log.Error().Err(err).
+			Msg("failed to encrypt totp secret with rek")
+
+		return nil, e.InternalErr(err)
+	}
+
+	if err := uc.repo.SaveTOTP(ctx, user.NewTOTP(userID, wrapped)); err != nil {
+		return nil, err
+	}
+
+	return &TOTPEnrollment{
+		URI:    totp.URI(uc.issuer, username, secret),
+		Secret: totp.EncodeSecret(secret),
+	}, nil
+}
+
+// ConfirmTOTP enables pending TOTP of the user if code is valid and issues new recovery codes.
+// Returns ErrNotFound if user has not enrolled, ErrConflict if TOTP is already enabled
+// and ErrValidation for invalid code.
+func (uc *TwoFactorUC) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	pending, err := uc.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pending.Enabled {
+		return nil, fmt.Errorf("[%w] user totp is already enabled", e.ErrConflict)
+	}
+
+	step, err := uc.validate(pending, code)
+	if errors.Is(err, e.ErrValidation) {
+		return nil, fmt.Errorf("[%w] totp code", e.ErrValidation)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := user.NewRecoveryCodes()
+	if err != nil {
+		return nil, e.InternalErr(err)
+	}
+
+	if err := uc.repo.EnableTOTP(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// VerifyLogin checks TOTP or recovery code of the user logging in, codes are accepted once.
+// Users without TOTP pass, unless their role requires it by policy: then true is returned
+// and the user may only be given token restricted to TOTP enrollment.
+// Returns ErrUnauthenticated if code is missing, invalid or already used
+// and ErrNotReady if TOTP code can not be checked as the server is sealed.
+func (uc *TwoFactorUC) VerifyLogin(ctx context.Context, usr *user.User, code, recoveryCode string) (bool, error) {
+	current, err := uc.repo.GetTOTP(ctx, usr.ID)
+	if errors.Is(err, e.ErrNotFound) || (err == nil && !current.Enabled) {
+		return slices.Contains(uc.requiredRoles, usr.Role), nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if recoveryCode != "" {
+		err := uc.repo.UseRecoveryCode(ctx, usr.ID, user.HashRecoveryCode(recoveryCode))
+		if errors.Is(err, e.ErrNotFound) {
+			return false, fmt.Errorf("[%w] invalid recovery code", e.ErrUnauthenticated)
+		}
+
+		return false, err
+	}
+
+	if code == "" {
+		return false, fmt.Errorf("[%w] totp code required", e.ErrUnauthenticated)
+	}
+
+	step, err := uc.validate(current, code)
+	if errors.Is(err, e.ErrValidation) {
+		return false, fmt.Errorf("[%w] invalid totp code", e.ErrUnauthenticated)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	err = uc.repo.AcceptTOTPStep(ctx, usr.ID, step)
+	if errors.Is(err, e.ErrConflict) {
+		return false, fmt.Errorf("[%w] totp code is already used", e.ErrUnauthenticated)
+	}
+
+	return false, err
+}
+
+// validate unwraps TOTP seed with REK and checks code against it.
+// Returns ErrNotReady while keystore is sealed as seed can not be unwrapped.
+func (uc *TwoFactorUC) validate(current *user.TOTP, code string) (int64, error) {
+	if !uc.keyStore.IsLoaded() {
+		return 0, fmt.Errorf("[%w] keystore is sealed", e.ErrNotReady)
+	}
+
+	rek, err := uc.keyStore.Get()
+	if err != nil {
+		uc.log.Error().Err(err).
+			Msg("failed to get rek from keystore")
+
+		return 0, e.InternalErr(err)
+	}
+
+	secret, err := keys.UnwrapSecret(rek, current.WrappedSecret)
+	if err != nil {
+		uc.log.Error().Err(err).
+			Str("user_id", current.UserID.String()).
+			Msg("failed to decrypt totp secret with rek")
+
+		return 0, e.InternalErr(err)
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return 0, e.ErrValidation
+	}
+
+	return step, nil
+}
@@ -2,59 +2,161 @@ package app
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"errors"
+	"fmt"
 
-	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/keys"
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
-	"github.com/patraden/ya-practicum-gophkeeper/server/internal/auth"
-	"github.com/patraden/ya-practicum-gophkeeper/server/internal/crypto/keystore"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
 	repository "github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
 	"github.com/rs/zerolog"
 )
 
+const fakeSaltLabel = "gophkeeper login salt"
+
+// LoginParams tells client how to derive auth key of the user before login.
+type LoginParams struct {
+	Salt             []byte
+	PasswordRequired bool
+}
+
 // UserUseCase defines the core operations related to user authentication and registration.
 type UserUseCase interface {
-	// RegisterUser registers a user (admin or regular) with proper authorization and key wrapping.
+	// RegisterUser registers a user (admin or regular) with auth key verifier.
 	RegisterUser(ctx context.Context, creds *dto.RegisterUserCredentials) (*user.User, error)
+	// GetLoginParams returns parameters of the auth key derivation of the user.
+	GetLoginParams(ctx context.Context, username string) (*LoginParams, error)
 	// ValidateUser checks user credentials against stored values.
 	ValidateUser(ctx context.Context, creds *dto.UserCredentials) (*user.User, error)
+	// ChangePassword replaces auth key of the user and DEKs re-wrapped with the new KEK.
+	ChangePassword(
+		ctx context.Context,
+		userID uuid.UUID,
+		creds *dto.ChangePasswordCredentials,
+		keys []*secret.WrappedKey,
+	) error
 }
 
 // UserUC implements the UserUseCase interface and coordinates user auth logic.
 type UserUC struct {
 	UserUseCase
-	repo     repository.UserRepository
-	keyStore keystore.Keystore
-	log      zerolog.Logger
+	repo    repository.UserRepository
+	saltKey []byte
+	log     zerolog.Logger
 }
 
 // NewUserUC returns a new instance of UserUC with dependencies injected.
-func NewUserUC(repo repository.UserRepository, keyStore keystore.Keystore, log zerolog.Logger) *UserUC {
+func NewUserUC(cfg *config.Config, repo repository.UserRepository, log zerolog.Logger) *UserUC {
 	return &UserUC{
-		repo:     repo,
-		keyStore: keyStore,
-		log:      log,
+		repo:    repo,
+		saltKey: []byte(cfg.JWTSecret),
+		log:     log,
 	}
 }
 
+// GetLoginParams returns salt of the user KEK, the client derives auth key from.
+// Unknown user gets made up salt which is stable per username, so that response
+// does not reveal whether user exists. Legacy user is asked for password to migrate.
+func (u *UserUC) GetLoginParams(ctx context.Context, username string) (*LoginParams, error) {
+	usr, err := u.repo.GetUser(ctx, username)
+	if errors.Is(err, e.ErrNotFound) {
+		mac := hmac.New(sha256.New, u.saltKey)
+		mac.Write([]byte(fakeSaltLabel))
+		mac.Write([]byte(username))
+
+		return &LoginParams{Salt: mac.Sum(nil)[:user.SaltLength]}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginParams{Salt: usr.Salt, PasswordRequired: usr.IsLegacy()}, nil
+}
+
 // ValidateUser checks the given credentials and returns the user if valid.
+// Legacy user authenticated with password is migrated to the presented auth key,
+// so that password is never required again.
 func (u *UserUC) ValidateUser(ctx context.Context, creds *dto.UserCredentials) (*user.User, error) {
-	return u.repo.ValidateUser(ctx, creds)
+	usr, err := u.repo.ValidateUser(ctx, creds)
+	if err != nil || !usr.IsLegacy() {
+		return usr, err
+	}
+
+	if len(creds.AuthKey) == 0 {
+		return nil, fmt.Errorf("[%w] auth key is required to migrate user", e.ErrValidation)
+	}
+
+	usr.SetAuthKey(creds.AuthKey, usr.Salt)
+
+	err = u.repo.MigrateUserAuth(ctx, usr)
+	if err != nil && !errors.Is(err, e.ErrConflict) {
+		return nil, err
+	}
+
+	u.log.Info().
+		Str("user_id", usr.ID.String()).
+		Msg("legacy user migrated to auth key")
+
+	return usr, nil
 }
 
-// RegisterUser registers a new user with the given credentials, wrapping their KEK with REK.
-// Only admins can register admin users.
+// ChangePassword checks current auth key of the user and stores the new one together with
+// DEKs of all user secret versions the client has re-wrapped with the new KEK.
+// Password itself is changed on client, server never sees either KEK.
+// Returns ErrValidation if current auth key is invalid and ErrConflict if keys do not match
+// the stored secret versions, in which case client should list keys and try again.
+func (u *UserUC) ChangePassword(
+	ctx context.Context,
+	userID uuid.UUID,
+	creds *dto.ChangePasswordCredentials,
+	keys []*secret.WrappedKey,
+) error {
+	usr, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !usr.CheckAuthKey(creds.AuthKey) {
+		return fmt.Errorf("[%w] user auth key", e.ErrValidation)
+	}
+
+	currentVerifier := usr.Verifier
+	usr.SetAuthKey(creds.NewAuthKey, creds.NewSalt)
+
+	if err := u.repo.ChangePassword(ctx, usr, currentVerifier, keys); err != nil {
+		return err
+	}
+
+	u.log.Info().
+		Str("user_id", usr.ID.String()).
+		Int("keys", len(keys)).
+		Msg("user password changed")
+
+	return nil
+}
+
+// RegisterUser registers a new user with the given credentials.
+// Server only stores verifier of the auth key derived by client, it never sees password or KEK.
+// Staff users (admin, auditor, operator) are created without bucket.
 func (u *UserUC) RegisterUser(ctx context.Context, creds *dto.RegisterUserCredentials) (*user.User, error) {
 	logCtx := u.log.With().
 		Str("username", creds.Username).Logger()
 
+	if len(creds.AuthKey) == 0 || len(creds.Salt) == 0 {
+		return nil, e.ErrInvalidInput
+	}
+
 	switch creds.Role {
-	case user.RoleAdmin:
-		return u.registerAdmin(ctx, creds, logCtx)
+	case user.RoleAdmin, user.RoleAuditor, user.RoleOperator:
+		return u.registerStaff(ctx, creds)
 	case user.RoleUser:
-		return u.registerUser(ctx, creds, logCtx)
+		return u.registerUser(ctx, creds)
 	default:
 		logCtx.Error().
 			Str("role", creds.Role.String()).
@@ -64,39 +166,15 @@ func (u *UserUC) RegisterUser(ctx context.Context, creds *dto.RegisterUserCreden
 	}
 }
 
-// registerAdmin handles the creation of a new admin user.
-// This requires the caller to already be authenticated as an admin.
-func (u *UserUC) registerAdmin(
-	ctx context.Context,
-	creds *dto.RegisterUserCredentials,
-	logCtx zerolog.Logger,
-) (*user.User, error) {
-	if creds.Role != user.RoleAdmin {
+// registerStaff handles the creation of a new admin, auditor or operator user.
+// Caller is authorized by the access policy before the request reaches use case.
+func (u *UserUC) registerStaff(ctx context.Context, creds *dto.RegisterUserCredentials) (*user.User, error) {
+	if !user.IsStaff(creds.Role) {
 		return nil, e.ErrInvalidInput
 	}
 
-	_, claims, err := auth.FromContext(ctx)
-	if err != nil {
-		logCtx.Error().Err(err).
-			Msg("failed to get auth user info")
-
-		return nil, e.ErrUnauthorized
-	}
-
-	if claims.Role != string(user.RoleAdmin) {
-		logCtx.Error().
-			Msg("user is not authorised to register admin user")
-
-		return nil, e.ErrUnauthorized
-	}
-
 	usr := user.New(creds.Username, creds.Role)
-	if err := usr.SetPassword(creds.Password); err != nil {
-		logCtx.Error().Err(err).
-			Msg("user password generation error")
-
-		return nil, e.InternalErr(err)
-	}
+	usr.SetAuthKey(creds.AuthKey, creds.Salt)
 
 	repoUser, err := u.repo.CreateAdmin(ctx, usr)
 	if errors.Is(err, e.ErrExists) {
@@ -110,51 +188,16 @@ func (u *UserUC) registerAdmin(
 	return repoUser, nil
 }
 
-// registerUser creates a new non-admin user with encrypted KEK stored in the database.
-func (u *UserUC) registerUser(
-	ctx context.Context,
-	creds *dto.RegisterUserCredentials,
-	logCtx zerolog.Logger,
-) (*user.User, error) {
+// registerUser creates a new non-admin user with S3 bucket.
+func (u *UserUC) registerUser(ctx context.Context, creds *dto.RegisterUserCredentials) (*user.User, error) {
 	if creds.Role != user.RoleUser {
 		return nil, e.ErrInvalidInput
 	}
 
 	usr := user.New(creds.Username, creds.Role)
-	if err := usr.SetPassword(creds.Password); err != nil {
-		logCtx.Error().Err(err).
-			Msg("user password generation error")
-
-		return nil, e.InternalErr(err)
-	}
-
-	kek, err := keys.KEK(usr, creds.Password)
-	if err != nil {
-		logCtx.Error().Err(err).
-			Msg("failed to generate kek for user")
-
-		return nil, e.InternalErr(err)
-	}
-
-	rek, err := u.keyStore.Get()
-	if err != nil {
-		logCtx.Error().Err(err).
-			Msg("failed to get rek from keystore")
-
-		return nil, e.InternalErr(err)
-	}
-
-	eKek, err := keys.WrapKEK(rek, kek)
-	if err != nil {
-		logCtx.Error().Err(err).
-			Msg("failed to encrypt kek with rek")
-
-		return nil, e.InternalErr(err)
-	}
-
-	key := user.NewKey(usr.ID, eKek, keys.EncryptionAlgo)
-	repoUser, err := u.repo.CreateUser(ctx, usr, key)
+	usr.SetAuthKey(creds.AuthKey, creds.Salt)
 
+	repoUser, err := u.repo.CreateUser(ctx, usr)
 	if errors.Is(err, e.ErrExists) {
 		return nil, err
 	}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/rs/zerolog"
@@ -19,15 +20,14 @@ type (
 	// a pointer so it fits in an interface{} without allocation. This technique
 	// for defining context keys was copied from Go 1.7's new use of context in net/http.
 	contextKey string
-	// TokenEncoder encodes User into jwt token string.
-	TokenEncoder func(*user.User) (string, error)
+	// TokenEncoder encodes User into jwt token string issued to the device.
+	TokenEncoder func(usr *user.User, deviceID uuid.UUID) (string, error)
 )
 
 // JWT package constants.
 const (
-	TokenCtxKey      = contextKey("Token")
-	ErrorCtxKey      = contextKey("TokenErr")
-	MaxTokenDuration = 365 * 24 * time.Hour
+	TokenCtxKey = contextKey("Token")
+	ErrorCtxKey = contextKey("TokenErr")
 )
 
 // FromContext gets token and claims from context added by GRPCServerVerifier interceptor.
@@ -50,18 +50,25 @@ func FromContext(ctx context.Context) (*jwt.Token, *Claims, error) {
 
 // Auth is a struct that provides JWT-based authentication and authorization capabilities.
 type Auth struct {
-	keyFunc jwt.Keyfunc
-	log     zerolog.Logger
+	keyFunc  jwt.Keyfunc
+	tokenTTL time.Duration
+	log      zerolog.Logger
 }
 
-// New creates a new object of type Auth.
-func New(keyFunc jwt.Keyfunc, log zerolog.Logger) *Auth {
+// New creates a new object of type Auth issuing access tokens valid for tokenTTL.
+func New(keyFunc jwt.Keyfunc, tokenTTL time.Duration, log zerolog.Logger) *Auth {
 	return &Auth{
-		keyFunc: keyFunc,
-		log:     log,
+		keyFunc:  keyFunc,
+		tokenTTL: tokenTTL,
+		log:      log,
 	}
 }
 
+// TokenTTL returns lifetime of issued access tokens.
+func (auth *Auth) TokenTTL() time.Duration {
+	return auth.tokenTTL
+}
+
 // Validate validates the JWT token tring and returns jwt.Token pointer if string is valid.
 func (auth *Auth) Validate(tokenString string) (*jwt.Token, error) {
 	claims := &Claims{}
@@ -88,16 +95,23 @@ func (auth *Auth) Validate(tokenString string) (*jwt.Token, error) {
 
 // Encoder returns jwt TokenEncoder for User.
 func (auth *Auth) Encoder() TokenEncoder {
-	return func(user *user.User) (string, error) {
+	return auth.ScopedEncoder("")
+}
+
+// ScopedEncoder returns jwt TokenEncoder for User issuing tokens restricted to scope.
+func (auth *Auth) ScopedEncoder(scope string) TokenEncoder {
+	return func(user *user.User, deviceID uuid.UUID) (string, error) {
 		now := time.Now()
 
 		claims := &Claims{
 			UserID:   user.ID.String(),
 			Username: user.Username,
 			Role:     user.Role.String(),
+			DeviceID: deviceID.String(),
+			Scope:    scope,
 			RegisteredClaims: jwt.RegisteredClaims{
 				IssuedAt:  jwt.NewNumericDate(now),
-				ExpiresAt: jwt.NewNumericDate(now.Add(MaxTokenDuration)),
+				ExpiresAt: jwt.NewNumericDate(now.Add(auth.tokenTTL)),
 			},
 		}
 
@@ -127,6 +141,7 @@ func (auth *Auth) Encoder() TokenEncoder {
 			Str("method", "HS256").
 			Str("user_id", user.ID.String()).
 			Str("username", user.Username).
+			Str("device_id", deviceID.String()).
 			Msg("generated user token")
 
 		return tokenString, nil
@@ -196,11 +211,71 @@ func GRPCServerAuthenticator(isPublicMethod func(method string) bool) grpc.Unary
 			return handler(ctx, req)
 		}
 
-		token, claims, err := FromContext(ctx)
-		if err != nil || token == nil || claims == nil {
+		if !isVerified(ctx) {
 			return nil, status.Errorf(codes.Unauthenticated, "Unauthorized")
 		}
 
 		return handler(ctx, req)
 	}
 }
+
+// VerifyGRPCStreamServer is the streaming counterpart of VerifyGRPCUnaryServer.
+// Token and error are injected into the context of the stream passed to the next handler.
+func VerifyGRPCStreamServer(auth *Auth, extractors ...TokenExtractor) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := stream.Context()
+		token, err := auth.VerifyContext(ctx, extractors...)
+		ctx = context.WithValue(ctx, TokenCtxKey, token)
+		ctx = context.WithValue(ctx, ErrorCtxKey, err)
+
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// GRPCServerStreamVerifier is the streaming counterpart of GRPCServerVerifier.
+func GRPCServerStreamVerifier(auth *Auth) grpc.StreamServerInterceptor {
+	return VerifyGRPCStreamServer(auth, MetaDataTokenExtractor)
+}
+
+// GRPCServerStreamAuthenticator is the streaming counterpart of GRPCServerAuthenticator.
+// Unverified streams are rejected before handler sends anything.
+func GRPCServerStreamAuthenticator(isPublicMethod func(method string) bool) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if isPublicMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		if !isVerified(stream.Context()) {
+			return status.Errorf(codes.Unauthenticated, "Unauthorized")
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+// isVerified reports whether verifier interceptor has injected valid token into the context.
+func isVerified(ctx context.Context) bool {
+	token, claims, err := FromContext(ctx)
+
+	return err == nil && token != nil && claims != nil
+}
+
+// contextStream is a server stream which context carries values injected by stream interceptors.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -14,7 +14,10 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -23,6 +26,8 @@ const (
 	userIDStr = "123e4567-e89b-12d3-a456-426614174000"
 )
 
+var deviceID = uuid.MustParse("5b1c7f0e-3a52-4f4e-9c8e-2d6f1a0b9c11")
+
 func setupTestUsers(t *testing.T) (*user.User, *user.User) {
 	t.Helper()
 
@@ -119,10 +124,10 @@ func TestAuthEncoder(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			jwtauth := auth.New(tt.keyFunc, logger)
+			jwtauth := auth.New(tt.keyFunc, time.Hour, logger)
 			encoder := jwtauth.Encoder()
 
-			token, err := encoder(tt.user)
+			token, err := encoder(tt.user, deviceID)
 
 			if tt.expectErr != nil {
 				require.ErrorIs(t, err, tt.expectErr)
@@ -140,10 +145,10 @@ func TestAuthVerifyValid(t *testing.T) {
 
 	logger := setupLogger(t)
 	usr, _ := setupTestUsers(t)
-	jwtauth := auth.New(mockKeyFunc, logger)
+	jwtauth := auth.New(mockKeyFunc, time.Hour, logger)
 	encoder := jwtauth.Encoder()
 
-	tokenString, err := encoder(usr)
+	tokenString, err := encoder(usr, deviceID)
 	require.NoError(t, err)
 
 	token, err := jwtauth.Validate(tokenString)
@@ -155,6 +160,9 @@ func TestAuthVerifyValid(t *testing.T) {
 	assert.True(t, ok, "Claims should be of type *auth.Claims")
 	assert.Equal(t, userName, claims.Username, "Username in claims should match")
 	assert.Equal(t, usr.ID.String(), claims.UserID, "User id in claims should match")
+	assert.Equal(t, deviceID.String(), claims.DeviceID, "Device id in claims should match")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, time.Minute,
+		"Token should expire after configured TTL")
 }
 
 func TestAuthVerifyInvalid(t *testing.T) {
@@ -162,20 +170,27 @@ func TestAuthVerifyInvalid(t *testing.T) {
 
 	logger := setupLogger(t)
 	usr, usrNil := setupTestUsers(t)
-	jwtauth := auth.New(mockKeyFunc, logger)
+	jwtauth := auth.New(mockKeyFunc, time.Hour, logger)
 
 	token, err := jwtauth.Validate("invalid.token")
 	require.ErrorIs(t, err, e.ErrInvalidInput, "Verification of an invalid token should error")
 	assert.Nil(t, token)
 
 	encoder := jwtauth.Encoder()
-	tokenString, err := encoder(usrNil)
+	tokenString, err := encoder(usrNil, deviceID)
 	require.NoError(t, err)
 
 	token, err = jwtauth.Validate(tokenString)
 	require.ErrorIs(t, err, e.ErrUnauthenticated, "Verification of a token with nil user_id should error")
 	assert.Nil(t, token)
 
+	tokenString, err = encoder(usr, uuid.Nil)
+	require.NoError(t, err)
+
+	token, err = jwtauth.Validate(tokenString)
+	require.ErrorIs(t, err, e.ErrUnauthenticated, "Verification of a token not bound to device should error")
+	assert.Nil(t, token)
+
 	expiredToken, err := ExpiredToken(t)
 	require.NoError(t, err)
 	token, err = jwtauth.Validate(expiredToken)
@@ -183,9 +198,9 @@ func TestAuthVerifyInvalid(t *testing.T) {
 	assert.Nil(t, token)
 
 	// Create token with wrong key
-	tempAuth := auth.New(func(_ *jwt.Token) (any, error) { return []byte("wrong_secret"), nil }, logger)
+	tempAuth := auth.New(func(_ *jwt.Token) (any, error) { return []byte("wrong_secret"), nil }, time.Hour, logger)
 	encoder = tempAuth.Encoder()
-	tokenString, err = encoder(usr)
+	tokenString, err = encoder(usr, deviceID)
 	require.NoError(t, err)
 
 	token, err = jwtauth.Validate(tokenString)
@@ -198,9 +213,9 @@ func TestAuthVerifyContext(t *testing.T) {
 
 	logger := setupLogger(t)
 	usr, _ := setupTestUsers(t)
-	jwtauth := auth.New(mockKeyFunc, logger)
+	jwtauth := auth.New(mockKeyFunc, time.Hour, logger)
 	encoder := jwtauth.Encoder()
-	validToken, err := encoder(usr)
+	validToken, err := encoder(usr, deviceID)
 
 	require.NoError(t, err)
 
@@ -240,3 +255,75 @@ func TestAuthVerifyContext(t *testing.T) {
 		})
 	}
 }
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestGRPCServerStreamAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	logger := setupLogger(t)
+	usr, _ := setupTestUsers(t)
+	jwtauth := auth.New(mockKeyFunc, time.Hour, logger)
+	validToken, err := jwtauth.Encoder()(usr, deviceID)
+
+	require.NoError(t, err)
+
+	const (
+		privateMethod = "/test.Service/Private"
+		publicMethod  = "/test.Service/Public"
+	)
+
+	isPublicMethod := func(method string) bool { return method == publicMethod }
+
+	tests := []struct {
+		name       string
+		method     string
+		metadata   map[string]string
+		expectCode codes.Code
+	}{
+		{"valid token", privateMethod, map[string]string{"authorization": "Bearer " + validToken}, codes.OK},
+		{"missing token", privateMethod, nil, codes.Unauthenticated},
+		{"invalid token", privateMethod, map[string]string{"authorization": "Bearer invalid"}, codes.Unauthenticated},
+		{"public method", publicMethod, nil, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.metadata != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.New(tt.metadata))
+			}
+
+			verifier := auth.GRPCServerStreamVerifier(jwtauth)
+			authenticator := auth.GRPCServerStreamAuthenticator(isPublicMethod)
+			info := &grpc.StreamServerInfo{FullMethod: tt.method}
+
+			var claims *auth.Claims
+
+			handler := func(_ any, stream grpc.ServerStream) error {
+				_, claims, _ = auth.FromContext(stream.Context())
+				return nil
+			}
+
+			err := verifier(nil, &testServerStream{ctx: ctx}, info, func(srv any, stream grpc.ServerStream) error {
+				return authenticator(srv, stream, info, handler)
+			})
+
+			require.Equal(t, tt.expectCode, status.Code(err))
+
+			if tt.metadata != nil && tt.expectCode == codes.OK {
+				require.NotNil(t, claims)
+				assert.Equal(t, usr.ID.String(), claims.UserID)
+			}
+		})
+	}
+}
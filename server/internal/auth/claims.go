@@ -6,12 +6,19 @@ import (
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 )
 
+// ScopeTOTPEnrollment restricts token to TOTP enrollment of the user
+// whose role requires 2FA by policy but who has not enabled it yet.
+const ScopeTOTPEnrollment = "totp_enrollment"
+
 // Claims represents the JWT claims for a user.
-// Includes the user ID and role along with standard JWT claims.
+// Includes the user ID, role and ID of the device token is issued to along with standard JWT claims.
+// Scope is empty for unrestricted tokens.
 type Claims struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	Role     string `json:"role"`
+	DeviceID string `json:"device_id"`
+	Scope    string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -21,5 +28,10 @@ func (c Claims) Validate() error {
 		return errors.ErrInvalidInput
 	}
 
+	// token which is not bound to device can not be revoked.
+	if deviceID, err := uuid.Parse(c.DeviceID); err != nil || deviceID == uuid.Nil {
+		return errors.ErrInvalidInput
+	}
+
 	return nil
 }
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeviceVerifier verifies that device the token is issued to is known and not revoked,
+// otherwise it returns ErrUnauthenticated.
+type DeviceVerifier interface {
+	VerifyDevice(ctx context.Context, userID, deviceID string) error
+}
+
+// GRPCServerDeviceValidator returns interceptor which rejects requests made with tokens
+// issued to revoked or unknown devices. It is chained after GRPCServerAuthenticator.
+// Verified token is checked on public methods too, as they may elevate caller by its token,
+// requests without verified token are passed through.
+func GRPCServerDeviceValidator(devices DeviceVerifier) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := verifyDevice(ctx, devices); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// GRPCServerStreamDeviceValidator is the streaming counterpart of GRPCServerDeviceValidator.
+func GRPCServerStreamDeviceValidator(devices DeviceVerifier) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := verifyDevice(stream.Context(), devices); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+func verifyDevice(ctx context.Context, devices DeviceVerifier) error {
+	if !isVerified(ctx) {
+		return nil
+	}
+
+	_, claims, _ := FromContext(ctx)
+
+	err := devices.VerifyDevice(ctx, claims.UserID, claims.DeviceID)
+	if errors.Is(err, e.ErrUnauthenticated) {
+		return status.Errorf(codes.Unauthenticated, "Unauthorized: device is revoked")
+	}
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "Internal Server Error: device verification")
+	}
+
+	return nil
+}
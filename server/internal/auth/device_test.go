@@ -0,0 +1,90 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type stubDeviceVerifier struct {
+	err      error
+	userID   string
+	deviceID string
+}
+
+func (v *stubDeviceVerifier) VerifyDevice(_ context.Context, userID, deviceID string) error {
+	v.userID = userID
+	v.deviceID = deviceID
+
+	return v.err
+}
+
+func TestGRPCServerDeviceValidator(t *testing.T) {
+	t.Parallel()
+
+	logger := setupLogger(t)
+	usr, _ := setupTestUsers(t)
+	jwtauth := auth.New(mockKeyFunc, time.Hour, logger)
+	validToken, err := jwtauth.Encoder()(usr, deviceID)
+
+	require.NoError(t, err)
+
+	const (
+		privateMethod = "/test.Service/Private"
+		publicMethod  = "/test.Service/Public"
+	)
+
+	tests := []struct {
+		name         string
+		method       string
+		token        string
+		verifyErr    error
+		expectCode   codes.Code
+		expectVerify bool
+	}{
+		{"active device", privateMethod, validToken, nil, codes.OK, true},
+		{"revoked device", privateMethod, validToken, e.ErrUnauthenticated, codes.Unauthenticated, true},
+		{"verification failure", privateMethod, validToken, e.ErrInternal, codes.Internal, true},
+		{"public method", publicMethod, validToken, nil, codes.OK, true},
+		{"public method of revoked device", publicMethod, validToken, e.ErrUnauthenticated, codes.Unauthenticated, true},
+		{"no token", publicMethod, "", e.ErrUnauthenticated, codes.OK, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.New(map[string]string{"authorization": "Bearer " + tt.token}))
+			}
+
+			devices := &stubDeviceVerifier{err: tt.verifyErr}
+			verifier := auth.GRPCServerVerifier(jwtauth)
+			validator := auth.GRPCServerDeviceValidator(devices)
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+			handler := func(_ context.Context, _ any) (any, error) { return struct{}{}, nil }
+
+			_, err := verifier(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				return validator(ctx, req, info, handler)
+			})
+
+			require.Equal(t, tt.expectCode, status.Code(err))
+
+			if tt.expectVerify {
+				require.Equal(t, usr.ID.String(), devices.userID)
+				require.Equal(t, deviceID.String(), devices.deviceID)
+			} else {
+				require.Empty(t, devices.deviceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Rule declares who may call a gRPC method.
+type Rule struct {
+	// Public methods are served without access token, e.g. Login.
+	Public bool
+	// Roles lists roles allowed to call the method.
+	Roles []user.Role
+	// Owner binds request to the caller: request user_id must match token user_id.
+	Owner bool
+	// OnBehalfRoles lists roles exempt from Owner check, i.e. allowed to act on any user.
+	OnBehalfRoles []user.Role
+	// Elevate returns roles required for the particular request instead of method rule,
+	// e.g. registration of admin user. Nil result keeps the method rule.
+	Elevate func(req any) []user.Role
+	// Enrollment methods are also served to tokens restricted to TOTP enrollment.
+	Enrollment bool
+}
+
+// Policy maps gRPC full method names to access rules.
+// Methods which are not listed in the policy are denied.
+type Policy map[string]Rule
+
+// userOwned is implemented by requests carrying user_id, e.g. secret service requests.
+type userOwned interface {
+	GetUserId() string
+}
+
+// IsPublic reports whether method is served without access token.
+// It fits as isPublicMethod argument of authentication interceptors.
+func (p Policy) IsPublic(method string) bool {
+	rule, ok := p[method]
+
+	return ok && rule.Public
+}
+
+// Authorize checks that the caller identified by context claims may call method with req.
+// Owner check is skipped for nil req, which is the case of streams before the first message.
+// Returns ErrUnauthenticated when token is missing and ErrPermissionDenied on policy violation.
+func (p Policy) Authorize(ctx context.Context, method string, req any) error {
+	rule, ok := p[method]
+	if !ok {
+		return fmt.Errorf("[%w] method %s is not covered by access policy", e.ErrPermissionDenied, method)
+	}
+
+	roles, public := rule.Roles, rule.Public
+	if rule.Elevate != nil && req != nil {
+		if elevated := rule.Elevate(req); elevated != nil {
+			roles, public = elevated, false
+		}
+	}
+
+	if public {
+		return nil
+	}
+
+	if !isVerified(ctx) {
+		return fmt.Errorf("[%w] method %s requires access token", e.ErrUnauthenticated, method)
+	}
+
+	_, claims, _ := FromContext(ctx)
+
+	role, err := user.ParseRole(claims.Role)
+	if err != nil {
+		return fmt.Errorf("[%w] token role %q", e.ErrPermissionDenied, claims.Role)
+	}
+
+	if !slices.Contains(roles, role) {
+		return fmt.Errorf("[%w] role %s may not call %s", e.ErrPermissionDenied, role, method)
+	}
+
+	if claims.Scope == ScopeTOTPEnrollment && !rule.Enrollment {
+		return fmt.Errorf("[%w] token is restricted to totp enrollment", e.ErrPermissionDenied)
+	}
+
+	if !rule.Owner || req == nil || slices.Contains(rule.OnBehalfRoles, role) {
+		return nil
+	}
+
+	owned, ok := req.(userOwned)
+	if !ok || owned.GetUserId() != claims.UserID {
+		return fmt.Errorf("[%w] request user does not match caller of %s", e.ErrPermissionDenied, method)
+	}
+
+	return nil
+}
+
+// GRPCServerAuthorizer returns interceptor enforcing access policy on unary requests.
+// It is chained after GRPCServerVerifier which injects token claims into context.
+func GRPCServerAuthorizer(policy Policy) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := policy.Authorize(ctx, info.FullMethod, req); err != nil {
+			return nil, authzStatus(err)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// GRPCServerStreamAuthorizer is the streaming counterpart of GRPCServerAuthorizer.
+// Method roles are checked when stream is opened, owner binding on every received message.
+func GRPCServerStreamAuthorizer(policy Policy) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := policy.Authorize(stream.Context(), info.FullMethod, nil); err != nil {
+			return authzStatus(err)
+		}
+
+		return handler(srv, &authorizedStream{ServerStream: stream, policy: policy, method: info.FullMethod})
+	}
+}
+
+// authzStatus maps authorization error to gRPC status.
+func authzStatus(err error) error {
+	if errors.Is(err, e.ErrUnauthenticated) {
+		return status.Errorf(codes.Unauthenticated, "Unauthorized")
+	}
+
+	return status.Errorf(codes.PermissionDenied, "Forbidden")
+}
+
+// authorizedStream authorizes every message received from client.
+type authorizedStream struct {
+	grpc.ServerStream
+	policy Policy
+	method string
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if err := s.policy.Authorize(s.Context(), s.method, m); err != nil {
+		return authzStatus(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCServerAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	logger := setupLogger(t)
+	jwtauth := auth.New(mockKeyFunc, time.Hour, logger)
+
+	tokenFor := func(role user.Role) (string, string) {
+		usr := user.New("tester", role)
+		token, err := jwtauth.Encoder()(usr, deviceID)
+		require.NoError(t, err)
+
+		return token, usr.ID.String()
+	}
+
+	userToken, userID := tokenFor(user.RoleUser)
+	adminToken, _ := tokenFor(user.RoleAdmin)
+	auditorToken, _ := tokenFor(user.RoleAuditor)
+	enrollmentToken, err := jwtauth.ScopedEncoder(auth.ScopeTOTPEnrollment)(user.New("tester", user.RoleAdmin), deviceID)
+	require.NoError(t, err)
+	otherUserID := uuid.NewString()
+
+	const (
+		publicMethod = "/test.Service/Public"
+		adminMethod  = "/test.Service/Admin"
+		ownedMethod  = "/test.Service/Owned"
+		readMethod   = "/test.Service/Read"
+		signupMethod = "/test.Service/Signup"
+		enrollMethod = "/test.Service/Enroll"
+	)
+
+	policy := auth.Policy{
+		publicMethod: {Public: true},
+		adminMethod:  {Roles: []user.Role{user.RoleAdmin}},
+		enrollMethod: {Roles: []user.Role{user.RoleAdmin}, Enrollment: true},
+		ownedMethod:  {Roles: []user.Role{user.RoleUser}, Owner: true},
+		readMethod: {
+			Roles:         []user.Role{user.RoleUser, user.RoleAuditor},
+			Owner:         true,
+			OnBehalfRoles: []user.Role{user.RoleAuditor},
+		},
+		signupMethod: {Public: true, Elevate: func(req any) []user.Role {
+			if r, ok := req.(*pb.RegisterRequest); ok && r.GetRole() != user.RoleUser {
+				return []user.Role{user.RoleAdmin}
+			}
+
+			return nil
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		token      string
+		req        any
+		expectCode codes.Code
+	}{
+		{"public method without token", publicMethod, "", nil, codes.OK},
+		{"unknown method", "/test.Service/Unknown", userToken, nil, codes.PermissionDenied},
+		{"no token", adminMethod, "", nil, codes.Unauthenticated},
+		{"admin method by admin", adminMethod, adminToken, nil, codes.OK},
+		{"admin method by user", adminMethod, userToken, nil, codes.PermissionDenied},
+		{"own request", ownedMethod, userToken, &pb.ListSecretsRequest{UserId: userID}, codes.OK},
+		{"request of other user", ownedMethod, userToken, &pb.ListSecretsRequest{UserId: otherUserID}, codes.PermissionDenied},
+		{"request without user", ownedMethod, userToken, &pb.UnsealRequest{}, codes.PermissionDenied},
+		{"auditor on behalf of user", readMethod, auditorToken, &pb.ListSecretsRequest{UserId: otherUserID}, codes.OK},
+		{"auditor on owned method", ownedMethod, auditorToken, &pb.ListSecretsRequest{UserId: otherUserID}, codes.PermissionDenied},
+		{"user signup", signupMethod, "", &pb.RegisterRequest{Role: user.RoleUser}, codes.OK},
+		{"admin signup without token", signupMethod, "", &pb.RegisterRequest{Role: user.RoleAdmin}, codes.Unauthenticated},
+		{"admin signup by user", signupMethod, userToken, &pb.RegisterRequest{Role: user.RoleAdmin}, codes.PermissionDenied},
+		{"admin signup by admin", signupMethod, adminToken, &pb.RegisterRequest{Role: user.RoleAuditor}, codes.OK},
+		{"enrollment token on enrollment method", enrollMethod, enrollmentToken, nil, codes.OK},
+		{"enrollment token on other method", adminMethod, enrollmentToken, nil, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.New(map[string]string{"authorization": "Bearer " + tt.token}))
+			}
+
+			verifier := auth.GRPCServerVerifier(jwtauth)
+			authorizer := auth.GRPCServerAuthorizer(policy)
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+			called := false
+			handler := func(_ context.Context, _ any) (any, error) {
+				called = true
+
+				return struct{}{}, nil
+			}
+
+			_, err := verifier(ctx, tt.req, info, func(ctx context.Context, req any) (any, error) {
+				return authorizer(ctx, req, info, handler)
+			})
+
+			require.Equal(t, tt.expectCode, status.Code(err))
+			require.Equal(t, tt.expectCode == codes.OK, called)
+		})
+	}
+}
+
+type recvStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	msg *pb.WatchSecretsRequest
+}
+
+func (s *recvStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recvStream) RecvMsg(m any) error {
+	req, _ := m.(*pb.WatchSecretsRequest)
+	req.UserId = s.msg.GetUserId()
+
+	return nil
+}
+
+func TestGRPCServerStreamAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	logger := setupLogger(t)
+	usr, _ := setupTestUsers(t)
+	jwtauth := auth.New(mockKeyFunc, time.Hour, logger)
+	token, err := jwtauth.Encoder()(usr, deviceID)
+	require.NoError(t, err)
+
+	const method = "/test.Service/Watch"
+
+	policy := auth.Policy{method: {Roles: []user.Role{user.RoleUser}, Owner: true}}
+
+	tests := []struct {
+		name       string
+		userID     string
+		expectCode codes.Code
+	}{
+		{"own stream", usr.ID.String(), codes.OK},
+		{"stream of other user", uuid.NewString(), codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := metadata.NewIncomingContext(
+				context.Background(),
+				metadata.New(map[string]string{"authorization": "Bearer " + token}),
+			)
+			stream := &recvStream{ctx: ctx, msg: &pb.WatchSecretsRequest{UserId: tt.userID}}
+			info := &grpc.StreamServerInfo{FullMethod: method}
+			verifier := auth.GRPCServerStreamVerifier(jwtauth)
+			authorizer := auth.GRPCServerStreamAuthorizer(policy)
+
+			err := verifier(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+				return authorizer(srv, ss, info, func(_ any, ss grpc.ServerStream) error {
+					return ss.RecvMsg(&pb.WatchSecretsRequest{})
+				})
+			})
+
+			require.Equal(t, tt.expectCode, status.Code(err))
+		})
+	}
+}
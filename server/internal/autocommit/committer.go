@@ -0,0 +1,104 @@
+package autocommit
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// Committer consumes object created events and commits uploads which clients never committed,
+// e.g. because client crashed right after upload.
+// All server replicas consume the same events, only the first one to lock upload request commits it,
+// the rest find it completed and ignore the event.
+type Committer struct {
+	repo     repository.SecretRepository
+	listener s3.EventListener
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	log      zerolog.Logger
+}
+
+// New creates committer of uploads consuming events from listener.
+func New(repo repository.SecretRepository, listener s3.EventListener, log zerolog.Logger) *Committer {
+	return &Committer{
+		repo:     repo,
+		listener: listener,
+		log:      log.With().Str("worker", "Committer").Logger(),
+	}
+}
+
+// Start consumes events in background until Stop is called.
+func (c *Committer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	events := c.listener.ListenObjectCreated(ctx)
+
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+
+		for event := range events {
+			c.Commit(ctx, event)
+		}
+	}()
+}
+
+// Stop stops consuming events and waits for background worker to exit or ctx to be done.
+func (c *Committer) Stop(ctx context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+
+	c.cancel()
+
+	stopped := make(chan struct{})
+
+	go func() {
+		c.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Commit finalizes upload request of the created object if it is still in progress.
+// Returns whether new version was committed.
+func (c *Committer) Commit(ctx context.Context, event s3.ObjectEvent) bool {
+	logCtx := c.log.With().
+		Str("bucket", event.BucketName).
+		Str("object_key", event.ObjectKey).Logger()
+
+	req, err := c.repo.CommitUploadedObject(ctx, event.BucketName, event.ObjectKey)
+
+	switch {
+	case err == nil:
+		logCtx.Info().
+			Str("user_id", req.UserID.String()).
+			Str("secret_id", req.SecretID.String()).
+			Str("version_id", req.VersionID.String()).
+			Msg("upload committed by s3 notification")
+
+		return true
+	case errors.Is(err, e.ErrNotFound), errors.Is(err, e.ErrConflict):
+		// already committed by client, expired or not an upload at all.
+		logCtx.Debug().Err(err).Msg("no upload in progress for object")
+	case errors.Is(err, e.ErrInvalidInput):
+		logCtx.Warn().Err(err).Msg("uploaded object does not match upload request")
+	default:
+		logCtx.Error().Err(err).Msg("failed to commit upload")
+	}
+
+	return false
+}
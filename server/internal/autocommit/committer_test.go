@@ -0,0 +1,73 @@
+package autocommit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/autocommit"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// uploadsRepo commits only objects registered as uploads in progress.
+type uploadsRepo struct {
+	repository.SecretRepository
+	uploads   map[string]bool
+	committed chan string
+}
+
+func (r *uploadsRepo) CommitUploadedObject(
+	_ context.Context,
+	bucketName, objectKey string,
+) (*secret.CommitRequest, error) {
+	key := bucketName + "/" + objectKey
+	if !r.uploads[key] {
+		return nil, fmt.Errorf("[%w] secret init request of object", e.ErrNotFound)
+	}
+
+	delete(r.uploads, key)
+	r.committed <- key
+
+	return &secret.CommitRequest{
+		UserID:      uuid.New(),
+		SecretID:    uuid.New(),
+		VersionID:   uuid.New(),
+		CommittedBy: secret.RequestCommitterS3,
+	}, nil
+}
+
+func TestCommitter(t *testing.T) {
+	t.Parallel()
+
+	repo := &uploadsRepo{
+		uploads:   map[string]bool{"bucket/uploaded": true},
+		committed: make(chan string, 1),
+	}
+	queue := autocommit.NewMemoryQueue(2)
+	committer := autocommit.New(repo, queue, logger.Stdout(zerolog.Disabled).GetZeroLog())
+
+	queue.Publish(s3.ObjectEvent{BucketName: "bucket", ObjectKey: "unknown"})
+	queue.Publish(s3.ObjectEvent{BucketName: "bucket", ObjectKey: "uploaded"})
+
+	committer.Start()
+
+	select {
+	case key := <-repo.committed:
+		require.Equal(t, "bucket/uploaded", key)
+	case <-time.After(time.Second):
+		t.Fatal("upload was not committed")
+	}
+
+	require.NoError(t, committer.Stop(context.Background()))
+
+	// upload is already committed, so repeated notification is ignored.
+	require.False(t, committer.Commit(context.Background(), s3.ObjectEvent{BucketName: "bucket", ObjectKey: "uploaded"}))
+}
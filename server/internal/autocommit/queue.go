@@ -0,0 +1,47 @@
+package autocommit
+
+import (
+	"context"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
+)
+
+// MemoryQueue is an in-memory source of object created events.
+// Useful for testing and for environments without bucket notifications.
+type MemoryQueue struct {
+	events chan s3.ObjectEvent
+}
+
+// NewMemoryQueue creates in-memory events queue of the given capacity.
+func NewMemoryQueue(capacity int) *MemoryQueue {
+	return &MemoryQueue{events: make(chan s3.ObjectEvent, capacity)}
+}
+
+// Publish puts event to the queue and blocks if queue is full.
+func (q *MemoryQueue) Publish(event s3.ObjectEvent) {
+	q.events <- event
+}
+
+// ListenObjectCreated streams published events until ctx is done.
+func (q *MemoryQueue) ListenObjectCreated(ctx context.Context) <-chan s3.ObjectEvent {
+	events := make(chan s3.ObjectEvent)
+
+	go func() {
+		defer close(events)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-q.events:
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/app"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/auth"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/autocommit"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/crypto/keystore"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/crypto/shamir"
@@ -16,9 +17,11 @@ import (
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/identity"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/minio"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/reaper"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/server"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/version"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/watch"
 	"github.com/rs/zerolog"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
@@ -67,29 +70,42 @@ func Server(cfg *config.Config) *fx.App {
 		fx.StartTimeout(time.Minute),
 		fx.StopTimeout(time.Minute),
 		fx.Supply(cfg),
-		fx.Supply(server.PublicGRPCMethods),
+		fx.Supply(server.AccessPolicy()),
 		fx.Supply(appLogger),
 		fx.Provide(version.New),
 		fx.Provide(func(l logger.Logger) zerolog.Logger { return l.GetZeroLog() }),
-		fx.Provide(func(l logger.Logger) *auth.Auth { return auth.New(jwtKeyFunc, l.GetZeroLog()) }),
+		fx.Provide(func(l logger.Logger) *auth.Auth { return auth.New(jwtKeyFunc, cfg.AccessTokenTTL, l.GetZeroLog()) }),
 		fx.Provide(pgDBFunc),
 		fx.Provide(shamir.NewCollector),
 		fx.Provide(fx.Annotate(identity.KeycloakPGManager, fx.As(new(identity.Manager)))),
-		fx.Provide(fx.Annotate(minio.NewClient, fx.As(new(s3.ServerOperator)))),
+		fx.Provide(fx.Annotate(minio.NewClient, fx.As(new(s3.ServerOperator)), fx.As(new(s3.EventListener)))),
 		fx.Provide(fx.Annotate(keystore.NewInMemoryKeystore, fx.As(new(keystore.Keystore)))),
 		fx.Provide(fx.Annotate(repository.NewREKRepo, fx.As(new(repository.REKRepository)))),
 		fx.Provide(fx.Annotate(repository.NewUserRepo, fx.As(new(repository.UserRepository)))),
 		fx.Provide(fx.Annotate(repository.NewSecretRepo, fx.As(new(repository.SecretRepository)))),
+		fx.Provide(fx.Annotate(repository.NewDeviceRepo, fx.As(new(repository.DeviceRepository)))),
+		fx.Provide(fx.Annotate(repository.NewRefreshTokenRepo, fx.As(new(repository.RefreshTokenRepository)))),
+		fx.Provide(fx.Annotate(repository.NewTOTPRepo, fx.As(new(repository.TOTPRepository)))),
 		fx.Provide(fx.Annotate(app.NewAdminUC, fx.As(new(app.AdminUseCase)))),
 		fx.Provide(fx.Annotate(app.NewUserUC, fx.As(new(app.UserUseCase)))),
+		fx.Provide(fx.Annotate(app.NewDeviceUC, fx.As(new(app.DeviceUseCase)), fx.As(new(auth.DeviceVerifier)))),
+		fx.Provide(fx.Annotate(app.NewSessionUC, fx.As(new(app.SessionUseCase)))),
+		fx.Provide(fx.Annotate(app.NewTwoFactorUC, fx.As(new(app.TwoFactorUseCase)))),
+		fx.Provide(watch.New),
+		fx.Provide(func(hub *watch.Hub) watch.Subscriber { return hub }),
 		fx.Provide(fx.Annotate(app.NewSecretUC, fx.As(new(app.SecretUseCase)))),
 		fx.Provide(fx.Annotate(grpchandler.NewAdminServer, fx.As(new(grpchandler.AdminServiceServer)))),
 		fx.Provide(fx.Annotate(grpchandler.NewUserServer, fx.As(new(grpchandler.UserServiceServer)))),
 		fx.Provide(fx.Annotate(grpchandler.NewSecretServer, fx.As(new(grpchandler.SecretServiceServer)))),
 		fx.Provide(server.New),
+		fx.Provide(reaper.New),
+		fx.Provide(autocommit.New),
 		fx.WithLogger(fxevent.NopLogger),
 		// fx.WithLogger(func() fxevent.Logger { return fxevent.NopLogger }),
 		fx.WithLogger(appLogger.GetFxLogger()),
 		fx.Invoke(fxServerInvoke),
+		fx.Invoke(fxReaperInvoke),
+		fx.Invoke(fxCommitterInvoke),
+		fx.Invoke(fxWatchHubInvoke),
 	)
 }
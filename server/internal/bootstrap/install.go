@@ -153,14 +153,23 @@ func createAdmin(ctx context.Context, userRepo repository.UserRepository, log ze
 		Str("operation", "createAdmin").
 		Logger()
 
-	adm := user.New(defaultAdmin, user.RoleAdmin)
-	if err := adm.SetPassword(defaultPassword); err != nil {
+	// server derives auth key of the default admin the same way client does it for other users.
+	salt, err := user.NewSalt()
+	if err != nil {
+		return err
+	}
+
+	authKey, err := keys.DeriveAuthKey(defaultPassword, salt)
+	if err != nil {
 		opLog.Error().Err(err).
-			Msg("failed to set default admin password")
+			Msg("failed to derive default admin auth key")
 
 		return err
 	}
 
+	adm := user.New(defaultAdmin, user.RoleAdmin)
+	adm.SetAuthKey(authKey, salt)
+
 	user, err := userRepo.CreateAdmin(ctx, adm)
 
 	switch {
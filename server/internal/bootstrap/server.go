@@ -6,9 +6,12 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/autocommit"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/reaper"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/server"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/version"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/watch"
 	"github.com/rs/zerolog"
 	"go.uber.org/fx"
 )
@@ -40,6 +43,36 @@ func fxServerInvoke(
 	})
 }
 
+func fxReaperInvoke(lc fx.Lifecycle, reaper *reaper.Reaper) {
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			reaper.Start()
+			return nil
+		},
+		OnStop: reaper.Stop,
+	})
+}
+
+func fxCommitterInvoke(lc fx.Lifecycle, committer *autocommit.Committer) {
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			committer.Start()
+			return nil
+		},
+		OnStop: committer.Stop,
+	})
+}
+
+func fxWatchHubInvoke(lc fx.Lifecycle, hub *watch.Hub) {
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			hub.Start()
+			return nil
+		},
+		OnStop: hub.Stop,
+	})
+}
+
 func handleSignals(shutdowner fx.Shutdowner, log zerolog.Logger) {
 	stopChan := make(chan os.Signal, 1)
 	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
@@ -1,25 +1,34 @@
 package config
 
+import "time"
+
 type Config struct {
-	ServerAddr           string `env:"SERVER_ADDRESS"`
-	ServerTLSKeyPath     string `env:"SERVER_TLS_KEY_PATH"`
-	ServerTLSCertPath    string `env:"SERVER_TLS_CERT_PATH"`
-	DatabaseDSN          string `env:"DATABASE_DSN"`
-	S3Endpoint           string `env:"S3_ENDPOINT"`
-	S3TLSCertPath        string `env:"S3_TLS_CERT_PATH"`
-	S3AccessKey          string `env:"S3_ACCESS_KEY"`
-	S3SecretKey          string `env:"S3_SECRET_KEY"`
-	S3AccountID          string `env:"S3_ACCOUNT_ID"`
-	S3Region             string `env:"S3_REGION"`
-	S3RedisRegion        string `env:"S3_REDIS_REGION"`
-	S3Token              string `env:"S3_TOKEN"`
-	IdentityTLSCertPath  string `env:"IDENTITY_TLS_CERT_PATH"`
-	IdentityEndpoint     string `env:"IDENTITY_ENDPOINT"`
-	IdentityClientID     string `env:"IDENTITY_OPENID_CLIENT_ID"`
-	IdentityClientSecret string `env:"IDENTITY_OPENID_CLIENT_SECRET"`
-	IdentityRealm        string `env:"IDENTITY_OPENID_REALM"`
-	JWTSecret            string `env:"JWT_SECRET"`
-	REKSharesPath        string `env:"REK_SHARES_PATH"`
+	ServerAddr           string        `env:"SERVER_ADDRESS"`
+	ServerTLSKeyPath     string        `env:"SERVER_TLS_KEY_PATH"`
+	ServerTLSCertPath    string        `env:"SERVER_TLS_CERT_PATH"`
+	DatabaseDSN          string        `env:"DATABASE_DSN"`
+	S3Endpoint           string        `env:"S3_ENDPOINT"`
+	S3TLSCertPath        string        `env:"S3_TLS_CERT_PATH"`
+	S3AccessKey          string        `env:"S3_ACCESS_KEY"`
+	S3SecretKey          string        `env:"S3_SECRET_KEY"`
+	S3AccountID          string        `env:"S3_ACCOUNT_ID"`
+	S3Region             string        `env:"S3_REGION"`
+	S3RedisRegion        string        `env:"S3_REDIS_REGION"`
+	S3Token              string        `env:"S3_TOKEN"`
+	IdentityTLSCertPath  string        `env:"IDENTITY_TLS_CERT_PATH"`
+	IdentityEndpoint     string        `env:"IDENTITY_ENDPOINT"`
+	IdentityClientID     string        `env:"IDENTITY_OPENID_CLIENT_ID"`
+	IdentityClientSecret string        `env:"IDENTITY_OPENID_CLIENT_SECRET"`
+	IdentityRealm        string        `env:"IDENTITY_OPENID_REALM"`
+	JWTSecret            string        `env:"JWT_SECRET"`
+	REKSharesPath        string        `env:"REK_SHARES_PATH"`
+	ReaperInterval       time.Duration `env:"REAPER_INTERVAL"`
+	ReaperBatchSize      int32         `env:"REAPER_BATCH_SIZE"`
+	WatchPollInterval    time.Duration `env:"WATCH_POLL_INTERVAL"`
+	AccessTokenTTL       time.Duration `env:"ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL      time.Duration `env:"REFRESH_TOKEN_TTL"`
+	TOTPIssuer           string        `env:"TOTP_ISSUER"`
+	TOTPRequiredRoles    []string      `env:"TOTP_REQUIRED_ROLES" envSeparator:","`
 	InstallMode          bool
 	DebugMode            bool
 }
@@ -45,6 +54,13 @@ func DefaultConfig() *Config {
 		IdentityRealm:        `gophkeeper`,
 		JWTSecret:            `d1a58c288a0226998149277b14993f6c73cf44ff9df3de548df4df25a13b251a`,
 		REKSharesPath:        `shares.json`,
+		ReaperInterval:       time.Minute,
+		ReaperBatchSize:      100,
+		WatchPollInterval:    30 * time.Second,
+		AccessTokenTTL:       15 * time.Minute,
+		RefreshTokenTTL:      30 * 24 * time.Hour,
+		TOTPIssuer:           `GophKeeper`,
+		TOTPRequiredRoles:    nil,
 		InstallMode:          false,
 		DebugMode:            false,
 	}
@@ -23,17 +23,39 @@ func GRPCServerStatusValidator(kstore Keystore) grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-		switch info.FullMethod {
-		case
-			pb.AdminService_Unseal_FullMethodName,
-			pb.UserService_Login_FullMethodName:
+		if isSealExempt(info.FullMethod) || kstore.IsLoaded() {
 			return handler(ctx, req)
 		}
 
-		if kstore.IsLoaded() {
-			return handler(ctx, req)
+		return nil, status.Errorf(codes.Unavailable, "server is sealed")
+	}
+}
+
+// GRPCServerStreamStatusValidator is the streaming counterpart of GRPCServerStatusValidator.
+// Streams are refused until the keystore is unsealed.
+func GRPCServerStreamStatusValidator(kstore Keystore) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if isSealExempt(info.FullMethod) || kstore.IsLoaded() {
+			return handler(srv, stream)
 		}
 
-		return nil, status.Errorf(codes.Unavailable, "server is sealed")
+		return status.Errorf(codes.Unavailable, "server is sealed")
 	}
 }
+
+// isSealExempt reports whether method is allowed while the keystore is sealed.
+func isSealExempt(method string) bool {
+	switch method {
+	case
+		pb.AdminService_Unseal_FullMethodName,
+		pb.UserService_Login_FullMethodName:
+		return true
+	}
+
+	return false
+}
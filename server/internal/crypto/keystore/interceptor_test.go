@@ -87,3 +87,65 @@ func TestGRPCServerStatusValidator(t *testing.T) {
 		})
 	}
 }
+
+type testServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *testServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestGRPCServerStreamStatusValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		isLoaded    bool
+		method      string
+		expectError bool
+	}{
+		{
+			name:        "stream allowed when keystore is loaded",
+			isLoaded:    true,
+			method:      pb.SecretService_WatchSecrets_FullMethodName,
+			expectError: false,
+		},
+		{
+			name:        "stream blocked when server is sealed",
+			isLoaded:    false,
+			method:      pb.SecretService_WatchSecrets_FullMethodName,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockKS := mock.NewMockKeystore(ctrl)
+			mockKS.EXPECT().IsLoaded().Return(tt.isLoaded).AnyTimes()
+
+			interceptor := keystore.GRPCServerStreamStatusValidator(mockKS)
+			called := false
+
+			handler := func(_ any, _ grpc.ServerStream) error {
+				called = true
+				return nil
+			}
+
+			err := interceptor(nil, &testServerStream{}, &grpc.StreamServerInfo{FullMethod: tt.method}, handler)
+
+			if tt.expectError {
+				require.Equal(t, codes.Unavailable, status.Code(err))
+				require.False(t, called)
+			} else {
+				require.NoError(t, err)
+				require.True(t, called)
+			}
+		})
+	}
+}
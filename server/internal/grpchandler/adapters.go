@@ -4,6 +4,7 @@ import (
 	"context"
 
 	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"google.golang.org/grpc"
 )
 
 type AdminServiceServer interface {
@@ -11,13 +12,36 @@ type AdminServiceServer interface {
 }
 
 type UserServiceServer interface {
+	GetLoginParams(ctx context.Context, r *pb.GetLoginParamsRequest) (*pb.GetLoginParamsResponse, error)
 	Login(ctx context.Context, r *pb.LoginRequest) (*pb.LoginResponse, error)
 	Register(ctx context.Context, r *pb.RegisterRequest) (*pb.RegisterResponse, error)
+	ListDevices(ctx context.Context, r *pb.ListDevicesRequest) (*pb.ListDevicesResponse, error)
+	RevokeDevice(ctx context.Context, r *pb.RevokeDeviceRequest) (*pb.RevokeDeviceResponse, error)
+	RefreshToken(ctx context.Context, r *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error)
+	Logout(ctx context.Context, r *pb.LogoutRequest) (*pb.LogoutResponse, error)
+	EnrollTOTP(ctx context.Context, r *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, r *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error)
+	ChangePassword(ctx context.Context, r *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error)
 }
 
 type SecretServiceServer interface {
 	SecretUpdateInit(ctx context.Context, req *pb.SecretUpdateInitRequest) (*pb.SecretUpdateInitResponse, error)
 	SecretUpdateCommit(ctx context.Context, req *pb.SecretUpdateCommitRequest) (*pb.SecretUpdateCommitResponse, error)
+	RenewUploadCredentials(
+		ctx context.Context,
+		req *pb.RenewUploadCredentialsRequest,
+	) (*pb.RenewUploadCredentialsResponse, error)
+	SecretGetInit(ctx context.Context, req *pb.SecretGetInitRequest) (*pb.SecretGetInitResponse, error)
+	ListSecrets(ctx context.Context, req *pb.ListSecretsRequest) (*pb.ListSecretsResponse, error)
+	ListSecretVersions(ctx context.Context, req *pb.ListSecretVersionsRequest) (*pb.ListSecretVersionsResponse, error)
+	GetSecretVersion(ctx context.Context, req *pb.GetSecretVersionRequest) (*pb.GetSecretVersionResponse, error)
+	SecretDelete(ctx context.Context, req *pb.SecretDeleteRequest) (*pb.SecretDeleteResponse, error)
+	ListSecretTombstones(
+		ctx context.Context,
+		req *pb.ListSecretTombstonesRequest,
+	) (*pb.ListSecretTombstonesResponse, error)
+	ListSecretKeys(ctx context.Context, req *pb.ListSecretKeysRequest) (*pb.ListSecretKeysResponse, error)
+	WatchSecrets(req *pb.WatchSecretsRequest, stream grpc.ServerStreamingServer[pb.SecretEvent]) error
 }
 
 type AdminServiceAdapter struct {
@@ -46,6 +70,13 @@ func NewUserServiceAdapter(impl UserServiceServer) *UserServiceAdapter {
 	}
 }
 
+func (u *UserServiceAdapter) GetLoginParams(
+	ctx context.Context,
+	req *pb.GetLoginParamsRequest,
+) (*pb.GetLoginParamsResponse, error) {
+	return u.impl.GetLoginParams(ctx, req)
+}
+
 func (u *UserServiceAdapter) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	return u.impl.Login(ctx, req)
 }
@@ -54,6 +85,55 @@ func (u *UserServiceAdapter) Register(ctx context.Context, req *pb.RegisterReque
 	return u.impl.Register(ctx, req)
 }
 
+func (u *UserServiceAdapter) ListDevices(
+	ctx context.Context,
+	req *pb.ListDevicesRequest,
+) (*pb.ListDevicesResponse, error) {
+	return u.impl.ListDevices(ctx, req)
+}
+
+func (u *UserServiceAdapter) RevokeDevice(
+	ctx context.Context,
+	req *pb.RevokeDeviceRequest,
+) (*pb.RevokeDeviceResponse, error) {
+	return u.impl.RevokeDevice(ctx, req)
+}
+
+func (u *UserServiceAdapter) RefreshToken(
+	ctx context.Context,
+	req *pb.RefreshTokenRequest,
+) (*pb.RefreshTokenResponse, error) {
+	return u.impl.RefreshToken(ctx, req)
+}
+
+func (u *UserServiceAdapter) Logout(
+	ctx context.Context,
+	req *pb.LogoutRequest,
+) (*pb.LogoutResponse, error) {
+	return u.impl.Logout(ctx, req)
+}
+
+func (u *UserServiceAdapter) EnrollTOTP(
+	ctx context.Context,
+	req *pb.EnrollTOTPRequest,
+) (*pb.EnrollTOTPResponse, error) {
+	return u.impl.EnrollTOTP(ctx, req)
+}
+
+func (u *UserServiceAdapter) ConfirmTOTP(
+	ctx context.Context,
+	req *pb.ConfirmTOTPRequest,
+) (*pb.ConfirmTOTPResponse, error) {
+	return u.impl.ConfirmTOTP(ctx, req)
+}
+
+func (u *UserServiceAdapter) ChangePassword(
+	ctx context.Context,
+	req *pb.ChangePasswordRequest,
+) (*pb.ChangePasswordResponse, error) {
+	return u.impl.ChangePassword(ctx, req)
+}
+
 type SecretServiceAdapter struct {
 	impl SecretServiceServer
 	pb.UnimplementedSecretServiceServer
@@ -78,3 +158,66 @@ func (s *SecretServiceAdapter) SecretUpdateCommit(
 ) (*pb.SecretUpdateCommitResponse, error) {
 	return s.impl.SecretUpdateCommit(ctx, req)
 }
+
+func (s *SecretServiceAdapter) RenewUploadCredentials(
+	ctx context.Context,
+	req *pb.RenewUploadCredentialsRequest,
+) (*pb.RenewUploadCredentialsResponse, error) {
+	return s.impl.RenewUploadCredentials(ctx, req)
+}
+
+func (s *SecretServiceAdapter) SecretGetInit(
+	ctx context.Context,
+	req *pb.SecretGetInitRequest,
+) (*pb.SecretGetInitResponse, error) {
+	return s.impl.SecretGetInit(ctx, req)
+}
+
+func (s *SecretServiceAdapter) ListSecrets(
+	ctx context.Context,
+	req *pb.ListSecretsRequest,
+) (*pb.ListSecretsResponse, error) {
+	return s.impl.ListSecrets(ctx, req)
+}
+
+func (s *SecretServiceAdapter) ListSecretVersions(
+	ctx context.Context,
+	req *pb.ListSecretVersionsRequest,
+) (*pb.ListSecretVersionsResponse, error) {
+	return s.impl.ListSecretVersions(ctx, req)
+}
+
+func (s *SecretServiceAdapter) GetSecretVersion(
+	ctx context.Context,
+	req *pb.GetSecretVersionRequest,
+) (*pb.GetSecretVersionResponse, error) {
+	return s.impl.GetSecretVersion(ctx, req)
+}
+
+func (s *SecretServiceAdapter) SecretDelete(
+	ctx context.Context,
+	req *pb.SecretDeleteRequest,
+) (*pb.SecretDeleteResponse, error) {
+	return s.impl.SecretDelete(ctx, req)
+}
+
+func (s *SecretServiceAdapter) ListSecretTombstones(
+	ctx context.Context,
+	req *pb.ListSecretTombstonesRequest,
+) (*pb.ListSecretTombstonesResponse, error) {
+	return s.impl.ListSecretTombstones(ctx, req)
+}
+
+func (s *SecretServiceAdapter) ListSecretKeys(
+	ctx context.Context,
+	req *pb.ListSecretKeysRequest,
+) (*pb.ListSecretKeysResponse, error) {
+	return s.impl.ListSecretKeys(ctx, req)
+}
+
+func (s *SecretServiceAdapter) WatchSecrets(
+	req *pb.WatchSecretsRequest,
+	stream grpc.ServerStreamingServer[pb.SecretEvent],
+) error {
+	return s.impl.WatchSecrets(req, stream)
+}
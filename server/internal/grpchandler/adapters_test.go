@@ -0,0 +1,76 @@
+package grpchandler_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/grpchandler"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// newSecretServiceClient serves impl through the adapter on in-memory listener.
+func newSecretServiceClient(t *testing.T, impl grpchandler.SecretServiceServer) pb.SecretServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterSecretServiceServer(srv, grpchandler.NewSecretServiceAdapter(impl))
+
+	go func() { _ = srv.Serve(listener) }()
+
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewSecretServiceClient(conn)
+}
+
+func TestSecretServiceAdapterRenewUploadCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	secretSrv := mock.NewMockSecretServiceServer(ctrl)
+	client := newSecretServiceClient(t, secretSrv)
+
+	req := &pb.RenewUploadCredentialsRequest{
+		UserId:   uuid.NewString(),
+		SecretId: uuid.NewString(),
+		Token:    42,
+	}
+	resp := &pb.RenewUploadCredentialsResponse{
+		Credentials: &pb.TemporaryCredentials{
+			AccessKeyId:     "access",
+			SecretAccessKey: "secret",
+			SessionToken:    "session",
+			Expiration:      "2026-01-01T00:00:00Z",
+		},
+	}
+
+	secretSrv.EXPECT().
+		RenewUploadCredentials(gomock.Any(), gomock.Cond(func(got *pb.RenewUploadCredentialsRequest) bool {
+			return proto.Equal(req, got)
+		})).
+		Return(resp, nil)
+
+	got, err := client.RenewUploadCredentials(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(resp, got))
+}
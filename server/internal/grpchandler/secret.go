@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/app"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
 	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -51,6 +54,11 @@ func (s *SecretServer) SecretUpdateInit(
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	var conflictErr *secret.VersionConflictError
+	if errors.As(err, &conflictErr) {
+		return nil, versionConflictStatus(conflictErr)
+	}
+
 	if errors.Is(err, e.ErrExists) {
 		return nil, status.Error(codes.AlreadyExists, err.Error())
 	}
@@ -65,3 +73,323 @@ func (s *SecretServer) SecretUpdateInit(
 
 	return resp.ToProto(), nil
 }
+
+// versionConflictStatus builds FailedPrecondition status carrying current version of the secret,
+// so that client can fetch it and resolve the conflict.
+func versionConflictStatus(conflictErr *secret.VersionConflictError) error {
+	conflict := dto.SecretVersionConflictFromDomain(conflictErr)
+
+	st, err := status.New(codes.FailedPrecondition, conflictErr.Error()).WithDetails(conflict.ToProto())
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, conflictErr.Error())
+	}
+
+	return st.Err()
+}
+
+func (s *SecretServer) SecretUpdateCommit(
+	ctx context.Context,
+	req *pb.SecretUpdateCommitRequest,
+) (*pb.SecretUpdateCommitResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	commitReq, err := dto.SecretUploadCommitRequestFromProto(req).ToDomain()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.app.CommitUploadRequest(ctx, commitReq)
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if errors.Is(err, e.ErrInvalidInput) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, e.ErrConflict) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.ToProto(), nil
+}
+
+func (s *SecretServer) RenewUploadCredentials(
+	ctx context.Context,
+	req *pb.RenewUploadCredentialsRequest,
+) (*pb.RenewUploadCredentialsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	renewReq, err := dto.RenewUploadCredentialsRequestFromProto(req).ToDomain()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	creds, err := s.app.RenewUploadCredentials(ctx, renewReq)
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if errors.Is(err, e.ErrInvalidInput) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RenewUploadCredentialsResponse{Credentials: creds.ToProto()}, nil
+}
+
+func (s *SecretServer) SecretGetInit(
+	ctx context.Context,
+	req *pb.SecretGetInitRequest,
+) (*pb.SecretGetInitResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	getReq, err := dto.SecretDownloadInitRequestFromProto(req).ToDomain()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.app.InitDownloadRequest(ctx, getReq)
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.ToProto(), nil
+}
+
+func (s *SecretServer) ListSecrets(
+	ctx context.Context,
+	req *pb.ListSecretsRequest,
+) (*pb.ListSecretsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	listReq, err := dto.ListSecretsRequestFromProto(req).ToDomain()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.app.ListSecrets(ctx, listReq)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.ToProto(), nil
+}
+
+func (s *SecretServer) ListSecretVersions(
+	ctx context.Context,
+	req *pb.ListSecretVersionsRequest,
+) (*pb.ListSecretVersionsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	scrt, err := dto.ListSecretVersionsRequestFromProto(req).ToDomain()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.app.ListSecretVersions(ctx, scrt)
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.ToProto(), nil
+}
+
+func (s *SecretServer) GetSecretVersion(
+	ctx context.Context,
+	req *pb.GetSecretVersionRequest,
+) (*pb.GetSecretVersionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	getReq, err := dto.GetSecretVersionRequestFromProto(req).ToDomain()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.app.InitDownloadRequest(ctx, getReq)
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.ToVersionProto(), nil
+}
+
+func (s *SecretServer) SecretDelete(
+	ctx context.Context,
+	req *pb.SecretDeleteRequest,
+) (*pb.SecretDeleteResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	deleteReq, err := dto.SecretDeleteRequestFromProto(req).ToDomain()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tombstone, err := s.app.DeleteSecret(ctx, deleteReq)
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if errors.Is(err, e.ErrConflict) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.SecretDeleteResponse{
+		UserId:    req.GetUserId(),
+		Tombstone: tombstone.ToProto(),
+	}, nil
+}
+
+func (s *SecretServer) ListSecretTombstones(
+	ctx context.Context,
+	req *pb.ListSecretTombstonesRequest,
+) (*pb.ListSecretTombstonesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	listReq := dto.ListSecretTombstonesRequestFromProto(req)
+
+	userID, err := listReq.ParseUserID()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.app.ListSecretTombstones(ctx, userID, listReq.Since)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.ToProto(), nil
+}
+
+// ListSecretKeys returns wrapped DEKs of all user secret versions for client re-wrapping.
+func (s *SecretServer) ListSecretKeys(
+	ctx context.Context,
+	req *pb.ListSecretKeysRequest,
+) (*pb.ListSecretKeysResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.app.ListSecretKeys(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.ToProto(), nil
+}
+
+// WatchSecrets streams events of user secrets recorded after the request cursor
+// and keeps the stream open sending new events until client cancels it.
+func (s *SecretServer) WatchSecrets(
+	req *pb.WatchSecretsRequest,
+	stream grpc.ServerStreamingServer[pb.SecretEvent],
+) error {
+	if req == nil {
+		return status.Error(codes.Internal, "nil request")
+	}
+
+	if err := req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	watchReq, err := dto.WatchSecretsRequestFromProto(req).ToDomain()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	send := func(event *dto.SecretEvent) error {
+		return stream.Send(event.ToProto())
+	}
+
+	err = s.app.WatchSecrets(stream.Context(), watchReq, send)
+	if err == nil {
+		return nil
+	}
+
+	// send errors already carry status of the broken stream, e.g. when client went away.
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
@@ -16,22 +18,59 @@ import (
 )
 
 type UserServer struct {
-	config *config.Config
-	auth   *auth.Auth
-	app    app.UserUseCase
-	log    zerolog.Logger
+	config    *config.Config
+	auth      *auth.Auth
+	app       app.UserUseCase
+	devices   app.DeviceUseCase
+	sessions  app.SessionUseCase
+	twoFactor app.TwoFactorUseCase
+	log       zerolog.Logger
 	pb.UnimplementedUserServiceServer
 }
 
-func NewUserServer(config *config.Config, auth *auth.Auth, app app.UserUseCase, log zerolog.Logger) *UserServer {
+func NewUserServer(
+	config *config.Config,
+	auth *auth.Auth,
+	app app.UserUseCase,
+	devices app.DeviceUseCase,
+	sessions app.SessionUseCase,
+	twoFactor app.TwoFactorUseCase,
+	log zerolog.Logger,
+) *UserServer {
 	return &UserServer{
-		config: config,
-		auth:   auth,
-		app:    app,
-		log:    log,
+		config:    config,
+		auth:      auth,
+		app:       app,
+		devices:   devices,
+		sessions:  sessions,
+		twoFactor: twoFactor,
+		log:       log,
 	}
 }
 
+func (s *UserServer) GetLoginParams(
+	ctx context.Context,
+	req *pb.GetLoginParamsRequest,
+) (*pb.GetLoginParamsResponse, error) {
+	if err := req.Validate(); err != nil {
+		s.log.Error().Err(err).
+			Str("operation", "GetLoginParams").
+			Msg("invalid grpc request")
+
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid params")
+	}
+
+	params, err := s.app.GetLoginParams(ctx, req.GetUsername())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: login params")
+	}
+
+	return &pb.GetLoginParamsResponse{
+		Salt:             params.Salt,
+		PasswordRequired: params.PasswordRequired,
+	}, nil
+}
+
 func (s *UserServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	if err := req.Validate(); err != nil {
 		s.log.Error().Err(err).
@@ -44,42 +83,65 @@ func (s *UserServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Login
 	creds := &dto.UserCredentials{
 		Username: req.GetUsername(),
 		Password: req.GetPassword(),
+		AuthKey:  req.GetAuthKey(),
 	}
 
+	// unknown user is not told apart from invalid credentials, as GetLoginParams does not tell it either.
 	usr, err := s.app.ValidateUser(ctx, creds)
-	if errors.Is(err, e.ErrValidation) {
+	if errors.Is(err, e.ErrValidation) || errors.Is(err, e.ErrNotFound) {
 		return nil, status.Error(codes.Internal, "Unauthorized: invalid user credentials")
 	}
 
-	if errors.Is(err, e.ErrNotFound) {
-		return nil, status.Error(codes.Internal, "Unauthorized: user not found")
-	}
-
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Internal Server Error: user validation")
 	}
 
-	tokenEnc := s.auth.Encoder()
+	enrollmentRequired, err := s.twoFactor.VerifyLogin(ctx, usr, req.GetTotpCode(), req.GetRecoveryCode())
+	if errors.Is(err, e.ErrUnauthenticated) {
+		if req.GetTotpCode() == "" && req.GetRecoveryCode() == "" {
+			return nil, status.Error(codes.Unauthenticated, "Unauthorized: two-factor code required")
+		}
+
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized: invalid two-factor code")
+	}
+
+	if errors.Is(err, e.ErrNotReady) {
+		return nil, status.Error(codes.Unavailable, "Server is sealed: two-factor code can not be verified")
+	}
 
-	token, err := tokenEnc(usr)
 	if err != nil {
-		s.log.Error().Err(err).
-			Msg("failed to generate token")
+		return nil, status.Error(codes.Internal, "Internal Server Error: two-factor verification")
+	}
 
-		return nil, status.Error(codes.Internal, "Internal Server Error: token creation")
+	deviceID, err := s.registerDevice(ctx, usr, req.GetDeviceId(), req.GetClientInfo())
+	if err != nil {
+		return nil, err
 	}
 
-	if err := auth.StoreTokenInGRPCHeader(ctx, token, s.log); err != nil {
-		s.log.Error().Err(err).
-			Msg("failed to inject token to headers")
+	if enrollmentRequired {
+		return s.enrollmentLogin(ctx, usr, deviceID)
+	}
 
-		return nil, status.Error(codes.Internal, "Internal Server Error: token injection")
+	session, err := s.sessions.IssueSession(ctx, usr, deviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: refresh token creation")
+	}
+
+	token, err := s.issueAccessToken(ctx, session)
+	if err != nil {
+		return nil, err
 	}
 
 	return &pb.LoginResponse{
-		UserId: usr.ID.String(),
-		Token:  token,
-		Role:   usr.Role,
+		UserId:                 usr.ID.String(),
+		Token:                  token,
+		Role:                   usr.Role,
+		TokenTtlSeconds:        uint32(s.auth.TokenTTL().Seconds()),
+		RefreshToken:           session.RefreshToken,
+		RefreshTokenTtlSeconds: uint32(s.config.RefreshTokenTTL.Seconds()),
+		Salt:                   usr.Salt,
+		Verifier:               usr.Verifier,
+		BucketName:             usr.BucketName,
 	}, nil
 }
 
@@ -94,7 +156,8 @@ func (s *UserServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb
 
 	creds := &dto.RegisterUserCredentials{
 		Username: req.GetUsername(),
-		Password: req.GetPassword(),
+		AuthKey:  req.GetAuthKey(),
+		Salt:     req.GetSalt(),
 		Role:     req.GetRole(),
 	}
 
@@ -107,28 +170,376 @@ func (s *UserServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb
 		return nil, status.Error(codes.Internal, "Internal Server Error: user registration")
 	}
 
-	tokenEnc := s.auth.Encoder()
+	deviceID, err := s.registerDevice(ctx, usr, req.GetDeviceId(), req.GetClientInfo())
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessions.IssueSession(ctx, usr, deviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: refresh token creation")
+	}
+
+	token, err := s.issueAccessToken(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RegisterResponse{
+		UserId:                 usr.ID.String(),
+		Token:                  token,
+		Role:                   usr.Role,
+		Verifier:               usr.Verifier,
+		Salt:                   usr.Salt,
+		BucketName:             usr.BucketName,
+		TokenTtlSeconds:        uint32(s.auth.TokenTTL().Seconds()),
+		RefreshToken:           session.RefreshToken,
+		RefreshTokenTtlSeconds: uint32(s.config.RefreshTokenTTL.Seconds()),
+	}, nil
+}
+
+func (s *UserServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	if err := req.Validate(); err != nil {
+		s.log.Error().Err(err).
+			Str("operation", "RefreshToken").
+			Msg("invalid grpc request")
+
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid params")
+	}
+
+	deviceID, err := uuid.Parse(req.GetDeviceId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid device id")
+	}
+
+	session, err := s.sessions.RefreshSession(ctx, req.GetRefreshToken(), deviceID)
+	if errors.Is(err, e.ErrUnauthenticated) {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized: invalid refresh token")
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: token refresh")
+	}
+
+	token, err := s.issueAccessToken(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RefreshTokenResponse{
+		Token:                  token,
+		TokenTtlSeconds:        uint32(s.auth.TokenTTL().Seconds()),
+		RefreshToken:           session.RefreshToken,
+		RefreshTokenTtlSeconds: uint32(s.config.RefreshTokenTTL.Seconds()),
+	}, nil
+}
+
+func (s *UserServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	if err := req.Validate(); err != nil {
+		s.log.Error().Err(err).
+			Str("operation", "Logout").
+			Msg("invalid grpc request")
+
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid params")
+	}
+
+	userID, claims, err := callerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var revoked int64
+
+	if req.GetAllDevices() {
+		revoked, err = s.sessions.RevokeUserSessions(ctx, userID)
+	} else {
+		deviceID, parseErr := uuid.Parse(claims.DeviceID)
+		if parseErr != nil {
+			return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+		}
+
+		revoked, err = s.sessions.RevokeDeviceSessions(ctx, userID, deviceID)
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: logout")
+	}
+
+	return &pb.LogoutResponse{RevokedTokens: uint32(revoked)}, nil
+}
+
+func (s *UserServer) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	if err := req.Validate(); err != nil {
+		s.log.Error().Err(err).
+			Str("operation", "EnrollTOTP").
+			Msg("invalid grpc request")
+
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid params")
+	}
+
+	userID, claims, err := callerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := s.twoFactor.EnrollTOTP(ctx, userID, claims.Username)
+	if errors.Is(err, e.ErrConflict) {
+		return nil, status.Error(codes.AlreadyExists, "Two-factor authentication is already enabled")
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: totp enrollment")
+	}
+
+	return &pb.EnrollTOTPResponse{
+		ProvisioningUri: enrollment.URI,
+		Secret:          enrollment.Secret,
+	}, nil
+}
+
+func (s *UserServer) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	if err := req.Validate(); err != nil {
+		s.log.Error().Err(err).
+			Str("operation", "ConfirmTOTP").
+			Msg("invalid grpc request")
+
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid params")
+	}
+
+	userID, _, err := callerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := s.twoFactor.ConfirmTOTP(ctx, userID, req.GetCode())
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is not enrolled")
+	}
+
+	if errors.Is(err, e.ErrConflict) {
+		return nil, status.Error(codes.AlreadyExists, "Two-factor authentication is already enabled")
+	}
+
+	if errors.Is(err, e.ErrValidation) {
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid totp code")
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: totp confirmation")
+	}
+
+	return &pb.ConfirmTOTPResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// ChangePassword stores new auth key of the caller with secret DEKs re-wrapped by client.
+// Refresh tokens of all user devices are revoked, so they have to log in with the new password.
+func (s *UserServer) ChangePassword(
+	ctx context.Context,
+	req *pb.ChangePasswordRequest,
+) (*pb.ChangePasswordResponse, error) {
+	if err := req.Validate(); err != nil {
+		s.log.Error().Err(err).
+			Str("operation", "ChangePassword").
+			Msg("invalid grpc request")
+
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid params")
+	}
+
+	userID, _, err := callerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := dto.SecretKeysFromProto(req.GetKeys())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid secret keys")
+	}
+
+	creds := &dto.ChangePasswordCredentials{
+		AuthKey:    req.GetAuthKey(),
+		NewAuthKey: req.GetNewAuthKey(),
+		NewSalt:    req.GetNewSalt(),
+	}
+
+	err = s.app.ChangePassword(ctx, userID, creds, keys)
+	if errors.Is(err, e.ErrValidation) {
+		return nil, status.Error(codes.PermissionDenied, "Forbidden: invalid current password")
+	}
+
+	if errors.Is(err, e.ErrConflict) {
+		return nil, status.Error(codes.Aborted, "Secret keys are outdated, list them again")
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: password change")
+	}
+
+	if _, err := s.sessions.RevokeUserSessions(ctx, userID); err != nil {
+		s.log.Error().Err(err).
+			Str("user_id", userID.String()).
+			Msg("failed to revoke sessions after password change")
+	}
+
+	return &pb.ChangePasswordResponse{RewrappedKeys: uint32(len(keys))}, nil //nolint:gosec // reason: keys count is bounded by request size
+}
+
+func (s *UserServer) ListDevices(ctx context.Context, req *pb.ListDevicesRequest) (*pb.ListDevicesResponse, error) {
+	if err := req.Validate(); err != nil {
+		s.log.Error().Err(err).
+			Str("operation", "ListDevices").
+			Msg("invalid grpc request")
+
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid params")
+	}
+
+	userID, claims, err := callerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := s.devices.ListDevices(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: list devices")
+	}
+
+	resp := &pb.ListDevicesResponse{Devices: make([]*pb.Device, 0, len(devices))}
+
+	for _, device := range devices {
+		info := dto.DeviceInfoFromDomain(device, claims.DeviceID)
+		resp.Devices = append(resp.Devices, info.ToProto())
+	}
+
+	return resp, nil
+}
+
+func (s *UserServer) RevokeDevice(ctx context.Context, req *pb.RevokeDeviceRequest) (*pb.RevokeDeviceResponse, error) {
+	if err := req.Validate(); err != nil {
+		s.log.Error().Err(err).
+			Str("operation", "RevokeDevice").
+			Msg("invalid grpc request")
+
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid params")
+	}
 
-	token, err := tokenEnc(usr)
+	userID, claims, err := callerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceID, err := uuid.Parse(req.GetDeviceId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Bad Request: invalid device id")
+	}
+
+	device, err := s.devices.RevokeDevice(ctx, userID, deviceID)
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "Device not found")
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: revoke device")
+	}
+
+	if _, err := s.sessions.RevokeDeviceSessions(ctx, userID, deviceID); err != nil {
+		return nil, status.Error(codes.Internal, "Internal Server Error: revoke device tokens")
+	}
+
+	info := dto.DeviceInfoFromDomain(device, claims.DeviceID)
+
+	return &pb.RevokeDeviceResponse{Device: info.ToProto()}, nil
+}
+
+// enrollmentLogin logs in user whose role requires TOTP not enabled yet.
+// Issued token only allows TOTP enrollment and no refresh token is issued.
+func (s *UserServer) enrollmentLogin(
+	ctx context.Context,
+	usr *user.User,
+	deviceID uuid.UUID,
+) (*pb.LoginResponse, error) {
+	s.log.Info().
+		Str("user_id", usr.ID.String()).
+		Str("role", usr.Role.String()).
+		Msg("two-factor authentication is required, issuing enrollment token")
+
+	session := &app.Session{User: usr, DeviceID: deviceID}
+
+	token, err := s.issueToken(ctx, s.auth.ScopedEncoder(auth.ScopeTOTPEnrollment), session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LoginResponse{
+		UserId:                 usr.ID.String(),
+		Token:                  token,
+		Role:                   usr.Role,
+		TokenTtlSeconds:        uint32(s.auth.TokenTTL().Seconds()),
+		Salt:                   usr.Salt,
+		Verifier:               usr.Verifier,
+		BucketName:             usr.BucketName,
+		TotpEnrollmentRequired: true,
+	}, nil
+}
+
+// issueAccessToken issues short-lived access token for the session and injects it to response headers.
+func (s *UserServer) issueAccessToken(ctx context.Context, session *app.Session) (string, error) {
+	return s.issueToken(ctx, s.auth.Encoder(), session)
+}
+
+func (s *UserServer) issueToken(ctx context.Context, tokenEnc auth.TokenEncoder, session *app.Session) (string, error) {
+	token, err := tokenEnc(session.User, session.DeviceID)
 	if err != nil {
 		s.log.Error().Err(err).Msg("failed to generate token")
-		return nil, status.Error(codes.Internal, "Internal Server Error: token creation")
+		return "", status.Error(codes.Internal, "Internal Server Error: token creation")
 	}
 
 	if err := auth.StoreTokenInGRPCHeader(ctx, token, s.log); err != nil {
 		s.log.Error().Err(err).
 			Msg("failed to inject token to headers")
 
-		return nil, status.Error(codes.Internal, "Internal Server Error: token injection")
+		return "", status.Error(codes.Internal, "Internal Server Error: token injection")
 	}
 
-	return &pb.RegisterResponse{
-		UserId:          usr.ID.String(),
-		Token:           token,
-		Role:            usr.Role,
-		Verifier:        usr.Verifier,
-		Salt:            usr.Salt,
-		BucketName:      usr.BucketName,
-		TokenTtlSeconds: uint32(auth.MaxTokenDuration.Seconds()), // this is just for simplicity
-	}, nil
+	return token, nil
+}
+
+// registerDevice records device the token is about to be issued to.
+func (s *UserServer) registerDevice(
+	ctx context.Context,
+	usr *user.User,
+	deviceID, clientInfo string,
+) (uuid.UUID, error) {
+	did, err := uuid.Parse(deviceID)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "Bad Request: invalid device id")
+	}
+
+	_, err = s.devices.RegisterDevice(ctx, user.NewDevice(usr.ID, did, clientInfo))
+	if errors.Is(err, e.ErrUnauthenticated) {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "Unauthorized: device is revoked")
+	}
+
+	if errors.Is(err, e.ErrConflict) {
+		return uuid.Nil, status.Error(codes.AlreadyExists, "Device is registered by another user")
+	}
+
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Internal, "Internal Server Error: device registration")
+	}
+
+	return did, nil
+}
+
+// callerClaims returns ID and token claims of the authenticated user making the request.
+func callerClaims(ctx context.Context) (uuid.UUID, *auth.Claims, error) {
+	_, claims, err := auth.FromContext(ctx)
+	if err != nil || claims == nil {
+		return uuid.Nil, nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return uuid.Nil, nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+
+	return userID, claims, nil
 }
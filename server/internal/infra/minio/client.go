@@ -3,6 +3,7 @@ package minio
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/notification"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/checksum"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/net/transport"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
@@ -142,29 +144,45 @@ func (c *Client) MakeBucket(
 	return nil
 }
 
-func (c *Client) SetBucketNotification(ctx context.Context, bucketName string) error {
-	logCtx := c.logCtx(bucketName)
-
-	redisARN := notification.NewArn("minio", "sqs", c.cfg.S3Region, c.cfg.S3AccountID, "redis")
-	queueConfig := notification.NewConfig(redisARN)
-
-	queueConfig.AddEvents(
-		notification.ObjectCreatedAll,
-		notification.ObjectRemovedAll,
-		notification.ObjectTransitionAll,
-		notification.ObjectAccessedAll,
-	)
-
-	notifyCfg := notification.Configuration{}
-	notifyCfg.AddQueue(queueConfig)
-
-	err := c.minio.SetBucketNotification(ctx, bucketName, notifyCfg)
-	if err != nil {
-		logCtx.Error().Err(err).Msg("failed to set bucket notifications")
-		return e.InternalErr(err)
-	}
+// ListenObjectCreated streams object created events of all buckets until ctx is done.
+// Events are received over MinIO listen API rather than a configured notification target,
+// so every server replica gets every event and no bucket notification setup is needed.
+// MinIO client reconnects on its own, so listening errors are only logged.
+func (c *Client) ListenObjectCreated(ctx context.Context) <-chan s3.ObjectEvent {
+	events := make(chan s3.ObjectEvent)
+	notifications := c.minio.ListenNotification(ctx, "", "", []string{string(notification.ObjectCreatedAll)})
+
+	go func() {
+		defer close(events)
+
+		for info := range notifications {
+			if info.Err != nil {
+				c.log.Warn().Err(info.Err).Msg("failed to listen bucket notifications")
+				continue
+			}
+
+			for _, record := range info.Records {
+				objectKey, err := url.QueryUnescape(record.S3.Object.Key)
+				if err != nil {
+					objectKey = record.S3.Object.Key
+				}
+
+				event := s3.ObjectEvent{
+					BucketName: record.S3.Bucket.Name,
+					ObjectKey:  objectKey,
+					Size:       record.S3.Object.Size,
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
 
-	return nil
+	return events
 }
 
 // setBucketTags applies the provided tags to the specified bucket.
@@ -190,6 +208,171 @@ func (c *Client) setBucketTags(
 	return nil
 }
 
+// StatObject fetches metadata of the object stored in the bucket together with its checksums.
+// Returns ErrNotFound if object or bucket does not exist.
+func (c *Client) StatObject(ctx context.Context, bucketName, objectKey string) (s3.ObjectInfo, error) {
+	logCtx := c.logCtx(bucketName).With().
+		Str("object_key", objectKey).Logger()
+
+	info, err := c.minio.StatObject(ctx, bucketName, objectKey, s3.StatObjectOptions{Checksum: true})
+	if err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case "NoSuchKey", "NoSuchBucket":
+			return s3.ObjectInfo{}, fmt.Errorf("[%w] MinIO object", e.ErrNotFound)
+		}
+
+		logCtx.Error().Err(err).Msg("failed to stat object")
+
+		return s3.ObjectInfo{}, e.InternalErr(err)
+	}
+
+	return info, nil
+}
+
+// ReadObject reads the whole object into memory provided it is not larger than maxSize.
+// Returns ErrNotFound if object or bucket does not exist.
+func (c *Client) ReadObject(ctx context.Context, bucketName, objectKey string, maxSize int64) ([]byte, error) {
+	logCtx := c.logCtx(bucketName).With().
+		Str("object_key", objectKey).Logger()
+
+	obj, err := c.minio.GetObject(ctx, bucketName, objectKey, minio.GetObjectOptions{})
+	if err != nil {
+		logCtx.Error().Err(err).Msg("failed to get object")
+		return nil, e.InternalErr(err)
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(io.LimitReader(obj, maxSize+1))
+	if err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case "NoSuchKey", "NoSuchBucket":
+			return nil, fmt.Errorf("[%w] MinIO object", e.ErrNotFound)
+		}
+
+		logCtx.Error().Err(err).Msg("failed to read object")
+
+		return nil, e.InternalErr(err)
+	}
+
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("[%w] MinIO object is too large", e.ErrInvalidInput)
+	}
+
+	return data, nil
+}
+
+// ObjectChecksum streams the whole object through SHA-256 and returns its checksum.
+// Returns ErrNotFound if object or bucket does not exist.
+func (c *Client) ObjectChecksum(ctx context.Context, bucketName, objectKey string) ([]byte, error) {
+	logCtx := c.logCtx(bucketName).With().
+		Str("object_key", objectKey).Logger()
+
+	obj, err := c.minio.GetObject(ctx, bucketName, objectKey, minio.GetObjectOptions{})
+	if err != nil {
+		logCtx.Error().Err(err).Msg("failed to get object")
+		return nil, e.InternalErr(err)
+	}
+	defer obj.Close()
+
+	hasher := checksum.New()
+	if _, err := io.Copy(hasher, obj); err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case "NoSuchKey", "NoSuchBucket":
+			return nil, fmt.Errorf("[%w] MinIO object", e.ErrNotFound)
+		}
+
+		logCtx.Error().Err(err).Msg("failed to read object")
+
+		return nil, e.InternalErr(err)
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// ListObjects lists objects of the bucket which keys start with prefix.
+// Missing bucket has no objects.
+func (c *Client) ListObjects(ctx context.Context, bucketName, prefix string) ([]s3.ObjectInfo, error) {
+	logCtx := c.logCtx(bucketName)
+	objects := make([]s3.ObjectInfo, 0)
+
+	for info := range c.minio.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if minio.ToErrorResponse(info.Err).Code == "NoSuchBucket" {
+			return objects, nil
+		}
+
+		if info.Err != nil {
+			logCtx.Error().Err(info.Err).
+				Str("prefix", prefix).
+				Msg("failed to list objects")
+
+			return nil, e.InternalErr(info.Err)
+		}
+
+		objects = append(objects, info)
+	}
+
+	return objects, nil
+}
+
+// RemoveObjects deletes objects from the bucket.
+// Objects which do not exist are ignored.
+func (c *Client) RemoveObjects(ctx context.Context, bucketName string, objectKeys []string) error {
+	logCtx := c.logCtx(bucketName)
+
+	objectsCh := make(chan minio.ObjectInfo, len(objectKeys))
+	for _, key := range objectKeys {
+		objectsCh <- minio.ObjectInfo{Key: key}
+	}
+
+	close(objectsCh)
+
+	var lastErr error
+
+	for rmErr := range c.minio.RemoveObjects(ctx, bucketName, objectsCh, minio.RemoveObjectsOptions{}) {
+		if minio.ToErrorResponse(rmErr.Err).Code == "NoSuchKey" {
+			continue
+		}
+
+		logCtx.Error().Err(rmErr.Err).
+			Str("object_key", rmErr.ObjectName).
+			Msg("failed to remove object")
+
+		lastErr = rmErr.Err
+	}
+
+	if lastErr != nil {
+		return e.InternalErr(lastErr)
+	}
+
+	logCtx.Debug().
+		Int("objects", len(objectKeys)).
+		Msg("objects removed")
+
+	return nil
+}
+
+// AbortMultipartUploads aborts all incomplete multipart uploads of the object,
+// so that parts uploaded for abandoned upload requests do not pile up in the bucket.
+// Object without incomplete uploads is ignored.
+func (c *Client) AbortMultipartUploads(ctx context.Context, bucketName, objectKey string) error {
+	err := c.minio.RemoveIncompleteUpload(ctx, bucketName, objectKey)
+	if err == nil {
+		return nil
+	}
+
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchUpload", "NoSuchBucket":
+		return nil
+	}
+
+	logCtx := c.logCtx(bucketName)
+	logCtx.Error().Err(err).
+		Str("object_key", objectKey).
+		Msg("failed to abort multipart uploads")
+
+	return e.InternalErr(err)
+}
+
 // GeneratePresignedPutURL generates a presigned PUT URL for uploading an object to a bucket.
 func (c *Client) GeneratePresignedPutURL(
 	ctx context.Context,
@@ -245,6 +428,22 @@ func (c *Client) AssumeRole(
 	return c.webIDClient.AssumeRole(ctx, identityToken, durationSeconds)
 }
 
+// AssumeReadOnlyRole returns temporary credentials restricted by session policy
+// to reading objects of the given bucket.
+func (c *Client) AssumeReadOnlyRole(
+	ctx context.Context,
+	identityToken string,
+	bucketName string,
+	durationSeconds int,
+) (*s3.TemporaryCredentials, error) {
+	policy, err := s3.ReadOnlyPolicy(bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.webIDClient.AssumeRoleWithPolicy(ctx, identityToken, durationSeconds, policy)
+}
+
 func (c *Client) AddCannedPolicy(_ context.Context, _ string, _ []byte) error {
 	// In a future iteration, implement support for a custom MinIO policy that restricts
 	// access to objects based on the user_id extracted from the identity JWT token.
@@ -30,6 +30,7 @@ const (
 	paramVersion         = "Version"
 	paramToken           = "WebIdentityToken"
 	paramDurationSeconds = "DurationSeconds"
+	paramPolicy          = "Policy"
 
 	actionAssumeRoleWithWebIdentity = "AssumeRoleWithWebIdentity"
 	apiVersion                      = "2011-06-15"
@@ -67,12 +68,23 @@ func NewMinioWebIdentityClient(
 }
 
 // AssumeRole performs the web identity authentication and returns temporary credentials.
+func (c *WebIdentityClient) AssumeRole(
+	ctx context.Context,
+	identityToken string,
+	durationSeconds int,
+) (*s3.TemporaryCredentials, error) {
+	return c.AssumeRoleWithPolicy(ctx, identityToken, durationSeconds, nil)
+}
+
+// AssumeRoleWithPolicy performs the web identity authentication and returns temporary credentials
+// further restricted by the provided session policy. Empty policy means no additional restrictions.
 //
 //nolint:funlen // reason : logging.
-func (c *WebIdentityClient) AssumeRole(
+func (c *WebIdentityClient) AssumeRoleWithPolicy(
 	ctx context.Context,
 	identityToken string,
 	durationSeconds int,
+	policy []byte,
 ) (*s3.TemporaryCredentials, error) {
 	form := url.Values{}
 	form.Set(paramAction, actionAssumeRoleWithWebIdentity)
@@ -80,6 +92,10 @@ func (c *WebIdentityClient) AssumeRole(
 	form.Set(paramToken, identityToken)
 	form.Set(paramDurationSeconds, strconv.Itoa(durationSeconds))
 
+	if len(policy) > 0 {
+		form.Set(paramPolicy, string(policy))
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.webURL, bytes.NewBufferString(form.Encode()))
 	if err != nil {
 		log.Error().Err(err).
@@ -9,6 +9,7 @@ import (
 
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/s3"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/testutil/http/roundtrip"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/minio"
 	"github.com/rs/zerolog"
@@ -120,3 +121,40 @@ func TestAssumeRoleEmptyCreds(t *testing.T) {
 	require.ErrorIs(t, err, e.ErrValidation)
 	assert.Contains(t, err.Error(), "validation")
 }
+
+func TestAssumeRoleWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	mockResponse := `<?xml version="1.0" encoding="UTF-8"?>
+		<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+			<AssumeRoleWithWebIdentityResult>
+				<Credentials>
+					<AccessKeyId>AKIAEXAMPLE</AccessKeyId>
+					<SecretAccessKey>secret123</SecretAccessKey>
+					<SessionToken>token123</SessionToken>
+					<Expiration>2025-06-19T12:00:00Z</Expiration>
+				</Credentials>
+			</AssumeRoleWithWebIdentityResult>
+		</AssumeRoleWithWebIdentityResponse>`
+
+	log := logger.Stdout(zerolog.DebugLevel).GetZeroLog()
+
+	policy, err := s3.ReadOnlyPolicy("user-bucket")
+	require.NoError(t, err)
+
+	mockHTTPClient := roundtrip.NewTestHTTPClient(func(req *http.Request) *http.Response {
+		require.NoError(t, req.ParseForm())
+		assert.JSONEq(t, string(policy), req.PostForm.Get("Policy"))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(mockResponse)),
+		}
+	})
+
+	client := minio.NewMinioWebIdentityClient("http://localhost:9000", mockHTTPClient, nil, log)
+
+	creds, err := client.AssumeRoleWithPolicy(context.Background(), "dummy-token", 3600, policy)
+	require.NoError(t, err)
+	assert.Equal(t, "AKIAEXAMPLE", creds.AccessKeyID)
+}
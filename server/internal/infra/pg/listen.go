@@ -0,0 +1,43 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+)
+
+// SecretEventsChannel is the notification channel which secret events are announced on.
+// Payload of notification is the id of the user which secret has changed.
+const SecretEventsChannel = "secret_events"
+
+// Listen opens dedicated connection, subscribes to the channel and calls handle with payload
+// of every notification until ctx is done or connection is lost.
+// Notifications are delivered to all listening server replicas once sending transaction is committed.
+func Listen(ctx context.Context, connString, channel string, handle func(payload string)) error {
+	conn, err := pgx.Connect(ctx, connString)
+	if err != nil {
+		return fmt.Errorf("[%w] pg listen connection", e.ErrUnavailable)
+	}
+
+	//nolint:contextcheck //reason: connection is closed even if ctx is already done.
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("[%w] pg listen %s", e.ErrUnavailable, channel)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("[%w] pg notification", e.ErrUnavailable)
+		}
+
+		handle(notification.Payload)
+	}
+}
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Completed requests keep the full upload history of a secret,
+-- so the same status may repeat for (user_id, secret_id).
+DROP INDEX IF EXISTS uniq_secret_requests_completed;
+CREATE INDEX idx_secret_requests_completed_user_secret ON secret_requests_completed(user_id, secret_id);
+
+CREATE UNIQUE INDEX uniq_secret_versions_version ON secret_versions(user_id, secret_id, version_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS uniq_secret_versions_version;
+DROP INDEX IF EXISTS idx_secret_requests_completed_user_secret;
+CREATE UNIQUE INDEX uniq_secret_requests_completed ON secret_requests_completed(user_id, secret_id, status);
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Tombstones outlive deleted secrets so that other devices
+-- learn about the deletion on their next sync.
+CREATE TABLE secret_tombstones (
+    user_id     UUID NOT NULL,
+    secret_id   UUID NOT NULL,
+    secret_name VARCHAR(64) NOT NULL,
+    version_id  UUID NOT NULL,
+    client_info VARCHAR(128) NOT NULL,
+    deleted_at  TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, secret_id)
+);
+
+CREATE INDEX idx_secret_tombstones_user_deleted ON secret_tombstones(user_id, deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_secret_tombstones_user_deleted;
+DROP TABLE IF EXISTS secret_tombstones;
+-- +goose StatementEnd
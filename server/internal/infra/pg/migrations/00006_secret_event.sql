@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Events are the change feed of user secrets which devices watch instead of polling.
+-- Event id is the cursor devices resume watching from.
+CREATE TYPE secret_event_type AS ENUM ('create', 'update', 'delete');
+
+CREATE TABLE secret_events (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     UUID NOT NULL,
+    secret_id   UUID NOT NULL,
+    secret_name VARCHAR(64) NOT NULL,
+    version_id  UUID NOT NULL,
+    event_type  secret_event_type NOT NULL,
+    client_info VARCHAR(128) NOT NULL,
+    created_at  TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_secret_events_user_id ON secret_events(user_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_secret_events_user_id;
+DROP TABLE IF EXISTS secret_events;
+DROP TYPE IF EXISTS secret_event_type;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Encrypted chunks of chunked secret versions stored once per user by keyed hash.
+CREATE TABLE secret_chunks (
+    user_id     UUID NOT NULL,
+    chunk_id    CHAR(64) NOT NULL,
+    chunk_size  BIGINT NOT NULL,
+    created_at  TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, chunk_id)
+);
+
+-- Chunks referenced by secret versions, chunk without references is garbage collected.
+CREATE TABLE secret_version_chunks (
+    user_id     UUID NOT NULL,
+    secret_id   UUID NOT NULL,
+    version_id  UUID NOT NULL,
+    chunk_id    CHAR(64) NOT NULL,
+    PRIMARY KEY (user_id, version_id, chunk_id),
+    FOREIGN KEY (user_id, secret_id) REFERENCES secrets(user_id, secret_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id, chunk_id) REFERENCES secret_chunks(user_id, chunk_id)
+);
+
+CREATE INDEX idx_secret_version_chunks_chunk ON secret_version_chunks(user_id, chunk_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_secret_version_chunks_chunk;
+DROP TABLE IF EXISTS secret_version_chunks;
+DROP TABLE IF EXISTS secret_chunks;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Devices user tokens are issued to, identified by ID generated at client installation.
+-- Tokens of revoked device are rejected.
+CREATE TABLE devices (
+    id            UUID PRIMARY KEY,
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_info   VARCHAR(128) NOT NULL,
+    first_seen_at TIMESTAMP NOT NULL,
+    last_seen_at  TIMESTAMP NOT NULL,
+    revoked_at    TIMESTAMP
+);
+
+CREATE INDEX idx_devices_user ON devices(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_devices_user;
+DROP TABLE IF EXISTS devices;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Refresh tokens issued to user devices along with short-lived access tokens.
+-- Only hash of the token is stored, token is revoked when exchanged for a new one.
+CREATE TABLE refresh_tokens (
+    id         UUID PRIMARY KEY,
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_id  UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_tokens_device ON refresh_tokens(user_id, device_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_refresh_tokens_device;
+DROP TABLE IF EXISTS refresh_tokens;
+-- +goose StatementEnd
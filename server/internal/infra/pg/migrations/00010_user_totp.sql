@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- TOTP second factor of users. Seed is wrapped with REK, enrollment is pending until confirmed.
+CREATE TABLE user_totp (
+    user_id    UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret     BYTEA NOT NULL,
+    enabled    BOOLEAN NOT NULL DEFAULT FALSE,
+    last_step  BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Single-use recovery codes replacing TOTP code on login. Only hashes are stored.
+CREATE TABLE user_recovery_codes (
+    user_id   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash BYTEA NOT NULL,
+    used_at   TIMESTAMP,
+    PRIMARY KEY (user_id, code_hash)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
+-- +goose StatementEnd
@@ -142,6 +142,68 @@ func (ns NullRequestType) Value() (driver.Value, error) {
 	return string(ns.RequestType), nil
 }
 
+type SecretEventType string
+
+const (
+	SecretEventTypeCreate SecretEventType = "create"
+	SecretEventTypeUpdate SecretEventType = "update"
+	SecretEventTypeDelete SecretEventType = "delete"
+)
+
+func (e *SecretEventType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SecretEventType(s)
+	case string:
+		*e = SecretEventType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SecretEventType: %T", src)
+	}
+	return nil
+}
+
+type NullSecretEventType struct {
+	SecretEventType SecretEventType
+	Valid           bool // Valid is true if SecretEventType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSecretEventType) Scan(value interface{}) error {
+	if value == nil {
+		ns.SecretEventType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SecretEventType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSecretEventType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SecretEventType), nil
+}
+
+type Device struct {
+	ID          uuid.UUID  `db:"id"`
+	UserID      uuid.UUID  `db:"user_id"`
+	ClientInfo  string     `db:"client_info"`
+	FirstSeenAt time.Time  `db:"first_seen_at"`
+	LastSeenAt  time.Time  `db:"last_seen_at"`
+	RevokedAt   *time.Time `db:"revoked_at"`
+}
+
+type RefreshToken struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	DeviceID  uuid.UUID  `db:"device_id"`
+	TokenHash []byte     `db:"token_hash"`
+	CreatedAt time.Time  `db:"created_at"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
 type Rek struct {
 	ID        bool      `db:"id"`
 	RekHash   []byte    `db:"rek_hash"`
@@ -157,6 +219,24 @@ type Secret struct {
 	UpdatedAt        time.Time `db:"updated_at"`
 }
 
+type SecretChunk struct {
+	UserID    uuid.UUID `db:"user_id"`
+	ChunkID   string    `db:"chunk_id"`
+	ChunkSize int64     `db:"chunk_size"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type SecretEvent struct {
+	ID         int64           `db:"id"`
+	UserID     uuid.UUID       `db:"user_id"`
+	SecretID   uuid.UUID       `db:"secret_id"`
+	SecretName string          `db:"secret_name"`
+	VersionID  uuid.UUID       `db:"version_id"`
+	EventType  SecretEventType `db:"event_type"`
+	ClientInfo string          `db:"client_info"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
+
 type SecretMetum struct {
 	ID        int64     `db:"id"`
 	UserID    uuid.UUID `db:"user_id"`
@@ -205,6 +285,15 @@ type SecretRequestsInProgress struct {
 	ExpiresAt       time.Time   `db:"expires_at"`
 }
 
+type SecretTombstone struct {
+	UserID     uuid.UUID `db:"user_id"`
+	SecretID   uuid.UUID `db:"secret_id"`
+	SecretName string    `db:"secret_name"`
+	VersionID  uuid.UUID `db:"version_id"`
+	ClientInfo string    `db:"client_info"`
+	DeletedAt  time.Time `db:"deleted_at"`
+}
+
 type SecretVersion struct {
 	ID              int64     `db:"id"`
 	UserID          uuid.UUID `db:"user_id"`
@@ -218,6 +307,13 @@ type SecretVersion struct {
 	CreatedAt       time.Time `db:"created_at"`
 }
 
+type SecretVersionChunk struct {
+	UserID    uuid.UUID `db:"user_id"`
+	SecretID  uuid.UUID `db:"secret_id"`
+	VersionID uuid.UUID `db:"version_id"`
+	ChunkID   string    `db:"chunk_id"`
+}
+
 type User struct {
 	ID         uuid.UUID `db:"id"`
 	Username   string    `db:"username"`
@@ -248,3 +344,18 @@ type UserIdentityToken struct {
 	CreatedAt        time.Time `db:"created_at"`
 	UpdatedAt        time.Time `db:"updated_at"`
 }
+
+type UserRecoveryCode struct {
+	UserID   uuid.UUID  `db:"user_id"`
+	CodeHash []byte     `db:"code_hash"`
+	UsedAt   *time.Time `db:"used_at"`
+}
+
+type UserTotp struct {
+	UserID    uuid.UUID `db:"user_id"`
+	Secret    []byte    `db:"secret"`
+	Enabled   bool      `db:"enabled"`
+	LastStep  int64     `db:"last_step"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
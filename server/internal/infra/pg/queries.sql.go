@@ -13,6 +13,85 @@ import (
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 )
 
+const AcceptUserTOTPStep = `-- name: AcceptUserTOTPStep :execrows
+UPDATE user_totp
+SET last_step = $1, updated_at = $2
+WHERE user_id = $3 AND enabled = TRUE AND last_step < $1
+`
+
+type AcceptUserTOTPStepParams struct {
+	LastStep  int64     `db:"last_step"`
+	UpdatedAt time.Time `db:"updated_at"`
+	UserID    uuid.UUID `db:"user_id"`
+}
+
+func (q *Queries) AcceptUserTOTPStep(ctx context.Context, arg AcceptUserTOTPStepParams) (int64, error) {
+	result, err := q.db.Exec(ctx, AcceptUserTOTPStep, arg.LastStep, arg.UpdatedAt, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const ChangeUserPassword = `-- name: ChangeUserPassword :execrows
+UPDATE users
+SET password = ''::bytea, salt = $1, verifier = $2, updated_at = $3
+WHERE id = $4 AND verifier = $5
+`
+
+type ChangeUserPasswordParams struct {
+	Salt            []byte    `db:"salt"`
+	Verifier        []byte    `db:"verifier"`
+	UpdatedAt       time.Time `db:"updated_at"`
+	ID              uuid.UUID `db:"id"`
+	CurrentVerifier []byte    `db:"current_verifier"`
+}
+
+func (q *Queries) ChangeUserPassword(ctx context.Context, arg ChangeUserPasswordParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ChangeUserPassword,
+		arg.Salt,
+		arg.Verifier,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.CurrentVerifier,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const CountUserSecretVersions = `-- name: CountUserSecretVersions :one
+SELECT COUNT(*)
+FROM secret_versions
+WHERE user_id = $1
+`
+
+func (q *Queries) CountUserSecretVersions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, CountUserSecretVersions, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountUserUploadsInProgress = `-- name: CountUserUploadsInProgress :one
+SELECT COUNT(*)
+FROM secret_requests_in_progress
+WHERE user_id = $1 AND request_type = 'put' AND expires_at > $2
+`
+
+type CountUserUploadsInProgressParams struct {
+	UserID uuid.UUID `db:"user_id"`
+	Now    time.Time `db:"now"`
+}
+
+func (q *Queries) CountUserUploadsInProgress(ctx context.Context, arg CountUserUploadsInProgressParams) (int64, error) {
+	row := q.db.QueryRow(ctx, CountUserUploadsInProgress, arg.UserID, arg.Now)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const CreateIdentityToken = `-- name: CreateIdentityToken :exec
 INSERT INTO user_identity_tokens (
     user_id,
@@ -66,6 +145,32 @@ func (q *Queries) CreateREKHash(ctx context.Context, rekHash []byte) error {
 	return err
 }
 
+const CreateRefreshToken = `-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (id, user_id, device_id, token_hash, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateRefreshTokenParams struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	DeviceID  uuid.UUID `db:"device_id"`
+	TokenHash []byte    `db:"token_hash"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
+	_, err := q.db.Exec(ctx, CreateRefreshToken,
+		arg.ID,
+		arg.UserID,
+		arg.DeviceID,
+		arg.TokenHash,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
 const CreateSecret = `-- name: CreateSecret :exec
 INSERT INTO secrets (user_id, secret_id, secret_name, current_version_id, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6)
@@ -92,6 +197,29 @@ func (q *Queries) CreateSecret(ctx context.Context, arg CreateSecretParams) erro
 	return err
 }
 
+const CreateSecretChunks = `-- name: CreateSecretChunks :exec
+INSERT INTO secret_chunks (user_id, chunk_id, chunk_size, created_at)
+SELECT $1, UNNEST($2::TEXT[]), UNNEST($3::BIGINT[]), $4
+ON CONFLICT (user_id, chunk_id) DO NOTHING
+`
+
+type CreateSecretChunksParams struct {
+	UserID     uuid.UUID `db:"user_id"`
+	ChunkIds   []string  `db:"chunk_ids"`
+	ChunkSizes []int64   `db:"chunk_sizes"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (q *Queries) CreateSecretChunks(ctx context.Context, arg CreateSecretChunksParams) error {
+	_, err := q.db.Exec(ctx, CreateSecretChunks,
+		arg.UserID,
+		arg.ChunkIds,
+		arg.ChunkSizes,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const CreateSecretCommitRequest = `-- name: CreateSecretCommitRequest :exec
 INSERT INTO secret_requests_completed (
     user_id,
@@ -157,6 +285,37 @@ func (q *Queries) CreateSecretCommitRequest(ctx context.Context, arg CreateSecre
 	return err
 }
 
+const CreateSecretEvent = `-- name: CreateSecretEvent :one
+INSERT INTO secret_events (user_id, secret_id, secret_name, version_id, event_type, client_info, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id
+`
+
+type CreateSecretEventParams struct {
+	UserID     uuid.UUID       `db:"user_id"`
+	SecretID   uuid.UUID       `db:"secret_id"`
+	SecretName string          `db:"secret_name"`
+	VersionID  uuid.UUID       `db:"version_id"`
+	EventType  SecretEventType `db:"event_type"`
+	ClientInfo string          `db:"client_info"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
+
+func (q *Queries) CreateSecretEvent(ctx context.Context, arg CreateSecretEventParams) (int64, error) {
+	row := q.db.QueryRow(ctx, CreateSecretEvent,
+		arg.UserID,
+		arg.SecretID,
+		arg.SecretName,
+		arg.VersionID,
+		arg.EventType,
+		arg.ClientInfo,
+		arg.CreatedAt,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const CreateSecretInitRequest = `-- name: CreateSecretInitRequest :one
 WITH candidate(parent_version_id) AS (
   -- Case: existing secret with matching parent
@@ -286,6 +445,106 @@ func (q *Queries) CreateSecretInitRequest(ctx context.Context, arg CreateSecretI
 	return i, err
 }
 
+const CreateSecretTombstone = `-- name: CreateSecretTombstone :exec
+INSERT INTO secret_tombstones (user_id, secret_id, secret_name, version_id, client_info, deleted_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateSecretTombstoneParams struct {
+	UserID     uuid.UUID `db:"user_id"`
+	SecretID   uuid.UUID `db:"secret_id"`
+	SecretName string    `db:"secret_name"`
+	VersionID  uuid.UUID `db:"version_id"`
+	ClientInfo string    `db:"client_info"`
+	DeletedAt  time.Time `db:"deleted_at"`
+}
+
+func (q *Queries) CreateSecretTombstone(ctx context.Context, arg CreateSecretTombstoneParams) error {
+	_, err := q.db.Exec(ctx, CreateSecretTombstone,
+		arg.UserID,
+		arg.SecretID,
+		arg.SecretName,
+		arg.VersionID,
+		arg.ClientInfo,
+		arg.DeletedAt,
+	)
+	return err
+}
+
+const CreateSecretVersion = `-- name: CreateSecretVersion :exec
+INSERT INTO secret_versions (
+    user_id,
+    secret_id,
+    version_id,
+    parent_version_id,
+    s3_url,
+    secret_size,
+    secret_hash,
+    secret_dek,
+    created_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    NULLIF($4::UUID, '00000000-0000-0000-0000-000000000000'::UUID),
+    $5,
+    $6,
+    $7,
+    $8,
+    $9
+)
+`
+
+type CreateSecretVersionParams struct {
+	UserID          uuid.UUID `db:"user_id"`
+	SecretID        uuid.UUID `db:"secret_id"`
+	VersionID       uuid.UUID `db:"version_id"`
+	ParentVersionID uuid.UUID `db:"parent_version_id"`
+	S3Url           string    `db:"s3_url"`
+	SecretSize      int64     `db:"secret_size"`
+	SecretHash      []byte    `db:"secret_hash"`
+	SecretDek       []byte    `db:"secret_dek"`
+	CreatedAt       time.Time `db:"created_at"`
+}
+
+func (q *Queries) CreateSecretVersion(ctx context.Context, arg CreateSecretVersionParams) error {
+	_, err := q.db.Exec(ctx, CreateSecretVersion,
+		arg.UserID,
+		arg.SecretID,
+		arg.VersionID,
+		arg.ParentVersionID,
+		arg.S3Url,
+		arg.SecretSize,
+		arg.SecretHash,
+		arg.SecretDek,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const CreateSecretVersionChunks = `-- name: CreateSecretVersionChunks :exec
+INSERT INTO secret_version_chunks (user_id, secret_id, version_id, chunk_id)
+SELECT $1, $2, $3, UNNEST($4::TEXT[])
+ON CONFLICT (user_id, version_id, chunk_id) DO NOTHING
+`
+
+type CreateSecretVersionChunksParams struct {
+	UserID    uuid.UUID `db:"user_id"`
+	SecretID  uuid.UUID `db:"secret_id"`
+	VersionID uuid.UUID `db:"version_id"`
+	ChunkIds  []string  `db:"chunk_ids"`
+}
+
+func (q *Queries) CreateSecretVersionChunks(ctx context.Context, arg CreateSecretVersionChunksParams) error {
+	_, err := q.db.Exec(ctx, CreateSecretVersionChunks,
+		arg.UserID,
+		arg.SecretID,
+		arg.VersionID,
+		arg.ChunkIds,
+	)
+	return err
+}
+
 const CreateUser = `-- name: CreateUser :one
 INSERT INTO users (id, username, role, created_at, updated_at, password, salt, verifier, bucket_name, identity_id)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
@@ -339,27 +598,18 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 	return i, err
 }
 
-const CreateUserKey = `-- name: CreateUserKey :exec
-INSERT INTO user_crypto_keys (user_id, kek, algorithm, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5)
+const CreateUserRecoveryCode = `-- name: CreateUserRecoveryCode :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+VALUES ($1, $2)
 `
 
-type CreateUserKeyParams struct {
-	UserID    uuid.UUID `db:"user_id"`
-	Kek       []byte    `db:"kek"`
-	Algorithm string    `db:"algorithm"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+type CreateUserRecoveryCodeParams struct {
+	UserID   uuid.UUID `db:"user_id"`
+	CodeHash []byte    `db:"code_hash"`
 }
 
-func (q *Queries) CreateUserKey(ctx context.Context, arg CreateUserKeyParams) error {
-	_, err := q.db.Exec(ctx, CreateUserKey,
-		arg.UserID,
-		arg.Kek,
-		arg.Algorithm,
-		arg.CreatedAt,
-		arg.UpdatedAt,
-	)
+func (q *Queries) CreateUserRecoveryCode(ctx context.Context, arg CreateUserRecoveryCodeParams) error {
+	_, err := q.db.Exec(ctx, CreateUserRecoveryCode, arg.UserID, arg.CodeHash)
 	return err
 }
 
@@ -373,6 +623,25 @@ func (q *Queries) DeleteIdentityToken(ctx context.Context, userID uuid.UUID) err
 	return err
 }
 
+const DeleteSecret = `-- name: DeleteSecret :execrows
+DELETE FROM secrets
+WHERE user_id = $1 AND secret_id = $2 AND current_version_id = $3
+`
+
+type DeleteSecretParams struct {
+	UserID    uuid.UUID `db:"user_id"`
+	SecretID  uuid.UUID `db:"secret_id"`
+	VersionID uuid.UUID `db:"version_id"`
+}
+
+func (q *Queries) DeleteSecret(ctx context.Context, arg DeleteSecretParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteSecret, arg.UserID, arg.SecretID, arg.VersionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const DeleteSecretInitRequest = `-- name: DeleteSecretInitRequest :exec
 DELETE FROM secret_requests_in_progress
 WHERE user_id = $1 AND secret_id = $2
@@ -388,6 +657,77 @@ func (q *Queries) DeleteSecretInitRequest(ctx context.Context, arg DeleteSecretI
 	return err
 }
 
+const DeleteUnreferencedSecretChunks = `-- name: DeleteUnreferencedSecretChunks :many
+DELETE FROM secret_chunks
+WHERE secret_chunks.user_id = $1
+  AND NOT EXISTS (
+    SELECT 1 FROM secret_version_chunks
+    WHERE secret_version_chunks.user_id = secret_chunks.user_id
+      AND secret_version_chunks.chunk_id = secret_chunks.chunk_id
+  )
+RETURNING chunk_id
+`
+
+func (q *Queries) DeleteUnreferencedSecretChunks(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, DeleteUnreferencedSecretChunks, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var chunk_id string
+		if err := rows.Scan(&chunk_id); err != nil {
+			return nil, err
+		}
+		items = append(items, chunk_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const DeleteUserKey = `-- name: DeleteUserKey :exec
+DELETE FROM user_crypto_keys
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserKey(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, DeleteUserKey, userID)
+	return err
+}
+
+const DeleteUserRecoveryCodes = `-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, DeleteUserRecoveryCodes, userID)
+	return err
+}
+
+const EnableUserTOTP = `-- name: EnableUserTOTP :execrows
+UPDATE user_totp
+SET enabled = TRUE, last_step = $1, updated_at = $2
+WHERE user_id = $3 AND enabled = FALSE
+`
+
+type EnableUserTOTPParams struct {
+	LastStep  int64     `db:"last_step"`
+	UpdatedAt time.Time `db:"updated_at"`
+	UserID    uuid.UUID `db:"user_id"`
+}
+
+func (q *Queries) EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (int64, error) {
+	result, err := q.db.Exec(ctx, EnableUserTOTP, arg.LastStep, arg.UpdatedAt, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const GetIdentityToken = `-- name: GetIdentityToken :one
 SELECT 
     user_id,
@@ -434,68 +774,1270 @@ func (q *Queries) GetREKHash(ctx context.Context) (GetREKHashRow, error) {
 	return i, err
 }
 
-const GetUser = `-- name: GetUser :one
-SELECT id, username, role, created_at, updated_at, password, salt, verifier, bucket_name, identity_id
-FROM users
-WHERE username = $1
+const GetRefreshToken = `-- name: GetRefreshToken :one
+SELECT id, user_id, device_id, token_hash, created_at, expires_at, revoked_at
+FROM refresh_tokens
+WHERE token_hash = $1
 `
 
-func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
-	row := q.db.QueryRow(ctx, GetUser, username)
-	var i User
+func (q *Queries) GetRefreshToken(ctx context.Context, tokenHash []byte) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, GetRefreshToken, tokenHash)
+	var i RefreshToken
 	err := row.Scan(
 		&i.ID,
-		&i.Username,
-		&i.Role,
+		&i.UserID,
+		&i.DeviceID,
+		&i.TokenHash,
 		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.Password,
-		&i.Salt,
-		&i.Verifier,
-		&i.BucketName,
-		&i.IdentityID,
+		&i.ExpiresAt,
+		&i.RevokedAt,
 	)
 	return i, err
 }
 
-const GetUserByID = `-- name: GetUserByID :one
-SELECT id, username, role, created_at, updated_at, password, salt, verifier, bucket_name, identity_id
-FROM users
-WHERE id = $1
+const GetSecretCurrentVersion = `-- name: GetSecretCurrentVersion :one
+SELECT
+  secrets.secret_id,
+  secrets.secret_name,
+  secret_versions.version_id,
+  secret_versions.parent_version_id,
+  secret_versions.s3_url,
+  secret_versions.secret_size,
+  secret_versions.secret_hash,
+  secret_versions.secret_dek,
+  COALESCE(secret_meta.meta, '{}')::JSONB AS meta,
+  secret_versions.created_at,
+  secrets.current_version_id
+FROM secrets
+JOIN secret_versions
+  ON secret_versions.user_id = secrets.user_id
+  AND secret_versions.secret_id = secrets.secret_id
+  AND secret_versions.version_id = secrets.current_version_id
+LEFT JOIN secret_meta
+  ON secret_meta.user_id = secrets.user_id
+  AND secret_meta.secret_id = secrets.secret_id
+WHERE secrets.user_id = $1 AND secrets.secret_name = $2
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
-	row := q.db.QueryRow(ctx, GetUserByID, id)
-	var i User
+type GetSecretCurrentVersionParams struct {
+	UserID     uuid.UUID `db:"user_id"`
+	SecretName string    `db:"secret_name"`
+}
+
+type GetSecretCurrentVersionRow struct {
+	SecretID         uuid.UUID `db:"secret_id"`
+	SecretName       string    `db:"secret_name"`
+	VersionID        uuid.UUID `db:"version_id"`
+	ParentVersionID  uuid.UUID `db:"parent_version_id"`
+	S3Url            string    `db:"s3_url"`
+	SecretSize       int64     `db:"secret_size"`
+	SecretHash       []byte    `db:"secret_hash"`
+	SecretDek        []byte    `db:"secret_dek"`
+	Meta             []byte    `db:"meta"`
+	CreatedAt        time.Time `db:"created_at"`
+	CurrentVersionID uuid.UUID `db:"current_version_id"`
+}
+
+func (q *Queries) GetSecretCurrentVersion(ctx context.Context, arg GetSecretCurrentVersionParams) (GetSecretCurrentVersionRow, error) {
+	row := q.db.QueryRow(ctx, GetSecretCurrentVersion, arg.UserID, arg.SecretName)
+	var i GetSecretCurrentVersionRow
 	err := row.Scan(
-		&i.ID,
-		&i.Username,
-		&i.Role,
+		&i.SecretID,
+		&i.SecretName,
+		&i.VersionID,
+		&i.ParentVersionID,
+		&i.S3Url,
+		&i.SecretSize,
+		&i.SecretHash,
+		&i.SecretDek,
+		&i.Meta,
 		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.Password,
-		&i.Salt,
-		&i.Verifier,
-		&i.BucketName,
-		&i.IdentityID,
+		&i.CurrentVersionID,
 	)
 	return i, err
 }
 
-const UpdateSecret = `-- name: UpdateSecret :exec
-UPDATE secrets
-SET current_version_id = $3,
-    updated_at = NOW()
+const GetSecretCurrentVersionID = `-- name: GetSecretCurrentVersionID :one
+SELECT current_version_id
+FROM secrets
 WHERE user_id = $1 AND secret_id = $2
 `
 
-type UpdateSecretParams struct {
-	UserID           uuid.UUID `db:"user_id"`
+type GetSecretCurrentVersionIDParams struct {
+	UserID   uuid.UUID `db:"user_id"`
+	SecretID uuid.UUID `db:"secret_id"`
+}
+
+func (q *Queries) GetSecretCurrentVersionID(ctx context.Context, arg GetSecretCurrentVersionIDParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, GetSecretCurrentVersionID, arg.UserID, arg.SecretID)
+	var current_version_id uuid.UUID
+	err := row.Scan(&current_version_id)
+	return current_version_id, err
+}
+
+const GetSecretForDelete = `-- name: GetSecretForDelete :one
+SELECT secret_id, current_version_id
+FROM secrets
+WHERE user_id = $1 AND secret_name = $2
+FOR UPDATE
+`
+
+type GetSecretForDeleteParams struct {
+	UserID     uuid.UUID `db:"user_id"`
+	SecretName string    `db:"secret_name"`
+}
+
+type GetSecretForDeleteRow struct {
 	SecretID         uuid.UUID `db:"secret_id"`
 	CurrentVersionID uuid.UUID `db:"current_version_id"`
 }
 
-func (q *Queries) UpdateSecret(ctx context.Context, arg UpdateSecretParams) error {
-	_, err := q.db.Exec(ctx, UpdateSecret, arg.UserID, arg.SecretID, arg.CurrentVersionID)
-	return err
+func (q *Queries) GetSecretForDelete(ctx context.Context, arg GetSecretForDeleteParams) (GetSecretForDeleteRow, error) {
+	row := q.db.QueryRow(ctx, GetSecretForDelete, arg.UserID, arg.SecretName)
+	var i GetSecretForDeleteRow
+	err := row.Scan(&i.SecretID, &i.CurrentVersionID)
+	return i, err
+}
+
+const GetSecretInitRequest = `-- name: GetSecretInitRequest :one
+SELECT
+  user_id,
+  secret_id,
+  secret_name,
+  s3_url,
+  version_id,
+  parent_version_id,
+  request_type,
+  token,
+  client_info,
+  secret_size,
+  secret_hash,
+  secret_dek,
+  meta,
+  created_at,
+  expires_at
+FROM secret_requests_in_progress
+WHERE user_id = $1 AND secret_id = $2
+`
+
+type GetSecretInitRequestParams struct {
+	UserID   uuid.UUID `db:"user_id"`
+	SecretID uuid.UUID `db:"secret_id"`
+}
+
+type GetSecretInitRequestRow struct {
+	UserID          uuid.UUID   `db:"user_id"`
+	SecretID        uuid.UUID   `db:"secret_id"`
+	SecretName      string      `db:"secret_name"`
+	S3Url           string      `db:"s3_url"`
+	VersionID       uuid.UUID   `db:"version_id"`
+	ParentVersionID uuid.UUID   `db:"parent_version_id"`
+	RequestType     RequestType `db:"request_type"`
+	Token           int64       `db:"token"`
+	ClientInfo      string      `db:"client_info"`
+	SecretSize      int64       `db:"secret_size"`
+	SecretHash      []byte      `db:"secret_hash"`
+	SecretDek       []byte      `db:"secret_dek"`
+	Meta            []byte      `db:"meta"`
+	CreatedAt       time.Time   `db:"created_at"`
+	ExpiresAt       time.Time   `db:"expires_at"`
+}
+
+func (q *Queries) GetSecretInitRequest(ctx context.Context, arg GetSecretInitRequestParams) (GetSecretInitRequestRow, error) {
+	row := q.db.QueryRow(ctx, GetSecretInitRequest, arg.UserID, arg.SecretID)
+	var i GetSecretInitRequestRow
+	err := row.Scan(
+		&i.UserID,
+		&i.SecretID,
+		&i.SecretName,
+		&i.S3Url,
+		&i.VersionID,
+		&i.ParentVersionID,
+		&i.RequestType,
+		&i.Token,
+		&i.ClientInfo,
+		&i.SecretSize,
+		&i.SecretHash,
+		&i.SecretDek,
+		&i.Meta,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const GetSecretInitRequestByObject = `-- name: GetSecretInitRequestByObject :one
+SELECT
+  secret_requests_in_progress.user_id,
+  secret_requests_in_progress.secret_id,
+  secret_requests_in_progress.secret_name,
+  secret_requests_in_progress.s3_url,
+  secret_requests_in_progress.version_id,
+  secret_requests_in_progress.parent_version_id,
+  secret_requests_in_progress.request_type,
+  secret_requests_in_progress.token,
+  secret_requests_in_progress.client_info,
+  secret_requests_in_progress.secret_size,
+  secret_requests_in_progress.secret_hash,
+  secret_requests_in_progress.secret_dek,
+  secret_requests_in_progress.meta,
+  secret_requests_in_progress.created_at,
+  secret_requests_in_progress.expires_at,
+  users.bucket_name
+FROM secret_requests_in_progress
+JOIN users ON users.id = secret_requests_in_progress.user_id
+WHERE users.bucket_name = $1
+  AND secret_requests_in_progress.s3_url = $2
+  AND secret_requests_in_progress.request_type = 'put'
+`
+
+type GetSecretInitRequestByObjectParams struct {
+	BucketName string `db:"bucket_name"`
+	S3Url      string `db:"s3_url"`
+}
+
+type GetSecretInitRequestByObjectRow struct {
+	UserID          uuid.UUID   `db:"user_id"`
+	SecretID        uuid.UUID   `db:"secret_id"`
+	SecretName      string      `db:"secret_name"`
+	S3Url           string      `db:"s3_url"`
+	VersionID       uuid.UUID   `db:"version_id"`
+	ParentVersionID uuid.UUID   `db:"parent_version_id"`
+	RequestType     RequestType `db:"request_type"`
+	Token           int64       `db:"token"`
+	ClientInfo      string      `db:"client_info"`
+	SecretSize      int64       `db:"secret_size"`
+	SecretHash      []byte      `db:"secret_hash"`
+	SecretDek       []byte      `db:"secret_dek"`
+	Meta            []byte      `db:"meta"`
+	CreatedAt       time.Time   `db:"created_at"`
+	ExpiresAt       time.Time   `db:"expires_at"`
+	BucketName      string      `db:"bucket_name"`
+}
+
+func (q *Queries) GetSecretInitRequestByObject(ctx context.Context, arg GetSecretInitRequestByObjectParams) (GetSecretInitRequestByObjectRow, error) {
+	row := q.db.QueryRow(ctx, GetSecretInitRequestByObject, arg.BucketName, arg.S3Url)
+	var i GetSecretInitRequestByObjectRow
+	err := row.Scan(
+		&i.UserID,
+		&i.SecretID,
+		&i.SecretName,
+		&i.S3Url,
+		&i.VersionID,
+		&i.ParentVersionID,
+		&i.RequestType,
+		&i.Token,
+		&i.ClientInfo,
+		&i.SecretSize,
+		&i.SecretHash,
+		&i.SecretDek,
+		&i.Meta,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.BucketName,
+	)
+	return i, err
+}
+
+const GetSecretVersion = `-- name: GetSecretVersion :one
+SELECT
+  secrets.secret_id,
+  secrets.secret_name,
+  secret_versions.version_id,
+  secret_versions.parent_version_id,
+  secret_versions.s3_url,
+  secret_versions.secret_size,
+  secret_versions.secret_hash,
+  secret_versions.secret_dek,
+  COALESCE(secret_meta.meta, '{}')::JSONB AS meta,
+  secret_versions.created_at,
+  secrets.current_version_id
+FROM secrets
+JOIN secret_versions
+  ON secret_versions.user_id = secrets.user_id
+  AND secret_versions.secret_id = secrets.secret_id
+LEFT JOIN secret_meta
+  ON secret_meta.user_id = secrets.user_id
+  AND secret_meta.secret_id = secrets.secret_id
+WHERE secrets.user_id = $1 AND secrets.secret_name = $2 AND secret_versions.version_id = $3
+`
+
+type GetSecretVersionParams struct {
+	UserID     uuid.UUID `db:"user_id"`
+	SecretName string    `db:"secret_name"`
+	VersionID  uuid.UUID `db:"version_id"`
+}
+
+type GetSecretVersionRow struct {
+	SecretID         uuid.UUID `db:"secret_id"`
+	SecretName       string    `db:"secret_name"`
+	VersionID        uuid.UUID `db:"version_id"`
+	ParentVersionID  uuid.UUID `db:"parent_version_id"`
+	S3Url            string    `db:"s3_url"`
+	SecretSize       int64     `db:"secret_size"`
+	SecretHash       []byte    `db:"secret_hash"`
+	SecretDek        []byte    `db:"secret_dek"`
+	Meta             []byte    `db:"meta"`
+	CreatedAt        time.Time `db:"created_at"`
+	CurrentVersionID uuid.UUID `db:"current_version_id"`
+}
+
+func (q *Queries) GetSecretVersion(ctx context.Context, arg GetSecretVersionParams) (GetSecretVersionRow, error) {
+	row := q.db.QueryRow(ctx, GetSecretVersion, arg.UserID, arg.SecretName, arg.VersionID)
+	var i GetSecretVersionRow
+	err := row.Scan(
+		&i.SecretID,
+		&i.SecretName,
+		&i.VersionID,
+		&i.ParentVersionID,
+		&i.S3Url,
+		&i.SecretSize,
+		&i.SecretHash,
+		&i.SecretDek,
+		&i.Meta,
+		&i.CreatedAt,
+		&i.CurrentVersionID,
+	)
+	return i, err
+}
+
+const GetUser = `-- name: GetUser :one
+SELECT id, username, role, created_at, updated_at, password, salt, verifier, bucket_name, identity_id
+FROM users
+WHERE username = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, GetUser, username)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Password,
+		&i.Salt,
+		&i.Verifier,
+		&i.BucketName,
+		&i.IdentityID,
+	)
+	return i, err
+}
+
+const GetUserByID = `-- name: GetUserByID :one
+SELECT id, username, role, created_at, updated_at, password, salt, verifier, bucket_name, identity_id
+FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, GetUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Password,
+		&i.Salt,
+		&i.Verifier,
+		&i.BucketName,
+		&i.IdentityID,
+	)
+	return i, err
+}
+
+const GetUserTOTP = `-- name: GetUserTOTP :one
+SELECT user_id, secret, enabled, last_step, created_at, updated_at
+FROM user_totp
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserTOTP(ctx context.Context, userID uuid.UUID) (UserTotp, error) {
+	row := q.db.QueryRow(ctx, GetUserTOTP, userID)
+	var i UserTotp
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.Enabled,
+		&i.LastStep,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const HasChunkedSecretUploads = `-- name: HasChunkedSecretUploads :one
+SELECT EXISTS (
+  SELECT 1 FROM secret_requests_in_progress
+  WHERE user_id = $1
+    AND request_type = 'put'
+    AND meta->>'storage' = 'chunked'
+)::BOOLEAN AS in_progress
+`
+
+func (q *Queries) HasChunkedSecretUploads(ctx context.Context, userID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, HasChunkedSecretUploads, userID)
+	var in_progress bool
+	err := row.Scan(&in_progress)
+	return in_progress, err
+}
+
+const ListDevices = `-- name: ListDevices :many
+SELECT id, user_id, client_info, first_seen_at, last_seen_at, revoked_at
+FROM devices
+WHERE user_id = $1
+ORDER BY first_seen_at
+`
+
+func (q *Queries) ListDevices(ctx context.Context, userID uuid.UUID) ([]Device, error) {
+	rows, err := q.db.Query(ctx, ListDevices, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Device
+	for rows.Next() {
+		var i Device
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ClientInfo,
+			&i.FirstSeenAt,
+			&i.LastSeenAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListExpiredSecretInitRequests = `-- name: ListExpiredSecretInitRequests :many
+SELECT
+  secret_requests_in_progress.user_id,
+  secret_requests_in_progress.secret_id,
+  secret_requests_in_progress.secret_name,
+  secret_requests_in_progress.s3_url,
+  secret_requests_in_progress.version_id,
+  secret_requests_in_progress.parent_version_id,
+  secret_requests_in_progress.request_type,
+  secret_requests_in_progress.token,
+  secret_requests_in_progress.client_info,
+  secret_requests_in_progress.secret_size,
+  secret_requests_in_progress.secret_hash,
+  secret_requests_in_progress.secret_dek,
+  secret_requests_in_progress.meta,
+  secret_requests_in_progress.created_at,
+  secret_requests_in_progress.expires_at,
+  users.bucket_name,
+  (
+    secret_requests_in_progress.request_type = 'put'
+    AND NOT EXISTS (
+      SELECT 1 FROM secret_versions
+      WHERE secret_versions.user_id = secret_requests_in_progress.user_id
+        AND secret_versions.s3_url = secret_requests_in_progress.s3_url
+    )
+  )::BOOLEAN AS orphaned
+FROM secret_requests_in_progress
+JOIN users ON users.id = secret_requests_in_progress.user_id
+WHERE secret_requests_in_progress.expires_at < $1
+ORDER BY secret_requests_in_progress.expires_at
+LIMIT $2
+FOR UPDATE OF secret_requests_in_progress SKIP LOCKED
+`
+
+type ListExpiredSecretInitRequestsParams struct {
+	ExpiredBefore time.Time `db:"expired_before"`
+	BatchSize     int32     `db:"batch_size"`
+}
+
+type ListExpiredSecretInitRequestsRow struct {
+	UserID          uuid.UUID   `db:"user_id"`
+	SecretID        uuid.UUID   `db:"secret_id"`
+	SecretName      string      `db:"secret_name"`
+	S3Url           string      `db:"s3_url"`
+	VersionID       uuid.UUID   `db:"version_id"`
+	ParentVersionID uuid.UUID   `db:"parent_version_id"`
+	RequestType     RequestType `db:"request_type"`
+	Token           int64       `db:"token"`
+	ClientInfo      string      `db:"client_info"`
+	SecretSize      int64       `db:"secret_size"`
+	SecretHash      []byte      `db:"secret_hash"`
+	SecretDek       []byte      `db:"secret_dek"`
+	Meta            []byte      `db:"meta"`
+	CreatedAt       time.Time   `db:"created_at"`
+	ExpiresAt       time.Time   `db:"expires_at"`
+	BucketName      string      `db:"bucket_name"`
+	Orphaned        bool        `db:"orphaned"`
+}
+
+func (q *Queries) ListExpiredSecretInitRequests(ctx context.Context, arg ListExpiredSecretInitRequestsParams) ([]ListExpiredSecretInitRequestsRow, error) {
+	rows, err := q.db.Query(ctx, ListExpiredSecretInitRequests, arg.ExpiredBefore, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExpiredSecretInitRequestsRow
+	for rows.Next() {
+		var i ListExpiredSecretInitRequestsRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.SecretID,
+			&i.SecretName,
+			&i.S3Url,
+			&i.VersionID,
+			&i.ParentVersionID,
+			&i.RequestType,
+			&i.Token,
+			&i.ClientInfo,
+			&i.SecretSize,
+			&i.SecretHash,
+			&i.SecretDek,
+			&i.Meta,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.BucketName,
+			&i.Orphaned,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListSecretChunks = `-- name: ListSecretChunks :many
+SELECT chunk_id
+FROM secret_chunks
+WHERE user_id = $1 AND chunk_id = ANY($2::TEXT[])
+`
+
+type ListSecretChunksParams struct {
+	UserID   uuid.UUID `db:"user_id"`
+	ChunkIds []string  `db:"chunk_ids"`
+}
+
+func (q *Queries) ListSecretChunks(ctx context.Context, arg ListSecretChunksParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, ListSecretChunks, arg.UserID, arg.ChunkIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var chunk_id string
+		if err := rows.Scan(&chunk_id); err != nil {
+			return nil, err
+		}
+		items = append(items, chunk_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListSecretEvents = `-- name: ListSecretEvents :many
+SELECT id, user_id, secret_id, secret_name, version_id, event_type, client_info, created_at
+FROM secret_events
+WHERE user_id = $1 AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListSecretEventsParams struct {
+	UserID    uuid.UUID `db:"user_id"`
+	AfterID   int64     `db:"after_id"`
+	BatchSize int32     `db:"batch_size"`
+}
+
+func (q *Queries) ListSecretEvents(ctx context.Context, arg ListSecretEventsParams) ([]SecretEvent, error) {
+	rows, err := q.db.Query(ctx, ListSecretEvents, arg.UserID, arg.AfterID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SecretEvent
+	for rows.Next() {
+		var i SecretEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.SecretID,
+			&i.SecretName,
+			&i.VersionID,
+			&i.EventType,
+			&i.ClientInfo,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListSecretObjects = `-- name: ListSecretObjects :many
+SELECT s3_url
+FROM secret_versions
+WHERE secret_versions.user_id = $1 AND secret_versions.secret_id = $2
+UNION
+SELECT s3_url
+FROM secret_requests_in_progress
+WHERE secret_requests_in_progress.user_id = $1
+  AND secret_requests_in_progress.secret_id = $2
+  AND secret_requests_in_progress.request_type = 'put'
+`
+
+type ListSecretObjectsParams struct {
+	UserID   uuid.UUID `db:"user_id"`
+	SecretID uuid.UUID `db:"secret_id"`
+}
+
+func (q *Queries) ListSecretObjects(ctx context.Context, arg ListSecretObjectsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, ListSecretObjects, arg.UserID, arg.SecretID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var s3_url string
+		if err := rows.Scan(&s3_url); err != nil {
+			return nil, err
+		}
+		items = append(items, s3_url)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListSecretTombstones = `-- name: ListSecretTombstones :many
+SELECT user_id, secret_id, secret_name, version_id, client_info, deleted_at
+FROM secret_tombstones
+WHERE user_id = $1 AND deleted_at > $2
+ORDER BY deleted_at
+`
+
+type ListSecretTombstonesParams struct {
+	UserID       uuid.UUID `db:"user_id"`
+	DeletedAfter time.Time `db:"deleted_after"`
+}
+
+func (q *Queries) ListSecretTombstones(ctx context.Context, arg ListSecretTombstonesParams) ([]SecretTombstone, error) {
+	rows, err := q.db.Query(ctx, ListSecretTombstones, arg.UserID, arg.DeletedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SecretTombstone
+	for rows.Next() {
+		var i SecretTombstone
+		if err := rows.Scan(
+			&i.UserID,
+			&i.SecretID,
+			&i.SecretName,
+			&i.VersionID,
+			&i.ClientInfo,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListSecretVersions = `-- name: ListSecretVersions :many
+SELECT
+  secrets.secret_id,
+  secrets.secret_name,
+  secrets.current_version_id,
+  secret_versions.version_id,
+  secret_versions.parent_version_id,
+  secret_versions.secret_size,
+  secret_versions.secret_hash,
+  secret_versions.created_at
+FROM secrets
+JOIN secret_versions
+  ON secret_versions.user_id = secrets.user_id
+  AND secret_versions.secret_id = secrets.secret_id
+WHERE secrets.user_id = $1 AND secrets.secret_name = $2
+ORDER BY secret_versions.id DESC
+`
+
+type ListSecretVersionsParams struct {
+	UserID     uuid.UUID `db:"user_id"`
+	SecretName string    `db:"secret_name"`
+}
+
+type ListSecretVersionsRow struct {
+	SecretID         uuid.UUID `db:"secret_id"`
+	SecretName       string    `db:"secret_name"`
+	CurrentVersionID uuid.UUID `db:"current_version_id"`
+	VersionID        uuid.UUID `db:"version_id"`
+	ParentVersionID  uuid.UUID `db:"parent_version_id"`
+	SecretSize       int64     `db:"secret_size"`
+	SecretHash       []byte    `db:"secret_hash"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+func (q *Queries) ListSecretVersions(ctx context.Context, arg ListSecretVersionsParams) ([]ListSecretVersionsRow, error) {
+	rows, err := q.db.Query(ctx, ListSecretVersions, arg.UserID, arg.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSecretVersionsRow
+	for rows.Next() {
+		var i ListSecretVersionsRow
+		if err := rows.Scan(
+			&i.SecretID,
+			&i.SecretName,
+			&i.CurrentVersionID,
+			&i.VersionID,
+			&i.ParentVersionID,
+			&i.SecretSize,
+			&i.SecretHash,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListSecrets = `-- name: ListSecrets :many
+SELECT
+  secrets.secret_id,
+  secrets.secret_name,
+  secrets.current_version_id,
+  secret_versions.parent_version_id,
+  secret_versions.secret_size,
+  secret_versions.secret_hash,
+  secrets.created_at,
+  secrets.updated_at
+FROM secrets
+JOIN secret_versions
+  ON secret_versions.user_id = secrets.user_id
+  AND secret_versions.secret_id = secrets.secret_id
+  AND secret_versions.version_id = secrets.current_version_id
+WHERE secrets.user_id = $1
+  AND starts_with(secrets.secret_name, $2::TEXT)
+  AND secrets.secret_name > $3::TEXT
+ORDER BY secrets.secret_name
+LIMIT $4
+`
+
+type ListSecretsParams struct {
+	UserID     uuid.UUID `db:"user_id"`
+	NamePrefix string    `db:"name_prefix"`
+	AfterName  string    `db:"after_name"`
+	PageLimit  int32     `db:"page_limit"`
+}
+
+type ListSecretsRow struct {
+	SecretID         uuid.UUID `db:"secret_id"`
+	SecretName       string    `db:"secret_name"`
+	CurrentVersionID uuid.UUID `db:"current_version_id"`
+	ParentVersionID  uuid.UUID `db:"parent_version_id"`
+	SecretSize       int64     `db:"secret_size"`
+	SecretHash       []byte    `db:"secret_hash"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+func (q *Queries) ListSecrets(ctx context.Context, arg ListSecretsParams) ([]ListSecretsRow, error) {
+	rows, err := q.db.Query(ctx, ListSecrets,
+		arg.UserID,
+		arg.NamePrefix,
+		arg.AfterName,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSecretsRow
+	for rows.Next() {
+		var i ListSecretsRow
+		if err := rows.Scan(
+			&i.SecretID,
+			&i.SecretName,
+			&i.CurrentVersionID,
+			&i.ParentVersionID,
+			&i.SecretSize,
+			&i.SecretHash,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUserSecretKeys = `-- name: ListUserSecretKeys :many
+SELECT secret_id, version_id, secret_dek
+FROM secret_versions
+WHERE user_id = $1
+ORDER BY id
+`
+
+type ListUserSecretKeysRow struct {
+	SecretID  uuid.UUID `db:"secret_id"`
+	VersionID uuid.UUID `db:"version_id"`
+	SecretDek []byte    `db:"secret_dek"`
+}
+
+func (q *Queries) ListUserSecretKeys(ctx context.Context, userID uuid.UUID) ([]ListUserSecretKeysRow, error) {
+	rows, err := q.db.Query(ctx, ListUserSecretKeys, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserSecretKeysRow
+	for rows.Next() {
+		var i ListUserSecretKeysRow
+		if err := rows.Scan(&i.SecretID, &i.VersionID, &i.SecretDek); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const LockSecretChunks = `-- name: LockSecretChunks :exec
+SELECT pg_advisory_xact_lock(hashtextextended(CAST($1::UUID AS TEXT), 0))
+`
+
+func (q *Queries) LockSecretChunks(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, LockSecretChunks, userID)
+	return err
+}
+
+const LockSecretInitRequest = `-- name: LockSecretInitRequest :one
+SELECT
+  user_id,
+  secret_id,
+  secret_name,
+  s3_url,
+  version_id,
+  parent_version_id,
+  request_type,
+  token,
+  client_info,
+  secret_size,
+  secret_hash,
+  secret_dek,
+  meta,
+  created_at,
+  expires_at
+FROM secret_requests_in_progress
+WHERE user_id = $1 AND secret_id = $2
+FOR UPDATE
+`
+
+type LockSecretInitRequestParams struct {
+	UserID   uuid.UUID `db:"user_id"`
+	SecretID uuid.UUID `db:"secret_id"`
+}
+
+type LockSecretInitRequestRow struct {
+	UserID          uuid.UUID   `db:"user_id"`
+	SecretID        uuid.UUID   `db:"secret_id"`
+	SecretName      string      `db:"secret_name"`
+	S3Url           string      `db:"s3_url"`
+	VersionID       uuid.UUID   `db:"version_id"`
+	ParentVersionID uuid.UUID   `db:"parent_version_id"`
+	RequestType     RequestType `db:"request_type"`
+	Token           int64       `db:"token"`
+	ClientInfo      string      `db:"client_info"`
+	SecretSize      int64       `db:"secret_size"`
+	SecretHash      []byte      `db:"secret_hash"`
+	SecretDek       []byte      `db:"secret_dek"`
+	Meta            []byte      `db:"meta"`
+	CreatedAt       time.Time   `db:"created_at"`
+	ExpiresAt       time.Time   `db:"expires_at"`
+}
+
+func (q *Queries) LockSecretInitRequest(ctx context.Context, arg LockSecretInitRequestParams) (LockSecretInitRequestRow, error) {
+	row := q.db.QueryRow(ctx, LockSecretInitRequest, arg.UserID, arg.SecretID)
+	var i LockSecretInitRequestRow
+	err := row.Scan(
+		&i.UserID,
+		&i.SecretID,
+		&i.SecretName,
+		&i.S3Url,
+		&i.VersionID,
+		&i.ParentVersionID,
+		&i.RequestType,
+		&i.Token,
+		&i.ClientInfo,
+		&i.SecretSize,
+		&i.SecretHash,
+		&i.SecretDek,
+		&i.Meta,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const MigrateUserAuth = `-- name: MigrateUserAuth :execrows
+UPDATE users
+SET password = ''::bytea, verifier = $1, updated_at = $2
+WHERE id = $3 AND password <> ''::bytea
+`
+
+type MigrateUserAuthParams struct {
+	Verifier  []byte    `db:"verifier"`
+	UpdatedAt time.Time `db:"updated_at"`
+	ID        uuid.UUID `db:"id"`
+}
+
+func (q *Queries) MigrateUserAuth(ctx context.Context, arg MigrateUserAuthParams) (int64, error) {
+	result, err := q.db.Exec(ctx, MigrateUserAuth, arg.Verifier, arg.UpdatedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const NotifySecretEvent = `-- name: NotifySecretEvent :exec
+SELECT pg_notify($1::TEXT, $2::TEXT)
+`
+
+type NotifySecretEventParams struct {
+	Channel string `db:"channel"`
+	Payload string `db:"payload"`
+}
+
+func (q *Queries) NotifySecretEvent(ctx context.Context, arg NotifySecretEventParams) error {
+	_, err := q.db.Exec(ctx, NotifySecretEvent, arg.Channel, arg.Payload)
+	return err
+}
+
+const RegisterDevice = `-- name: RegisterDevice :one
+INSERT INTO devices (id, user_id, client_info, first_seen_at, last_seen_at)
+VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (id) DO UPDATE
+SET client_info = EXCLUDED.client_info,
+    last_seen_at = EXCLUDED.last_seen_at
+WHERE devices.user_id = EXCLUDED.user_id
+RETURNING id, user_id, client_info, first_seen_at, last_seen_at, revoked_at
+`
+
+type RegisterDeviceParams struct {
+	ID         uuid.UUID `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	ClientInfo string    `db:"client_info"`
+	SeenAt     time.Time `db:"seen_at"`
+}
+
+func (q *Queries) RegisterDevice(ctx context.Context, arg RegisterDeviceParams) (Device, error) {
+	row := q.db.QueryRow(ctx, RegisterDevice,
+		arg.ID,
+		arg.UserID,
+		arg.ClientInfo,
+		arg.SeenAt,
+	)
+	var i Device
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ClientInfo,
+		&i.FirstSeenAt,
+		&i.LastSeenAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const RevokeDevice = `-- name: RevokeDevice :one
+UPDATE devices
+SET revoked_at = COALESCE(revoked_at, $1)
+WHERE user_id = $2 AND id = $3
+RETURNING id, user_id, client_info, first_seen_at, last_seen_at, revoked_at
+`
+
+type RevokeDeviceParams struct {
+	RevokedAt *time.Time `db:"revoked_at"`
+	UserID    uuid.UUID  `db:"user_id"`
+	ID        uuid.UUID  `db:"id"`
+}
+
+func (q *Queries) RevokeDevice(ctx context.Context, arg RevokeDeviceParams) (Device, error) {
+	row := q.db.QueryRow(ctx, RevokeDevice, arg.RevokedAt, arg.UserID, arg.ID)
+	var i Device
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ClientInfo,
+		&i.FirstSeenAt,
+		&i.LastSeenAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const RevokeDeviceRefreshTokens = `-- name: RevokeDeviceRefreshTokens :execrows
+UPDATE refresh_tokens
+SET revoked_at = $1
+WHERE user_id = $2 AND device_id = $3 AND revoked_at IS NULL
+`
+
+type RevokeDeviceRefreshTokensParams struct {
+	RevokedAt *time.Time `db:"revoked_at"`
+	UserID    uuid.UUID  `db:"user_id"`
+	DeviceID  uuid.UUID  `db:"device_id"`
+}
+
+func (q *Queries) RevokeDeviceRefreshTokens(ctx context.Context, arg RevokeDeviceRefreshTokensParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RevokeDeviceRefreshTokens, arg.RevokedAt, arg.UserID, arg.DeviceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const RevokeRefreshToken = `-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens
+SET revoked_at = $1
+WHERE id = $2 AND revoked_at IS NULL
+`
+
+type RevokeRefreshTokenParams struct {
+	RevokedAt *time.Time `db:"revoked_at"`
+	ID        uuid.UUID  `db:"id"`
+}
+
+func (q *Queries) RevokeRefreshToken(ctx context.Context, arg RevokeRefreshTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RevokeRefreshToken, arg.RevokedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const RevokeUserRefreshTokens = `-- name: RevokeUserRefreshTokens :execrows
+UPDATE refresh_tokens
+SET revoked_at = $1
+WHERE user_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeUserRefreshTokensParams struct {
+	RevokedAt *time.Time `db:"revoked_at"`
+	UserID    uuid.UUID  `db:"user_id"`
+}
+
+func (q *Queries) RevokeUserRefreshTokens(ctx context.Context, arg RevokeUserRefreshTokensParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RevokeUserRefreshTokens, arg.RevokedAt, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const RewrapUserSecretKeys = `-- name: RewrapUserSecretKeys :execrows
+UPDATE secret_versions AS v
+SET secret_dek = k.secret_dek
+FROM (
+    SELECT
+        unnest($2::uuid[]) AS secret_id,
+        unnest($3::uuid[]) AS version_id,
+        unnest($4::bytea[]) AS secret_dek
+) AS k
+WHERE v.user_id = $1 AND v.secret_id = k.secret_id AND v.version_id = k.version_id
+`
+
+type RewrapUserSecretKeysParams struct {
+	UserID     uuid.UUID   `db:"user_id"`
+	SecretIds  []uuid.UUID `db:"secret_ids"`
+	VersionIds []uuid.UUID `db:"version_ids"`
+	SecretDeks [][]byte    `db:"secret_deks"`
+}
+
+func (q *Queries) RewrapUserSecretKeys(ctx context.Context, arg RewrapUserSecretKeysParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RewrapUserSecretKeys,
+		arg.UserID,
+		arg.SecretIds,
+		arg.VersionIds,
+		arg.SecretDeks,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const SaveUserTOTP = `-- name: SaveUserTOTP :execrows
+INSERT INTO user_totp (user_id, secret, enabled, last_step, created_at, updated_at)
+VALUES ($1, $2, FALSE, 0, $3, $4)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret,
+    created_at = EXCLUDED.created_at,
+    updated_at = EXCLUDED.updated_at
+WHERE user_totp.enabled = FALSE
+`
+
+type SaveUserTOTPParams struct {
+	UserID    uuid.UUID `db:"user_id"`
+	Secret    []byte    `db:"secret"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (q *Queries) SaveUserTOTP(ctx context.Context, arg SaveUserTOTPParams) (int64, error) {
+	result, err := q.db.Exec(ctx, SaveUserTOTP,
+		arg.UserID,
+		arg.Secret,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const TouchDevice = `-- name: TouchDevice :one
+UPDATE devices
+SET last_seen_at = GREATEST(last_seen_at, $1)
+WHERE user_id = $2 AND id = $3
+RETURNING id, user_id, client_info, first_seen_at, last_seen_at, revoked_at
+`
+
+type TouchDeviceParams struct {
+	SeenAt time.Time `db:"seen_at"`
+	UserID uuid.UUID `db:"user_id"`
+	ID     uuid.UUID `db:"id"`
+}
+
+func (q *Queries) TouchDevice(ctx context.Context, arg TouchDeviceParams) (Device, error) {
+	row := q.db.QueryRow(ctx, TouchDevice, arg.SeenAt, arg.UserID, arg.ID)
+	var i Device
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ClientInfo,
+		&i.FirstSeenAt,
+		&i.LastSeenAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const TryAdvisoryXactLock = `-- name: TryAdvisoryXactLock :one
+SELECT pg_try_advisory_xact_lock($1::BIGINT)::BOOLEAN AS locked
+`
+
+func (q *Queries) TryAdvisoryXactLock(ctx context.Context, lockKey int64) (bool, error) {
+	row := q.db.QueryRow(ctx, TryAdvisoryXactLock, lockKey)
+	var locked bool
+	err := row.Scan(&locked)
+	return locked, err
+}
+
+const UpdateSecret = `-- name: UpdateSecret :execrows
+UPDATE secrets
+SET current_version_id = $1,
+    updated_at = $2
+WHERE user_id = $3 AND secret_id = $4 AND current_version_id = $5
+`
+
+type UpdateSecretParams struct {
+	VersionID       uuid.UUID `db:"version_id"`
+	UpdatedAt       time.Time `db:"updated_at"`
+	UserID          uuid.UUID `db:"user_id"`
+	SecretID        uuid.UUID `db:"secret_id"`
+	ParentVersionID uuid.UUID `db:"parent_version_id"`
+}
+
+func (q *Queries) UpdateSecret(ctx context.Context, arg UpdateSecretParams) (int64, error) {
+	result, err := q.db.Exec(ctx, UpdateSecret,
+		arg.VersionID,
+		arg.UpdatedAt,
+		arg.UserID,
+		arg.SecretID,
+		arg.ParentVersionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const UpsertSecretMeta = `-- name: UpsertSecretMeta :exec
+INSERT INTO secret_meta (user_id, secret_id, meta, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (user_id, secret_id) DO UPDATE
+SET meta = EXCLUDED.meta,
+    updated_at = EXCLUDED.updated_at
+`
+
+type UpsertSecretMetaParams struct {
+	UserID    uuid.UUID `db:"user_id"`
+	SecretID  uuid.UUID `db:"secret_id"`
+	Meta      []byte    `db:"meta"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (q *Queries) UpsertSecretMeta(ctx context.Context, arg UpsertSecretMetaParams) error {
+	_, err := q.db.Exec(ctx, UpsertSecretMeta,
+		arg.UserID,
+		arg.SecretID,
+		arg.Meta,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const UseUserRecoveryCode = `-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET used_at = $1
+WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL
+`
+
+type UseUserRecoveryCodeParams struct {
+	UsedAt   *time.Time `db:"used_at"`
+	UserID   uuid.UUID  `db:"user_id"`
+	CodeHash []byte     `db:"code_hash"`
+}
+
+func (q *Queries) UseUserRecoveryCode(ctx context.Context, arg UseUserRecoveryCodeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, UseUserRecoveryCode, arg.UsedAt, arg.UserID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
@@ -8,9 +8,46 @@ SET id = users.id,
     updated_at = users.updated_at
 RETURNING id, username, role, created_at, updated_at, password, salt, verifier, bucket_name, identity_id;
 
--- name: CreateUserKey :exec
-INSERT INTO user_crypto_keys (user_id, kek, algorithm, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5);
+-- name: MigrateUserAuth :execrows
+UPDATE users
+SET password = ''::bytea, verifier = @verifier, updated_at = @updated_at
+WHERE id = @id AND password <> ''::bytea;
+
+-- name: DeleteUserKey :exec
+DELETE FROM user_crypto_keys
+WHERE user_id = @user_id;
+
+-- name: ChangeUserPassword :execrows
+UPDATE users
+SET password = ''::bytea, salt = @salt, verifier = @verifier, updated_at = @updated_at
+WHERE id = @id AND verifier = @current_verifier;
+
+-- name: CountUserUploadsInProgress :one
+SELECT COUNT(*)
+FROM secret_requests_in_progress
+WHERE user_id = @user_id AND request_type = 'put' AND expires_at > @now;
+
+-- name: CountUserSecretVersions :one
+SELECT COUNT(*)
+FROM secret_versions
+WHERE user_id = @user_id;
+
+-- name: ListUserSecretKeys :many
+SELECT secret_id, version_id, secret_dek
+FROM secret_versions
+WHERE user_id = @user_id
+ORDER BY id;
+
+-- name: RewrapUserSecretKeys :execrows
+UPDATE secret_versions AS v
+SET secret_dek = k.secret_dek
+FROM (
+    SELECT
+        unnest(@secret_ids::uuid[]) AS secret_id,
+        unnest(@version_ids::uuid[]) AS version_id,
+        unnest(@secret_deks::bytea[]) AS secret_dek
+) AS k
+WHERE v.user_id = @user_id AND v.secret_id = k.secret_id AND v.version_id = k.version_id;
 
 -- name: GetUser :one
 SELECT id, username, role, created_at, updated_at, password, salt, verifier, bucket_name, identity_id
@@ -70,11 +107,124 @@ LIMIT 1;
 INSERT INTO secrets (user_id, secret_id, secret_name, current_version_id, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6);
 
--- name: UpdateSecret :exec
+-- name: UpdateSecret :execrows
 UPDATE secrets
-SET current_version_id = $3,
-    updated_at = NOW()
-WHERE user_id = $1 AND secret_id = $2;
+SET current_version_id = @version_id,
+    updated_at = @updated_at
+WHERE user_id = @user_id AND secret_id = @secret_id AND current_version_id = @parent_version_id;
+
+-- name: CreateSecretVersion :exec
+INSERT INTO secret_versions (
+    user_id,
+    secret_id,
+    version_id,
+    parent_version_id,
+    s3_url,
+    secret_size,
+    secret_hash,
+    secret_dek,
+    created_at
+) VALUES (
+    @user_id,
+    @secret_id,
+    @version_id,
+    NULLIF(@parent_version_id::UUID, '00000000-0000-0000-0000-000000000000'::UUID),
+    @s3_url,
+    @secret_size,
+    @secret_hash,
+    @secret_dek,
+    @created_at
+);
+
+-- name: UpsertSecretMeta :exec
+INSERT INTO secret_meta (user_id, secret_id, meta, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (user_id, secret_id) DO UPDATE
+SET meta = EXCLUDED.meta,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetSecretCurrentVersion :one
+SELECT
+  secrets.secret_id,
+  secrets.secret_name,
+  secret_versions.version_id,
+  secret_versions.parent_version_id,
+  secret_versions.s3_url,
+  secret_versions.secret_size,
+  secret_versions.secret_hash,
+  secret_versions.secret_dek,
+  COALESCE(secret_meta.meta, '{}')::JSONB AS meta,
+  secret_versions.created_at,
+  secrets.current_version_id
+FROM secrets
+JOIN secret_versions
+  ON secret_versions.user_id = secrets.user_id
+  AND secret_versions.secret_id = secrets.secret_id
+  AND secret_versions.version_id = secrets.current_version_id
+LEFT JOIN secret_meta
+  ON secret_meta.user_id = secrets.user_id
+  AND secret_meta.secret_id = secrets.secret_id
+WHERE secrets.user_id = $1 AND secrets.secret_name = $2;
+
+-- name: GetSecretVersion :one
+SELECT
+  secrets.secret_id,
+  secrets.secret_name,
+  secret_versions.version_id,
+  secret_versions.parent_version_id,
+  secret_versions.s3_url,
+  secret_versions.secret_size,
+  secret_versions.secret_hash,
+  secret_versions.secret_dek,
+  COALESCE(secret_meta.meta, '{}')::JSONB AS meta,
+  secret_versions.created_at,
+  secrets.current_version_id
+FROM secrets
+JOIN secret_versions
+  ON secret_versions.user_id = secrets.user_id
+  AND secret_versions.secret_id = secrets.secret_id
+LEFT JOIN secret_meta
+  ON secret_meta.user_id = secrets.user_id
+  AND secret_meta.secret_id = secrets.secret_id
+WHERE secrets.user_id = $1 AND secrets.secret_name = $2 AND secret_versions.version_id = $3;
+
+-- name: ListSecretVersions :many
+SELECT
+  secrets.secret_id,
+  secrets.secret_name,
+  secrets.current_version_id,
+  secret_versions.version_id,
+  secret_versions.parent_version_id,
+  secret_versions.secret_size,
+  secret_versions.secret_hash,
+  secret_versions.created_at
+FROM secrets
+JOIN secret_versions
+  ON secret_versions.user_id = secrets.user_id
+  AND secret_versions.secret_id = secrets.secret_id
+WHERE secrets.user_id = $1 AND secrets.secret_name = $2
+ORDER BY secret_versions.id DESC;
+
+-- name: ListSecrets :many
+SELECT
+  secrets.secret_id,
+  secrets.secret_name,
+  secrets.current_version_id,
+  secret_versions.parent_version_id,
+  secret_versions.secret_size,
+  secret_versions.secret_hash,
+  secrets.created_at,
+  secrets.updated_at
+FROM secrets
+JOIN secret_versions
+  ON secret_versions.user_id = secrets.user_id
+  AND secret_versions.secret_id = secrets.secret_id
+  AND secret_versions.version_id = secrets.current_version_id
+WHERE secrets.user_id = @user_id
+  AND starts_with(secrets.secret_name, @name_prefix::TEXT)
+  AND secrets.secret_name > @after_name::TEXT
+ORDER BY secrets.secret_name
+LIMIT @page_limit;
 
 -- name: CreateSecretInitRequest :one
 WITH candidate(parent_version_id) AS (
@@ -129,6 +279,47 @@ RETURNING
   created_at,
   expires_at;
 
+-- name: GetSecretInitRequest :one
+SELECT
+  user_id,
+  secret_id,
+  secret_name,
+  s3_url,
+  version_id,
+  parent_version_id,
+  request_type,
+  token,
+  client_info,
+  secret_size,
+  secret_hash,
+  secret_dek,
+  meta,
+  created_at,
+  expires_at
+FROM secret_requests_in_progress
+WHERE user_id = $1 AND secret_id = $2;
+
+-- name: LockSecretInitRequest :one
+SELECT
+  user_id,
+  secret_id,
+  secret_name,
+  s3_url,
+  version_id,
+  parent_version_id,
+  request_type,
+  token,
+  client_info,
+  secret_size,
+  secret_hash,
+  secret_dek,
+  meta,
+  created_at,
+  expires_at
+FROM secret_requests_in_progress
+WHERE user_id = $1 AND secret_id = $2
+FOR UPDATE;
+
 -- name: DeleteSecretInitRequest :exec
 DELETE FROM secret_requests_in_progress
 WHERE user_id = $1 AND secret_id = $2;
@@ -154,4 +345,238 @@ INSERT INTO secret_requests_completed (
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8,
     $9, $10, $11, $12, $13, $14, $15, $16
-);
\ No newline at end of file
+);
+-- name: GetSecretForDelete :one
+SELECT secret_id, current_version_id
+FROM secrets
+WHERE user_id = $1 AND secret_name = $2
+FOR UPDATE;
+
+-- name: ListSecretObjects :many
+SELECT s3_url
+FROM secret_versions
+WHERE secret_versions.user_id = $1 AND secret_versions.secret_id = $2
+UNION
+SELECT s3_url
+FROM secret_requests_in_progress
+WHERE secret_requests_in_progress.user_id = $1
+  AND secret_requests_in_progress.secret_id = $2
+  AND secret_requests_in_progress.request_type = 'put';
+
+-- name: DeleteSecret :execrows
+DELETE FROM secrets
+WHERE user_id = @user_id AND secret_id = @secret_id AND current_version_id = @version_id;
+
+-- name: CreateSecretTombstone :exec
+INSERT INTO secret_tombstones (user_id, secret_id, secret_name, version_id, client_info, deleted_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListSecretTombstones :many
+SELECT user_id, secret_id, secret_name, version_id, client_info, deleted_at
+FROM secret_tombstones
+WHERE user_id = @user_id AND deleted_at > @deleted_after
+ORDER BY deleted_at;
+
+-- name: TryAdvisoryXactLock :one
+SELECT pg_try_advisory_xact_lock(@lock_key::BIGINT)::BOOLEAN AS locked;
+
+-- name: ListExpiredSecretInitRequests :many
+SELECT
+  secret_requests_in_progress.user_id,
+  secret_requests_in_progress.secret_id,
+  secret_requests_in_progress.secret_name,
+  secret_requests_in_progress.s3_url,
+  secret_requests_in_progress.version_id,
+  secret_requests_in_progress.parent_version_id,
+  secret_requests_in_progress.request_type,
+  secret_requests_in_progress.token,
+  secret_requests_in_progress.client_info,
+  secret_requests_in_progress.secret_size,
+  secret_requests_in_progress.secret_hash,
+  secret_requests_in_progress.secret_dek,
+  secret_requests_in_progress.meta,
+  secret_requests_in_progress.created_at,
+  secret_requests_in_progress.expires_at,
+  users.bucket_name,
+  (
+    secret_requests_in_progress.request_type = 'put'
+    AND NOT EXISTS (
+      SELECT 1 FROM secret_versions
+      WHERE secret_versions.user_id = secret_requests_in_progress.user_id
+        AND secret_versions.s3_url = secret_requests_in_progress.s3_url
+    )
+  )::BOOLEAN AS orphaned
+FROM secret_requests_in_progress
+JOIN users ON users.id = secret_requests_in_progress.user_id
+WHERE secret_requests_in_progress.expires_at < @expired_before
+ORDER BY secret_requests_in_progress.expires_at
+LIMIT @batch_size
+FOR UPDATE OF secret_requests_in_progress SKIP LOCKED;
+
+-- name: GetSecretInitRequestByObject :one
+SELECT
+  secret_requests_in_progress.user_id,
+  secret_requests_in_progress.secret_id,
+  secret_requests_in_progress.secret_name,
+  secret_requests_in_progress.s3_url,
+  secret_requests_in_progress.version_id,
+  secret_requests_in_progress.parent_version_id,
+  secret_requests_in_progress.request_type,
+  secret_requests_in_progress.token,
+  secret_requests_in_progress.client_info,
+  secret_requests_in_progress.secret_size,
+  secret_requests_in_progress.secret_hash,
+  secret_requests_in_progress.secret_dek,
+  secret_requests_in_progress.meta,
+  secret_requests_in_progress.created_at,
+  secret_requests_in_progress.expires_at,
+  users.bucket_name
+FROM secret_requests_in_progress
+JOIN users ON users.id = secret_requests_in_progress.user_id
+WHERE users.bucket_name = @bucket_name
+  AND secret_requests_in_progress.s3_url = @s3_url
+  AND secret_requests_in_progress.request_type = 'put';
+
+-- name: GetSecretCurrentVersionID :one
+SELECT current_version_id
+FROM secrets
+WHERE user_id = $1 AND secret_id = $2;
+
+-- name: CreateSecretEvent :one
+INSERT INTO secret_events (user_id, secret_id, secret_name, version_id, event_type, client_info, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id;
+
+-- name: NotifySecretEvent :exec
+SELECT pg_notify(@channel::TEXT, @payload::TEXT);
+
+-- name: ListSecretEvents :many
+SELECT id, user_id, secret_id, secret_name, version_id, event_type, client_info, created_at
+FROM secret_events
+WHERE user_id = @user_id AND id > @after_id
+ORDER BY id
+LIMIT @batch_size;
+
+-- name: ListSecretChunks :many
+SELECT chunk_id
+FROM secret_chunks
+WHERE user_id = @user_id AND chunk_id = ANY(@chunk_ids::TEXT[]);
+
+-- name: CreateSecretChunks :exec
+INSERT INTO secret_chunks (user_id, chunk_id, chunk_size, created_at)
+SELECT @user_id, UNNEST(@chunk_ids::TEXT[]), UNNEST(@chunk_sizes::BIGINT[]), @created_at
+ON CONFLICT (user_id, chunk_id) DO NOTHING;
+
+-- name: CreateSecretVersionChunks :exec
+INSERT INTO secret_version_chunks (user_id, secret_id, version_id, chunk_id)
+SELECT @user_id, @secret_id, @version_id, UNNEST(@chunk_ids::TEXT[])
+ON CONFLICT (user_id, version_id, chunk_id) DO NOTHING;
+
+-- name: DeleteUnreferencedSecretChunks :many
+DELETE FROM secret_chunks
+WHERE secret_chunks.user_id = @user_id
+  AND NOT EXISTS (
+    SELECT 1 FROM secret_version_chunks
+    WHERE secret_version_chunks.user_id = secret_chunks.user_id
+      AND secret_version_chunks.chunk_id = secret_chunks.chunk_id
+  )
+RETURNING chunk_id;
+
+-- name: HasChunkedSecretUploads :one
+SELECT EXISTS (
+  SELECT 1 FROM secret_requests_in_progress
+  WHERE user_id = @user_id
+    AND request_type = 'put'
+    AND meta->>'storage' = 'chunked'
+)::BOOLEAN AS in_progress;
+
+-- name: LockSecretChunks :exec
+SELECT pg_advisory_xact_lock(hashtextextended(CAST(@user_id::UUID AS TEXT), 0));
+
+-- name: RegisterDevice :one
+INSERT INTO devices (id, user_id, client_info, first_seen_at, last_seen_at)
+VALUES (@id, @user_id, @client_info, @seen_at, @seen_at)
+ON CONFLICT (id) DO UPDATE
+SET client_info = EXCLUDED.client_info,
+    last_seen_at = EXCLUDED.last_seen_at
+WHERE devices.user_id = EXCLUDED.user_id
+RETURNING *;
+
+-- name: TouchDevice :one
+UPDATE devices
+SET last_seen_at = GREATEST(last_seen_at, @seen_at)
+WHERE user_id = @user_id AND id = @id
+RETURNING *;
+
+-- name: ListDevices :many
+SELECT *
+FROM devices
+WHERE user_id = @user_id
+ORDER BY first_seen_at;
+
+-- name: RevokeDevice :one
+UPDATE devices
+SET revoked_at = COALESCE(revoked_at, @revoked_at)
+WHERE user_id = @user_id AND id = @id
+RETURNING *;
+
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (id, user_id, device_id, token_hash, created_at, expires_at)
+VALUES (@id, @user_id, @device_id, @token_hash, @created_at, @expires_at);
+
+-- name: GetRefreshToken :one
+SELECT *
+FROM refresh_tokens
+WHERE token_hash = @token_hash;
+
+-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens
+SET revoked_at = @revoked_at
+WHERE id = @id AND revoked_at IS NULL;
+
+-- name: RevokeDeviceRefreshTokens :execrows
+UPDATE refresh_tokens
+SET revoked_at = @revoked_at
+WHERE user_id = @user_id AND device_id = @device_id AND revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :execrows
+UPDATE refresh_tokens
+SET revoked_at = @revoked_at
+WHERE user_id = @user_id AND revoked_at IS NULL;
+
+-- name: SaveUserTOTP :execrows
+INSERT INTO user_totp (user_id, secret, enabled, last_step, created_at, updated_at)
+VALUES (@user_id, @secret, FALSE, 0, @created_at, @updated_at)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret,
+    created_at = EXCLUDED.created_at,
+    updated_at = EXCLUDED.updated_at
+WHERE user_totp.enabled = FALSE;
+
+-- name: GetUserTOTP :one
+SELECT *
+FROM user_totp
+WHERE user_id = @user_id;
+
+-- name: EnableUserTOTP :execrows
+UPDATE user_totp
+SET enabled = TRUE, last_step = @last_step, updated_at = @updated_at
+WHERE user_id = @user_id AND enabled = FALSE;
+
+-- name: AcceptUserTOTPStep :execrows
+UPDATE user_totp
+SET last_step = @last_step, updated_at = @updated_at
+WHERE user_id = @user_id AND enabled = TRUE AND last_step < @last_step;
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = @user_id;
+
+-- name: CreateUserRecoveryCode :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+VALUES (@user_id, @code_hash);
+
+-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET used_at = @used_at
+WHERE user_id = @user_id AND code_hash = @code_hash AND used_at IS NULL;
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: server/internal/auth/device.go
+//
+// Generated by this command:
+//
+//	mockgen -source=server/internal/auth/device.go -destination=server/internal/mock/device.go -package=mock DeviceVerifier
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeviceVerifier is a mock of DeviceVerifier interface.
+type MockDeviceVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceVerifierMockRecorder is the mock recorder for MockDeviceVerifier.
+type MockDeviceVerifierMockRecorder struct {
+	mock *MockDeviceVerifier
+}
+
+// NewMockDeviceVerifier creates a new mock instance.
+func NewMockDeviceVerifier(ctrl *gomock.Controller) *MockDeviceVerifier {
+	mock := &MockDeviceVerifier{ctrl: ctrl}
+	mock.recorder = &MockDeviceVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceVerifier) EXPECT() *MockDeviceVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyDevice mocks base method.
+func (m *MockDeviceVerifier) VerifyDevice(ctx context.Context, userID, deviceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyDevice", ctx, userID, deviceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyDevice indicates an expected call of VerifyDevice.
+func (mr *MockDeviceVerifierMockRecorder) VerifyDevice(ctx, userID, deviceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyDevice", reflect.TypeOf((*MockDeviceVerifier)(nil).VerifyDevice), ctx, userID, deviceID)
+}
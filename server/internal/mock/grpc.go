@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=server/internal/grpchandler/adapters.go -destination=server/internal/mock/grpc.go -package=mock SecretServiceServer
+//	mockgen -source=server/internal/grpchandler/adapters.go -destination=server/internal/mock/grpc.go -package=mock
 //
 
 // Package mock is a generated GoMock package.
@@ -15,6 +15,7 @@ import (
 
 	proto "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
 	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
 )
 
 // MockAdminServiceServer is a mock of AdminServiceServer interface.
@@ -80,6 +81,81 @@ func (m *MockUserServiceServer) EXPECT() *MockUserServiceServerMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockUserServiceServer) ChangePassword(ctx context.Context, r *proto.ChangePasswordRequest) (*proto.ChangePasswordResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, r)
+	ret0, _ := ret[0].(*proto.ChangePasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUserServiceServerMockRecorder) ChangePassword(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserServiceServer)(nil).ChangePassword), ctx, r)
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockUserServiceServer) ConfirmTOTP(ctx context.Context, r *proto.ConfirmTOTPRequest) (*proto.ConfirmTOTPResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, r)
+	ret0, _ := ret[0].(*proto.ConfirmTOTPResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockUserServiceServerMockRecorder) ConfirmTOTP(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockUserServiceServer)(nil).ConfirmTOTP), ctx, r)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockUserServiceServer) EnrollTOTP(ctx context.Context, r *proto.EnrollTOTPRequest) (*proto.EnrollTOTPResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", ctx, r)
+	ret0, _ := ret[0].(*proto.EnrollTOTPResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockUserServiceServerMockRecorder) EnrollTOTP(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockUserServiceServer)(nil).EnrollTOTP), ctx, r)
+}
+
+// GetLoginParams mocks base method.
+func (m *MockUserServiceServer) GetLoginParams(ctx context.Context, r *proto.GetLoginParamsRequest) (*proto.GetLoginParamsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginParams", ctx, r)
+	ret0, _ := ret[0].(*proto.GetLoginParamsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginParams indicates an expected call of GetLoginParams.
+func (mr *MockUserServiceServerMockRecorder) GetLoginParams(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginParams", reflect.TypeOf((*MockUserServiceServer)(nil).GetLoginParams), ctx, r)
+}
+
+// ListDevices mocks base method.
+func (m *MockUserServiceServer) ListDevices(ctx context.Context, r *proto.ListDevicesRequest) (*proto.ListDevicesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDevices", ctx, r)
+	ret0, _ := ret[0].(*proto.ListDevicesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDevices indicates an expected call of ListDevices.
+func (mr *MockUserServiceServerMockRecorder) ListDevices(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDevices", reflect.TypeOf((*MockUserServiceServer)(nil).ListDevices), ctx, r)
+}
+
 // Login mocks base method.
 func (m *MockUserServiceServer) Login(ctx context.Context, r *proto.LoginRequest) (*proto.LoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +171,36 @@ func (mr *MockUserServiceServerMockRecorder) Login(ctx, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServiceServer)(nil).Login), ctx, r)
 }
 
+// Logout mocks base method.
+func (m *MockUserServiceServer) Logout(ctx context.Context, r *proto.LogoutRequest) (*proto.LogoutResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, r)
+	ret0, _ := ret[0].(*proto.LogoutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserServiceServerMockRecorder) Logout(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserServiceServer)(nil).Logout), ctx, r)
+}
+
+// RefreshToken mocks base method.
+func (m *MockUserServiceServer) RefreshToken(ctx context.Context, r *proto.RefreshTokenRequest) (*proto.RefreshTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, r)
+	ret0, _ := ret[0].(*proto.RefreshTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockUserServiceServerMockRecorder) RefreshToken(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockUserServiceServer)(nil).RefreshToken), ctx, r)
+}
+
 // Register mocks base method.
 func (m *MockUserServiceServer) Register(ctx context.Context, r *proto.RegisterRequest) (*proto.RegisterResponse, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +216,21 @@ func (mr *MockUserServiceServerMockRecorder) Register(ctx, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserServiceServer)(nil).Register), ctx, r)
 }
 
+// RevokeDevice mocks base method.
+func (m *MockUserServiceServer) RevokeDevice(ctx context.Context, r *proto.RevokeDeviceRequest) (*proto.RevokeDeviceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeDevice", ctx, r)
+	ret0, _ := ret[0].(*proto.RevokeDeviceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeDevice indicates an expected call of RevokeDevice.
+func (mr *MockUserServiceServerMockRecorder) RevokeDevice(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeDevice", reflect.TypeOf((*MockUserServiceServer)(nil).RevokeDevice), ctx, r)
+}
+
 // MockSecretServiceServer is a mock of SecretServiceServer interface.
 type MockSecretServiceServer struct {
 	ctrl     *gomock.Controller
@@ -134,32 +255,166 @@ func (m *MockSecretServiceServer) EXPECT() *MockSecretServiceServerMockRecorder
 	return m.recorder
 }
 
+// GetSecretVersion mocks base method.
+func (m *MockSecretServiceServer) GetSecretVersion(ctx context.Context, req *proto.GetSecretVersionRequest) (*proto.GetSecretVersionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretVersion", ctx, req)
+	ret0, _ := ret[0].(*proto.GetSecretVersionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretVersion indicates an expected call of GetSecretVersion.
+func (mr *MockSecretServiceServerMockRecorder) GetSecretVersion(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretVersion", reflect.TypeOf((*MockSecretServiceServer)(nil).GetSecretVersion), ctx, req)
+}
+
+// ListSecretKeys mocks base method.
+func (m *MockSecretServiceServer) ListSecretKeys(ctx context.Context, req *proto.ListSecretKeysRequest) (*proto.ListSecretKeysResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretKeys", ctx, req)
+	ret0, _ := ret[0].(*proto.ListSecretKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretKeys indicates an expected call of ListSecretKeys.
+func (mr *MockSecretServiceServerMockRecorder) ListSecretKeys(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretKeys", reflect.TypeOf((*MockSecretServiceServer)(nil).ListSecretKeys), ctx, req)
+}
+
+// ListSecretTombstones mocks base method.
+func (m *MockSecretServiceServer) ListSecretTombstones(ctx context.Context, req *proto.ListSecretTombstonesRequest) (*proto.ListSecretTombstonesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretTombstones", ctx, req)
+	ret0, _ := ret[0].(*proto.ListSecretTombstonesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretTombstones indicates an expected call of ListSecretTombstones.
+func (mr *MockSecretServiceServerMockRecorder) ListSecretTombstones(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretTombstones", reflect.TypeOf((*MockSecretServiceServer)(nil).ListSecretTombstones), ctx, req)
+}
+
+// ListSecretVersions mocks base method.
+func (m *MockSecretServiceServer) ListSecretVersions(ctx context.Context, req *proto.ListSecretVersionsRequest) (*proto.ListSecretVersionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretVersions", ctx, req)
+	ret0, _ := ret[0].(*proto.ListSecretVersionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretVersions indicates an expected call of ListSecretVersions.
+func (mr *MockSecretServiceServerMockRecorder) ListSecretVersions(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretVersions", reflect.TypeOf((*MockSecretServiceServer)(nil).ListSecretVersions), ctx, req)
+}
+
+// ListSecrets mocks base method.
+func (m *MockSecretServiceServer) ListSecrets(ctx context.Context, req *proto.ListSecretsRequest) (*proto.ListSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", ctx, req)
+	ret0, _ := ret[0].(*proto.ListSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MockSecretServiceServerMockRecorder) ListSecrets(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockSecretServiceServer)(nil).ListSecrets), ctx, req)
+}
+
+// RenewUploadCredentials mocks base method.
+func (m *MockSecretServiceServer) RenewUploadCredentials(ctx context.Context, req *proto.RenewUploadCredentialsRequest) (*proto.RenewUploadCredentialsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewUploadCredentials", ctx, req)
+	ret0, _ := ret[0].(*proto.RenewUploadCredentialsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewUploadCredentials indicates an expected call of RenewUploadCredentials.
+func (mr *MockSecretServiceServerMockRecorder) RenewUploadCredentials(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewUploadCredentials", reflect.TypeOf((*MockSecretServiceServer)(nil).RenewUploadCredentials), ctx, req)
+}
+
+// SecretDelete mocks base method.
+func (m *MockSecretServiceServer) SecretDelete(ctx context.Context, req *proto.SecretDeleteRequest) (*proto.SecretDeleteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretDelete", ctx, req)
+	ret0, _ := ret[0].(*proto.SecretDeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretDelete indicates an expected call of SecretDelete.
+func (mr *MockSecretServiceServerMockRecorder) SecretDelete(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretDelete", reflect.TypeOf((*MockSecretServiceServer)(nil).SecretDelete), ctx, req)
+}
+
+// SecretGetInit mocks base method.
+func (m *MockSecretServiceServer) SecretGetInit(ctx context.Context, req *proto.SecretGetInitRequest) (*proto.SecretGetInitResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretGetInit", ctx, req)
+	ret0, _ := ret[0].(*proto.SecretGetInitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretGetInit indicates an expected call of SecretGetInit.
+func (mr *MockSecretServiceServerMockRecorder) SecretGetInit(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretGetInit", reflect.TypeOf((*MockSecretServiceServer)(nil).SecretGetInit), ctx, req)
+}
+
 // SecretUpdateCommit mocks base method.
-func (m *MockSecretServiceServer) SecretUpdateCommit(arg0 context.Context, arg1 *proto.SecretUpdateCommitRequest) (*proto.SecretUpdateCommitResponse, error) {
+func (m *MockSecretServiceServer) SecretUpdateCommit(ctx context.Context, req *proto.SecretUpdateCommitRequest) (*proto.SecretUpdateCommitResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SecretUpdateCommit", arg0, arg1)
+	ret := m.ctrl.Call(m, "SecretUpdateCommit", ctx, req)
 	ret0, _ := ret[0].(*proto.SecretUpdateCommitResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SecretUpdateCommit indicates an expected call of SecretUpdateCommit.
-func (mr *MockSecretServiceServerMockRecorder) SecretUpdateCommit(arg0, arg1 any) *gomock.Call {
+func (mr *MockSecretServiceServerMockRecorder) SecretUpdateCommit(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretUpdateCommit", reflect.TypeOf((*MockSecretServiceServer)(nil).SecretUpdateCommit), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretUpdateCommit", reflect.TypeOf((*MockSecretServiceServer)(nil).SecretUpdateCommit), ctx, req)
 }
 
 // SecretUpdateInit mocks base method.
-func (m *MockSecretServiceServer) SecretUpdateInit(arg0 context.Context, arg1 *proto.SecretUpdateInitRequest) (*proto.SecretUpdateInitResponse, error) {
+func (m *MockSecretServiceServer) SecretUpdateInit(ctx context.Context, req *proto.SecretUpdateInitRequest) (*proto.SecretUpdateInitResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SecretUpdateInit", arg0, arg1)
+	ret := m.ctrl.Call(m, "SecretUpdateInit", ctx, req)
 	ret0, _ := ret[0].(*proto.SecretUpdateInitResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SecretUpdateInit indicates an expected call of SecretUpdateInit.
-func (mr *MockSecretServiceServerMockRecorder) SecretUpdateInit(arg0, arg1 any) *gomock.Call {
+func (mr *MockSecretServiceServerMockRecorder) SecretUpdateInit(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretUpdateInit", reflect.TypeOf((*MockSecretServiceServer)(nil).SecretUpdateInit), ctx, req)
+}
+
+// WatchSecrets mocks base method.
+func (m *MockSecretServiceServer) WatchSecrets(req *proto.WatchSecretsRequest, stream grpc.ServerStreamingServer[proto.SecretEvent]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchSecrets", req, stream)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchSecrets indicates an expected call of WatchSecrets.
+func (mr *MockSecretServiceServerMockRecorder) WatchSecrets(req, stream any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretUpdateInit", reflect.TypeOf((*MockSecretServiceServer)(nil).SecretUpdateInit), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchSecrets", reflect.TypeOf((*MockSecretServiceServer)(nil).WatchSecrets), req, stream)
 }
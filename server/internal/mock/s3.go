@@ -11,6 +11,7 @@ package mock
 
 import (
 	context "context"
+	io "io"
 	url "net/url"
 	reflect "reflect"
 	time "time"
@@ -86,18 +87,154 @@ func (mr *MockBucketManagerMockRecorder) RemoveBucket(ctx, bucketName any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBucket", reflect.TypeOf((*MockBucketManager)(nil).RemoveBucket), ctx, bucketName)
 }
 
-// SetBucketNotification mocks base method.
-func (m *MockBucketManager) SetBucketNotification(ctx context.Context, bucketName string) error {
+// MockObjectManager is a mock of ObjectManager interface.
+type MockObjectManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockObjectManagerMockRecorder is the mock recorder for MockObjectManager.
+type MockObjectManagerMockRecorder struct {
+	mock *MockObjectManager
+}
+
+// NewMockObjectManager creates a new mock instance.
+func NewMockObjectManager(ctrl *gomock.Controller) *MockObjectManager {
+	mock := &MockObjectManager{ctrl: ctrl}
+	mock.recorder = &MockObjectManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectManager) EXPECT() *MockObjectManagerMockRecorder {
+	return m.recorder
+}
+
+// AbortMultipartUploads mocks base method.
+func (m *MockObjectManager) AbortMultipartUploads(ctx context.Context, bucketName, objectKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipartUploads", ctx, bucketName, objectKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortMultipartUploads indicates an expected call of AbortMultipartUploads.
+func (mr *MockObjectManagerMockRecorder) AbortMultipartUploads(ctx, bucketName, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUploads", reflect.TypeOf((*MockObjectManager)(nil).AbortMultipartUploads), ctx, bucketName, objectKey)
+}
+
+// ListObjects mocks base method.
+func (m *MockObjectManager) ListObjects(ctx context.Context, bucketName, prefix string) ([]s3.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjects", ctx, bucketName, prefix)
+	ret0, _ := ret[0].([]s3.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockObjectManagerMockRecorder) ListObjects(ctx, bucketName, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockObjectManager)(nil).ListObjects), ctx, bucketName, prefix)
+}
+
+// ObjectChecksum mocks base method.
+func (m *MockObjectManager) ObjectChecksum(ctx context.Context, bucketName, objectKey string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ObjectChecksum", ctx, bucketName, objectKey)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectChecksum indicates an expected call of ObjectChecksum.
+func (mr *MockObjectManagerMockRecorder) ObjectChecksum(ctx, bucketName, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectChecksum", reflect.TypeOf((*MockObjectManager)(nil).ObjectChecksum), ctx, bucketName, objectKey)
+}
+
+// ReadObject mocks base method.
+func (m *MockObjectManager) ReadObject(ctx context.Context, bucketName, objectKey string, maxSize int64) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetBucketNotification", ctx, bucketName)
+	ret := m.ctrl.Call(m, "ReadObject", ctx, bucketName, objectKey, maxSize)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadObject indicates an expected call of ReadObject.
+func (mr *MockObjectManagerMockRecorder) ReadObject(ctx, bucketName, objectKey, maxSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadObject", reflect.TypeOf((*MockObjectManager)(nil).ReadObject), ctx, bucketName, objectKey, maxSize)
+}
+
+// RemoveObjects mocks base method.
+func (m *MockObjectManager) RemoveObjects(ctx context.Context, bucketName string, objectKeys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveObjects", ctx, bucketName, objectKeys)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SetBucketNotification indicates an expected call of SetBucketNotification.
-func (mr *MockBucketManagerMockRecorder) SetBucketNotification(ctx, bucketName any) *gomock.Call {
+// RemoveObjects indicates an expected call of RemoveObjects.
+func (mr *MockObjectManagerMockRecorder) RemoveObjects(ctx, bucketName, objectKeys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveObjects", reflect.TypeOf((*MockObjectManager)(nil).RemoveObjects), ctx, bucketName, objectKeys)
+}
+
+// StatObject mocks base method.
+func (m *MockObjectManager) StatObject(ctx context.Context, bucketName, objectKey string) (s3.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatObject", ctx, bucketName, objectKey)
+	ret0, _ := ret[0].(s3.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatObject indicates an expected call of StatObject.
+func (mr *MockObjectManagerMockRecorder) StatObject(ctx, bucketName, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatObject", reflect.TypeOf((*MockObjectManager)(nil).StatObject), ctx, bucketName, objectKey)
+}
+
+// MockEventListener is a mock of EventListener interface.
+type MockEventListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventListenerMockRecorder
+	isgomock struct{}
+}
+
+// MockEventListenerMockRecorder is the mock recorder for MockEventListener.
+type MockEventListenerMockRecorder struct {
+	mock *MockEventListener
+}
+
+// NewMockEventListener creates a new mock instance.
+func NewMockEventListener(ctrl *gomock.Controller) *MockEventListener {
+	mock := &MockEventListener{ctrl: ctrl}
+	mock.recorder = &MockEventListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventListener) EXPECT() *MockEventListenerMockRecorder {
+	return m.recorder
+}
+
+// ListenObjectCreated mocks base method.
+func (m *MockEventListener) ListenObjectCreated(ctx context.Context) <-chan s3.ObjectEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenObjectCreated", ctx)
+	ret0, _ := ret[0].(<-chan s3.ObjectEvent)
+	return ret0
+}
+
+// ListenObjectCreated indicates an expected call of ListenObjectCreated.
+func (mr *MockEventListenerMockRecorder) ListenObjectCreated(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBucketNotification", reflect.TypeOf((*MockBucketManager)(nil).SetBucketNotification), ctx, bucketName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenObjectCreated", reflect.TypeOf((*MockEventListener)(nil).ListenObjectCreated), ctx)
 }
 
 // MockURLManager is a mock of URLManager interface.
@@ -177,6 +314,21 @@ func (mr *MockSecurityManagerMockRecorder) AddCannedPolicy(ctx, name, policyJSON
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCannedPolicy", reflect.TypeOf((*MockSecurityManager)(nil).AddCannedPolicy), ctx, name, policyJSON)
 }
 
+// AssumeReadOnlyRole mocks base method.
+func (m *MockSecurityManager) AssumeReadOnlyRole(ctx context.Context, identityToken, bucketName string, durationSeconds int) (*s3.TemporaryCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssumeReadOnlyRole", ctx, identityToken, bucketName, durationSeconds)
+	ret0, _ := ret[0].(*s3.TemporaryCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssumeReadOnlyRole indicates an expected call of AssumeReadOnlyRole.
+func (mr *MockSecurityManagerMockRecorder) AssumeReadOnlyRole(ctx, identityToken, bucketName, durationSeconds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssumeReadOnlyRole", reflect.TypeOf((*MockSecurityManager)(nil).AssumeReadOnlyRole), ctx, identityToken, bucketName, durationSeconds)
+}
+
 // AssumeRole mocks base method.
 func (m *MockSecurityManager) AssumeRole(ctx context.Context, identityToken string, durationSeconds int) (*s3.TemporaryCredentials, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +368,20 @@ func (m *MockServerOperator) EXPECT() *MockServerOperatorMockRecorder {
 	return m.recorder
 }
 
+// AbortMultipartUploads mocks base method.
+func (m *MockServerOperator) AbortMultipartUploads(ctx context.Context, bucketName, objectKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipartUploads", ctx, bucketName, objectKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortMultipartUploads indicates an expected call of AbortMultipartUploads.
+func (mr *MockServerOperatorMockRecorder) AbortMultipartUploads(ctx, bucketName, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUploads", reflect.TypeOf((*MockServerOperator)(nil).AbortMultipartUploads), ctx, bucketName, objectKey)
+}
+
 // AddCannedPolicy mocks base method.
 func (m *MockServerOperator) AddCannedPolicy(ctx context.Context, name string, policyJSON []byte) error {
 	m.ctrl.T.Helper()
@@ -230,6 +396,21 @@ func (mr *MockServerOperatorMockRecorder) AddCannedPolicy(ctx, name, policyJSON
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCannedPolicy", reflect.TypeOf((*MockServerOperator)(nil).AddCannedPolicy), ctx, name, policyJSON)
 }
 
+// AssumeReadOnlyRole mocks base method.
+func (m *MockServerOperator) AssumeReadOnlyRole(ctx context.Context, identityToken, bucketName string, durationSeconds int) (*s3.TemporaryCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssumeReadOnlyRole", ctx, identityToken, bucketName, durationSeconds)
+	ret0, _ := ret[0].(*s3.TemporaryCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssumeReadOnlyRole indicates an expected call of AssumeReadOnlyRole.
+func (mr *MockServerOperatorMockRecorder) AssumeReadOnlyRole(ctx, identityToken, bucketName, durationSeconds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssumeReadOnlyRole", reflect.TypeOf((*MockServerOperator)(nil).AssumeReadOnlyRole), ctx, identityToken, bucketName, durationSeconds)
+}
+
 // AssumeRole mocks base method.
 func (m *MockServerOperator) AssumeRole(ctx context.Context, identityToken string, durationSeconds int) (*s3.TemporaryCredentials, error) {
 	m.ctrl.T.Helper()
@@ -275,6 +456,21 @@ func (mr *MockServerOperatorMockRecorder) GeneratePresignedPutURL(ctx, bucketNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePresignedPutURL", reflect.TypeOf((*MockServerOperator)(nil).GeneratePresignedPutURL), ctx, bucketName, objectKey, expiry)
 }
 
+// ListObjects mocks base method.
+func (m *MockServerOperator) ListObjects(ctx context.Context, bucketName, prefix string) ([]s3.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjects", ctx, bucketName, prefix)
+	ret0, _ := ret[0].([]s3.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockServerOperatorMockRecorder) ListObjects(ctx, bucketName, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockServerOperator)(nil).ListObjects), ctx, bucketName, prefix)
+}
+
 // MakeBucket mocks base method.
 func (m *MockServerOperator) MakeBucket(ctx context.Context, bucketName string, tags map[string]string) error {
 	m.ctrl.T.Helper()
@@ -289,6 +485,36 @@ func (mr *MockServerOperatorMockRecorder) MakeBucket(ctx, bucketName, tags any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeBucket", reflect.TypeOf((*MockServerOperator)(nil).MakeBucket), ctx, bucketName, tags)
 }
 
+// ObjectChecksum mocks base method.
+func (m *MockServerOperator) ObjectChecksum(ctx context.Context, bucketName, objectKey string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ObjectChecksum", ctx, bucketName, objectKey)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectChecksum indicates an expected call of ObjectChecksum.
+func (mr *MockServerOperatorMockRecorder) ObjectChecksum(ctx, bucketName, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectChecksum", reflect.TypeOf((*MockServerOperator)(nil).ObjectChecksum), ctx, bucketName, objectKey)
+}
+
+// ReadObject mocks base method.
+func (m *MockServerOperator) ReadObject(ctx context.Context, bucketName, objectKey string, maxSize int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadObject", ctx, bucketName, objectKey, maxSize)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadObject indicates an expected call of ReadObject.
+func (mr *MockServerOperatorMockRecorder) ReadObject(ctx, bucketName, objectKey, maxSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadObject", reflect.TypeOf((*MockServerOperator)(nil).ReadObject), ctx, bucketName, objectKey, maxSize)
+}
+
 // RemoveBucket mocks base method.
 func (m *MockServerOperator) RemoveBucket(ctx context.Context, bucketName string) error {
 	m.ctrl.T.Helper()
@@ -303,18 +529,33 @@ func (mr *MockServerOperatorMockRecorder) RemoveBucket(ctx, bucketName any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBucket", reflect.TypeOf((*MockServerOperator)(nil).RemoveBucket), ctx, bucketName)
 }
 
-// SetBucketNotification mocks base method.
-func (m *MockServerOperator) SetBucketNotification(ctx context.Context, bucketName string) error {
+// RemoveObjects mocks base method.
+func (m *MockServerOperator) RemoveObjects(ctx context.Context, bucketName string, objectKeys []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetBucketNotification", ctx, bucketName)
+	ret := m.ctrl.Call(m, "RemoveObjects", ctx, bucketName, objectKeys)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SetBucketNotification indicates an expected call of SetBucketNotification.
-func (mr *MockServerOperatorMockRecorder) SetBucketNotification(ctx, bucketName any) *gomock.Call {
+// RemoveObjects indicates an expected call of RemoveObjects.
+func (mr *MockServerOperatorMockRecorder) RemoveObjects(ctx, bucketName, objectKeys any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBucketNotification", reflect.TypeOf((*MockServerOperator)(nil).SetBucketNotification), ctx, bucketName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveObjects", reflect.TypeOf((*MockServerOperator)(nil).RemoveObjects), ctx, bucketName, objectKeys)
+}
+
+// StatObject mocks base method.
+func (m *MockServerOperator) StatObject(ctx context.Context, bucketName, objectKey string) (s3.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatObject", ctx, bucketName, objectKey)
+	ret0, _ := ret[0].(s3.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatObject indicates an expected call of StatObject.
+func (mr *MockServerOperatorMockRecorder) StatObject(ctx, bucketName, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatObject", reflect.TypeOf((*MockServerOperator)(nil).StatObject), ctx, bucketName, objectKey)
 }
 
 // MockClientOperator is a mock of ClientOperator interface.
@@ -369,3 +610,154 @@ func (mr *MockClientOperatorMockRecorder) PutObject(ctx, bucketName, objectName,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockClientOperator)(nil).PutObject), ctx, bucketName, objectName, filePath, opts)
 }
+
+// MockMultipartOperator is a mock of MultipartOperator interface.
+type MockMultipartOperator struct {
+	ctrl     *gomock.Controller
+	recorder *MockMultipartOperatorMockRecorder
+	isgomock struct{}
+}
+
+// MockMultipartOperatorMockRecorder is the mock recorder for MockMultipartOperator.
+type MockMultipartOperatorMockRecorder struct {
+	mock *MockMultipartOperator
+}
+
+// NewMockMultipartOperator creates a new mock instance.
+func NewMockMultipartOperator(ctrl *gomock.Controller) *MockMultipartOperator {
+	mock := &MockMultipartOperator{ctrl: ctrl}
+	mock.recorder = &MockMultipartOperatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMultipartOperator) EXPECT() *MockMultipartOperatorMockRecorder {
+	return m.recorder
+}
+
+// AbortMultipartUpload mocks base method.
+func (m *MockMultipartOperator) AbortMultipartUpload(ctx context.Context, bucketName, objectName, uploadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipartUpload", ctx, bucketName, objectName, uploadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortMultipartUpload indicates an expected call of AbortMultipartUpload.
+func (mr *MockMultipartOperatorMockRecorder) AbortMultipartUpload(ctx, bucketName, objectName, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUpload", reflect.TypeOf((*MockMultipartOperator)(nil).AbortMultipartUpload), ctx, bucketName, objectName, uploadID)
+}
+
+// CompleteMultipartUpload mocks base method.
+func (m *MockMultipartOperator) CompleteMultipartUpload(ctx context.Context, bucketName, objectName, uploadID string, parts []s3.CompletePart) (s3.UploadInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMultipartUpload", ctx, bucketName, objectName, uploadID, parts)
+	ret0, _ := ret[0].(s3.UploadInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMultipartUpload indicates an expected call of CompleteMultipartUpload.
+func (mr *MockMultipartOperatorMockRecorder) CompleteMultipartUpload(ctx, bucketName, objectName, uploadID, parts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipartUpload", reflect.TypeOf((*MockMultipartOperator)(nil).CompleteMultipartUpload), ctx, bucketName, objectName, uploadID, parts)
+}
+
+// GetObjectRange mocks base method.
+func (m *MockMultipartOperator) GetObjectRange(ctx context.Context, bucketName, objectName string, offset, length int64, dest io.Writer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectRange", ctx, bucketName, objectName, offset, length, dest)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectRange indicates an expected call of GetObjectRange.
+func (mr *MockMultipartOperatorMockRecorder) GetObjectRange(ctx, bucketName, objectName, offset, length, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectRange", reflect.TypeOf((*MockMultipartOperator)(nil).GetObjectRange), ctx, bucketName, objectName, offset, length, dest)
+}
+
+// NewMultipartUpload mocks base method.
+func (m *MockMultipartOperator) NewMultipartUpload(ctx context.Context, bucketName, objectName string, opts s3.PutObjectOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewMultipartUpload", ctx, bucketName, objectName, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewMultipartUpload indicates an expected call of NewMultipartUpload.
+func (mr *MockMultipartOperatorMockRecorder) NewMultipartUpload(ctx, bucketName, objectName, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMultipartUpload", reflect.TypeOf((*MockMultipartOperator)(nil).NewMultipartUpload), ctx, bucketName, objectName, opts)
+}
+
+// PutObjectPart mocks base method.
+func (m *MockMultipartOperator) PutObjectPart(ctx context.Context, bucketName, objectName, uploadID string, partNumber int, data io.Reader, size int64, checksum []byte) (s3.ObjectPart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObjectPart", ctx, bucketName, objectName, uploadID, partNumber, data, size, checksum)
+	ret0, _ := ret[0].(s3.ObjectPart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutObjectPart indicates an expected call of PutObjectPart.
+func (mr *MockMultipartOperatorMockRecorder) PutObjectPart(ctx, bucketName, objectName, uploadID, partNumber, data, size, checksum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObjectPart", reflect.TypeOf((*MockMultipartOperator)(nil).PutObjectPart), ctx, bucketName, objectName, uploadID, partNumber, data, size, checksum)
+}
+
+// MockChunkOperator is a mock of ChunkOperator interface.
+type MockChunkOperator struct {
+	ctrl     *gomock.Controller
+	recorder *MockChunkOperatorMockRecorder
+	isgomock struct{}
+}
+
+// MockChunkOperatorMockRecorder is the mock recorder for MockChunkOperator.
+type MockChunkOperatorMockRecorder struct {
+	mock *MockChunkOperator
+}
+
+// NewMockChunkOperator creates a new mock instance.
+func NewMockChunkOperator(ctrl *gomock.Controller) *MockChunkOperator {
+	mock := &MockChunkOperator{ctrl: ctrl}
+	mock.recorder = &MockChunkOperatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChunkOperator) EXPECT() *MockChunkOperatorMockRecorder {
+	return m.recorder
+}
+
+// ObjectExists mocks base method.
+func (m *MockChunkOperator) ObjectExists(ctx context.Context, bucketName, objectName string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ObjectExists", ctx, bucketName, objectName)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectExists indicates an expected call of ObjectExists.
+func (mr *MockChunkOperatorMockRecorder) ObjectExists(ctx, bucketName, objectName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectExists", reflect.TypeOf((*MockChunkOperator)(nil).ObjectExists), ctx, bucketName, objectName)
+}
+
+// PutObjectBytes mocks base method.
+func (m *MockChunkOperator) PutObjectBytes(ctx context.Context, bucketName, objectName string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObjectBytes", ctx, bucketName, objectName, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutObjectBytes indicates an expected call of PutObjectBytes.
+func (mr *MockChunkOperatorMockRecorder) PutObjectBytes(ctx, bucketName, objectName, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObjectBytes", reflect.TypeOf((*MockChunkOperator)(nil).PutObjectBytes), ctx, bucketName, objectName, data)
+}
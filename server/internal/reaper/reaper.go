@@ -0,0 +1,120 @@
+package reaper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// Reaper periodically expires abandoned upload requests, so that their secrets
+// can be updated again, and removes objects and chunks uploaded for them.
+type Reaper struct {
+	repo      repository.SecretRepository
+	interval  time.Duration
+	batchSize int32
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	log       zerolog.Logger
+}
+
+// New creates reaper of expired upload requests.
+func New(cfg *config.Config, repo repository.SecretRepository, log zerolog.Logger) *Reaper {
+	return &Reaper{
+		repo:      repo,
+		interval:  cfg.ReaperInterval,
+		batchSize: cfg.ReaperBatchSize,
+		log:       log.With().Str("worker", "Reaper").Logger(),
+	}
+}
+
+// Start runs cleanup in background every reaper interval until Stop is called.
+func (r *Reaper) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.Run(ctx)
+			}
+		}
+	}()
+}
+
+// Stop cancels running cleanup and waits for background worker to exit or ctx to be done.
+func (r *Reaper) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+
+	r.cancel()
+
+	stopped := make(chan struct{})
+
+	go func() {
+		r.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run expires requests batch by batch until none is left and reports what was cleaned up.
+func (r *Reaper) Run(ctx context.Context) *secret.ExpireReport {
+	total := &secret.ExpireReport{}
+	expiredBefore := time.Now().UTC()
+
+	for ctx.Err() == nil {
+		report, err := r.repo.ExpireSecretInitRequests(ctx, expiredBefore, r.batchSize)
+		if err != nil {
+			r.log.Error().Err(err).Msg("failed to expire upload requests")
+			break
+		}
+
+		if report.Skipped {
+			r.log.Debug().Msg("upload requests cleanup is running on another replica")
+
+			total.Skipped = true
+
+			break
+		}
+
+		total.Add(report)
+
+		if report.Expired < int(r.batchSize) {
+			break
+		}
+	}
+
+	if total.Expired > 0 {
+		r.log.Info().
+			Int("expired_requests", total.Expired).
+			Int("orphaned_objects", total.OrphanedObjects).
+			Int("removed_objects", total.RemovedObjects).
+			Int("aborted_uploads", total.AbortedUploads).
+			Int("removed_chunks", total.RemovedChunks).
+			Msg("expired upload requests cleaned up")
+	}
+
+	return total
+}
@@ -0,0 +1,105 @@
+package reaper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/reaper"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// batchesRepo returns prepared cleanup batches one by one.
+type batchesRepo struct {
+	repository.SecretRepository
+	batches   []*secret.ExpireReport
+	err       error
+	calls     int
+	batchSize int32
+}
+
+func (r *batchesRepo) ExpireSecretInitRequests(
+	_ context.Context,
+	_ time.Time,
+	batchSize int32,
+) (*secret.ExpireReport, error) {
+	r.batchSize = batchSize
+
+	if r.calls == len(r.batches) {
+		return nil, r.err
+	}
+
+	report := r.batches[r.calls]
+	r.calls++
+
+	return report, nil
+}
+
+func TestReaperRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		batches  []*secret.ExpireReport
+		err      error
+		calls    int
+		expected *secret.ExpireReport
+	}{
+		{
+			name:     "nothing expired",
+			batches:  []*secret.ExpireReport{{}},
+			calls:    1,
+			expected: &secret.ExpireReport{},
+		},
+		{
+			name: "batches until partial one",
+			batches: []*secret.ExpireReport{
+				{Expired: 2, OrphanedObjects: 1, RemovedObjects: 1, AbortedUploads: 2},
+				{Expired: 1, AbortedUploads: 1, RemovedChunks: 4},
+			},
+			calls: 2,
+			expected: &secret.ExpireReport{
+				Expired: 3, OrphanedObjects: 1, RemovedObjects: 1, AbortedUploads: 3, RemovedChunks: 4,
+			},
+		},
+		{
+			name:     "running on another replica",
+			batches:  []*secret.ExpireReport{{Skipped: true}},
+			calls:    1,
+			expected: &secret.ExpireReport{Skipped: true},
+		},
+		{
+			name: "repository failure",
+			batches: []*secret.ExpireReport{
+				{Expired: 2, AbortedUploads: 2},
+			},
+			err:      e.ErrUnavailable,
+			calls:    1,
+			expected: &secret.ExpireReport{Expired: 2, AbortedUploads: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.DefaultConfig()
+			cfg.ReaperBatchSize = 2
+
+			repo := &batchesRepo{batches: tt.batches, err: tt.err}
+			r := reaper.New(cfg, repo, logger.Stdout(zerolog.Disabled).GetZeroLog())
+
+			report := r.Run(context.Background())
+
+			require.Equal(t, tt.expected, report)
+			require.Equal(t, tt.calls, repo.calls)
+			require.Equal(t, cfg.ReaperBatchSize, repo.batchSize)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+)
+
+// chunkedVersion is the manifest of the chunked secret version being committed
+// together with chunks whose objects were found in S3 during verification.
+type chunkedVersion struct {
+	manifest *secret.Manifest
+	uploaded map[string]struct{}
+}
+
+// verifySecretChunks reads manifest of the chunked secret version and confirms that objects
+// of the chunks it references are uploaded. Chunks already registered for the user are not checked,
+// so that only chunks uploaded with this version are looked up in S3.
+// Returns nil for secrets which are not chunked.
+func (repo *SecretRepo) verifySecretChunks(
+	ctx context.Context,
+	bucketName string,
+	initReq *secret.InitRequest,
+) (*chunkedVersion, error) {
+	if !initReq.MetaData.Chunked() {
+		return nil, nil //nolint:nilnil // reason: secret is not chunked
+	}
+
+	data, err := repo.s3client.ReadObject(ctx, bucketName, initReq.S3URL, secret.MaxManifestSize)
+	if errors.Is(err, e.ErrNotFound) {
+		return nil, fmt.Errorf("[%w] secret manifest is not uploaded", e.ErrInvalidInput)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := secret.ParseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] secret manifest: %s", e.ErrInvalidInput, err.Error())
+	}
+
+	chunks := manifest.UniqueChunks()
+
+	var registered []string
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		registered, err = repo.queries.ListSecretChunks(ctx, pg.ListSecretChunksParams{
+			UserID:   initReq.UserID,
+			ChunkIds: chunkIDs(chunks),
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		return nil, e.InternalErr(dbErr)
+	}
+
+	known := make(map[string]struct{}, len(registered))
+	for _, chunkID := range registered {
+		known[chunkID] = struct{}{}
+	}
+
+	version := &chunkedVersion{manifest: manifest, uploaded: make(map[string]struct{})}
+
+	for _, ref := range chunks {
+		if _, ok := known[ref.ID]; ok {
+			continue
+		}
+
+		info, err := repo.s3client.StatObject(ctx, bucketName, secret.ChunkObjectKey(ref.ID))
+		if errors.Is(err, e.ErrNotFound) {
+			return nil, fmt.Errorf("[%w] secret chunk %s is not uploaded", e.ErrInvalidInput, ref.ID)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Size != ref.Size {
+			return nil, fmt.Errorf("[%w] secret chunk %s size", e.ErrInvalidInput, ref.ID)
+		}
+
+		version.uploaded[ref.ID] = struct{}{}
+	}
+
+	return version, nil
+}
+
+// recordSecretChunks registers chunks of the committed version and references them from it.
+// Chunks registry is locked per user, so that chunks found registered during verification
+// can not be garbage collected by concurrent deletion before they are referenced.
+func (repo *SecretRepo) recordSecretChunks(
+	ctx context.Context,
+	queries *pg.Queries,
+	req *secret.CommitRequest,
+	version *chunkedVersion,
+) error {
+	if version == nil || len(version.manifest.Chunks) == 0 {
+		return nil
+	}
+
+	if err := queries.LockSecretChunks(ctx, req.UserID); err != nil {
+		return err
+	}
+
+	chunks := version.manifest.UniqueChunks()
+	ids := chunkIDs(chunks)
+
+	registered, err := queries.ListSecretChunks(ctx, pg.ListSecretChunksParams{
+		UserID:   req.UserID,
+		ChunkIds: ids,
+	})
+	if err != nil {
+		return err
+	}
+
+	present := make(map[string]struct{}, len(registered)+len(version.uploaded))
+	for _, chunkID := range registered {
+		present[chunkID] = struct{}{}
+	}
+
+	for chunkID := range version.uploaded {
+		present[chunkID] = struct{}{}
+	}
+
+	sizes := make([]int64, 0, len(chunks))
+
+	for _, ref := range chunks {
+		if _, ok := present[ref.ID]; !ok {
+			return fmt.Errorf("[%w] secret chunk %s was garbage collected", e.ErrInvalidInput, ref.ID)
+		}
+
+		sizes = append(sizes, ref.Size)
+	}
+
+	if err := queries.CreateSecretChunks(ctx, pg.CreateSecretChunksParams{
+		UserID:     req.UserID,
+		ChunkIds:   ids,
+		ChunkSizes: sizes,
+		CreatedAt:  req.FinishedAt,
+	}); err != nil {
+		return err
+	}
+
+	return queries.CreateSecretVersionChunks(ctx, pg.CreateSecretVersionChunksParams{
+		UserID:    req.UserID,
+		SecretID:  req.SecretID,
+		VersionID: req.VersionID,
+		ChunkIds:  ids,
+	})
+}
+
+// collectSecretChunks removes chunks no longer referenced by any version of the user secrets
+// and returns keys of their objects to be removed from S3 once deletion is committed.
+func collectSecretChunks(ctx context.Context, queries *pg.Queries, req *secret.DeleteRequest) ([]string, error) {
+	if err := queries.LockSecretChunks(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	chunkIDs, err := queries.DeleteUnreferencedSecretChunks(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	objectKeys := make([]string, 0, len(chunkIDs))
+	for _, chunkID := range chunkIDs {
+		objectKeys = append(objectKeys, secret.ChunkObjectKey(chunkID))
+	}
+
+	return objectKeys, nil
+}
+
+// removeOrphanedChunks removes chunk objects of the user uploaded before uploadedBefore which
+// are not registered for any committed version, e.g. left by upload requests which expired.
+// User with chunked upload in progress is skipped, as the upload reuses chunks found in the bucket.
+// Returns the number of removed chunk objects.
+func (repo *SecretRepo) removeOrphanedChunks(
+	ctx context.Context,
+	userID uuid.UUID,
+	bucketName string,
+	uploadedBefore time.Time,
+) (int, error) {
+	objects, err := repo.s3client.ListObjects(ctx, bucketName, secret.ChunkKeyPrefix)
+	if err != nil {
+		return 0, err
+	}
+
+	objectKeys := make(map[string]string, len(objects))
+
+	for _, object := range objects {
+		chunkID, ok := secret.ChunkIDFromObjectKey(object.Key)
+		if ok && object.LastModified.Before(uploadedBefore) {
+			objectKeys[chunkID] = object.Key
+		}
+	}
+
+	if len(objectKeys) == 0 {
+		return 0, nil
+	}
+
+	var (
+		inProgress bool
+		registered []string
+	)
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		inProgress, err = repo.queries.HasChunkedSecretUploads(ctx, userID)
+		if err != nil || inProgress {
+			return err
+		}
+
+		registered, err = repo.queries.ListSecretChunks(ctx, pg.ListSecretChunksParams{
+			UserID:   userID,
+			ChunkIds: slices.Collect(maps.Keys(objectKeys)),
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		return 0, e.InternalErr(dbErr)
+	}
+
+	if inProgress {
+		return 0, nil
+	}
+
+	for _, chunkID := range registered {
+		delete(objectKeys, chunkID)
+	}
+
+	if len(objectKeys) == 0 {
+		return 0, nil
+	}
+
+	if err := repo.s3client.RemoveObjects(ctx, bucketName, slices.Sorted(maps.Values(objectKeys))); err != nil {
+		return 0, err
+	}
+
+	return len(objectKeys), nil
+}
+
+func chunkIDs(chunks []secret.ChunkRef) []string {
+	ids := make([]string, 0, len(chunks))
+	for _, ref := range chunks {
+		ids = append(ids, ref.ID)
+	}
+
+	return ids
+}
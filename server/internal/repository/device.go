@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/retry"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+	"github.com/rs/zerolog"
+)
+
+// DeviceRepository defines persistence operations of devices user tokens are issued to.
+type DeviceRepository interface {
+	// RegisterDevice records device on login or updates client info and last seen time of the known one.
+	RegisterDevice(ctx context.Context, device *user.Device) (*user.Device, error)
+	// TouchDevice updates last seen time of the user device.
+	TouchDevice(ctx context.Context, userID, deviceID uuid.UUID) (*user.Device, error)
+	// ListDevices lists user devices ordered by first seen time.
+	ListDevices(ctx context.Context, userID uuid.UUID) ([]*user.Device, error)
+	// RevokeDevice marks user device as revoked.
+	RevokeDevice(ctx context.Context, userID, deviceID uuid.UUID) (*user.Device, error)
+}
+
+// DeviceRepo implements DeviceRepository using PostgreSQL.
+type DeviceRepo struct {
+	queries *pg.Queries
+	log     zerolog.Logger
+}
+
+// NewDeviceRepo creates a new instance of DeviceRepo.
+func NewDeviceRepo(db *pg.DB, log zerolog.Logger) *DeviceRepo {
+	return &DeviceRepo{
+		queries: pg.New(db.ConnPool),
+		log:     log,
+	}
+}
+
+func (repo *DeviceRepo) withDBRetry(ctx context.Context, dbOp func() error) error {
+	return retry.PG(ctx, backoff.NewExponentialBackOff(), repo.log, dbOp)
+}
+
+func (repo *DeviceRepo) logWithDeviceContext(userID, deviceID uuid.UUID, op string) zerolog.Logger {
+	return repo.log.With().
+		Str("repo", "DeviceRepo").
+		Str("operation", op).
+		Str("user_id", userID.String()).
+		Str("device_id", deviceID.String()).Logger()
+}
+
+// RegisterDevice records the device seen for the first time or updates client info and
+// last seen time of the known one. Revoked device stays revoked.
+// Returns ErrConflict if device ID is already registered by another user.
+func (repo *DeviceRepo) RegisterDevice(ctx context.Context, device *user.Device) (*user.Device, error) {
+	var dbDevice *user.Device
+
+	logCtx := repo.logWithDeviceContext(device.UserID, device.ID, "RegisterDevice")
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		pgDevice, err := repo.queries.RegisterDevice(ctx, ToRegisterDeviceParams(device))
+		if err != nil {
+			return err
+		}
+
+		dbDevice = FromPGDevice(pgDevice)
+
+		return nil
+	})
+
+	if errors.Is(dbErr, pgx.ErrNoRows) || errors.Is(dbErr, sql.ErrNoRows) {
+		logCtx.Error().Msg("device is registered by another user")
+		return nil, fmt.Errorf("[%w] device", e.ErrConflict)
+	}
+
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to register device")
+		return nil, e.InternalErr(dbErr)
+	}
+
+	return dbDevice, nil
+}
+
+// TouchDevice updates last seen time of the user device and returns it.
+// Returns ErrNotFound if user has no such device.
+func (repo *DeviceRepo) TouchDevice(ctx context.Context, userID, deviceID uuid.UUID) (*user.Device, error) {
+	var dbDevice *user.Device
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		pgDevice, err := repo.queries.TouchDevice(ctx, pg.TouchDeviceParams{
+			SeenAt: time.Now().UTC(),
+			UserID: userID,
+			ID:     deviceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		dbDevice = FromPGDevice(pgDevice)
+
+		return nil
+	})
+
+	if errors.Is(dbErr, pgx.ErrNoRows) || errors.Is(dbErr, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] device", e.ErrNotFound)
+	}
+
+	if dbErr != nil {
+		logCtx := repo.logWithDeviceContext(userID, deviceID, "TouchDevice")
+		logCtx.Error().Err(dbErr).Msg("failed to update device")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	return dbDevice, nil
+}
+
+// ListDevices returns user devices ordered by first seen time.
+func (repo *DeviceRepo) ListDevices(ctx context.Context, userID uuid.UUID) ([]*user.Device, error) {
+	var devices []*user.Device
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		pgDevices, err := repo.queries.ListDevices(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		devices = make([]*user.Device, 0, len(pgDevices))
+		for _, pgDevice := range pgDevices {
+			devices = append(devices, FromPGDevice(pgDevice))
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		repo.log.Error().Err(dbErr).
+			Str("repo", "DeviceRepo").
+			Str("operation", "ListDevices").
+			Str("user_id", userID.String()).
+			Msg("failed to list devices")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	return devices, nil
+}
+
+// RevokeDevice marks user device as revoked, revoking already revoked device keeps its revocation time.
+// Returns ErrNotFound if user has no such device.
+func (repo *DeviceRepo) RevokeDevice(ctx context.Context, userID, deviceID uuid.UUID) (*user.Device, error) {
+	var dbDevice *user.Device
+
+	logCtx := repo.logWithDeviceContext(userID, deviceID, "RevokeDevice")
+	revokedAt := time.Now().UTC()
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		pgDevice, err := repo.queries.RevokeDevice(ctx, pg.RevokeDeviceParams{
+			RevokedAt: &revokedAt,
+			UserID:    userID,
+			ID:        deviceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		dbDevice = FromPGDevice(pgDevice)
+
+		return nil
+	})
+
+	if errors.Is(dbErr, pgx.ErrNoRows) || errors.Is(dbErr, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] device", e.ErrNotFound)
+	}
+
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to revoke device")
+		return nil, e.InternalErr(dbErr)
+	}
+
+	logCtx.Info().Msg("device revoked")
+
+	return dbDevice, nil
+}
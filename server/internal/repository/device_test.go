@@ -0,0 +1,152 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+)
+
+var deviceColumns = []string{"id", "user_id", "client_info", "first_seen_at", "last_seen_at", "revoked_at"}
+
+func deviceRows(device *user.Device) *pgxmock.Rows {
+	var revokedAt *time.Time
+	if device.IsRevoked() {
+		revokedAt = &device.RevokedAt
+	}
+
+	return pgxmock.NewRows(deviceColumns).AddRow(
+		device.ID, device.UserID, device.ClientInfo, device.FirstSeenAt, device.LastSeenAt, revokedAt,
+	)
+}
+
+func TestDeviceRepoRegisterDevice(t *testing.T) {
+	t.Parallel()
+
+	revoked := user.NewDevice(uuid.New(), uuid.New(), "mac=00:11:22:33:44:55;host=laptop")
+	revoked.RevokedAt = time.Now().UTC()
+
+	tests := []struct {
+		name          string
+		device        *user.Device
+		mockBehavior  func(pool pgxmock.PgxPoolIface, device *user.Device)
+		expectErr     error
+		expectRevoked bool
+	}{
+		{
+			name:   "new device",
+			device: user.NewDevice(uuid.New(), uuid.New(), "mac=00:11:22:33:44:55;host=laptop"),
+			mockBehavior: func(pool pgxmock.PgxPoolIface, device *user.Device) {
+				pool.ExpectQuery(`INSERT INTO devices`).
+					WithArgs(device.ID, device.UserID, device.ClientInfo, device.LastSeenAt).
+					WillReturnRows(deviceRows(device))
+			},
+		},
+		{
+			name:   "revoked device stays revoked",
+			device: revoked,
+			mockBehavior: func(pool pgxmock.PgxPoolIface, device *user.Device) {
+				pool.ExpectQuery(`INSERT INTO devices`).
+					WithArgs(device.ID, device.UserID, device.ClientInfo, device.LastSeenAt).
+					WillReturnRows(deviceRows(device))
+			},
+			expectRevoked: true,
+		},
+		{
+			name:   "device of another user",
+			device: user.NewDevice(uuid.New(), uuid.New(), "mac=00:11:22:33:44:55;host=laptop"),
+			mockBehavior: func(pool pgxmock.PgxPoolIface, device *user.Device) {
+				pool.ExpectQuery(`INSERT INTO devices`).
+					WithArgs(device.ID, device.UserID, device.ClientInfo, device.LastSeenAt).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectErr: e.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewDeviceRepo(&pg.DB{ConnPool: mockPool}, log)
+
+			tt.mockBehavior(mockPool, tt.device)
+
+			device, err := repo.RegisterDevice(context.Background(), tt.device)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				require.Nil(t, device)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.device.ID, device.ID)
+				assert.Equal(t, tt.expectRevoked, device.IsRevoked())
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeviceRepoRevokeDevice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		found     bool
+		expectErr error
+	}{
+		{name: "device revoked", found: true},
+		{name: "device not found", found: false, expectErr: e.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewDeviceRepo(&pg.DB{ConnPool: mockPool}, log)
+			device := user.NewDevice(uuid.New(), uuid.New(), "mac=00:11:22:33:44:55;host=laptop")
+
+			query := mockPool.ExpectQuery(`UPDATE devices`).
+				WithArgs(pgxmock.AnyArg(), device.UserID, device.ID)
+
+			if tt.found {
+				revoked := *device
+				revoked.RevokedAt = time.Now().UTC()
+				query.WillReturnRows(deviceRows(&revoked))
+			} else {
+				query.WillReturnError(pgx.ErrNoRows)
+			}
+
+			result, err := repo.RevokeDevice(context.Background(), device.UserID, device.ID)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				require.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, result.IsRevoked())
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
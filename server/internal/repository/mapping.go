@@ -2,6 +2,7 @@
 package repository
 
 import (
+	"github.com/google/uuid"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
@@ -40,18 +41,6 @@ func FromPGUser(u pg.User) *user.User {
 	}
 }
 
-// ToCreateUserKeyParams maps a domain-level Key to pg.CreateUserKeyParams
-// for SQL insert using sqlc.
-func ToCreateUserKeyParams(k *user.Key) pg.CreateUserKeyParams {
-	return pg.CreateUserKeyParams{
-		UserID:    k.UserID,
-		Kek:       k.Kek,
-		Algorithm: k.Algorithm,
-		CreatedAt: k.CreatedAt,
-		UpdatedAt: k.UpdatedAt,
-	}
-}
-
 // FromPGKey maps a pg.Key (returned by sqlc) to a domain-level Key model.
 func FromPGKey(k pg.UserCryptoKey) *user.Key {
 	return &user.Key{
@@ -125,6 +114,184 @@ func FromCreateSecretInitRequestParams(row pg.CreateSecretInitRequestRow) *secre
 	}
 }
 
+func FromGetSecretInitRequestRow(row pg.GetSecretInitRequestRow) *secret.InitRequest {
+	return FromCreateSecretInitRequestParams(pg.CreateSecretInitRequestRow(row))
+}
+
+// FromListExpiredSecretInitRequestsRow maps expired upload request together with the bucket of its owner.
+func FromListExpiredSecretInitRequestsRow(row pg.ListExpiredSecretInitRequestsRow) *secret.InitRequest {
+	req := FromCreateSecretInitRequestParams(pg.CreateSecretInitRequestRow{
+		UserID:          row.UserID,
+		SecretID:        row.SecretID,
+		SecretName:      row.SecretName,
+		S3Url:           row.S3Url,
+		VersionID:       row.VersionID,
+		ParentVersionID: row.ParentVersionID,
+		RequestType:     row.RequestType,
+		Token:           row.Token,
+		ClientInfo:      row.ClientInfo,
+		SecretSize:      row.SecretSize,
+		SecretHash:      row.SecretHash,
+		SecretDek:       row.SecretDek,
+		Meta:            row.Meta,
+		CreatedAt:       row.CreatedAt,
+		ExpiresAt:       row.ExpiresAt,
+	})
+	req.User = &user.User{ID: row.UserID, BucketName: row.BucketName}
+
+	return req
+}
+
+// FromGetSecretInitRequestByObjectRow maps upload request found by its object together with the bucket of its owner.
+func FromGetSecretInitRequestByObjectRow(row pg.GetSecretInitRequestByObjectRow) *secret.InitRequest {
+	req := FromCreateSecretInitRequestParams(pg.CreateSecretInitRequestRow{
+		UserID:          row.UserID,
+		SecretID:        row.SecretID,
+		SecretName:      row.SecretName,
+		S3Url:           row.S3Url,
+		VersionID:       row.VersionID,
+		ParentVersionID: row.ParentVersionID,
+		RequestType:     row.RequestType,
+		Token:           row.Token,
+		ClientInfo:      row.ClientInfo,
+		SecretSize:      row.SecretSize,
+		SecretHash:      row.SecretHash,
+		SecretDek:       row.SecretDek,
+		Meta:            row.Meta,
+		CreatedAt:       row.CreatedAt,
+		ExpiresAt:       row.ExpiresAt,
+	})
+	req.User = &user.User{ID: row.UserID, BucketName: row.BucketName}
+
+	return req
+}
+
+// FillFromSecretVersion fills read request with details of secret version.
+func FillFromSecretVersion(req *secret.InitRequest, row pg.GetSecretVersionRow) {
+	var metaData secret.MetaData
+	if err := metaData.UnmarshalJSON(row.Meta); err != nil {
+		metaData = secret.MetaData{}
+	}
+
+	req.SecretID = row.SecretID
+	req.SecretName = row.SecretName
+	req.VersionID = row.VersionID
+	req.ParentVersionID = row.ParentVersionID
+	req.S3URL = row.S3Url
+	req.SecretSize = row.SecretSize
+	req.SecretHash = row.SecretHash
+	req.SecretDEK = row.SecretDek
+	req.MetaData = metaData
+	req.CurrentVersionID = row.CurrentVersionID
+}
+
+// FromListSecretVersionsRows maps pg.ListSecretVersionsRow slice to domain-level secret History.
+func FromListSecretVersionsRows(userID uuid.UUID, rows []pg.ListSecretVersionsRow) *secret.History {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	history := &secret.History{
+		SecretID:         rows[0].SecretID,
+		SecretName:       rows[0].SecretName,
+		CurrentVersionID: rows[0].CurrentVersionID,
+		Versions:         make([]*secret.Version, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		history.Versions = append(history.Versions, &secret.Version{
+			ID:         row.VersionID,
+			UserID:     userID,
+			SecretID:   row.SecretID,
+			ParentID:   row.ParentVersionID,
+			SecretSize: row.SecretSize,
+			SecretHash: row.SecretHash,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+
+	return history
+}
+
+// ToCreateSecretGetRequestParams maps completed read request to pg.CreateSecretCommitRequestParams
+// so that reads are kept in the same requests history as uploads.
+func ToCreateSecretGetRequestParams(req *secret.InitRequest) pg.CreateSecretCommitRequestParams {
+	return pg.CreateSecretCommitRequestParams{
+		UserID:          req.UserID,
+		SecretID:        req.SecretID,
+		S3Url:           req.S3URL,
+		VersionID:       req.VersionID,
+		ParentVersionID: req.ParentVersionID,
+		RequestType:     pg.RequestTypeGet,
+		Token:           req.Token,
+		ClientInfo:      req.ClientInfo,
+		SecretSize:      req.SecretSize,
+		SecretHash:      req.SecretHash,
+		SecretDek:       req.SecretDEK,
+		CreatedAt:       req.CreatedAt,
+		ExpiresAt:       req.ExpiresAt,
+		FinishedAt:      req.CreatedAt,
+		Status:          pg.RequestStatusCompleted,
+		CommitedBy:      pg.RequestCommiterServer,
+	}
+}
+
+// FromListSecretsRow maps a pg.ListSecretsRow to a domain-level Secret with its current version.
+func FromListSecretsRow(userID uuid.UUID, row pg.ListSecretsRow) *secret.Secret {
+	return &secret.Secret{
+		ID:               row.SecretID,
+		UserID:           userID,
+		Name:             row.SecretName,
+		CurrentVersionID: row.CurrentVersionID,
+		CreatedAt:        row.CreatedAt,
+		UpdatedAt:        row.UpdatedAt,
+		CurrentVersion: &secret.Version{
+			ID:         row.CurrentVersionID,
+			UserID:     userID,
+			SecretID:   row.SecretID,
+			ParentID:   row.ParentVersionID,
+			SecretSize: row.SecretSize,
+			SecretHash: row.SecretHash,
+			CreatedAt:  row.UpdatedAt,
+		},
+	}
+}
+
+func ToCreateSecretCommitRequestParams(req *secret.CommitRequest) pg.CreateSecretCommitRequestParams {
+	return pg.CreateSecretCommitRequestParams{
+		UserID:          req.UserID,
+		SecretID:        req.SecretID,
+		S3Url:           req.S3URL,
+		VersionID:       req.VersionID,
+		ParentVersionID: req.ParentVersionID,
+		RequestType:     pg.RequestType(req.RequestType),
+		Token:           req.Token,
+		ClientInfo:      req.ClientInfo,
+		SecretSize:      req.SecretSize,
+		SecretHash:      req.SecretHash,
+		SecretDek:       req.SecretDEK,
+		CreatedAt:       req.CreatedAt,
+		ExpiresAt:       req.ExpiresAt,
+		FinishedAt:      req.FinishedAt,
+		Status:          pg.RequestStatus(req.Status),
+		CommitedBy:      pg.RequestCommiter(req.CommittedBy),
+	}
+}
+
+func ToCreateSecretVersionParams(req *secret.CommitRequest) pg.CreateSecretVersionParams {
+	return pg.CreateSecretVersionParams{
+		UserID:          req.UserID,
+		SecretID:        req.SecretID,
+		VersionID:       req.VersionID,
+		ParentVersionID: req.ParentVersionID,
+		S3Url:           req.S3URL,
+		SecretSize:      req.SecretSize,
+		SecretHash:      req.SecretHash,
+		SecretDek:       req.SecretDEK,
+		CreatedAt:       req.FinishedAt,
+	}
+}
+
 func FromPGIdentityToken(t pg.UserIdentityToken) *user.IdentityToken {
 	return &user.IdentityToken{
 		UserID:           t.UserID,
@@ -137,3 +304,150 @@ func FromPGIdentityToken(t pg.UserIdentityToken) *user.IdentityToken {
 		UpdatedAt:        t.UpdatedAt,
 	}
 }
+
+func ToCreateSecretTombstoneParams(t *secret.Tombstone) pg.CreateSecretTombstoneParams {
+	return pg.CreateSecretTombstoneParams{
+		UserID:     t.UserID,
+		SecretID:   t.SecretID,
+		SecretName: t.SecretName,
+		VersionID:  t.VersionID,
+		ClientInfo: t.ClientInfo,
+		DeletedAt:  t.DeletedAt,
+	}
+}
+
+func FromPGSecretTombstone(t pg.SecretTombstone) *secret.Tombstone {
+	return &secret.Tombstone{
+		UserID:     t.UserID,
+		SecretID:   t.SecretID,
+		SecretName: t.SecretName,
+		VersionID:  t.VersionID,
+		ClientInfo: t.ClientInfo,
+		DeletedAt:  t.DeletedAt,
+	}
+}
+
+func ToCreateSecretEventParams(ev *secret.Event) pg.CreateSecretEventParams {
+	return pg.CreateSecretEventParams{
+		UserID:     ev.UserID,
+		SecretID:   ev.SecretID,
+		SecretName: ev.SecretName,
+		VersionID:  ev.VersionID,
+		EventType:  pg.SecretEventType(ev.Type),
+		ClientInfo: ev.ClientInfo,
+		CreatedAt:  ev.CreatedAt,
+	}
+}
+
+func FromPGSecretEvent(ev pg.SecretEvent) *secret.Event {
+	return &secret.Event{
+		Cursor:     ev.ID,
+		UserID:     ev.UserID,
+		SecretID:   ev.SecretID,
+		SecretName: ev.SecretName,
+		VersionID:  ev.VersionID,
+		Type:       secret.EventType(ev.EventType),
+		ClientInfo: ev.ClientInfo,
+		CreatedAt:  ev.CreatedAt,
+	}
+}
+
+func ToRegisterDeviceParams(d *user.Device) pg.RegisterDeviceParams {
+	return pg.RegisterDeviceParams{
+		ID:         d.ID,
+		UserID:     d.UserID,
+		ClientInfo: d.ClientInfo,
+		SeenAt:     d.LastSeenAt,
+	}
+}
+
+func FromPGDevice(d pg.Device) *user.Device {
+	device := &user.Device{
+		ID:          d.ID,
+		UserID:      d.UserID,
+		ClientInfo:  d.ClientInfo,
+		FirstSeenAt: d.FirstSeenAt,
+		LastSeenAt:  d.LastSeenAt,
+	}
+
+	if d.RevokedAt != nil {
+		device.RevokedAt = *d.RevokedAt
+	}
+
+	return device
+}
+
+func ToCreateRefreshTokenParams(t *user.RefreshToken) pg.CreateRefreshTokenParams {
+	return pg.CreateRefreshTokenParams{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		DeviceID:  t.DeviceID,
+		TokenHash: t.TokenHash,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+	}
+}
+
+func FromPGRefreshToken(t pg.RefreshToken) *user.RefreshToken {
+	token := &user.RefreshToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		DeviceID:  t.DeviceID,
+		TokenHash: t.TokenHash,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+	}
+
+	if t.RevokedAt != nil {
+		token.RevokedAt = *t.RevokedAt
+	}
+
+	return token
+}
+
+func ToSaveUserTOTPParams(t *user.TOTP) pg.SaveUserTOTPParams {
+	return pg.SaveUserTOTPParams{
+		UserID:    t.UserID,
+		Secret:    t.WrappedSecret,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func FromPGUserTOTP(t pg.UserTotp) *user.TOTP {
+	return &user.TOTP{
+		UserID:        t.UserID,
+		WrappedSecret: t.Secret,
+		Enabled:       t.Enabled,
+		LastStep:      t.LastStep,
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}
+}
+
+// FromPGSecretKey maps wrapped DEK of the secret version to domain key.
+func FromPGSecretKey(row pg.ListUserSecretKeysRow) *secret.WrappedKey {
+	return &secret.WrappedKey{
+		SecretID:  row.SecretID,
+		VersionID: row.VersionID,
+		DEK:       row.SecretDek,
+	}
+}
+
+// ToRewrapUserSecretKeysParams maps re-wrapped keys to column arrays of the batch update.
+func ToRewrapUserSecretKeysParams(userID uuid.UUID, keys []*secret.WrappedKey) pg.RewrapUserSecretKeysParams {
+	params := pg.RewrapUserSecretKeysParams{
+		UserID:     userID,
+		SecretIds:  make([]uuid.UUID, 0, len(keys)),
+		VersionIds: make([]uuid.UUID, 0, len(keys)),
+		SecretDeks: make([][]byte, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		params.SecretIds = append(params.SecretIds, key.SecretID)
+		params.VersionIds = append(params.VersionIds, key.VersionID)
+		params.SecretDeks = append(params.SecretDeks, key.DEK)
+	}
+
+	return params
+}
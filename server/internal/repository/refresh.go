@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/retry"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+	"github.com/rs/zerolog"
+)
+
+// RefreshTokenRepository defines persistence operations of refresh tokens issued to user devices.
+type RefreshTokenRepository interface {
+	// CreateRefreshToken stores newly issued refresh token.
+	CreateRefreshToken(ctx context.Context, token *user.RefreshToken) error
+	// GetRefreshToken gets refresh token by its hash.
+	GetRefreshToken(ctx context.Context, tokenHash []byte) (*user.RefreshToken, error)
+	// RotateRefreshToken revokes used refresh token and stores the one issued instead.
+	RotateRefreshToken(ctx context.Context, used, next *user.RefreshToken) error
+	// RevokeDeviceRefreshTokens revokes all refresh tokens of the user device.
+	RevokeDeviceRefreshTokens(ctx context.Context, userID, deviceID uuid.UUID) (int64, error)
+	// RevokeUserRefreshTokens revokes all refresh tokens of the user.
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// RefreshTokenRepo implements RefreshTokenRepository using PostgreSQL.
+type RefreshTokenRepo struct {
+	connPool pg.ConnectionPool
+	queries  *pg.Queries
+	log      zerolog.Logger
+}
+
+// NewRefreshTokenRepo creates a new instance of RefreshTokenRepo.
+func NewRefreshTokenRepo(db *pg.DB, log zerolog.Logger) *RefreshTokenRepo {
+	return &RefreshTokenRepo{
+		connPool: db.ConnPool,
+		queries:  pg.New(db.ConnPool),
+		log:      log,
+	}
+}
+
+func (repo *RefreshTokenRepo) withDBRetry(ctx context.Context, dbOp func() error) error {
+	return retry.PG(ctx, backoff.NewExponentialBackOff(), repo.log, dbOp)
+}
+
+func (repo *RefreshTokenRepo) logWithDeviceContext(userID, deviceID uuid.UUID, op string) zerolog.Logger {
+	return repo.log.With().
+		Str("repo", "RefreshTokenRepo").
+		Str("operation", op).
+		Str("user_id", userID.String()).
+		Str("device_id", deviceID.String()).Logger()
+}
+
+// CreateRefreshToken stores newly issued refresh token.
+func (repo *RefreshTokenRepo) CreateRefreshToken(ctx context.Context, token *user.RefreshToken) error {
+	dbErr := repo.withDBRetry(ctx, func() error {
+		return repo.queries.CreateRefreshToken(ctx, ToCreateRefreshTokenParams(token))
+	})
+	if dbErr != nil {
+		logCtx := repo.logWithDeviceContext(token.UserID, token.DeviceID, "CreateRefreshToken")
+		logCtx.Error().Err(dbErr).Msg("failed to create refresh token")
+
+		return e.InternalErr(dbErr)
+	}
+
+	return nil
+}
+
+// GetRefreshToken gets refresh token by its hash, including revoked and expired ones.
+// Returns ErrNotFound if token has never been issued.
+func (repo *RefreshTokenRepo) GetRefreshToken(ctx context.Context, tokenHash []byte) (*user.RefreshToken, error) {
+	var token *user.RefreshToken
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		pgToken, err := repo.queries.GetRefreshToken(ctx, tokenHash)
+		if err != nil {
+			return err
+		}
+
+		token = FromPGRefreshToken(pgToken)
+
+		return nil
+	})
+
+	if errors.Is(dbErr, pgx.ErrNoRows) || errors.Is(dbErr, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] refresh token", e.ErrNotFound)
+	}
+
+	if dbErr != nil {
+		repo.log.Error().Err(dbErr).
+			Str("repo", "RefreshTokenRepo").
+			Str("operation", "GetRefreshToken").
+			Msg("failed to get refresh token")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	return token, nil
+}
+
+// RotateRefreshToken revokes used refresh token and stores the one issued instead in a single transaction.
+// Returns ErrConflict if used token has already been revoked, e.g. by concurrent refresh.
+func (repo *RefreshTokenRepo) RotateRefreshToken(ctx context.Context, used, next *user.RefreshToken) error {
+	logCtx := repo.logWithDeviceContext(used.UserID, used.DeviceID, "RotateRefreshToken")
+	revokedAt := next.CreatedAt
+
+	queryFn := func(queries *pg.Queries) error {
+		revoked, err := queries.RevokeRefreshToken(ctx, pg.RevokeRefreshTokenParams{
+			RevokedAt: &revokedAt,
+			ID:        used.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if revoked == 0 {
+			return fmt.Errorf("[%w] refresh token is already used", e.ErrConflict)
+		}
+
+		return queries.CreateRefreshToken(ctx, ToCreateRefreshTokenParams(next))
+	}
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		return pg.WithinTrx(ctx, repo.connPool, pgx.TxOptions{}, queryFn)(repo.queries)
+	})
+
+	if errors.Is(dbErr, e.ErrConflict) {
+		return dbErr
+	}
+
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to rotate refresh token")
+		return e.InternalErr(dbErr)
+	}
+
+	return nil
+}
+
+// RevokeDeviceRefreshTokens revokes all active refresh tokens of the user device
+// and returns number of revoked tokens.
+func (repo *RefreshTokenRepo) RevokeDeviceRefreshTokens(ctx context.Context, userID, deviceID uuid.UUID) (int64, error) {
+	var revoked int64
+
+	logCtx := repo.logWithDeviceContext(userID, deviceID, "RevokeDeviceRefreshTokens")
+	revokedAt := time.Now().UTC()
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		revoked, err = repo.queries.RevokeDeviceRefreshTokens(ctx, pg.RevokeDeviceRefreshTokensParams{
+			RevokedAt: &revokedAt,
+			UserID:    userID,
+			DeviceID:  deviceID,
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to revoke device refresh tokens")
+		return 0, e.InternalErr(dbErr)
+	}
+
+	logCtx.Info().Int64("revoked", revoked).Msg("device refresh tokens revoked")
+
+	return revoked, nil
+}
+
+// RevokeUserRefreshTokens revokes all active refresh tokens of the user and returns number of revoked tokens.
+func (repo *RefreshTokenRepo) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var revoked int64
+
+	logCtx := repo.log.With().
+		Str("repo", "RefreshTokenRepo").
+		Str("operation", "RevokeUserRefreshTokens").
+		Str("user_id", userID.String()).Logger()
+	revokedAt := time.Now().UTC()
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		revoked, err = repo.queries.RevokeUserRefreshTokens(ctx, pg.RevokeUserRefreshTokensParams{
+			RevokedAt: &revokedAt,
+			UserID:    userID,
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to revoke user refresh tokens")
+		return 0, e.InternalErr(dbErr)
+	}
+
+	logCtx.Info().Int64("revoked", revoked).Msg("user refresh tokens revoked")
+
+	return revoked, nil
+}
@@ -0,0 +1,137 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+)
+
+func newRefreshToken(t *testing.T) (*user.RefreshToken, string) {
+	t.Helper()
+
+	token, tokenString, err := user.NewRefreshToken(uuid.New(), uuid.New(), time.Hour)
+	require.NoError(t, err)
+
+	return token, tokenString
+}
+
+func TestRefreshTokenRepoGetRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		found     bool
+		revoked   bool
+		expectErr error
+	}{
+		{name: "active token", found: true},
+		{name: "used token", found: true, revoked: true},
+		{name: "unknown token", found: false, expectErr: e.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewRefreshTokenRepo(&pg.DB{ConnPool: mockPool}, log)
+			token, tokenString := newRefreshToken(t)
+
+			var revokedAt *time.Time
+			if tt.revoked {
+				now := time.Now().UTC()
+				revokedAt = &now
+			}
+
+			query := mockPool.ExpectQuery(`FROM refresh_tokens`).WithArgs(user.HashRefreshToken(tokenString))
+			if tt.found {
+				query.WillReturnRows(pgxmock.NewRows([]string{
+					"id", "user_id", "device_id", "token_hash", "created_at", "expires_at", "revoked_at",
+				}).AddRow(
+					token.ID, token.UserID, token.DeviceID, token.TokenHash, token.CreatedAt, token.ExpiresAt, revokedAt,
+				))
+			} else {
+				query.WillReturnError(pgx.ErrNoRows)
+			}
+
+			result, err := repo.GetRefreshToken(context.Background(), user.HashRefreshToken(tokenString))
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				require.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, token.ID, result.ID)
+				assert.Equal(t, tt.revoked, result.IsRevoked())
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefreshTokenRepoRotateRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		revoked   int64
+		expectErr error
+	}{
+		{name: "token rotated", revoked: 1},
+		{name: "token already used", revoked: 0, expectErr: e.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewRefreshTokenRepo(&pg.DB{ConnPool: mockPool}, log)
+			used, _ := newRefreshToken(t)
+			next, _, err := user.NewRefreshToken(used.UserID, used.DeviceID, time.Hour)
+			require.NoError(t, err)
+
+			mockPool.ExpectBegin()
+			mockPool.ExpectExec(`UPDATE refresh_tokens`).
+				WithArgs(pgxmock.AnyArg(), used.ID).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.revoked))
+
+			if tt.expectErr == nil {
+				mockPool.ExpectExec(`INSERT INTO refresh_tokens`).
+					WithArgs(next.ID, next.UserID, next.DeviceID, next.TokenHash, next.CreatedAt, next.ExpiresAt).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectCommit()
+			} else {
+				mockPool.ExpectRollback()
+			}
+
+			err = repo.RotateRefreshToken(context.Background(), used, next)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/checksum"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/retry"
@@ -26,8 +31,24 @@ type SecretRepository interface {
 		ctx context.Context,
 		req *secret.CommitRequest,
 	) (*secret.CommitRequest, error)
+	CreateSecretGetRequest(
+		ctx context.Context,
+		req *secret.InitRequest,
+	) (*secret.InitRequest, error)
+	RenewUploadCredentials(ctx context.Context, req *secret.RenewRequest) (*s3.TemporaryCredentials, error)
+	ListSecrets(ctx context.Context, req *secret.ListRequest) ([]*secret.Secret, error)
+	ListSecretVersions(ctx context.Context, userID uuid.UUID, secretName string) (*secret.History, error)
+	DeleteSecret(ctx context.Context, req *secret.DeleteRequest) (*secret.Tombstone, error)
+	ListSecretTombstones(ctx context.Context, userID uuid.UUID, since time.Time) ([]*secret.Tombstone, error)
+	ExpireSecretInitRequests(ctx context.Context, expiredBefore time.Time, batchSize int32) (*secret.ExpireReport, error)
+	CommitUploadedObject(ctx context.Context, bucketName, objectKey string) (*secret.CommitRequest, error)
+	ListSecretEvents(ctx context.Context, req *secret.WatchRequest, batchSize int32) ([]*secret.Event, error)
+	ListSecretKeys(ctx context.Context, userID uuid.UUID) ([]*secret.WrappedKey, error)
 }
 
+// expireLockKey is the advisory lock key which serializes cleanup of expired requests across server replicas.
+const expireLockKey int64 = 0x676b5f6578706972
+
 // SecretRepo implements SecretRepository using PostgreSQL and S3.
 type SecretRepo struct {
 	SecretRepository
@@ -81,8 +102,17 @@ func (repo *SecretRepo) CreateSecretInitRequest(
 
 	queryFn := func(queries *pg.Queries) error {
 		row, err := queries.CreateSecretInitRequest(ctx, ToCreateSecretInitRequestParams(req))
-		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("[%w] secret parent version", e.ErrInvalidInput)
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
+			// secret exists, but its current version is not the parent of the upload.
+			currentVersionID, err := queries.GetSecretCurrentVersionID(ctx, pg.GetSecretCurrentVersionIDParams{
+				UserID:   req.UserID,
+				SecretID: req.SecretID,
+			})
+			if err != nil {
+				return err
+			}
+
+			return secret.NewVersionConflictError(req, currentVersionID)
 		}
 
 		if err != nil {
@@ -102,7 +132,7 @@ func (repo *SecretRepo) CreateSecretInitRequest(
 		return dbReq, dbErr
 	}
 
-	if errors.Is(dbErr, e.ErrInvalidInput) || errors.Is(dbErr, e.ErrExists) {
+	if errors.Is(dbErr, e.ErrConflict) {
 		return nil, dbErr
 	}
 
@@ -140,11 +170,856 @@ func (repo *SecretRepo) getS3Credentials(
 	return creds, nil
 }
 
+// RenewUploadCredentials issues fresh S3 credentials for the upload request in progress.
+// Returns ErrNotFound if there is no such request or it has already expired.
+func (repo *SecretRepo) RenewUploadCredentials(
+	ctx context.Context,
+	req *secret.RenewRequest,
+) (*s3.TemporaryCredentials, error) {
+	var initReq *secret.InitRequest
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		row, err := repo.queries.GetSecretInitRequest(ctx, pg.GetSecretInitRequestParams{
+			UserID:   req.UserID,
+			SecretID: req.SecretID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("[%w] secret init request", e.ErrNotFound)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		initReq = FromGetSecretInitRequestRow(row)
+
+		return nil
+	})
+	if errors.Is(dbErr, e.ErrNotFound) {
+		return nil, dbErr
+	}
+
+	if dbErr != nil {
+		repo.log.Error().Err(dbErr).
+			Str("user_id", req.UserID.String()).
+			Str("secret_id", req.SecretID.String()).
+			Msg("failed to get secret init request")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	if initReq.IsExpired() {
+		return nil, fmt.Errorf("[%w] secret init request expired", e.ErrNotFound)
+	}
+
+	if err := req.Validate(initReq); err != nil {
+		return nil, err
+	}
+
+	initReq.User = req.User
+
+	creds, err := repo.getS3Credentials(ctx, initReq)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] create s3 credentials", e.ErrInternal)
+	}
+
+	return creds, nil
+}
+
+// CreateSecretCommitRequest completes upload request in progress.
+// It validates request against the one created at init phase, confirms that uploaded
+// S3 object matches declared size and hash and then atomically moves request to completed,
+// creates new secret version and advances current version of the secret.
 func (repo *SecretRepo) CreateSecretCommitRequest(
-	_ context.Context,
-	_ *secret.CommitRequest,
+	ctx context.Context,
+	req *secret.CommitRequest,
 ) (*secret.CommitRequest, error) {
-	var dbReq *secret.CommitRequest
+	logCtx := repo.log.With().
+		Str("repo", "SecretRepo").
+		Str("operation", "CreateSecretCommitRequest").
+		Str("user_id", req.UserID.String()).
+		Str("secret_id", req.SecretID.String()).
+		Str("clientInfo", req.ClientInfo).Logger()
 
-	return dbReq, nil
+	initReq, err := repo.getSecretInitRequest(ctx, req)
+	if err != nil {
+		if !errors.Is(err, e.ErrNotFound) && !errors.Is(err, e.ErrInvalidInput) {
+			logCtx.Error().Err(err).Msg("failed to get secret init request")
+		}
+
+		return nil, err
+	}
+
+	if err := repo.verifyS3Object(ctx, req.User.BucketName, initReq, req.PartHashes); err != nil {
+		return nil, err
+	}
+
+	chunked, err := repo.verifySecretChunks(ctx, req.User.BucketName, initReq)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Complete(initReq, req.CommittedBy)
+
+	queryFn := func(queries *pg.Queries) error {
+		// lock in progress request to prevent concurrent commits.
+		lockedReq, err := repo.getLockedSecretInitRequest(ctx, queries, req)
+		if err != nil {
+			return err
+		}
+
+		if lockedReq.Token != initReq.Token {
+			return fmt.Errorf("[%w] secret init request", e.ErrConflict)
+		}
+
+		if err := queries.CreateSecretCommitRequest(ctx, ToCreateSecretCommitRequestParams(req)); err != nil {
+			return err
+		}
+
+		if err := queries.DeleteSecretInitRequest(ctx, pg.DeleteSecretInitRequestParams{
+			UserID:   req.UserID,
+			SecretID: req.SecretID,
+		}); err != nil {
+			return err
+		}
+
+		if err := repo.advanceSecretVersion(ctx, queries, req); err != nil {
+			return err
+		}
+
+		if err := repo.recordSecretChunks(ctx, queries, req, chunked); err != nil {
+			return err
+		}
+
+		if err := repo.recordSecretEvent(ctx, queries, secret.NewCommitEvent(req)); err != nil {
+			return err
+		}
+
+		metaData, err := req.MetaData.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("[%w] secret metadata", e.ErrMarshal)
+		}
+
+		return queries.UpsertSecretMeta(ctx, pg.UpsertSecretMetaParams{
+			UserID:    req.UserID,
+			SecretID:  req.SecretID,
+			Meta:      metaData,
+			CreatedAt: req.FinishedAt,
+		})
+	}
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		return pg.WithinTrx(ctx, repo.connPool, pgx.TxOptions{}, queryFn)(repo.queries)
+	})
+
+	if errors.Is(dbErr, e.ErrNotFound) || errors.Is(dbErr, e.ErrConflict) || errors.Is(dbErr, e.ErrMarshal) ||
+		errors.Is(dbErr, e.ErrInvalidInput) {
+		return nil, dbErr
+	}
+
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to commit secret request")
+		return nil, e.InternalErr(dbErr)
+	}
+
+	return req, nil
+}
+
+// CommitUploadedObject commits upload request in progress which the uploaded object belongs to
+// on behalf of S3, so that version is created even if client never commits it.
+// Returns ErrNotFound if there is no such request, e.g. when client has already committed it.
+func (repo *SecretRepo) CommitUploadedObject(
+	ctx context.Context,
+	bucketName, objectKey string,
+) (*secret.CommitRequest, error) {
+	var initReq *secret.InitRequest
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		row, err := repo.queries.GetSecretInitRequestByObject(ctx, pg.GetSecretInitRequestByObjectParams{
+			BucketName: bucketName,
+			S3Url:      objectKey,
+		})
+		if err != nil {
+			return err
+		}
+
+		initReq = FromGetSecretInitRequestByObjectRow(row)
+
+		return nil
+	})
+	if errors.Is(dbErr, pgx.ErrNoRows) || errors.Is(dbErr, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] secret init request of object", e.ErrNotFound)
+	}
+
+	if dbErr != nil {
+		repo.log.Error().Err(dbErr).
+			Str("repo", "SecretRepo").
+			Str("operation", "CommitUploadedObject").
+			Str("bucket", bucketName).
+			Str("object_key", objectKey).
+			Msg("failed to get secret init request of object")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	return repo.CreateSecretCommitRequest(ctx, secret.NewCommitRequest(initReq, secret.RequestCommitterS3))
+}
+
+// getSecretInitRequest fetches upload request in progress and validates commit request against it.
+// Request is not locked, it is fetched again under lock when commit is recorded.
+func (repo *SecretRepo) getSecretInitRequest(
+	ctx context.Context,
+	req *secret.CommitRequest,
+) (*secret.InitRequest, error) {
+	var initReq *secret.InitRequest
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		row, err := repo.queries.GetSecretInitRequest(ctx, pg.GetSecretInitRequestParams{
+			UserID:   req.UserID,
+			SecretID: req.SecretID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("[%w] secret init request", e.ErrNotFound)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		initReq = FromGetSecretInitRequestRow(row)
+
+		return nil
+	})
+	if errors.Is(dbErr, e.ErrNotFound) {
+		return nil, dbErr
+	}
+
+	if dbErr != nil {
+		return nil, e.InternalErr(dbErr)
+	}
+
+	if initReq.IsExpired() {
+		return nil, fmt.Errorf("[%w] secret init request expired", e.ErrNotFound)
+	}
+
+	if err := req.Validate(initReq); err != nil {
+		return nil, err
+	}
+
+	return initReq, nil
+}
+
+// getLockedSecretInitRequest fetches upload request in progress locking it until the end of transaction.
+func (repo *SecretRepo) getLockedSecretInitRequest(
+	ctx context.Context,
+	queries *pg.Queries,
+	req *secret.CommitRequest,
+) (*secret.InitRequest, error) {
+	row, err := queries.LockSecretInitRequest(ctx, pg.LockSecretInitRequestParams{
+		UserID:   req.UserID,
+		SecretID: req.SecretID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] secret init request", e.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return FromGetSecretInitRequestRow(pg.GetSecretInitRequestRow(row)), nil
+}
+
+// verifyS3Object confirms that uploaded object exists and matches declared size and SHA-256 checksum,
+// so that corrupted or truncated upload never becomes current version.
+// Checksum S3 stores for object uploaded in many parts is composed of parts checksums, so the object
+// is hashed instead. partHashes reported by client reject corrupted parts before the object is read.
+func (repo *SecretRepo) verifyS3Object(
+	ctx context.Context,
+	bucketName string,
+	initReq *secret.InitRequest,
+	partHashes [][]byte,
+) error {
+	info, err := repo.s3client.StatObject(ctx, bucketName, initReq.S3URL)
+	if errors.Is(err, e.ErrNotFound) {
+		return fmt.Errorf("[%w] secret object is not uploaded", e.ErrInvalidInput)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if info.Size != initReq.SecretSize {
+		return fmt.Errorf("[%w] secret object size", e.ErrInvalidInput)
+	}
+
+	matched, verifiable := checksum.MatchS3(info.ChecksumSHA256, initReq.SecretHash)
+	if !verifiable {
+		if len(partHashes) > 0 && info.ChecksumSHA256 != checksum.S3Composite(partHashes...) {
+			return fmt.Errorf("[%w] secret object parts checksum", e.ErrInvalidInput)
+		}
+
+		sum, err := repo.s3client.ObjectChecksum(ctx, bucketName, initReq.S3URL)
+		if errors.Is(err, e.ErrNotFound) {
+			return fmt.Errorf("[%w] secret object is not uploaded", e.ErrInvalidInput)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		matched = bytes.Equal(sum, initReq.SecretHash)
+	}
+
+	if !matched {
+		return fmt.Errorf("[%w] secret object checksum", e.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// advanceSecretVersion stores new secret version and makes it current.
+// Returns ErrConflict if current version of the secret has changed since upload was initiated.
+func (repo *SecretRepo) advanceSecretVersion(
+	ctx context.Context,
+	queries *pg.Queries,
+	req *secret.CommitRequest,
+) error {
+	if req.ParentVersionID == uuid.Nil {
+		err := queries.CreateSecret(ctx, pg.CreateSecretParams{
+			UserID:           req.UserID,
+			SecretID:         req.SecretID,
+			SecretName:       req.SecretName,
+			CurrentVersionID: req.VersionID,
+			CreatedAt:        req.FinishedAt,
+			UpdatedAt:        req.FinishedAt,
+		})
+		if pg.IsUniqueViolation(err) {
+			return fmt.Errorf("[%w] secret already exists", e.ErrConflict)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return queries.CreateSecretVersion(ctx, ToCreateSecretVersionParams(req))
+	}
+
+	if err := queries.CreateSecretVersion(ctx, ToCreateSecretVersionParams(req)); err != nil {
+		return err
+	}
+
+	rows, err := queries.UpdateSecret(ctx, pg.UpdateSecretParams{
+		VersionID:       req.VersionID,
+		UpdatedAt:       req.FinishedAt,
+		UserID:          req.UserID,
+		SecretID:        req.SecretID,
+		ParentVersionID: req.ParentVersionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows != 1 {
+		return fmt.Errorf("[%w] secret current version has changed", e.ErrConflict)
+	}
+
+	return nil
+}
+
+// getSecretVersion fetches requested version of the secret or the current one if version is not specified.
+func (repo *SecretRepo) getSecretVersion(
+	ctx context.Context,
+	queries *pg.Queries,
+	req *secret.InitRequest,
+) (pg.GetSecretVersionRow, error) {
+	if req.VersionID != uuid.Nil {
+		return queries.GetSecretVersion(ctx, pg.GetSecretVersionParams{
+			UserID:     req.UserID,
+			SecretName: req.SecretName,
+			VersionID:  req.VersionID,
+		})
+	}
+
+	row, err := queries.GetSecretCurrentVersion(ctx, pg.GetSecretCurrentVersionParams{
+		UserID:     req.UserID,
+		SecretName: req.SecretName,
+	})
+
+	return pg.GetSecretVersionRow(row), err
+}
+
+// CreateSecretGetRequest resolves requested (current by default) version of the secret by its name,
+// records read request in requests history and issues read-only S3 credentials.
+// Returns ErrNotFound if secret does not exist.
+func (repo *SecretRepo) CreateSecretGetRequest(
+	ctx context.Context,
+	req *secret.InitRequest,
+) (*secret.InitRequest, error) {
+	logCtx := repo.logWithRequestContext(req, "CreateSecretGetRequest")
+
+	queryFn := func(queries *pg.Queries) error {
+		row, err := repo.getSecretVersion(ctx, queries, req)
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("[%w] secret %s version", e.ErrNotFound, req.SecretName)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		FillFromSecretVersion(req, row)
+		req.SetExpiration()
+
+		return queries.CreateSecretCommitRequest(ctx, ToCreateSecretGetRequestParams(req))
+	}
+
+	dbErr := repo.withDBRetry(ctx, func() error { return queryFn(repo.queries) })
+	if errors.Is(dbErr, e.ErrNotFound) {
+		return nil, dbErr
+	}
+
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to create secret get request")
+		return nil, e.InternalErr(dbErr)
+	}
+
+	idToken, err := repo.idClient.GetToken(ctx, req.User)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] create s3 credentials", e.ErrInternal)
+	}
+
+	creds, err := repo.s3client.AssumeReadOnlyRole(ctx, idToken.AccessToken, req.User.BucketName, req.UploadDuration())
+	if err != nil {
+		return nil, fmt.Errorf("[%w] create s3 credentials", e.ErrInternal)
+	}
+
+	req.S3Creds = creds
+
+	return req, nil
+}
+
+// ListSecrets returns a page of user secrets with their current versions ordered by name.
+func (repo *SecretRepo) ListSecrets(ctx context.Context, req *secret.ListRequest) ([]*secret.Secret, error) {
+	var rows []pg.ListSecretsRow
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		rows, err = repo.queries.ListSecrets(ctx, pg.ListSecretsParams{
+			UserID:     req.UserID,
+			NamePrefix: req.NamePrefix,
+			AfterName:  req.AfterName,
+			PageLimit:  req.PageSize,
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		repo.log.Error().Err(dbErr).
+			Str("repo", "SecretRepo").
+			Str("operation", "ListSecrets").
+			Str("user_id", req.UserID.String()).
+			Msg("failed to list secrets")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	secrets := make([]*secret.Secret, 0, len(rows))
+	for _, row := range rows {
+		secrets = append(secrets, FromListSecretsRow(req.UserID, row))
+	}
+
+	return secrets, nil
+}
+
+// ListSecretVersions returns history of the secret versions ordered from newest to oldest.
+// Returns ErrNotFound if secret does not exist.
+func (repo *SecretRepo) ListSecretVersions(
+	ctx context.Context,
+	userID uuid.UUID,
+	secretName string,
+) (*secret.History, error) {
+	var rows []pg.ListSecretVersionsRow
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		rows, err = repo.queries.ListSecretVersions(ctx, pg.ListSecretVersionsParams{
+			UserID:     userID,
+			SecretName: secretName,
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		repo.log.Error().Err(dbErr).
+			Str("repo", "SecretRepo").
+			Str("operation", "ListSecretVersions").
+			Str("user_id", userID.String()).
+			Str("secretName", secretName).
+			Msg("failed to list secret versions")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	history := FromListSecretVersionsRows(userID, rows)
+	if history == nil {
+		return nil, fmt.Errorf("[%w] secret %s", e.ErrNotFound, secretName)
+	}
+
+	return history, nil
+}
+
+// DeleteSecret deletes the secret together with its versions and metadata provided
+// its current version matches the expected one and records a tombstone for other devices.
+// S3 objects of the secret and chunks no longer referenced by other secrets are removed
+// once deletion is committed.
+// Returns ErrNotFound if secret does not exist and ErrConflict if current version has changed.
+func (repo *SecretRepo) DeleteSecret(ctx context.Context, req *secret.DeleteRequest) (*secret.Tombstone, error) {
+	logCtx := repo.log.With().
+		Str("repo", "SecretRepo").
+		Str("operation", "DeleteSecret").
+		Str("user_id", req.UserID.String()).
+		Str("secretName", req.SecretName).
+		Str("clientInfo", req.ClientInfo).Logger()
+
+	var (
+		tombstone  *secret.Tombstone
+		objectKeys []string
+	)
+
+	queryFn := func(queries *pg.Queries) error {
+		row, err := queries.GetSecretForDelete(ctx, pg.GetSecretForDeleteParams{
+			UserID:     req.UserID,
+			SecretName: req.SecretName,
+		})
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("[%w] secret %s", e.ErrNotFound, req.SecretName)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if row.CurrentVersionID != req.VersionID {
+			return fmt.Errorf("[%w] secret current version has changed", e.ErrConflict)
+		}
+
+		objectKeys, err = queries.ListSecretObjects(ctx, pg.ListSecretObjectsParams{
+			UserID:   req.UserID,
+			SecretID: row.SecretID,
+		})
+		if err != nil {
+			return err
+		}
+
+		rows, err := queries.DeleteSecret(ctx, pg.DeleteSecretParams{
+			UserID:    req.UserID,
+			SecretID:  row.SecretID,
+			VersionID: req.VersionID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if rows != 1 {
+			return fmt.Errorf("[%w] secret current version has changed", e.ErrConflict)
+		}
+
+		if err := queries.DeleteSecretInitRequest(ctx, pg.DeleteSecretInitRequestParams{
+			UserID:   req.UserID,
+			SecretID: row.SecretID,
+		}); err != nil {
+			return err
+		}
+
+		// versions referencing chunks are deleted together with the secret.
+		chunkKeys, err := collectSecretChunks(ctx, queries, req)
+		if err != nil {
+			return err
+		}
+
+		objectKeys = append(objectKeys, chunkKeys...)
+
+		tombstone = secret.NewTombstone(req, row.SecretID)
+
+		if err := queries.CreateSecretTombstone(ctx, ToCreateSecretTombstoneParams(tombstone)); err != nil {
+			return err
+		}
+
+		return repo.recordSecretEvent(ctx, queries, secret.NewDeleteEvent(tombstone))
+	}
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		return pg.WithinTrx(ctx, repo.connPool, pgx.TxOptions{}, queryFn)(repo.queries)
+	})
+
+	if errors.Is(dbErr, e.ErrNotFound) || errors.Is(dbErr, e.ErrConflict) {
+		return nil, dbErr
+	}
+
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to delete secret")
+		return nil, e.InternalErr(dbErr)
+	}
+
+	// secret is already deleted at this point, so leftover objects are only logged.
+	if err := repo.s3client.RemoveObjects(ctx, req.User.BucketName, objectKeys); err != nil {
+		logCtx.Warn().Err(err).
+			Strs("objects", objectKeys).
+			Msg("failed to remove secret objects")
+	}
+
+	return tombstone, nil
+}
+
+// ListSecretTombstones returns user secrets deleted after since ordered by deletion time.
+func (repo *SecretRepo) ListSecretTombstones(
+	ctx context.Context,
+	userID uuid.UUID,
+	since time.Time,
+) ([]*secret.Tombstone, error) {
+	var rows []pg.SecretTombstone
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		rows, err = repo.queries.ListSecretTombstones(ctx, pg.ListSecretTombstonesParams{
+			UserID:       userID,
+			DeletedAfter: since,
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		repo.log.Error().Err(dbErr).
+			Str("repo", "SecretRepo").
+			Str("operation", "ListSecretTombstones").
+			Str("user_id", userID.String()).
+			Msg("failed to list secret tombstones")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	tombstones := make([]*secret.Tombstone, 0, len(rows))
+	for _, row := range rows {
+		tombstones = append(tombstones, FromPGSecretTombstone(row))
+	}
+
+	return tombstones, nil
+}
+
+// ListSecretKeys returns wrapped DEKs of all versions of user secrets.
+func (repo *SecretRepo) ListSecretKeys(ctx context.Context, userID uuid.UUID) ([]*secret.WrappedKey, error) {
+	var rows []pg.ListUserSecretKeysRow
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		rows, err = repo.queries.ListUserSecretKeys(ctx, userID)
+
+		return err
+	})
+	if dbErr != nil {
+		repo.log.Error().Err(dbErr).
+			Str("repo", "SecretRepo").
+			Str("operation", "ListSecretKeys").
+			Str("user_id", userID.String()).
+			Msg("failed to list secret keys")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	keys := make([]*secret.WrappedKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, FromPGSecretKey(row))
+	}
+
+	return keys, nil
+}
+
+// recordSecretEvent appends event to the change feed within the transaction of the change
+// and notifies watching replicas once the transaction is committed.
+func (repo *SecretRepo) recordSecretEvent(ctx context.Context, queries *pg.Queries, ev *secret.Event) error {
+	cursor, err := queries.CreateSecretEvent(ctx, ToCreateSecretEventParams(ev))
+	if err != nil {
+		return err
+	}
+
+	ev.Cursor = cursor
+
+	return queries.NotifySecretEvent(ctx, pg.NotifySecretEventParams{
+		Channel: pg.SecretEventsChannel,
+		Payload: ev.UserID.String(),
+	})
+}
+
+// ListSecretEvents returns a batch of user secret events recorded after the request cursor ordered by cursor.
+func (repo *SecretRepo) ListSecretEvents(
+	ctx context.Context,
+	req *secret.WatchRequest,
+	batchSize int32,
+) ([]*secret.Event, error) {
+	var rows []pg.SecretEvent
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		rows, err = repo.queries.ListSecretEvents(ctx, pg.ListSecretEventsParams{
+			UserID:    req.UserID,
+			AfterID:   req.Cursor,
+			BatchSize: batchSize,
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		repo.log.Error().Err(dbErr).
+			Str("repo", "SecretRepo").
+			Str("operation", "ListSecretEvents").
+			Str("user_id", req.UserID.String()).
+			Int64("cursor", req.Cursor).
+			Msg("failed to list secret events")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	events := make([]*secret.Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, FromPGSecretEvent(row))
+	}
+
+	return events, nil
+}
+
+// ExpireSecretInitRequests moves a batch of upload requests expired before expiredBefore
+// to completed requests with expired status and removes their orphaned S3 objects.
+// Batch is processed under transaction level advisory lock, so that only one replica cleans up at a time,
+// report is marked as skipped if the lock is held by another replica.
+func (repo *SecretRepo) ExpireSecretInitRequests(
+	ctx context.Context,
+	expiredBefore time.Time,
+	batchSize int32,
+) (*secret.ExpireReport, error) {
+	logCtx := repo.log.With().
+		Str("repo", "SecretRepo").
+		Str("operation", "ExpireSecretInitRequests").Logger()
+
+	var (
+		report  *secret.ExpireReport
+		orphans map[string][]string
+		uploads map[string][]string
+		chunked map[uuid.UUID]string
+	)
+
+	queryFn := func(queries *pg.Queries) error {
+		report = &secret.ExpireReport{}
+		orphans = make(map[string][]string)
+		uploads = make(map[string][]string)
+		chunked = make(map[uuid.UUID]string)
+
+		locked, err := queries.TryAdvisoryXactLock(ctx, expireLockKey)
+		if err != nil {
+			return err
+		}
+
+		if !locked {
+			report.Skipped = true
+			return nil
+		}
+
+		rows, err := queries.ListExpiredSecretInitRequests(ctx, pg.ListExpiredSecretInitRequestsParams{
+			ExpiredBefore: expiredBefore,
+			BatchSize:     batchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			initReq := FromListExpiredSecretInitRequestsRow(row)
+
+			err := queries.CreateSecretCommitRequest(ctx, ToCreateSecretCommitRequestParams(secret.NewExpiredRequest(initReq)))
+			if err != nil {
+				return err
+			}
+
+			if err := queries.DeleteSecretInitRequest(ctx, pg.DeleteSecretInitRequestParams{
+				UserID:   initReq.UserID,
+				SecretID: initReq.SecretID,
+			}); err != nil {
+				return err
+			}
+
+			uploads[row.BucketName] = append(uploads[row.BucketName], row.S3Url)
+
+			if initReq.RequestType == secret.RequestTypePut && initReq.MetaData.Chunked() {
+				chunked[initReq.UserID] = row.BucketName
+			}
+
+			if row.Orphaned {
+				orphans[row.BucketName] = append(orphans[row.BucketName], row.S3Url)
+				report.OrphanedObjects++
+			}
+
+			report.Expired++
+		}
+
+		return nil
+	}
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		return pg.WithinTrx(ctx, repo.connPool, pgx.TxOptions{}, queryFn)(repo.queries)
+	})
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to expire secret init requests")
+		return nil, e.InternalErr(dbErr)
+	}
+
+	// requests are already expired at this point, so leftover uploads and objects are only logged.
+	for bucketName, objectKeys := range uploads {
+		for _, objectKey := range objectKeys {
+			if err := repo.s3client.AbortMultipartUploads(ctx, bucketName, objectKey); err != nil {
+				logCtx.Warn().Err(err).
+					Str("bucket", bucketName).
+					Str("object_key", objectKey).
+					Msg("failed to abort multipart uploads of expired request")
+
+				continue
+			}
+
+			report.AbortedUploads++
+		}
+	}
+
+	for bucketName, objectKeys := range orphans {
+		if err := repo.s3client.RemoveObjects(ctx, bucketName, objectKeys); err != nil {
+			logCtx.Warn().Err(err).
+				Str("bucket", bucketName).
+				Strs("objects", objectKeys).
+				Msg("failed to remove orphaned objects")
+
+			continue
+		}
+
+		report.RemovedObjects += len(objectKeys)
+	}
+
+	// chunks are only registered on commit, so ones uploaded for expired requests are collected here.
+	for userID, bucketName := range chunked {
+		removed, err := repo.removeOrphanedChunks(ctx, userID, bucketName, expiredBefore)
+		if err != nil {
+			logCtx.Warn().Err(err).
+				Str("user_id", userID.String()).
+				Str("bucket", bucketName).
+				Msg("failed to remove orphaned chunks")
+
+			continue
+		}
+
+		report.RemovedChunks += removed
+	}
+
+	return report, nil
 }
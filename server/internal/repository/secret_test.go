@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,8 +12,15 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/crypto/checksum"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
@@ -24,10 +32,6 @@ import (
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/mock"
 	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
-	"github.com/rs/zerolog"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-	"go.uber.org/mock/gomock"
 )
 
 func defaultSecretInitRequest(t *testing.T) *secret.InitRequest {
@@ -47,7 +51,7 @@ func defaultSecretInitRequest(t *testing.T) *secret.InitRequest {
 		Token:           123,
 		ClientInfo:      "test-client",
 		SecretSize:      1024,
-		SecretHash:      []byte("hash"),
+		SecretHash:      checksum.Sum([]byte("secret")),
 		SecretDEK:       []byte("dek"),
 		MetaData:        secret.MetaData{"key": "value"},
 		CreatedAt:       time.Now().UTC(),
@@ -100,7 +104,7 @@ func mockSuccessCase(
 		Return(&s3.TemporaryCredentials{AccessKeyID: "key", SecretAccessKey: "secret"}, nil)
 }
 
-func mockInvalidParentVersion(
+func mockOutdatedParentVersion(
 	t *testing.T,
 	pool pgxmock.PgxPoolIface,
 	_ *mock.MockIdentityManager,
@@ -119,6 +123,9 @@ func mockInvalidParentVersion(
 			req.SecretHash, req.SecretDEK, meta, req.CreatedAt, req.ExpiresAt,
 		).
 		WillReturnError(sql.ErrNoRows)
+	pool.ExpectQuery(`SELECT current_version_id`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(pgxmock.NewRows([]string{"current_version_id"}).AddRow(uuid.New()))
 }
 
 func mockConflictVersionOrTime(
@@ -254,9 +261,9 @@ func TestSecretRepoCreateSecretInitRequest(t *testing.T) {
 			expectErr:    nil,
 		},
 		{
-			name:         "invalid parent version",
-			mockBehavior: mockInvalidParentVersion,
-			expectErr:    e.ErrInvalidInput,
+			name:         "outdated parent version",
+			mockBehavior: mockOutdatedParentVersion,
+			expectErr:    e.ErrConflict,
 		},
 		{
 			name:         "conflict version or outdated time",
@@ -367,7 +374,7 @@ func TestSecretRepoCreateSecretInitRequestIntegration(t *testing.T) {
 				Token:           123,
 				ClientInfo:      "test-agent",
 				SecretSize:      100,
-				SecretHash:      []byte("hash"),
+				SecretHash:      checksum.Sum([]byte("secret")),
 				SecretDEK:       []byte("dek"),
 				MetaData:        secret.MetaData{"k1": "v1"},
 				CreatedAt:       time.Now().UTC(),
@@ -386,3 +393,1288 @@ func TestSecretRepoCreateSecretInitRequestIntegration(t *testing.T) {
 	wg.Wait()
 	assert.Equal(t, failed, count.Load())
 }
+
+func commitRequestFromInit(t *testing.T, req *secret.InitRequest) *secret.CommitRequest {
+	t.Helper()
+
+	return &secret.CommitRequest{
+		UserID:          req.UserID,
+		SecretID:        req.SecretID,
+		VersionID:       req.VersionID,
+		ParentVersionID: req.ParentVersionID,
+		RequestType:     secret.RequestTypePut,
+		Token:           req.Token,
+		ClientInfo:      req.ClientInfo,
+		SecretSize:      req.SecretSize,
+		SecretHash:      req.SecretHash,
+		SecretDEK:       req.SecretDEK,
+		CommittedBy:     secret.RequestCommitterUser,
+		User:            req.User,
+	}
+}
+
+func initRequestRows(t *testing.T, req *secret.InitRequest) *pgxmock.Rows {
+	t.Helper()
+
+	meta, err := req.MetaData.MarshalJSON()
+	require.NoError(t, err)
+
+	return pgxmock.NewRows([]string{
+		"user_id", "secret_id", "secret_name", "s3_url", "version_id", "parent_version_id",
+		"request_type", "token", "client_info", "secret_size", "secret_hash", "secret_dek",
+		"meta", "created_at", "expires_at",
+	}).AddRow(
+		req.UserID, req.SecretID, req.SecretName, req.S3URL, req.VersionID, req.ParentVersionID,
+		pg.RequestType(req.RequestType), req.Token, req.ClientInfo, req.SecretSize,
+		req.SecretHash, req.SecretDEK, meta, req.CreatedAt, req.ExpiresAt,
+	)
+}
+
+func anyArgs(n int) []any {
+	args := make([]any, n)
+	for i := range args {
+		args[i] = pgxmock.AnyArg()
+	}
+
+	return args
+}
+
+func expectSecretEvent(pool pgxmock.PgxPoolIface) {
+	pool.ExpectQuery(`INSERT INTO secret_events`).
+		WithArgs(anyArgs(7)...).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	pool.ExpectExec(`SELECT pg_notify`).
+		WithArgs(pg.SecretEventsChannel, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+}
+
+func expectS3Object(s3Client *mock.MockServerOperator, req *secret.InitRequest, size int64) {
+	s3Client.EXPECT().
+		StatObject(gomock.Any(), req.User.BucketName, req.S3URL).
+		Return(s3.ObjectInfo{Size: size, ChecksumSHA256: checksum.S3(req.SecretHash)}, nil)
+}
+
+type commitMockBehavior func(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+)
+
+func mockCommitNewSecret(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+
+	expectS3Object(s3Client, req, req.SecretSize)
+	expectNewSecretCommitted(t, pool, req)
+}
+
+// expectNewSecretCommitted expects verified upload of the new secret to be committed.
+func expectNewSecretCommitted(t *testing.T, pool pgxmock.PgxPoolIface, req *secret.InitRequest) {
+	t.Helper()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+	pool.ExpectExec(`INSERT INTO secret_requests_completed`).
+		WithArgs(anyArgs(16)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec(`DELETE FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectExec(`INSERT INTO secrets`).
+		WithArgs(anyArgs(6)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec(`INSERT INTO secret_versions`).
+		WithArgs(anyArgs(9)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	expectSecretEvent(pool)
+	pool.ExpectExec(`INSERT INTO secret_meta`).
+		WithArgs(anyArgs(4)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectCommit()
+}
+
+func mockCommitNewVersion(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	req.ParentVersionID = uuid.New()
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+
+	expectS3Object(s3Client, req, req.SecretSize)
+
+	pool.ExpectBegin()
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+	pool.ExpectExec(`INSERT INTO secret_requests_completed`).
+		WithArgs(anyArgs(16)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec(`DELETE FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectExec(`INSERT INTO secret_versions`).
+		WithArgs(anyArgs(9)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec(`UPDATE secrets`).
+		WithArgs(anyArgs(5)...).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	expectSecretEvent(pool)
+	pool.ExpectExec(`INSERT INTO secret_meta`).
+		WithArgs(anyArgs(4)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectCommit()
+}
+
+func mockCommitVersionConflict(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	req.ParentVersionID = uuid.New()
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+
+	expectS3Object(s3Client, req, req.SecretSize)
+
+	pool.ExpectBegin()
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+	pool.ExpectExec(`INSERT INTO secret_requests_completed`).
+		WithArgs(anyArgs(16)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec(`DELETE FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectExec(`INSERT INTO secret_versions`).
+		WithArgs(anyArgs(9)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec(`UPDATE secrets`).
+		WithArgs(anyArgs(5)...).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	pool.ExpectRollback()
+}
+
+func mockCommitRequestNotFound(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	_ *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnError(pgx.ErrNoRows)
+}
+
+func mockCommitInvalidToken(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	_ *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	dbReq := *req
+	dbReq.Token = req.Token + 1
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, &dbReq))
+}
+
+func mockCommitObjectSizeMismatch(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+
+	expectS3Object(s3Client, req, req.SecretSize-1)
+}
+
+func mockCommitObjectChecksumMismatch(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+
+	s3Client.EXPECT().
+		StatObject(gomock.Any(), req.User.BucketName, req.S3URL).
+		Return(s3.ObjectInfo{Size: req.SecretSize, ChecksumSHA256: checksum.S3(checksum.Sum([]byte("corrupted")))}, nil)
+}
+
+// multipartHashes returns checksums of parts the test object is uploaded in.
+func multipartHashes() [][]byte {
+	return [][]byte{checksum.Sum([]byte("part1")), checksum.Sum([]byte("part2"))}
+}
+
+// expectMultipartS3Object expects object uploaded in two parts with composite checksum
+// to be hashed as a whole with sum as the result.
+func expectMultipartS3Object(s3Client *mock.MockServerOperator, req *secret.InitRequest, sum []byte) {
+	s3Client.EXPECT().
+		StatObject(gomock.Any(), req.User.BucketName, req.S3URL).
+		Return(s3.ObjectInfo{Size: req.SecretSize, ChecksumSHA256: checksum.S3Composite(multipartHashes()...)}, nil)
+	s3Client.EXPECT().
+		ObjectChecksum(gomock.Any(), req.User.BucketName, req.S3URL).
+		Return(sum, nil)
+}
+
+func mockCommitMultipartObject(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+
+	expectMultipartS3Object(s3Client, req, req.SecretHash)
+	expectNewSecretCommitted(t, pool, req)
+}
+
+func mockCommitMultipartObjectPartsCorrupted(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+
+	// parts checksums reported by client do not compose the stored one, object is not read.
+	s3Client.EXPECT().
+		StatObject(gomock.Any(), req.User.BucketName, req.S3URL).
+		Return(s3.ObjectInfo{Size: req.SecretSize, ChecksumSHA256: checksum.S3Composite(multipartHashes()...)}, nil)
+}
+
+func mockCommitMultipartObjectMismatch(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+
+	expectMultipartS3Object(s3Client, req, checksum.Sum([]byte("other content")))
+}
+
+func mockCommitChunkNotUploaded(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	chunkID := strings.Repeat("ab", 32)
+	req.MetaData = secret.MetaData{secret.MetaKeyStorage: secret.StorageChunked}
+
+	manifest := secret.NewManifest()
+	manifest.Size = 10
+	manifest.Chunks = append(manifest.Chunks, secret.ChunkRef{ID: chunkID, Size: 42})
+	data, err := manifest.Marshal()
+	require.NoError(t, err)
+
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnRows(initRequestRows(t, req))
+
+	expectS3Object(s3Client, req, req.SecretSize)
+
+	s3Client.EXPECT().
+		ReadObject(gomock.Any(), req.User.BucketName, req.S3URL, int64(secret.MaxManifestSize)).
+		Return(data, nil)
+	pool.ExpectQuery(`FROM secret_chunks`).
+		WithArgs(req.UserID, []string{chunkID}).
+		WillReturnRows(pgxmock.NewRows([]string{"chunk_id"}))
+	s3Client.EXPECT().
+		StatObject(gomock.Any(), req.User.BucketName, secret.ChunkObjectKey(chunkID)).
+		Return(s3.ObjectInfo{}, e.ErrNotFound)
+}
+
+//nolint:funlen // reason: allow table driven testing func to be lengthy.
+func TestSecretRepoCreateSecretCommitRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mockBehavior commitMockBehavior
+		partHashes   [][]byte
+		expectErr    error
+	}{
+		{
+			name:         "success new secret",
+			mockBehavior: mockCommitNewSecret,
+			expectErr:    nil,
+		},
+		{
+			name:         "success new version",
+			mockBehavior: mockCommitNewVersion,
+			expectErr:    nil,
+		},
+		{
+			name:         "current version conflict",
+			mockBehavior: mockCommitVersionConflict,
+			expectErr:    e.ErrConflict,
+		},
+		{
+			name:         "request not found",
+			mockBehavior: mockCommitRequestNotFound,
+			expectErr:    e.ErrNotFound,
+		},
+		{
+			name:         "invalid token",
+			mockBehavior: mockCommitInvalidToken,
+			expectErr:    e.ErrInvalidInput,
+		},
+		{
+			name:         "object size mismatch",
+			mockBehavior: mockCommitObjectSizeMismatch,
+			expectErr:    e.ErrInvalidInput,
+		},
+		{
+			name:         "object checksum mismatch",
+			mockBehavior: mockCommitObjectChecksumMismatch,
+			expectErr:    e.ErrInvalidInput,
+		},
+		{
+			name:         "success multipart object",
+			mockBehavior: mockCommitMultipartObject,
+			partHashes:   multipartHashes(),
+			expectErr:    nil,
+		},
+		{
+			name:         "success multipart object without parts checksums",
+			mockBehavior: mockCommitMultipartObject,
+			expectErr:    nil,
+		},
+		{
+			name:         "multipart object parts corrupted",
+			mockBehavior: mockCommitMultipartObjectPartsCorrupted,
+			partHashes:   [][]byte{multipartHashes()[0], checksum.Sum([]byte("corrupted"))},
+			expectErr:    e.ErrInvalidInput,
+		},
+		{
+			name:         "multipart object content differs from declared hash",
+			mockBehavior: mockCommitMultipartObjectMismatch,
+			partHashes:   multipartHashes(),
+			expectErr:    e.ErrInvalidInput,
+		},
+		{
+			name:         "chunk not uploaded",
+			mockBehavior: mockCommitChunkNotUploaded,
+			expectErr:    e.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			initReq := defaultSecretInitRequest(t)
+			idClient := mock.NewMockIdentityManager(ctrl)
+			s3Client := mock.NewMockServerOperator(ctrl)
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			db := &pg.DB{ConnPool: mockPool}
+			repo := repository.NewSecretRepo(db, s3Client, idClient, log)
+
+			tt.mockBehavior(t, mockPool, s3Client, initReq)
+
+			req := commitRequestFromInit(t, initReq)
+			req.PartHashes = tt.partHashes
+
+			result, err := repo.CreateSecretCommitRequest(context.Background(), req)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				require.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Equal(t, secret.RequestStatusCompleted, result.Status)
+				assert.Equal(t, initReq.SecretName, result.SecretName)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func currentVersionRows(t *testing.T, req *secret.InitRequest) *pgxmock.Rows {
+	t.Helper()
+
+	meta, err := req.MetaData.MarshalJSON()
+	require.NoError(t, err)
+
+	return pgxmock.NewRows([]string{
+		"secret_id", "secret_name", "version_id", "parent_version_id", "s3_url",
+		"secret_size", "secret_hash", "secret_dek", "meta", "created_at", "current_version_id",
+	}).AddRow(
+		req.SecretID, req.SecretName, req.VersionID, req.ParentVersionID, req.S3URL,
+		req.SecretSize, req.SecretHash, req.SecretDEK, meta, req.CreatedAt, req.VersionID,
+	)
+}
+
+//nolint:funlen // reason: allow table driven testing func to be lengthy.
+func TestSecretRepoCreateSecretGetRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		byVersion    bool
+		mockBehavior mockBehavior
+		expectErr    error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(
+				t *testing.T,
+				pool pgxmock.PgxPoolIface,
+				idClient *mock.MockIdentityManager,
+				s3Client *mock.MockServerOperator,
+				req *secret.InitRequest,
+			) {
+				t.Helper()
+
+				pool.ExpectQuery(`FROM secrets`).
+					WithArgs(req.UserID, req.SecretName).
+					WillReturnRows(currentVersionRows(t, req))
+				pool.ExpectExec(`INSERT INTO secret_requests_completed`).
+					WithArgs(anyArgs(16)...).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				idClient.EXPECT().
+					GetToken(gomock.Any(), req.User).
+					Return(&user.IdentityToken{AccessToken: "token"}, nil)
+
+				s3Client.EXPECT().
+					AssumeReadOnlyRole(gomock.Any(), "token", req.User.BucketName, req.UploadDuration()).
+					Return(&s3.TemporaryCredentials{AccessKeyID: "key", SecretAccessKey: "secret"}, nil)
+			},
+			expectErr: nil,
+		},
+		{
+			name:      "success by version",
+			byVersion: true,
+			mockBehavior: func(
+				t *testing.T,
+				pool pgxmock.PgxPoolIface,
+				idClient *mock.MockIdentityManager,
+				s3Client *mock.MockServerOperator,
+				req *secret.InitRequest,
+			) {
+				t.Helper()
+
+				pool.ExpectQuery(`FROM secrets`).
+					WithArgs(req.UserID, req.SecretName, req.VersionID).
+					WillReturnRows(currentVersionRows(t, req))
+				pool.ExpectExec(`INSERT INTO secret_requests_completed`).
+					WithArgs(anyArgs(16)...).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				idClient.EXPECT().
+					GetToken(gomock.Any(), req.User).
+					Return(&user.IdentityToken{AccessToken: "token"}, nil)
+
+				s3Client.EXPECT().
+					AssumeReadOnlyRole(gomock.Any(), "token", req.User.BucketName, req.UploadDuration()).
+					Return(&s3.TemporaryCredentials{AccessKeyID: "key", SecretAccessKey: "secret"}, nil)
+			},
+			expectErr: nil,
+		},
+		{
+			name: "secret not found",
+			mockBehavior: func(
+				t *testing.T,
+				pool pgxmock.PgxPoolIface,
+				_ *mock.MockIdentityManager,
+				_ *mock.MockServerOperator,
+				req *secret.InitRequest,
+			) {
+				t.Helper()
+
+				pool.ExpectQuery(`FROM secrets`).
+					WithArgs(req.UserID, req.SecretName).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectErr: e.ErrNotFound,
+		},
+		{
+			name:      "version not found",
+			byVersion: true,
+			mockBehavior: func(
+				t *testing.T,
+				pool pgxmock.PgxPoolIface,
+				_ *mock.MockIdentityManager,
+				_ *mock.MockServerOperator,
+				req *secret.InitRequest,
+			) {
+				t.Helper()
+
+				pool.ExpectQuery(`FROM secrets`).
+					WithArgs(req.UserID, req.SecretName, req.VersionID).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectErr: e.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stored := defaultSecretInitRequest(t)
+			idClient := mock.NewMockIdentityManager(ctrl)
+			s3Client := mock.NewMockServerOperator(ctrl)
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			db := &pg.DB{ConnPool: mockPool}
+			repo := repository.NewSecretRepo(db, s3Client, idClient, log)
+
+			tt.mockBehavior(t, mockPool, idClient, s3Client, stored)
+
+			req := &secret.InitRequest{
+				UserID:      stored.UserID,
+				User:        stored.User,
+				SecretName:  stored.SecretName,
+				RequestType: secret.RequestTypeGet,
+				ClientInfo:  "test-client",
+				CreatedAt:   time.Now().UTC(),
+			}
+
+			if tt.byVersion {
+				req.VersionID = stored.VersionID
+			}
+
+			result, err := repo.CreateSecretGetRequest(context.Background(), req)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				require.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Equal(t, stored.SecretID, result.SecretID)
+				assert.Equal(t, stored.VersionID, result.VersionID)
+				assert.Equal(t, stored.SecretDEK, result.SecretDEK)
+				assert.Equal(t, stored.VersionID, result.CurrentVersionID)
+				assert.NotNil(t, result.S3Creds)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSecretRepoListSecrets(t *testing.T) {
+	t.Parallel()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+	db := &pg.DB{ConnPool: mockPool}
+	repo := repository.NewSecretRepo(db, mock.NewMockServerOperator(ctrl), mock.NewMockIdentityManager(ctrl), log)
+
+	req := &secret.ListRequest{UserID: uuid.New(), NamePrefix: "te", AfterName: "a", PageSize: 2}
+	now := time.Now().UTC()
+
+	mockPool.ExpectQuery(`FROM secrets`).
+		WithArgs(req.UserID, req.NamePrefix, req.AfterName, req.PageSize).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"secret_id", "secret_name", "current_version_id", "parent_version_id",
+			"secret_size", "secret_hash", "created_at", "updated_at",
+		}).
+			AddRow(uuid.New(), "test1", uuid.New(), uuid.Nil, int64(10), []byte("h1"), now, now).
+			AddRow(uuid.New(), "test2", uuid.New(), uuid.New(), int64(20), []byte("h2"), now, now))
+
+	secrets, err := repo.ListSecrets(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+	assert.Equal(t, "test1", secrets[0].Name)
+	assert.Equal(t, int64(20), secrets[1].CurrentVersion.SecretSize)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSecretRepoListSecretVersions(t *testing.T) {
+	t.Parallel()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+	db := &pg.DB{ConnPool: mockPool}
+	repo := repository.NewSecretRepo(db, mock.NewMockServerOperator(ctrl), mock.NewMockIdentityManager(ctrl), log)
+
+	userID, secretID := uuid.New(), uuid.New()
+	first, second := uuid.New(), uuid.New()
+	now := time.Now().UTC()
+	columns := []string{
+		"secret_id", "secret_name", "current_version_id", "version_id",
+		"parent_version_id", "secret_size", "secret_hash", "created_at",
+	}
+
+	mockPool.ExpectQuery(`FROM secrets`).
+		WithArgs(userID, "test").
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow(secretID, "test", second, second, first, int64(20), []byte("h2"), now).
+			AddRow(secretID, "test", second, first, uuid.Nil, int64(10), []byte("h1"), now))
+
+	history, err := repo.ListSecretVersions(context.Background(), userID, "test")
+	require.NoError(t, err)
+	assert.Equal(t, secretID, history.SecretID)
+	assert.Equal(t, second, history.CurrentVersionID)
+	require.Len(t, history.Versions, 2)
+	assert.Equal(t, first, history.Versions[0].ParentID)
+	assert.Equal(t, uuid.Nil, history.Versions[1].ParentID)
+
+	mockPool.ExpectQuery(`FROM secrets`).
+		WithArgs(userID, "missing").
+		WillReturnRows(pgxmock.NewRows(columns))
+
+	_, err = repo.ListSecretVersions(context.Background(), userID, "missing")
+	require.ErrorIs(t, err, e.ErrNotFound)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+//nolint:funlen // reason: allow table driven testing func to be lengthy.
+func TestSecretRepoDeleteSecret(t *testing.T) {
+	t.Parallel()
+
+	type deleteMockBehavior func(
+		pool pgxmock.PgxPoolIface,
+		s3Client *mock.MockServerOperator,
+		req *secret.DeleteRequest,
+		secretID uuid.UUID,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior deleteMockBehavior
+		expectErr    error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(
+				pool pgxmock.PgxPoolIface,
+				s3Client *mock.MockServerOperator,
+				req *secret.DeleteRequest,
+				secretID uuid.UUID,
+			) {
+				pool.ExpectBegin()
+				pool.ExpectQuery(`FROM secrets`).
+					WithArgs(req.UserID, req.SecretName).
+					WillReturnRows(pgxmock.NewRows([]string{"secret_id", "current_version_id"}).
+						AddRow(secretID, req.VersionID))
+				pool.ExpectQuery(`FROM secret_versions`).
+					WithArgs(req.UserID, secretID).
+					WillReturnRows(pgxmock.NewRows([]string{"s3_url"}).AddRow("v1").AddRow("v2"))
+				pool.ExpectExec(`DELETE FROM secrets`).
+					WithArgs(req.UserID, secretID, req.VersionID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				pool.ExpectExec(`DELETE FROM secret_requests_in_progress`).
+					WithArgs(req.UserID, secretID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				pool.ExpectExec(`pg_advisory_xact_lock`).
+					WithArgs(req.UserID).
+					WillReturnResult(pgxmock.NewResult("SELECT", 1))
+				pool.ExpectQuery(`DELETE FROM secret_chunks`).
+					WithArgs(req.UserID).
+					WillReturnRows(pgxmock.NewRows([]string{"chunk_id"}).AddRow("c1"))
+				pool.ExpectExec(`INSERT INTO secret_tombstones`).
+					WithArgs(anyArgs(6)...).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				expectSecretEvent(pool)
+				pool.ExpectCommit()
+
+				s3Client.EXPECT().
+					RemoveObjects(gomock.Any(), req.User.BucketName, []string{"v1", "v2", "chunks/c1.chunk"}).
+					Return(nil)
+			},
+			expectErr: nil,
+		},
+		{
+			name: "secret not found",
+			mockBehavior: func(
+				pool pgxmock.PgxPoolIface,
+				_ *mock.MockServerOperator,
+				req *secret.DeleteRequest,
+				_ uuid.UUID,
+			) {
+				pool.ExpectBegin()
+				pool.ExpectQuery(`FROM secrets`).
+					WithArgs(req.UserID, req.SecretName).
+					WillReturnError(pgx.ErrNoRows)
+				pool.ExpectRollback()
+			},
+			expectErr: e.ErrNotFound,
+		},
+		{
+			name: "current version conflict",
+			mockBehavior: func(
+				pool pgxmock.PgxPoolIface,
+				_ *mock.MockServerOperator,
+				req *secret.DeleteRequest,
+				secretID uuid.UUID,
+			) {
+				pool.ExpectBegin()
+				pool.ExpectQuery(`FROM secrets`).
+					WithArgs(req.UserID, req.SecretName).
+					WillReturnRows(pgxmock.NewRows([]string{"secret_id", "current_version_id"}).
+						AddRow(secretID, uuid.New()))
+				pool.ExpectRollback()
+			},
+			expectErr: e.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			initReq := defaultSecretInitRequest(t)
+			s3Client := mock.NewMockServerOperator(ctrl)
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			db := &pg.DB{ConnPool: mockPool}
+			repo := repository.NewSecretRepo(db, s3Client, mock.NewMockIdentityManager(ctrl), log)
+
+			req := &secret.DeleteRequest{
+				UserID:     initReq.UserID,
+				SecretName: initReq.SecretName,
+				VersionID:  initReq.VersionID,
+				ClientInfo: "test-client",
+				User:       initReq.User,
+			}
+
+			tt.mockBehavior(mockPool, s3Client, req, initReq.SecretID)
+
+			tombstone, err := repo.DeleteSecret(context.Background(), req)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				require.Nil(t, tombstone)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, initReq.SecretID, tombstone.SecretID)
+				assert.Equal(t, req.VersionID, tombstone.VersionID)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func expiredRequestRows(t *testing.T, req *secret.InitRequest, orphaned bool) *pgxmock.Rows {
+	t.Helper()
+
+	meta, err := req.MetaData.MarshalJSON()
+	require.NoError(t, err)
+
+	return pgxmock.NewRows([]string{
+		"user_id", "secret_id", "secret_name", "s3_url", "version_id", "parent_version_id",
+		"request_type", "token", "client_info", "secret_size", "secret_hash", "secret_dek",
+		"meta", "created_at", "expires_at", "bucket_name", "orphaned",
+	}).AddRow(
+		req.UserID, req.SecretID, req.SecretName, req.S3URL, req.VersionID, req.ParentVersionID,
+		pg.RequestType(req.RequestType), req.Token, req.ClientInfo, req.SecretSize,
+		req.SecretHash, req.SecretDEK, meta, req.CreatedAt, req.ExpiresAt, req.User.BucketName, orphaned,
+	)
+}
+
+// mockExpireChunkedRequest expects chunked upload request to be expired without orphaned manifest.
+func mockExpireChunkedRequest(
+	t *testing.T,
+	pool pgxmock.PgxPoolIface,
+	s3Client *mock.MockServerOperator,
+	req *secret.InitRequest,
+) {
+	t.Helper()
+
+	req.MetaData[secret.MetaKeyStorage] = secret.StorageChunked
+
+	pool.ExpectBegin()
+	pool.ExpectQuery(`pg_try_advisory_xact_lock`).
+		WithArgs(anyArgs(1)...).
+		WillReturnRows(pgxmock.NewRows([]string{"locked"}).AddRow(true))
+	pool.ExpectQuery(`FROM secret_requests_in_progress`).
+		WithArgs(anyArgs(2)...).
+		WillReturnRows(expiredRequestRows(t, req, false))
+	pool.ExpectExec(`INSERT INTO secret_requests_completed`).
+		WithArgs(anyArgs(16)...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec(`DELETE FROM secret_requests_in_progress`).
+		WithArgs(req.UserID, req.SecretID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectCommit()
+
+	s3Client.EXPECT().
+		AbortMultipartUploads(gomock.Any(), req.User.BucketName, req.S3URL).
+		Return(nil)
+}
+
+// bucketChunks returns chunk objects: registered and unregistered ones uploaded before cleanup,
+// unregistered one uploaded after cleanup started and an object which is not a chunk.
+func bucketChunks(registered, orphaned, fresh string) []s3.ObjectInfo {
+	uploaded := time.Now().UTC().Add(-time.Hour)
+
+	return []s3.ObjectInfo{
+		{Key: secret.ChunkObjectKey(registered), LastModified: uploaded},
+		{Key: secret.ChunkObjectKey(orphaned), LastModified: uploaded},
+		{Key: secret.ChunkObjectKey(fresh), LastModified: time.Now().UTC().Add(time.Hour)},
+		{Key: secret.ChunkKeyPrefix + "unknown", LastModified: uploaded},
+	}
+}
+
+func TestSecretRepoExpireSecretInitRequests(t *testing.T) {
+	t.Parallel()
+
+	type expireMockBehavior func(
+		pool pgxmock.PgxPoolIface,
+		s3Client *mock.MockServerOperator,
+		req *secret.InitRequest,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior expireMockBehavior
+		expected     *secret.ExpireReport
+	}{
+		{
+			name: "orphaned upload removed",
+			mockBehavior: func(pool pgxmock.PgxPoolIface, s3Client *mock.MockServerOperator, req *secret.InitRequest) {
+				pool.ExpectBegin()
+				pool.ExpectQuery(`pg_try_advisory_xact_lock`).
+					WithArgs(anyArgs(1)...).
+					WillReturnRows(pgxmock.NewRows([]string{"locked"}).AddRow(true))
+				pool.ExpectQuery(`FROM secret_requests_in_progress`).
+					WithArgs(anyArgs(2)...).
+					WillReturnRows(expiredRequestRows(t, req, true))
+				pool.ExpectExec(`INSERT INTO secret_requests_completed`).
+					WithArgs(anyArgs(16)...).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				pool.ExpectExec(`DELETE FROM secret_requests_in_progress`).
+					WithArgs(req.UserID, req.SecretID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				pool.ExpectCommit()
+
+				s3Client.EXPECT().
+					AbortMultipartUploads(gomock.Any(), req.User.BucketName, req.S3URL).
+					Return(nil)
+				s3Client.EXPECT().
+					RemoveObjects(gomock.Any(), req.User.BucketName, []string{req.S3URL}).
+					Return(nil)
+			},
+			expected: &secret.ExpireReport{Expired: 1, OrphanedObjects: 1, RemovedObjects: 1, AbortedUploads: 1},
+		},
+		{
+			name: "multipart upload of referenced object aborted",
+			mockBehavior: func(pool pgxmock.PgxPoolIface, s3Client *mock.MockServerOperator, req *secret.InitRequest) {
+				pool.ExpectBegin()
+				pool.ExpectQuery(`pg_try_advisory_xact_lock`).
+					WithArgs(anyArgs(1)...).
+					WillReturnRows(pgxmock.NewRows([]string{"locked"}).AddRow(true))
+				pool.ExpectQuery(`FROM secret_requests_in_progress`).
+					WithArgs(anyArgs(2)...).
+					WillReturnRows(expiredRequestRows(t, req, false))
+				pool.ExpectExec(`INSERT INTO secret_requests_completed`).
+					WithArgs(anyArgs(16)...).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				pool.ExpectExec(`DELETE FROM secret_requests_in_progress`).
+					WithArgs(req.UserID, req.SecretID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				pool.ExpectCommit()
+
+				s3Client.EXPECT().
+					AbortMultipartUploads(gomock.Any(), req.User.BucketName, req.S3URL).
+					Return(nil)
+			},
+			expected: &secret.ExpireReport{Expired: 1, AbortedUploads: 1},
+		},
+		{
+			name: "orphaned chunks of expired request removed",
+			mockBehavior: func(pool pgxmock.PgxPoolIface, s3Client *mock.MockServerOperator, req *secret.InitRequest) {
+				mockExpireChunkedRequest(t, pool, s3Client, req)
+
+				registered, orphaned, fresh := strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64)
+
+				s3Client.EXPECT().
+					ListObjects(gomock.Any(), req.User.BucketName, secret.ChunkKeyPrefix).
+					Return(bucketChunks(registered, orphaned, fresh), nil)
+				pool.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(req.UserID).
+					WillReturnRows(pgxmock.NewRows([]string{"in_progress"}).AddRow(false))
+				pool.ExpectQuery(`FROM secret_chunks`).
+					WithArgs(req.UserID, pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"chunk_id"}).AddRow(registered))
+				s3Client.EXPECT().
+					RemoveObjects(gomock.Any(), req.User.BucketName, []string{secret.ChunkObjectKey(orphaned)}).
+					Return(nil)
+			},
+			expected: &secret.ExpireReport{Expired: 1, AbortedUploads: 1, RemovedChunks: 1},
+		},
+		{
+			name: "chunks kept while chunked upload is in progress",
+			mockBehavior: func(pool pgxmock.PgxPoolIface, s3Client *mock.MockServerOperator, req *secret.InitRequest) {
+				mockExpireChunkedRequest(t, pool, s3Client, req)
+
+				s3Client.EXPECT().
+					ListObjects(gomock.Any(), req.User.BucketName, secret.ChunkKeyPrefix).
+					Return(bucketChunks(strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64)), nil)
+				pool.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(req.UserID).
+					WillReturnRows(pgxmock.NewRows([]string{"in_progress"}).AddRow(true))
+			},
+			expected: &secret.ExpireReport{Expired: 1, AbortedUploads: 1},
+		},
+		{
+			name: "object removal failed",
+			mockBehavior: func(pool pgxmock.PgxPoolIface, s3Client *mock.MockServerOperator, req *secret.InitRequest) {
+				pool.ExpectBegin()
+				pool.ExpectQuery(`pg_try_advisory_xact_lock`).
+					WithArgs(anyArgs(1)...).
+					WillReturnRows(pgxmock.NewRows([]string{"locked"}).AddRow(true))
+				pool.ExpectQuery(`FROM secret_requests_in_progress`).
+					WithArgs(anyArgs(2)...).
+					WillReturnRows(expiredRequestRows(t, req, true))
+				pool.ExpectExec(`INSERT INTO secret_requests_completed`).
+					WithArgs(anyArgs(16)...).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				pool.ExpectExec(`DELETE FROM secret_requests_in_progress`).
+					WithArgs(req.UserID, req.SecretID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				pool.ExpectCommit()
+
+				s3Client.EXPECT().
+					AbortMultipartUploads(gomock.Any(), req.User.BucketName, req.S3URL).
+					Return(e.ErrUnavailable)
+				s3Client.EXPECT().
+					RemoveObjects(gomock.Any(), req.User.BucketName, []string{req.S3URL}).
+					Return(e.ErrUnavailable)
+			},
+			expected: &secret.ExpireReport{Expired: 1, OrphanedObjects: 1},
+		},
+		{
+			name: "lock held by another replica",
+			mockBehavior: func(pool pgxmock.PgxPoolIface, _ *mock.MockServerOperator, _ *secret.InitRequest) {
+				pool.ExpectBegin()
+				pool.ExpectQuery(`pg_try_advisory_xact_lock`).
+					WithArgs(anyArgs(1)...).
+					WillReturnRows(pgxmock.NewRows([]string{"locked"}).AddRow(false))
+				pool.ExpectCommit()
+			},
+			expected: &secret.ExpireReport{Skipped: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := defaultSecretInitRequest(t)
+			s3Client := mock.NewMockServerOperator(ctrl)
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			db := &pg.DB{ConnPool: mockPool}
+			repo := repository.NewSecretRepo(db, s3Client, mock.NewMockIdentityManager(ctrl), log)
+
+			tt.mockBehavior(mockPool, s3Client, req)
+
+			report, err := repo.ExpireSecretInitRequests(context.Background(), time.Now().UTC(), 10)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, report)
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSecretRepoCommitUploadedObject(t *testing.T) {
+	t.Parallel()
+
+	objectRows := func(t *testing.T, req *secret.InitRequest) *pgxmock.Rows {
+		t.Helper()
+
+		meta, err := req.MetaData.MarshalJSON()
+		require.NoError(t, err)
+
+		return pgxmock.NewRows([]string{
+			"user_id", "secret_id", "secret_name", "s3_url", "version_id", "parent_version_id",
+			"request_type", "token", "client_info", "secret_size", "secret_hash", "secret_dek",
+			"meta", "created_at", "expires_at", "bucket_name",
+		}).AddRow(
+			req.UserID, req.SecretID, req.SecretName, req.S3URL, req.VersionID, req.ParentVersionID,
+			pg.RequestType(req.RequestType), req.Token, req.ClientInfo, req.SecretSize,
+			req.SecretHash, req.SecretDEK, meta, req.CreatedAt, req.ExpiresAt, req.User.BucketName,
+		)
+	}
+
+	tests := []struct {
+		name         string
+		mockBehavior commitMockBehavior
+		expectErr    error
+	}{
+		{
+			name: "upload committed by s3",
+			mockBehavior: func(
+				t *testing.T,
+				pool pgxmock.PgxPoolIface,
+				s3Client *mock.MockServerOperator,
+				req *secret.InitRequest,
+			) {
+				t.Helper()
+
+				pool.ExpectQuery(`FROM secret_requests_in_progress`).
+					WithArgs(req.User.BucketName, req.S3URL).
+					WillReturnRows(objectRows(t, req))
+				mockCommitNewSecret(t, pool, s3Client, req)
+			},
+			expectErr: nil,
+		},
+		{
+			name: "multipart upload committed by s3",
+			mockBehavior: func(
+				t *testing.T,
+				pool pgxmock.PgxPoolIface,
+				s3Client *mock.MockServerOperator,
+				req *secret.InitRequest,
+			) {
+				t.Helper()
+
+				pool.ExpectQuery(`FROM secret_requests_in_progress`).
+					WithArgs(req.User.BucketName, req.S3URL).
+					WillReturnRows(objectRows(t, req))
+				mockCommitMultipartObject(t, pool, s3Client, req)
+			},
+			expectErr: nil,
+		},
+		{
+			name: "multipart object differs from declared hash",
+			mockBehavior: func(
+				t *testing.T,
+				pool pgxmock.PgxPoolIface,
+				s3Client *mock.MockServerOperator,
+				req *secret.InitRequest,
+			) {
+				t.Helper()
+
+				pool.ExpectQuery(`FROM secret_requests_in_progress`).
+					WithArgs(req.User.BucketName, req.S3URL).
+					WillReturnRows(objectRows(t, req))
+				mockCommitMultipartObjectMismatch(t, pool, s3Client, req)
+			},
+			expectErr: e.ErrInvalidInput,
+		},
+		{
+			name: "no upload in progress",
+			mockBehavior: func(
+				t *testing.T,
+				pool pgxmock.PgxPoolIface,
+				_ *mock.MockServerOperator,
+				req *secret.InitRequest,
+			) {
+				t.Helper()
+
+				pool.ExpectQuery(`FROM secret_requests_in_progress`).
+					WithArgs(req.User.BucketName, req.S3URL).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectErr: e.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			initReq := defaultSecretInitRequest(t)
+			s3Client := mock.NewMockServerOperator(ctrl)
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			db := &pg.DB{ConnPool: mockPool}
+			repo := repository.NewSecretRepo(db, s3Client, mock.NewMockIdentityManager(ctrl), log)
+
+			tt.mockBehavior(t, mockPool, s3Client, initReq)
+
+			result, err := repo.CommitUploadedObject(context.Background(), initReq.User.BucketName, initReq.S3URL)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				require.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, secret.RequestCommitterS3, result.CommittedBy)
+				assert.Equal(t, secret.RequestStatusCompleted, result.Status)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSecretRepoRenewUploadCredentials(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		prepare   func(req *secret.InitRequest, renewReq *secret.RenewRequest)
+		notFound  bool
+		renewed   bool
+		expectErr error
+	}{
+		{
+			name:    "credentials renewed",
+			prepare: func(_ *secret.InitRequest, _ *secret.RenewRequest) {},
+			renewed: true,
+		},
+		{
+			name:      "no upload in progress",
+			prepare:   func(_ *secret.InitRequest, _ *secret.RenewRequest) {},
+			notFound:  true,
+			expectErr: e.ErrNotFound,
+		},
+		{
+			name: "upload request expired",
+			prepare: func(req *secret.InitRequest, _ *secret.RenewRequest) {
+				req.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+			},
+			expectErr: e.ErrNotFound,
+		},
+		{
+			name: "invalid upload token",
+			prepare: func(_ *secret.InitRequest, renewReq *secret.RenewRequest) {
+				renewReq.Token = 456
+			},
+			expectErr: e.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			initReq := defaultSecretInitRequest(t)
+			renewReq := &secret.RenewRequest{
+				UserID:   initReq.UserID,
+				SecretID: initReq.SecretID,
+				Token:    initReq.Token,
+				User:     initReq.User,
+			}
+			tt.prepare(initReq, renewReq)
+
+			s3Client := mock.NewMockServerOperator(ctrl)
+			idClient := mock.NewMockIdentityManager(ctrl)
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			db := &pg.DB{ConnPool: mockPool}
+			repo := repository.NewSecretRepo(db, s3Client, idClient, log)
+
+			query := mockPool.ExpectQuery(`FROM secret_requests_in_progress`).
+				WithArgs(initReq.UserID, initReq.SecretID)
+			if tt.notFound {
+				query.WillReturnError(pgx.ErrNoRows)
+			} else {
+				query.WillReturnRows(initRequestRows(t, initReq))
+			}
+
+			if tt.renewed {
+				idClient.EXPECT().
+					GetToken(gomock.Any(), initReq.User).
+					Return(&user.IdentityToken{AccessToken: "token"}, nil)
+				s3Client.EXPECT().
+					AssumeRole(gomock.Any(), "token", initReq.UploadDuration()).
+					Return(&s3.TemporaryCredentials{AccessKeyID: "key", SecretAccessKey: "secret"}, nil)
+			}
+
+			creds, err := repo.RenewUploadCredentials(context.Background(), renewReq)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				require.Nil(t, creds)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "key", creds.AccessKeyID)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
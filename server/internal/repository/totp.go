@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/retry"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+	"github.com/rs/zerolog"
+)
+
+// TOTPRepository defines persistence operations of user TOTP second factor and recovery codes.
+type TOTPRepository interface {
+	// SaveTOTP stores pending TOTP enrollment replacing previous pending one.
+	SaveTOTP(ctx context.Context, totp *user.TOTP) error
+	// GetTOTP gets TOTP of the user.
+	GetTOTP(ctx context.Context, userID uuid.UUID) (*user.TOTP, error)
+	// EnableTOTP enables pending TOTP and replaces user recovery codes.
+	EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryHashes [][]byte) error
+	// AcceptTOTPStep records time step of the code accepted on login.
+	AcceptTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	// UseRecoveryCode marks recovery code as used.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash []byte) error
+}
+
+// TOTPRepo implements TOTPRepository using PostgreSQL.
+type TOTPRepo struct {
+	connPool pg.ConnectionPool
+	queries  *pg.Queries
+	log      zerolog.Logger
+}
+
+// NewTOTPRepo creates a new instance of TOTPRepo.
+func NewTOTPRepo(db *pg.DB, log zerolog.Logger) *TOTPRepo {
+	return &TOTPRepo{
+		connPool: db.ConnPool,
+		queries:  pg.New(db.ConnPool),
+		log:      log,
+	}
+}
+
+func (repo *TOTPRepo) withDBRetry(ctx context.Context, dbOp func() error) error {
+	return retry.PG(ctx, backoff.NewExponentialBackOff(), repo.log, dbOp)
+}
+
+func (repo *TOTPRepo) logWithUserContext(userID uuid.UUID, op string) zerolog.Logger {
+	return repo.log.With().
+		Str("repo", "TOTPRepo").
+		Str("operation", op).
+		Str("user_id", userID.String()).Logger()
+}
+
+// SaveTOTP stores pending TOTP enrollment of the user replacing previous pending one.
+// Returns ErrConflict if TOTP is already enabled for the user.
+func (repo *TOTPRepo) SaveTOTP(ctx context.Context, totp *user.TOTP) error {
+	var saved int64
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		saved, err = repo.queries.SaveUserTOTP(ctx, ToSaveUserTOTPParams(totp))
+
+		return err
+	})
+	if dbErr != nil {
+		logCtx := repo.logWithUserContext(totp.UserID, "SaveTOTP")
+		logCtx.Error().Err(dbErr).Msg("failed to save user totp")
+
+		return e.InternalErr(dbErr)
+	}
+
+	if saved == 0 {
+		return fmt.Errorf("[%w] user totp is already enabled", e.ErrConflict)
+	}
+
+	return nil
+}
+
+// GetTOTP gets TOTP of the user, either pending or enabled.
+// Returns ErrNotFound if user has never enrolled.
+func (repo *TOTPRepo) GetTOTP(ctx context.Context, userID uuid.UUID) (*user.TOTP, error) {
+	var totp *user.TOTP
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		pgTOTP, err := repo.queries.GetUserTOTP(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		totp = FromPGUserTOTP(pgTOTP)
+
+		return nil
+	})
+
+	if errors.Is(dbErr, pgx.ErrNoRows) || errors.Is(dbErr, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%w] user totp", e.ErrNotFound)
+	}
+
+	if dbErr != nil {
+		logCtx := repo.logWithUserContext(userID, "GetTOTP")
+		logCtx.Error().Err(dbErr).Msg("failed to get user totp")
+
+		return nil, e.InternalErr(dbErr)
+	}
+
+	return totp, nil
+}
+
+// EnableTOTP enables pending TOTP of the user remembering time step of the confirmation code
+// and replaces user recovery codes in a single transaction.
+// Returns ErrConflict if TOTP is not pending, e.g. confirmed concurrently.
+func (repo *TOTPRepo) EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryHashes [][]byte) error {
+	logCtx := repo.logWithUserContext(userID, "EnableTOTP")
+	now := time.Now().UTC()
+
+	queryFn := func(queries *pg.Queries) error {
+		enabled, err := queries.EnableUserTOTP(ctx, pg.EnableUserTOTPParams{
+			LastStep:  step,
+			UpdatedAt: now,
+			UserID:    userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if enabled == 0 {
+			return fmt.Errorf("[%w] user totp is not pending", e.ErrConflict)
+		}
+
+		if err := queries.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+			return err
+		}
+
+		for _, hash := range recoveryHashes {
+			err := queries.CreateUserRecoveryCode(ctx, pg.CreateUserRecoveryCodeParams{
+				UserID:   userID,
+				CodeHash: hash,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		return pg.WithinTrx(ctx, repo.connPool, pgx.TxOptions{}, queryFn)(repo.queries)
+	})
+
+	if errors.Is(dbErr, e.ErrConflict) {
+		return dbErr
+	}
+
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to enable user totp")
+		return e.InternalErr(dbErr)
+	}
+
+	logCtx.Info().Msg("user totp enabled")
+
+	return nil
+}
+
+// AcceptTOTPStep records time step of the code accepted on login.
+// Returns ErrConflict if code of the same or later step has already been accepted, i.e. code is replayed.
+func (repo *TOTPRepo) AcceptTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	var accepted int64
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		accepted, err = repo.queries.AcceptUserTOTPStep(ctx, pg.AcceptUserTOTPStepParams{
+			LastStep:  step,
+			UpdatedAt: time.Now().UTC(),
+			UserID:    userID,
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		logCtx := repo.logWithUserContext(userID, "AcceptTOTPStep")
+		logCtx.Error().Err(dbErr).Msg("failed to accept totp step")
+
+		return e.InternalErr(dbErr)
+	}
+
+	if accepted == 0 {
+		return fmt.Errorf("[%w] totp code is already used", e.ErrConflict)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks recovery code of the user as used.
+// Returns ErrNotFound if code has never been issued or has already been used.
+func (repo *TOTPRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash []byte) error {
+	var used int64
+
+	usedAt := time.Now().UTC()
+
+	dbErr := repo.withDBRetry(ctx, func() error {
+		var err error
+
+		used, err = repo.queries.UseUserRecoveryCode(ctx, pg.UseUserRecoveryCodeParams{
+			UsedAt:   &usedAt,
+			UserID:   userID,
+			CodeHash: codeHash,
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		logCtx := repo.logWithUserContext(userID, "UseRecoveryCode")
+		logCtx.Error().Err(dbErr).Msg("failed to use recovery code")
+
+		return e.InternalErr(dbErr)
+	}
+
+	if used == 0 {
+		return fmt.Errorf("[%w] recovery code", e.ErrNotFound)
+	}
+
+	logCtx := repo.logWithUserContext(userID, "UseRecoveryCode")
+	logCtx.Info().Msg("recovery code used")
+
+	return nil
+}
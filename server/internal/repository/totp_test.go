@@ -0,0 +1,154 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+)
+
+func TestTOTPRepoSaveTOTP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		saved     int64
+		expectErr error
+	}{
+		{name: "pending enrollment saved", saved: 1},
+		{name: "totp already enabled", saved: 0, expectErr: e.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewTOTPRepo(&pg.DB{ConnPool: mockPool}, log)
+			totp := user.NewTOTP(uuid.New(), []byte("wrapped"))
+
+			mockPool.ExpectExec(`INSERT INTO user_totp`).
+				WithArgs(totp.UserID, totp.WrappedSecret, totp.CreatedAt, totp.UpdatedAt).
+				WillReturnResult(pgxmock.NewResult("INSERT", tt.saved))
+
+			err = repo.SaveTOTP(context.Background(), totp)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTOTPRepoEnableTOTP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		enabled   int64
+		expectErr error
+	}{
+		{name: "pending totp enabled", enabled: 1},
+		{name: "totp not pending", enabled: 0, expectErr: e.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewTOTPRepo(&pg.DB{ConnPool: mockPool}, log)
+			userID := uuid.New()
+			_, hashes, err := user.NewRecoveryCodes()
+			require.NoError(t, err)
+
+			mockPool.ExpectBegin()
+			mockPool.ExpectExec(`UPDATE user_totp`).
+				WithArgs(int64(42), pgxmock.AnyArg(), userID).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.enabled))
+
+			if tt.expectErr == nil {
+				mockPool.ExpectExec(`DELETE FROM user_recovery_codes`).
+					WithArgs(userID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+				for _, hash := range hashes {
+					mockPool.ExpectExec(`INSERT INTO user_recovery_codes`).
+						WithArgs(userID, hash).
+						WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				}
+
+				mockPool.ExpectCommit()
+			} else {
+				mockPool.ExpectRollback()
+			}
+
+			err = repo.EnableTOTP(context.Background(), userID, 42, hashes)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTOTPRepoUseRecoveryCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		used      int64
+		expectErr error
+	}{
+		{name: "code used", used: 1},
+		{name: "unknown or used code", used: 0, expectErr: e.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewTOTPRepo(&pg.DB{ConnPool: mockPool}, log)
+			userID := uuid.New()
+			hash := user.HashRecoveryCode("abcdefgh-ijklmnop")
+
+			mockPool.ExpectExec(`UPDATE user_recovery_codes`).
+				WithArgs(pgxmock.AnyArg(), userID, hash).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.used))
+
+			err = repo.UseRecoveryCode(context.Background(), userID, hash)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
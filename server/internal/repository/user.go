@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
 	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
@@ -21,16 +23,20 @@ import (
 
 // UserRepository defines user-related persistence operations.
 type UserRepository interface {
-	// CreateUser registers a regular user with KEK and an S3 bucket.
-	CreateUser(ctx context.Context, usr *user.User, kek *user.Key) (*user.User, error)
+	// CreateUser registers a regular user with an S3 bucket.
+	CreateUser(ctx context.Context, usr *user.User) (*user.User, error)
 	// GetUser get user by username.
 	GetUser(ctx context.Context, username string) (*user.User, error)
 	// GetUserByID get user by user id.
 	GetUserByID(ctx context.Context, uid uuid.UUID) (*user.User, error)
-	// CreateAdmin inserts an admin user without S3/KEK provisioning.
+	// CreateAdmin inserts a staff user (admin, auditor, operator) without S3 provisioning.
 	CreateAdmin(ctx context.Context, usr *user.User) (*user.User, error)
 	// ValidateUser Validates user credentials on Login.
 	ValidateUser(ctx context.Context, creds *dto.UserCredentials) (*user.User, error)
+	// MigrateUserAuth switches legacy user to auth key verifier and drops password hash and KEK.
+	MigrateUserAuth(ctx context.Context, usr *user.User) error
+	// ChangePassword swaps user verifier and re-wrapped DEKs of all user secret versions at once.
+	ChangePassword(ctx context.Context, usr *user.User, currentVerifier []byte, keys []*secret.WrappedKey) error
 }
 
 // UserRepo implements UserRepository using PostgreSQL and S3.
@@ -73,14 +79,14 @@ func (repo *UserRepo) logWithUserContext(usr *user.User, op string) zerolog.Logg
 		Str("user_role", usr.Role.String()).Logger()
 }
 
-// CreateAdmin inserts an admin user directly into the database.
-// This method does not create an S3 bucket or KEK entry.
+// CreateAdmin inserts an admin, auditor or operator user directly into the database.
+// This method does not create an S3 bucket.
 // Returns ErrExists if the user or username already exists.
 func (repo *UserRepo) CreateAdmin(ctx context.Context, usr *user.User) (*user.User, error) {
 	logCtx := repo.logWithUserContext(usr, "CreateAdmin")
 
-	if usr.Role != user.RoleAdmin {
-		logCtx.Error().Msg("expected user with admin role")
+	if !user.IsStaff(usr.Role) {
+		logCtx.Error().Msg("expected user with staff role")
 		return nil, e.ErrInvalidInput
 	}
 
@@ -128,7 +134,7 @@ func (repo *UserRepo) CreateAdmin(ctx context.Context, usr *user.User) (*user.Us
 // S3 bucket for storing the user's secrets.
 //
 // The operation is transactional in spirit: it first attempts to create the
-// S3 bucket, then inserts the user/key into the database. If the database insertion
+// S3 bucket, then inserts the user into the database. If the database insertion
 // fails, the previously created bucket is removed as a best-effort compensation.
 //
 // It returns the created user with sensitive fields like Password zeroed out.
@@ -136,7 +142,7 @@ func (repo *UserRepo) CreateAdmin(ctx context.Context, usr *user.User) (*user.Us
 // it returns ErrServerInternal.
 //
 // This method retries transient database failures using an exponential backoff strategy.
-func (repo *UserRepo) CreateUser(ctx context.Context, usr *user.User, key *user.Key) (*user.User, error) {
+func (repo *UserRepo) CreateUser(ctx context.Context, usr *user.User) (*user.User, error) {
 	logCtx := repo.logWithUserContext(usr, "CreateUser")
 
 	if usr.Role != user.RoleUser {
@@ -156,8 +162,8 @@ func (repo *UserRepo) CreateUser(ctx context.Context, usr *user.User, key *user.
 	}
 
 	var dbUsr *user.User
-	// Create pg user and it's key within trx.
-	queryFn := pg.WithinTrx(ctx, repo.connPool, pgx.TxOptions{}, func(queries *pg.Queries) error {
+
+	queryFn := func(queries *pg.Queries) error {
 		pgUser, err := queries.CreateUser(ctx, ToCreateUserParams(usr))
 
 		if pg.IsUniqueViolation(err) {
@@ -168,10 +174,6 @@ func (repo *UserRepo) CreateUser(ctx context.Context, usr *user.User, key *user.
 			return err
 		}
 
-		if err = queries.CreateUserKey(ctx, ToCreateUserKeyParams(key)); err != nil {
-			return err
-		}
-
 		dbUsr = FromPGUser(pgUser)
 
 		// The DB enforces uniqueness on ID and username, but only one constraint may trigger.
@@ -181,7 +183,7 @@ func (repo *UserRepo) CreateUser(ctx context.Context, usr *user.User, key *user.
 		}
 
 		return nil
-	})
+	}
 
 	dbErr := repo.withDBRetry(ctx, func() error { return queryFn(repo.queries) })
 	if errors.Is(dbErr, e.ErrExists) {
@@ -280,8 +282,9 @@ func (repo *UserRepo) GetUserByID(ctx context.Context, uid uuid.UUID) (*user.Use
 
 // ValidateUser authenticates a user based on provided credentials.
 //
-// It first fetches the user record by username and then verifies the password.
-// Returns ErrValidation if the password is incorrect and ErrNotFound if the user does not exist.
+// It first fetches the user record by username and then verifies the auth key,
+// or the password of legacy user which is not migrated to auth key yet.
+// Returns ErrValidation if credentials are incorrect and ErrNotFound if the user does not exist.
 // For all other errors, it returns ErrInternal.
 func (repo *UserRepo) ValidateUser(ctx context.Context, creds *dto.UserCredentials) (*user.User, error) {
 	logCtx := repo.log.With().
@@ -295,14 +298,137 @@ func (repo *UserRepo) ValidateUser(ctx context.Context, creds *dto.UserCredentia
 		return nil, err
 	}
 
-	if !user.CheckPassword(creds.Password) {
+	if user.IsLegacy() && !user.CheckPassword(creds.Password) {
 		logCtx.Info().Msg("invalid password attempt")
 		return nil, fmt.Errorf("[%w] user password", e.ErrValidation)
 	}
 
+	if !user.IsLegacy() && !user.CheckAuthKey(creds.AuthKey) {
+		logCtx.Info().Msg("invalid auth key attempt")
+		return nil, fmt.Errorf("[%w] user auth key", e.ErrValidation)
+	}
+
 	return user, nil
 }
 
+// MigrateUserAuth stores auth key verifier of legacy user, clears its password hash
+// and deletes KEK wrapped by server, so that server keeps no key material of the user.
+//
+// Returns ErrConflict if user is already migrated, e.g. by concurrent login.
+// For all other errors, it returns ErrInternal.
+func (repo *UserRepo) MigrateUserAuth(ctx context.Context, usr *user.User) error {
+	logCtx := repo.logWithUserContext(usr, "MigrateUserAuth")
+
+	queryFn := pg.WithinTrx(ctx, repo.connPool, pgx.TxOptions{}, func(queries *pg.Queries) error {
+		rows, err := queries.MigrateUserAuth(ctx, pg.MigrateUserAuthParams{
+			ID:        usr.ID,
+			Verifier:  usr.Verifier,
+			UpdatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return fmt.Errorf("[%w] user is already migrated", e.ErrConflict)
+		}
+
+		return queries.DeleteUserKey(ctx, usr.ID)
+	})
+
+	dbErr := repo.withDBRetry(ctx, func() error { return queryFn(repo.queries) })
+	if errors.Is(dbErr, e.ErrConflict) {
+		return dbErr
+	}
+
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to migrate user auth")
+		return e.InternalErr(dbErr)
+	}
+
+	return nil
+}
+
+// ChangePassword stores new salt and verifier of the user together with DEKs of all user secret versions
+// re-wrapped by client with the new KEK, and deletes KEK wrapped by server if legacy one is still stored.
+//
+// Transaction is serializable: keys must cover exactly the versions stored at commit time,
+// so that no version is left wrapped with the old KEK. Returns ErrConflict if password was changed
+// concurrently, user has uploads in progress or versions changed since client listed the keys.
+// For all other errors, it returns ErrInternal.
+func (repo *UserRepo) ChangePassword(
+	ctx context.Context,
+	usr *user.User,
+	currentVerifier []byte,
+	keys []*secret.WrappedKey,
+) error {
+	logCtx := repo.logWithUserContext(usr, "ChangePassword")
+	trxOptions := pgx.TxOptions{IsoLevel: pgx.Serializable}
+
+	queryFn := pg.WithinTrx(ctx, repo.connPool, trxOptions, func(queries *pg.Queries) error {
+		now := time.Now().UTC()
+
+		rows, err := queries.ChangeUserPassword(ctx, pg.ChangeUserPasswordParams{
+			ID:              usr.ID,
+			Salt:            usr.Salt,
+			Verifier:        usr.Verifier,
+			UpdatedAt:       now,
+			CurrentVerifier: currentVerifier,
+		})
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return fmt.Errorf("[%w] user password is changed concurrently", e.ErrConflict)
+		}
+
+		uploads, err := queries.CountUserUploadsInProgress(ctx, pg.CountUserUploadsInProgressParams{
+			UserID: usr.ID,
+			Now:    now,
+		})
+		if err != nil {
+			return err
+		}
+
+		if uploads > 0 {
+			return fmt.Errorf("[%w] user has uploads in progress", e.ErrConflict)
+		}
+
+		versions, err := queries.CountUserSecretVersions(ctx, usr.ID)
+		if err != nil {
+			return err
+		}
+
+		rewrapped, err := queries.RewrapUserSecretKeys(ctx, ToRewrapUserSecretKeysParams(usr.ID, keys))
+		if err != nil {
+			return err
+		}
+
+		if rewrapped != versions || rewrapped != int64(len(keys)) {
+			return fmt.Errorf("[%w] secret versions changed while keys were re-wrapped", e.ErrConflict)
+		}
+
+		return queries.DeleteUserKey(ctx, usr.ID)
+	})
+
+	dbErr := repo.withDBRetry(ctx, func() error { return queryFn(repo.queries) })
+	if errors.Is(dbErr, e.ErrConflict) {
+		return dbErr
+	}
+
+	if pg.IsSerializationFailure(dbErr) {
+		return fmt.Errorf("[%w] user secrets changed concurrently", e.ErrConflict)
+	}
+
+	if dbErr != nil {
+		logCtx.Error().Err(dbErr).Msg("failed to change user password")
+		return e.InternalErr(dbErr)
+	}
+
+	return nil
+}
+
 // createIdentityUser attempts to create identity user.
 func (repo *UserRepo) createIdentityUser(ctx context.Context, usr *user.User) error {
 	iuid, err := repo.idClient.CreateUser(ctx, usr)
@@ -0,0 +1,244 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/secret"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/dto"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/repository"
+)
+
+func userRows(usr *user.User) *pgxmock.Rows {
+	return pgxmock.NewRows([]string{
+		"id", "username", "role", "created_at", "updated_at",
+		"password", "salt", "verifier", "bucket_name", "identity_id",
+	}).AddRow(
+		usr.ID, usr.Username, usr.Role, usr.CreatedAt, usr.UpdatedAt,
+		usr.Password, usr.Salt, usr.Verifier, usr.BucketName, usr.IdentityID,
+	)
+}
+
+func TestUserRepoValidateUser(t *testing.T) {
+	t.Parallel()
+
+	authKey := []byte("0123456789abcdef0123456789abcdef")
+
+	migrated := user.New("migrated", user.RoleUser)
+	migrated.SetAuthKey(authKey, []byte("0123456789abcdef"))
+
+	legacy := user.New("legacy", user.RoleUser)
+	require.NoError(t, legacy.SetPassword("legacy_password"))
+
+	tests := []struct {
+		name      string
+		usr       *user.User
+		creds     *dto.UserCredentials
+		expectErr error
+	}{
+		{
+			name:  "valid auth key",
+			usr:   migrated,
+			creds: &dto.UserCredentials{Username: migrated.Username, AuthKey: authKey},
+		},
+		{
+			name:      "invalid auth key",
+			usr:       migrated,
+			creds:     &dto.UserCredentials{Username: migrated.Username, AuthKey: []byte("bad")},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:      "password is not accepted from migrated user",
+			usr:       migrated,
+			creds:     &dto.UserCredentials{Username: migrated.Username, Password: "legacy_password"},
+			expectErr: e.ErrValidation,
+		},
+		{
+			name:  "valid legacy password",
+			usr:   legacy,
+			creds: &dto.UserCredentials{Username: legacy.Username, Password: "legacy_password", AuthKey: authKey},
+		},
+		{
+			name:      "invalid legacy password",
+			usr:       legacy,
+			creds:     &dto.UserCredentials{Username: legacy.Username, Password: "wrong_password", AuthKey: authKey},
+			expectErr: e.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewUserRepo(&pg.DB{ConnPool: mockPool}, nil, nil, log)
+
+			mockPool.ExpectQuery(`SELECT (.+) FROM users`).
+				WithArgs(tt.usr.Username).
+				WillReturnRows(userRows(tt.usr))
+
+			usr, err := repo.ValidateUser(context.Background(), tt.creds)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.usr.ID, usr.ID)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepoMigrateUserAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		migrated  int64
+		expectErr error
+	}{
+		{name: "legacy user migrated", migrated: 1},
+		{name: "user already migrated", migrated: 0, expectErr: e.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewUserRepo(&pg.DB{ConnPool: mockPool}, nil, nil, log)
+
+			usr := user.New("legacy", user.RoleUser)
+			usr.SetAuthKey([]byte("0123456789abcdef0123456789abcdef"), []byte("0123456789abcdef"))
+
+			mockPool.ExpectBegin()
+			mockPool.ExpectExec(`UPDATE users`).
+				WithArgs(usr.Verifier, pgxmock.AnyArg(), usr.ID).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.migrated))
+
+			if tt.expectErr == nil {
+				mockPool.ExpectExec(`DELETE FROM user_crypto_keys`).
+					WithArgs(usr.ID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				mockPool.ExpectCommit()
+			} else {
+				mockPool.ExpectRollback()
+			}
+
+			err = repo.MigrateUserAuth(context.Background(), usr)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepoChangePassword(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		changed   int64
+		uploads   int64
+		versions  int64
+		rewrapped int64
+		expectErr error
+	}{
+		{name: "password changed with all keys re-wrapped", changed: 1, versions: 2, rewrapped: 2},
+		{name: "current verifier is outdated", changed: 0, expectErr: e.ErrConflict},
+		{name: "upload in progress", changed: 1, uploads: 1, expectErr: e.ErrConflict},
+		{name: "secret version created meanwhile", changed: 1, versions: 3, rewrapped: 2, expectErr: e.ErrConflict},
+		{name: "secret version deleted meanwhile", changed: 1, versions: 1, rewrapped: 1, expectErr: e.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+
+			log := logger.Stdout(zerolog.Disabled).GetZeroLog()
+			repo := repository.NewUserRepo(&pg.DB{ConnPool: mockPool}, nil, nil, log)
+
+			usr := user.New("changer", user.RoleUser)
+			usr.SetAuthKey([]byte("0123456789abcdef0123456789abcdef"), []byte("0123456789abcdef"))
+			currentVerifier := usr.Verifier
+			usr.SetAuthKey([]byte("fedcba9876543210fedcba9876543210"), []byte("fedcba9876543210"))
+
+			keys := []*secret.WrappedKey{
+				{SecretID: uuid.New(), VersionID: uuid.New(), DEK: []byte("wrapped dek 1")},
+				{SecretID: uuid.New(), VersionID: uuid.New(), DEK: []byte("wrapped dek 2")},
+			}
+
+			mockPool.ExpectBeginTx(pgx.TxOptions{IsoLevel: pgx.Serializable})
+			mockPool.ExpectExec(`UPDATE users`).
+				WithArgs(usr.Salt, usr.Verifier, pgxmock.AnyArg(), usr.ID, currentVerifier).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.changed))
+
+			defer func() {
+				require.NoError(t, mockPool.ExpectationsWereMet())
+			}()
+
+			if tt.changed == 0 {
+				mockPool.ExpectRollback()
+				require.ErrorIs(t, repo.ChangePassword(context.Background(), usr, currentVerifier, keys), tt.expectErr)
+
+				return
+			}
+
+			mockPool.ExpectQuery(`FROM secret_requests`).
+				WithArgs(usr.ID, pgxmock.AnyArg()).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(tt.uploads))
+
+			if tt.uploads > 0 {
+				mockPool.ExpectRollback()
+				require.ErrorIs(t, repo.ChangePassword(context.Background(), usr, currentVerifier, keys), tt.expectErr)
+
+				return
+			}
+
+			mockPool.ExpectQuery(`FROM secret_versions`).
+				WithArgs(usr.ID).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(tt.versions))
+			mockPool.ExpectExec(`UPDATE secret_versions`).
+				WithArgs(usr.ID, pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rewrapped))
+
+			if tt.expectErr != nil {
+				mockPool.ExpectRollback()
+				require.ErrorIs(t, repo.ChangePassword(context.Background(), usr, currentVerifier, keys), tt.expectErr)
+
+				return
+			}
+
+			mockPool.ExpectExec(`DELETE FROM user_crypto_keys`).
+				WithArgs(usr.ID).
+				WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			mockPool.ExpectCommit()
+
+			require.NoError(t, repo.ChangePassword(context.Background(), usr, currentVerifier, keys))
+		})
+	}
+}
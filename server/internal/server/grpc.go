@@ -34,8 +34,9 @@ func New(
 	userSrv grpchandler.UserServiceServer,
 	secretSrv grpchandler.SecretServiceServer,
 	authenticator *auth.Auth,
+	devices auth.DeviceVerifier,
 	kstore keystore.Keystore,
-	isPublicMethod func(method string) bool,
+	policy auth.Policy,
 	log zerolog.Logger,
 ) (*GRPCServer, error) {
 	cert, err := tls.LoadX509KeyPair(config.ServerTLSCertPath, config.ServerTLSKeyPath)
@@ -61,13 +62,23 @@ func New(
 	creds := credentials.NewTLS(tlsCfg)
 	interceptors := grpc.ChainUnaryInterceptor(
 		auth.GRPCServerVerifier(authenticator),
-		auth.GRPCServerAuthenticator(isPublicMethod),
+		auth.GRPCServerAuthenticator(policy.IsPublic),
+		auth.GRPCServerAuthorizer(policy),
+		auth.GRPCServerDeviceValidator(devices),
 		keystore.GRPCServerStatusValidator(kstore),
 	)
+	streamInterceptors := grpc.ChainStreamInterceptor(
+		auth.GRPCServerStreamVerifier(authenticator),
+		auth.GRPCServerStreamAuthenticator(policy.IsPublic),
+		auth.GRPCServerStreamAuthorizer(policy),
+		auth.GRPCServerStreamDeviceValidator(devices),
+		keystore.GRPCServerStreamStatusValidator(kstore),
+	)
 
 	grpcSrv := grpc.NewServer(
 		grpc.Creds(creds),
 		interceptors,
+		streamInterceptors,
 	)
 
 	return &GRPCServer{
@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	e "github.com/patraden/ya-practicum-gophkeeper/pkg/errors"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/logger"
 	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
 	"github.com/patraden/ya-practicum-gophkeeper/pkg/testutil/certtest"
@@ -21,7 +24,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestGRPCServerWithTLS(t *testing.T) {
@@ -40,13 +46,14 @@ func TestGRPCServerWithTLS(t *testing.T) {
 	}
 
 	jwtKeyFunc := func(*jwt.Token) (any, error) { return []byte(cfg.JWTSecret), nil }
-	authenticator := auth.New(jwtKeyFunc, log)
-	isPublicMethod := func(method string) bool { return method == pb.AdminService_Unseal_FullMethodName }
+	authenticator := auth.New(jwtKeyFunc, time.Hour, log)
+	policy := auth.Policy{pb.AdminService_Unseal_FullMethodName: {Public: true}}
 
 	ctrl := gomock.NewController(t)
 	adminSrv := mock.NewMockAdminServiceServer(ctrl)
 	userSrv := mock.NewMockUserServiceServer(ctrl)
 	secretSrv := mock.NewMockSecretServiceServer(ctrl)
+	devices := mock.NewMockDeviceVerifier(ctrl)
 	kstore := keystore.NewInMemoryKeystore()
 
 	adminSrv.EXPECT().
@@ -56,7 +63,7 @@ func TestGRPCServerWithTLS(t *testing.T) {
 			Status:  pb.SealStatus_SEAL_STATUS_UNSEALED,
 		}, nil)
 
-	server, err := server.New(cfg, adminSrv, userSrv, secretSrv, authenticator, kstore, isPublicMethod, log)
+	server, err := server.New(cfg, adminSrv, userSrv, secretSrv, authenticator, devices, kstore, policy, log)
 	require.NoError(t, err)
 
 	runErrCh := make(chan error, 1)
@@ -91,3 +98,75 @@ func TestGRPCServerWithTLS(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, <-runErrCh)
 }
+
+func TestGRPCServerRejectsRevokedDeviceElevation(t *testing.T) {
+	t.Parallel()
+
+	log := logger.Stdout(zerolog.DebugLevel).GetZeroLog()
+	tmpDir := t.TempDir()
+
+	caCertPath, serverCertPath, serverKeyPath := certtest.GenerateTestCertificates(t, tmpDir, log)
+
+	cfg := &config.Config{
+		ServerAddr:        "127.0.0.1:50056",
+		ServerTLSCertPath: serverCertPath,
+		ServerTLSKeyPath:  serverKeyPath,
+		JWTSecret:         "secret",
+	}
+
+	jwtKeyFunc := func(*jwt.Token) (any, error) { return []byte(cfg.JWTSecret), nil }
+	authenticator := auth.New(jwtKeyFunc, time.Hour, log)
+
+	admin, err := user.NewWithID(uuid.NewString(), "admin", user.RoleAdmin)
+	require.NoError(t, err)
+
+	deviceID := uuid.New()
+	adminToken, err := authenticator.Encoder()(admin, deviceID)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	adminSrv := mock.NewMockAdminServiceServer(ctrl)
+	userSrv := mock.NewMockUserServiceServer(ctrl)
+	secretSrv := mock.NewMockSecretServiceServer(ctrl)
+	devices := mock.NewMockDeviceVerifier(ctrl)
+	kstore := keystore.NewInMemoryKeystore()
+
+	// admin device is revoked, so its token must not elevate public registration to staff role.
+	devices.EXPECT().
+		VerifyDevice(gomock.Any(), admin.ID.String(), deviceID.String()).
+		Return(e.ErrUnauthenticated)
+
+	server, err := server.New(cfg, adminSrv, userSrv, secretSrv, authenticator, devices, kstore, server.AccessPolicy(), log)
+	require.NoError(t, err)
+
+	runErrCh := make(chan error, 1)
+	go func() {
+		runErrCh <- server.Run()
+	}()
+
+	caCert, err := os.ReadFile(caCertPath)
+	require.NoError(t, err)
+
+	certPool := x509.NewCertPool()
+	require.True(t, certPool.AppendCertsFromPEM(caCert))
+
+	creds := credentials.NewClientTLSFromCert(certPool, "localhost")
+	conn, err := grpc.NewClient(cfg.ServerAddr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	client := pb.NewUserServiceClient(conn)
+	_, err = client.Register(
+		metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+adminToken),
+		&pb.RegisterRequest{Username: "operator", Role: user.RoleOperator},
+	)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	err = server.Shutdown(ctx)
+	require.NoError(t, err)
+	require.NoError(t, <-runErrCh)
+}
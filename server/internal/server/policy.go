@@ -0,0 +1,66 @@
+package server
+
+import (
+	"github.com/patraden/ya-practicum-gophkeeper/pkg/domain/user"
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/auth"
+)
+
+// Role sets shared by access policy rules.
+var (
+	anyRole       = []user.Role{user.RoleUser, user.RoleAdmin, user.RoleAuditor, user.RoleOperator}
+	secretOwner   = []user.Role{user.RoleUser}
+	secretReaders = []user.Role{user.RoleUser, user.RoleAuditor}
+	onBehalf      = []user.Role{user.RoleAuditor}
+	unsealers     = []user.Role{user.RoleAdmin, user.RoleOperator}
+	admins        = []user.Role{user.RoleAdmin}
+)
+
+// AccessPolicy returns method level access policy of gophkeeper gRPC services.
+//
+// Secret service requests are bound to the caller: user_id of request must be the token user.
+// Auditors may list secrets metadata of any user, but never get download credentials.
+func AccessPolicy() auth.Policy {
+	return auth.Policy{
+		pb.UserService_GetLoginParams_FullMethodName: {Public: true},
+		pb.UserService_Login_FullMethodName:          {Public: true},
+		pb.UserService_RefreshToken_FullMethodName:   {Public: true},
+		pb.UserService_Register_FullMethodName:       {Public: true, Elevate: registerRoles},
+		pb.UserService_ListDevices_FullMethodName:    {Roles: anyRole},
+		pb.UserService_RevokeDevice_FullMethodName:   {Roles: anyRole},
+		pb.UserService_Logout_FullMethodName:         {Roles: anyRole},
+		pb.UserService_EnrollTOTP_FullMethodName:     {Roles: anyRole, Enrollment: true},
+		pb.UserService_ConfirmTOTP_FullMethodName:    {Roles: anyRole, Enrollment: true},
+		pb.UserService_ChangePassword_FullMethodName: {Roles: anyRole},
+
+		pb.AdminService_Unseal_FullMethodName: {Roles: unsealers},
+
+		pb.SecretService_SecretUpdateInit_FullMethodName:       {Roles: secretOwner, Owner: true},
+		pb.SecretService_SecretUpdateCommit_FullMethodName:     {Roles: secretOwner, Owner: true},
+		pb.SecretService_RenewUploadCredentials_FullMethodName: {Roles: secretOwner, Owner: true},
+		pb.SecretService_SecretGetInit_FullMethodName:          {Roles: secretOwner, Owner: true},
+		pb.SecretService_GetSecretVersion_FullMethodName:       {Roles: secretOwner, Owner: true},
+		pb.SecretService_SecretDelete_FullMethodName:           {Roles: secretOwner, Owner: true},
+		pb.SecretService_WatchSecrets_FullMethodName:           {Roles: secretOwner, Owner: true},
+		pb.SecretService_ListSecretKeys_FullMethodName:         {Roles: secretOwner, Owner: true},
+		pb.SecretService_ListSecrets_FullMethodName: {
+			Roles: secretReaders, Owner: true, OnBehalfRoles: onBehalf,
+		},
+		pb.SecretService_ListSecretVersions_FullMethodName: {
+			Roles: secretReaders, Owner: true, OnBehalfRoles: onBehalf,
+		},
+		pb.SecretService_ListSecretTombstones_FullMethodName: {
+			Roles: secretReaders, Owner: true, OnBehalfRoles: onBehalf,
+		},
+	}
+}
+
+// registerRoles requires admin caller to register staff users.
+// Self registration of regular users stays public.
+func registerRoles(req any) []user.Role {
+	if r, ok := req.(*pb.RegisterRequest); ok && r.GetRole() != user.RoleUser {
+		return admins
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package server_test
+
+import (
+	"testing"
+
+	pb "github.com/patraden/ya-practicum-gophkeeper/pkg/proto/gophkeeper/v1"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/server"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestAccessPolicyCoversAllMethods(t *testing.T) {
+	t.Parallel()
+
+	policy := server.AccessPolicy()
+	services := []grpc.ServiceDesc{
+		pb.UserService_ServiceDesc,
+		pb.AdminService_ServiceDesc,
+		pb.SecretService_ServiceDesc,
+	}
+
+	for _, desc := range services {
+		for _, method := range desc.Methods {
+			fullMethod := "/" + desc.ServiceName + "/" + method.MethodName
+			require.Contains(t, policy, fullMethod)
+		}
+
+		for _, stream := range desc.Streams {
+			fullMethod := "/" + desc.ServiceName + "/" + stream.StreamName
+			require.Contains(t, policy, fullMethod)
+		}
+	}
+
+	require.True(t, policy.IsPublic(pb.UserService_Login_FullMethodName))
+	require.False(t, policy.IsPublic(pb.SecretService_ListSecrets_FullMethodName))
+}
@@ -0,0 +1,169 @@
+package watch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/config"
+	"github.com/patraden/ya-practicum-gophkeeper/server/internal/infra/pg"
+	"github.com/rs/zerolog"
+)
+
+// Subscriber wakes up watchers of user secrets when secret events are recorded.
+type Subscriber interface {
+	// Subscribe returns channel signalled whenever events of the user are recorded and
+	// function which cancels the subscription. Signals are coalesced, so that watcher
+	// has to read all events recorded after its cursor once signalled.
+	Subscribe(userID uuid.UUID) (<-chan struct{}, func())
+}
+
+// Hub listens to secret events notifications sent by any server replica
+// and fans them out to watchers of this replica.
+type Hub struct {
+	dsn    string
+	mu     sync.Mutex
+	subs   map[uuid.UUID]map[chan struct{}]struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    zerolog.Logger
+}
+
+// New creates hub of secret events notifications.
+func New(cfg *config.Config, log zerolog.Logger) *Hub {
+	return &Hub{
+		dsn:  cfg.DatabaseDSN,
+		subs: make(map[uuid.UUID]map[chan struct{}]struct{}),
+		log:  log.With().Str("worker", "WatchHub").Logger(),
+	}
+}
+
+// Start listens to notifications in background reconnecting on failures until Stop is called.
+func (h *Hub) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+
+	h.wg.Add(1)
+
+	go func() {
+		defer h.wg.Done()
+
+		reconnect := backoff.NewExponentialBackOff()
+		reconnect.MaxElapsedTime = 0
+
+		for ctx.Err() == nil {
+			started := time.Now()
+
+			err := pg.Listen(ctx, h.dsn, pg.SecretEventsChannel, h.notify)
+			if err == nil {
+				return
+			}
+
+			if time.Since(started) > reconnect.MaxInterval {
+				reconnect.Reset()
+			}
+
+			delay := reconnect.NextBackOff()
+			h.log.Error().Err(err).
+				Dur("retry_in", delay).
+				Msg("secret events listener disconnected")
+
+			// watchers may have missed notifications while listener was down.
+			h.notifyAll()
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
+		}
+	}()
+}
+
+// Stop cancels listening and waits for background worker to exit or ctx to be done.
+func (h *Hub) Stop(ctx context.Context) error {
+	if h.cancel == nil {
+		return nil
+	}
+
+	h.cancel()
+
+	stopped := make(chan struct{})
+
+	go func() {
+		h.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Subscribe registers watcher of the user secrets.
+func (h *Hub) Subscribe(userID uuid.UUID) (<-chan struct{}, func()) {
+	signal := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[chan struct{}]struct{})
+	}
+
+	h.subs[userID][signal] = struct{}{}
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.subs[userID], signal)
+
+		if len(h.subs[userID]) == 0 {
+			delete(h.subs, userID)
+		}
+	}
+
+	return signal, unsubscribe
+}
+
+func (h *Hub) notify(payload string) {
+	userID, err := uuid.Parse(payload)
+	if err != nil {
+		h.log.Warn().
+			Str("payload", payload).
+			Msg("unexpected secret events notification")
+
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for signal := range h.subs[userID] {
+		wake(signal)
+	}
+}
+
+func (h *Hub) notifyAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, signals := range h.subs {
+		for signal := range signals {
+			wake(signal)
+		}
+	}
+}
+
+// wake signals watcher unless it has a pending signal already.
+func wake(signal chan struct{}) {
+	select {
+	case signal <- struct{}{}:
+	default:
+	}
+}